@@ -0,0 +1,182 @@
+package engine
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/piwi3910/SlabCut/internal/model"
+)
+
+// maxBuyListSubsetItems is the largest catalog for which every combination
+// of sheet sizes is tried. Larger catalogs try single sizes and pairs.
+const maxBuyListSubsetItems = 8
+
+// buyListTimeBudget bounds the buy list search. When it runs out, the
+// cheapest combination found so far is returned.
+var buyListTimeBudget = 20 * time.Second
+
+// OptimizeBuyList chooses which catalog sheets to buy for the parts. Each
+// combination of catalog sizes is packed with a real optimization run; the
+// cheapest combination that places every part wins, after minimum order
+// quantities are applied and any sheet that can be swapped for a cheaper
+// size is downsized.
+//
+// creditPercent (0-100) credits that share of the value of reusable offcuts
+// and of surplus sheets bought to meet a minimum order against the cost. Use
+// 0 to ignore offcut value.
+//
+// The search always uses the guillotine algorithm to keep it fast, and
+// stops after buyListTimeBudget with the cheapest combination found so far,
+// marked Incomplete. It is slow on large catalogs; call it off the UI
+// goroutine.
+func (o *Optimizer) OptimizeBuyList(parts []model.Part, catalog []model.CatalogSheet, creditPercent float64) (model.BuyList, error) {
+	if len(catalog) == 0 {
+		return model.BuyList{}, fmt.Errorf("supplier catalog is empty")
+	}
+	parts = model.SheetParts(parts)
+	pieces := 0
+	for _, p := range parts {
+		pieces += p.Quantity
+	}
+	if pieces == 0 {
+		return model.BuyList{}, fmt.Errorf("no parts to buy sheets for")
+	}
+
+	settings := o.Settings
+	settings.Algorithm = model.AlgorithmGuillotine
+	search := New(settings)
+
+	deadline := time.Now().Add(buyListTimeBudget)
+	var best model.BuyList
+	found, incomplete := false, false
+	for _, subset := range catalogSubsets(len(catalog)) {
+		if time.Now().After(deadline) {
+			incomplete = true
+			break
+		}
+		// Offer enough of each size that supply never limits the layout
+		var stocks []model.StockSheet
+		ids := make(map[string]int)
+		for _, idx := range subset {
+			s := catalog[idx].ToStockSheet(pieces)
+			ids[s.ID] = idx
+			stocks = append(stocks, s)
+		}
+		result := search.Optimize(parts, stocks)
+		if len(result.UnplacedParts) > 0 || len(result.Sheets) == 0 {
+			continue
+		}
+		result = search.downsizeSheets(result, catalog, subset, ids, deadline)
+
+		plan, err := buildBuyList(result, catalog, ids, settings.KerfWidth, creditPercent)
+		if err != nil {
+			return model.BuyList{}, err
+		}
+		if !found || plan.NetCost() < best.NetCost()-0.005 ||
+			(plan.NetCost() < best.NetCost()+0.005 && plan.TotalSheets() < best.TotalSheets()) {
+			best = plan
+			found = true
+		}
+	}
+	if !found && incomplete {
+		return model.BuyList{}, fmt.Errorf("no combination of catalog sheets that fits every part was found within %v", buyListTimeBudget)
+	}
+	if !found {
+		return model.BuyList{}, fmt.Errorf("no combination of catalog sheets fits every part")
+	}
+	best.Incomplete = incomplete
+	return best, nil
+}
+
+// catalogSubsets lists the combinations of catalog indices to try.
+func catalogSubsets(n int) [][]int {
+	var subsets [][]int
+	if n <= maxBuyListSubsetItems {
+		for mask := 1; mask < 1<<n; mask++ {
+			var s []int
+			for i := 0; i < n; i++ {
+				if mask&(1<<i) != 0 {
+					s = append(s, i)
+				}
+			}
+			subsets = append(subsets, s)
+		}
+		return subsets
+	}
+	for i := 0; i < n; i++ {
+		subsets = append(subsets, []int{i})
+		for j := i + 1; j < n; j++ {
+			subsets = append(subsets, []int{i, j})
+		}
+	}
+	return append(subsets, allIndices(n))
+}
+
+func allIndices(n int) []int {
+	s := make([]int, n)
+	for i := range s {
+		s[i] = i
+	}
+	return s
+}
+
+// downsizeSheets replaces each sheet in the result with the cheapest size in
+// the subset that its parts can be repacked onto, if that is cheaper. Sheets
+// left when the deadline passes keep their size.
+func (o *Optimizer) downsizeSheets(result model.OptimizeResult, catalog []model.CatalogSheet, subset []int, ids map[string]int, deadline time.Time) model.OptimizeResult {
+	for i, sheet := range result.Sheets {
+		if time.Now().After(deadline) {
+			break
+		}
+		var sheetParts []model.Part
+		for _, p := range sheet.Placements {
+			sheetParts = append(sheetParts, p.Part)
+		}
+		for _, idx := range subset {
+			c := catalog[idx]
+			if c.Price >= sheet.Stock.PricePerSheet || c.Width*c.Height < sheet.UsedArea() {
+				continue
+			}
+			stock := c.ToStockSheet(1)
+			trial := o.Optimize(sheetParts, []model.StockSheet{stock})
+			if len(trial.UnplacedParts) == 0 && len(trial.Sheets) == 1 {
+				ids[stock.ID] = idx
+				result.Sheets[i] = trial.Sheets[0]
+				sheet = trial.Sheets[0]
+			}
+		}
+	}
+	return result
+}
+
+// buildBuyList counts the sheets of each catalog size used by the result and
+// prices the order. Every sheet must come from the catalog, listed in ids.
+func buildBuyList(result model.OptimizeResult, catalog []model.CatalogSheet, ids map[string]int, kerf, creditPercent float64) (model.BuyList, error) {
+	plan := model.BuyList{Result: result}
+	used := make([]int, len(catalog))
+	for _, sheet := range result.Sheets {
+		idx, ok := ids[sheet.Stock.ID]
+		if !ok {
+			return model.BuyList{}, fmt.Errorf("sheet %q is not from the supplier catalog", sheet.Stock.Label)
+		}
+		used[idx]++
+	}
+	for idx, c := range catalog {
+		if used[idx] == 0 {
+			continue
+		}
+		buy := c.OrderQuantity(used[idx])
+		line := model.BuyListLine{Sheet: c, Used: used[idx], Buy: buy, Cost: float64(buy) * c.Price}
+		plan.Lines = append(plan.Lines, line)
+		plan.SheetCost += line.Cost
+		if creditPercent > 0 {
+			plan.OffcutCredit += float64(buy-used[idx]) * c.Price * creditPercent / 100
+		}
+	}
+	if creditPercent > 0 {
+		for _, o := range model.DetectAllOffcuts(result, kerf) {
+			plan.OffcutCredit += o.PricePerSheet * creditPercent / 100
+		}
+	}
+	return plan, nil
+}
@@ -0,0 +1,111 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/piwi3910/SlabCut/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func buyListSettings() model.CutSettings {
+	s := model.DefaultSettings()
+	s.KerfWidth = 0
+	s.EdgeTrim = 0
+	s.StockTabs.Enabled = false
+	return s
+}
+
+func TestOptimizeBuyList_ChoosesCheapestSizes(t *testing.T) {
+	opt := New(buyListSettings())
+	catalog := []model.CatalogSheet{
+		{Name: "Full", Width: 2440, Height: 1220, Price: 60},
+		{Name: "Half", Width: 1220, Height: 1220, Price: 25},
+	}
+	// Fits exactly on one half sheet; a full sheet would be wasteful
+	parts := []model.Part{model.NewPart("Panel", 600, 600, 4)}
+
+	plan, err := opt.OptimizeBuyList(parts, catalog, 0)
+
+	require.NoError(t, err)
+	assertValidLayout(t, opt.Settings, plan.Result)
+	require.Len(t, plan.Lines, 1)
+	assert.Equal(t, "Half", plan.Lines[0].Sheet.Name)
+	assert.Equal(t, 1, plan.Lines[0].Buy)
+	assert.InDelta(t, 25.0, plan.NetCost(), 1e-9)
+	assert.Empty(t, plan.Result.UnplacedParts)
+}
+
+func TestOptimizeBuyList_MixesSizes(t *testing.T) {
+	opt := New(buyListSettings())
+	catalog := []model.CatalogSheet{
+		{Name: "Full", Width: 2440, Height: 1220, Price: 50},
+		{Name: "Half", Width: 1220, Height: 1220, Price: 30},
+	}
+	// One full sheet of parts plus a small leftover part
+	parts := []model.Part{
+		model.NewPart("Big", 2440, 1220, 1),
+		model.NewPart("Small", 500, 500, 1),
+	}
+
+	plan, err := opt.OptimizeBuyList(parts, catalog, 0)
+
+	require.NoError(t, err)
+	assertValidLayout(t, opt.Settings, plan.Result)
+	assert.Equal(t, 2, plan.TotalSheets())
+	assert.InDelta(t, 80.0, plan.SheetCost, 1e-9)
+}
+
+func TestOptimizeBuyList_MinimumOrderQuantity(t *testing.T) {
+	opt := New(buyListSettings())
+	catalog := []model.CatalogSheet{
+		{Name: "Cheap bulk", Width: 1220, Height: 1220, Price: 20, MinOrderQty: 5},
+		{Name: "Single", Width: 1220, Height: 1220, Price: 30},
+	}
+	parts := []model.Part{model.NewPart("Panel", 1200, 1200, 1)}
+
+	plan, err := opt.OptimizeBuyList(parts, catalog, 0)
+	require.NoError(t, err)
+	require.Len(t, plan.Lines, 1)
+	assert.Equal(t, "Single", plan.Lines[0].Sheet.Name)
+
+	// Crediting surplus sheets at full value makes the bulk order cheaper
+	plan, err = opt.OptimizeBuyList(parts, catalog, 100)
+	require.NoError(t, err)
+	require.Len(t, plan.Lines, 1)
+	assert.Equal(t, "Cheap bulk", plan.Lines[0].Sheet.Name)
+	assert.Equal(t, 5, plan.Lines[0].Buy)
+	assert.InDelta(t, 100.0, plan.SheetCost, 1e-9)
+	assert.InDelta(t, 80.0, plan.OffcutCredit, 1e-9)
+}
+
+func TestOptimizeBuyList_NothingFits(t *testing.T) {
+	opt := New(buyListSettings())
+	catalog := []model.CatalogSheet{{Name: "Small", Width: 600, Height: 600, Price: 10}}
+
+	_, err := opt.OptimizeBuyList([]model.Part{model.NewPart("Huge", 2000, 1000, 1)}, catalog, 0)
+	assert.Error(t, err)
+
+	_, err = opt.OptimizeBuyList([]model.Part{model.NewPart("A", 100, 100, 1)}, nil, 0)
+	assert.Error(t, err)
+}
+
+func TestOptimizeBuyList_StopsAtTimeBudget(t *testing.T) {
+	defer func(budget time.Duration) { buyListTimeBudget = budget }(buyListTimeBudget)
+	buyListTimeBudget = 0
+
+	opt := New(buyListSettings())
+	catalog := []model.CatalogSheet{{Name: "Half", Width: 1220, Height: 1220, Price: 25}}
+	_, err := opt.OptimizeBuyList([]model.Part{model.NewPart("Panel", 600, 600, 1)}, catalog, 0)
+	assert.ErrorContains(t, err, "within")
+}
+
+func TestBuildBuyList_UnknownSheet(t *testing.T) {
+	catalog := []model.CatalogSheet{{Name: "Half", Width: 1220, Height: 1220, Price: 25}}
+	result := model.OptimizeResult{Sheets: []model.SheetResult{
+		{Stock: model.NewStockSheet("Offcut", 500, 500, 1)},
+	}}
+	_, err := buildBuyList(result, catalog, map[string]int{}, 0, 0)
+	assert.Error(t, err, "a sheet outside the catalog must not be counted as catalog size 0")
+}
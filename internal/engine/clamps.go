@@ -0,0 +1,79 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/piwi3910/SlabCut/internal/model"
+)
+
+// ClampHits reports the labels of the clamps the dust shoe hits while the
+// parts of a layout are being cut.
+type ClampHits func(result model.OptimizeResult) []string
+
+// AvoidClampCollisions re-runs Optimize while the dust shoe would hit a
+// clamp during a cut, as reported by hits. Each run widens the exclusion
+// zone of every clamp that was hit by the clearance the dust shoe needs,
+// plus the tool radius the toolpath runs outside the part, so the parts cut
+// next to it move away. A run that leaves more parts unplaced is discarded
+// and the last good layout kept, with a warning.
+func (o *Optimizer) AvoidClampCollisions(parts []model.Part, stocks []model.StockSheet, result model.OptimizeResult, hits ClampHits) model.OptimizeResult {
+	s := o.Settings
+	margin := s.DustShoeWidth/2 + s.DustShoeClearance + s.ToolDiameter/2
+
+	grown := make([]bool, len(s.ClampZones))
+	var moved, warnings []string
+	for {
+		hit := make(map[string]bool)
+		for _, label := range hits(result) {
+			hit[label] = true
+		}
+		var more []string
+		for i, cz := range s.ClampZones {
+			if hit[cz.Label] && !grown[i] {
+				grown[i] = true
+				more = append(more, fmt.Sprintf("%q", cz.Label))
+			}
+		}
+		if len(more) == 0 {
+			break
+		}
+
+		retry := s
+		retry.ClampZones = make([]model.ClampZone, len(s.ClampZones))
+		for i, cz := range s.ClampZones {
+			if grown[i] {
+				cz.X, cz.Y = cz.X-margin, cz.Y-margin
+				cz.Width, cz.Height = cz.Width+2*margin, cz.Height+2*margin
+			}
+			retry.ClampZones[i] = cz
+		}
+		next := New(retry).Optimize(parts, stocks)
+		if unplacedCount(next) > unplacedCount(result) {
+			warnings = append(warnings, fmt.Sprintf(
+				"Could not move parts clear of clamp %s for the dust shoe without leaving parts unplaced",
+				strings.Join(more, ", ")))
+			break
+		}
+		result = next
+		moved = append(moved, more...)
+	}
+
+	if len(moved) > 0 {
+		warnings = append(warnings, fmt.Sprintf(
+			"Moved parts clear of clamp %s for the dust shoe", strings.Join(moved, ", ")))
+	}
+	// Every run repeats the optimizer's own warnings, so only the kept
+	// run's are reported, followed by the ones added here
+	result.Warnings = append(append([]string(nil), result.Warnings...), warnings...)
+	return result
+}
+
+// unplacedCount returns the number of parts a result leaves unplaced.
+func unplacedCount(result model.OptimizeResult) int {
+	n := 0
+	for _, p := range result.UnplacedParts {
+		n += p.Quantity
+	}
+	return n
+}
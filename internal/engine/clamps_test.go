@@ -0,0 +1,97 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/piwi3910/SlabCut/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDustShoeSettings() model.CutSettings {
+	settings := defaultTestSettings()
+	settings.DustShoeEnabled = true
+	settings.DustShoeWidth = 80
+	settings.DustShoeClearance = 5
+	settings.AvoidClampCollisions = true
+	settings.ClampZones = []model.ClampZone{
+		{Label: "Corner", X: 0, Y: 0, Width: 50, Height: 50, ZHeight: 25},
+	}
+	return settings
+}
+
+// nearClamps reports the clamps a placement comes within reach of, standing
+// in for the dust shoe check of the G-code generator.
+func nearClamps(settings model.CutSettings, reach float64) ClampHits {
+	return func(result model.OptimizeResult) []string {
+		var labels []string
+		for _, cz := range settings.ClampZones {
+			hit := false
+			for _, sheet := range result.Sheets {
+				for _, p := range sheet.Placements {
+					if p.X < cz.X+cz.Width+reach && p.X+p.PlacedWidth() > cz.X-reach &&
+						p.Y < cz.Y+cz.Height+reach && p.Y+p.PlacedHeight() > cz.Y-reach {
+						hit = true
+					}
+				}
+			}
+			if hit {
+				labels = append(labels, cz.Label)
+			}
+		}
+		return labels
+	}
+}
+
+func TestAvoidClampCollisions_MovesParts(t *testing.T) {
+	parts := []model.Part{model.NewPart("A", 200, 100, 2)}
+	stocks := []model.StockSheet{model.NewStockSheet("Sheet", 1000, 600, 1)}
+
+	settings := newDustShoeSettings()
+	hits := nearClamps(settings, 45)
+	opt := New(settings)
+	plain := opt.Optimize(parts, stocks)
+	require.NotEmpty(t, hits(plain), "parts next to the clamp should collide")
+
+	result := opt.AvoidClampCollisions(parts, stocks, plain, hits)
+	assertValidLayout(t, opt.Settings, result)
+
+	assert.Empty(t, result.UnplacedParts)
+	assert.Empty(t, hits(result), "parts should be cut clear of the clamp")
+	assert.Contains(t, result.Warnings, `Moved parts clear of clamp "Corner" for the dust shoe`)
+}
+
+func TestAvoidClampCollisions_KeepsPartsPlaced(t *testing.T) {
+	// The sheet is too small to keep the part clear of the clamp
+	parts := []model.Part{model.NewPart("A", 200, 100, 1)}
+	stocks := []model.StockSheet{model.NewStockSheet("Sheet", 260, 160, 1)}
+
+	settings := newDustShoeSettings()
+	opt := New(settings)
+	result := opt.AvoidClampCollisions(parts, stocks, opt.Optimize(parts, stocks), nearClamps(settings, 45))
+
+	assert.Empty(t, result.UnplacedParts, "the part should stay placed")
+	require.NotEmpty(t, result.Warnings)
+	assert.Contains(t, result.Warnings[len(result.Warnings)-1], "without leaving parts unplaced")
+}
+
+func TestAvoidClampCollisions_WarnsOnce(t *testing.T) {
+	oak := model.NewPart("Oak", 100, 100, 1)
+	oak.Material = "Oak"
+	parts := []model.Part{model.NewPart("A", 200, 100, 2), oak}
+	sheet := model.NewStockSheet("Sheet", 1000, 600, 1)
+	sheet.Material = "MDF"
+	stocks := []model.StockSheet{sheet}
+
+	settings := newDustShoeSettings()
+	opt := New(settings)
+	plain := opt.Optimize(parts, stocks)
+	require.Len(t, plain.Warnings, 1)
+
+	result := opt.AvoidClampCollisions(parts, stocks, plain, nearClamps(settings, 45))
+	assert.Equal(t, []string{
+		plain.Warnings[0],
+		`Moved parts clear of clamp "Corner" for the dust shoe`,
+	}, result.Warnings)
+	assert.Len(t, plain.Warnings, 1, "the original result should be left as it was")
+}
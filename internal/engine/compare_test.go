@@ -38,6 +38,7 @@ func TestCompareScenarios_Basic(t *testing.T) {
 		if r.SheetsUsed == 0 {
 			t.Errorf("result %d: expected at least one sheet used", i)
 		}
+		assertValidLayout(t, r.Scenario.Settings, r.Result)
 		if r.TotalCuts == 0 {
 			t.Errorf("result %d: expected at least one cut", i)
 		}
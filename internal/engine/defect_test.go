@@ -0,0 +1,75 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/piwi3910/SlabCut/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func defectSettings() model.CutSettings {
+	s := model.DefaultSettings()
+	s.KerfWidth = 0
+	s.EdgeTrim = 0
+	s.StockTabs.Enabled = false
+	return s
+}
+
+func assertAvoidsDefects(t *testing.T, result model.OptimizeResult) {
+	t.Helper()
+	for _, sheet := range result.Sheets {
+		for _, p := range sheet.Placements {
+			for _, d := range sheet.Stock.Defects {
+				for _, z := range d.ExclusionZones() {
+					overlaps := p.X < z.X+z.Width && p.X+p.PlacedWidth() > z.X &&
+						p.Y < z.Y+z.Height && p.Y+p.PlacedHeight() > z.Y
+					assert.False(t, overlaps, "part %s overlaps defect %s", p.Part.Label, d.Label)
+				}
+			}
+		}
+	}
+}
+
+func TestOptimize_AvoidsSheetDefects(t *testing.T) {
+	stock := model.NewStockSheet("Damaged", 1000, 1000, 1)
+	stock.Defects = []model.Defect{
+		{Label: "Broken corner", Outline: model.Outline{{X: 0, Y: 0}, {X: 300, Y: 0}, {X: 0, Y: 300}}},
+		model.NewRectDefect("Knot", 600, 600, 40, 40),
+	}
+	parts := []model.Part{model.NewPart("Panel", 400, 300, 3)}
+
+	result := New(defectSettings()).Optimize(parts, []model.StockSheet{stock})
+	assertValidLayout(t, defectSettings(), result)
+
+	require.Len(t, result.Sheets, 1)
+	assert.Empty(t, result.UnplacedParts)
+	assertAvoidsDefects(t, result)
+}
+
+func TestOptimize_DefectsOnlyAffectTheirSheet(t *testing.T) {
+	damaged := model.NewStockSheet("Damaged", 1000, 1000, 1)
+	damaged.Defects = []model.Defect{model.NewRectDefect("Dent", 0, 0, 1000, 600)}
+	clean := model.NewStockSheet("Clean", 1000, 1000, 1)
+	parts := []model.Part{model.NewPart("Big", 900, 900, 1), model.NewPart("Strip", 900, 300, 1)}
+
+	result := New(defectSettings()).Optimize(parts, []model.StockSheet{damaged, clean})
+	assertValidLayout(t, defectSettings(), result)
+
+	assert.Empty(t, result.UnplacedParts)
+	assertAvoidsDefects(t, result)
+}
+
+func TestOptimizeGenetic_AvoidsSheetDefects(t *testing.T) {
+	settings := defectSettings()
+	settings.Algorithm = model.AlgorithmGenetic
+	stock := model.NewStockSheet("Damaged", 1000, 1000, 2)
+	stock.Defects = []model.Defect{model.NewRectDefect("Dent", 400, 400, 200, 200)}
+	parts := []model.Part{model.NewPart("Panel", 300, 300, 6)}
+
+	result := New(settings).Optimize(parts, []model.StockSheet{stock})
+	assertValidLayout(t, settings, result)
+
+	assert.Empty(t, result.UnplacedParts)
+	assertAvoidsDefects(t, result)
+}
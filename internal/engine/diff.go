@@ -0,0 +1,136 @@
+package engine
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/piwi3910/SlabCut/internal/model"
+)
+
+// PlacementChange describes how one placed part differs between two layouts.
+// Sheet indexes are 0-based; -1 means the part is not on a sheet in that
+// layout.
+type PlacementChange struct {
+	Label     string
+	FromSheet int
+	ToSheet   int
+}
+
+// String describes the change, e.g. "Shelf: sheet 1 -> sheet 2".
+func (c PlacementChange) String() string {
+	switch {
+	case c.FromSheet < 0:
+		return fmt.Sprintf("%s: added to sheet %d", c.Label, c.ToSheet+1)
+	case c.ToSheet < 0:
+		return fmt.Sprintf("%s: removed from sheet %d", c.Label, c.FromSheet+1)
+	case c.FromSheet == c.ToSheet:
+		return fmt.Sprintf("%s: moved on sheet %d", c.Label, c.FromSheet+1)
+	default:
+		return fmt.Sprintf("%s: sheet %d -> sheet %d", c.Label, c.FromSheet+1, c.ToSheet+1)
+	}
+}
+
+// LayoutDiff summarises how placements changed between two layouts of the
+// same project.
+type LayoutDiff struct {
+	Unchanged int               // Placements at the same position on the same sheet
+	Moved     []PlacementChange // Placements that moved, rotated or changed sheet
+	Added     []PlacementChange // Placements without a counterpart in the old layout
+	Removed   []PlacementChange // Placements without a counterpart in the new layout
+}
+
+// Changed reports whether any placement moved, appeared or disappeared.
+func (d LayoutDiff) Changed() bool {
+	return len(d.Moved)+len(d.Added)+len(d.Removed) > 0
+}
+
+// Summary returns a one-line count of the changes.
+func (d LayoutDiff) Summary() string {
+	return fmt.Sprintf("%d moved, %d added, %d removed, %d unchanged",
+		len(d.Moved), len(d.Added), len(d.Removed), d.Unchanged)
+}
+
+// Details returns one line per change: moves first, then additions and
+// removals.
+func (d LayoutDiff) Details() string {
+	var lines []string
+	for _, group := range [][]PlacementChange{d.Moved, d.Added, d.Removed} {
+		for _, c := range group {
+			lines = append(lines, c.String())
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// sheetPlacement is a placement together with the index of its sheet.
+type sheetPlacement struct {
+	sheet int
+	p     model.Placement
+}
+
+// DiffLayouts compares two layouts part by part. Instances of the same part
+// are matched to an identical position first, then pairwise in sheet order;
+// any left over were added or removed.
+func DiffLayouts(prev, next model.OptimizeResult) LayoutDiff {
+	var keys []string
+	seen := make(map[string]bool)
+	before := make(map[string][]sheetPlacement)
+	after := make(map[string][]sheetPlacement)
+	collect := func(result model.OptimizeResult, into map[string][]sheetPlacement) {
+		for i, sheet := range result.Sheets {
+			for _, p := range sheet.Placements {
+				key := partKey(p.Part)
+				if !seen[key] {
+					seen[key] = true
+					keys = append(keys, key)
+				}
+				into[key] = append(into[key], sheetPlacement{sheet: i, p: p})
+			}
+		}
+	}
+	collect(prev, before)
+	collect(next, after)
+
+	var diff LayoutDiff
+	for _, key := range keys {
+		olds, news := before[key], after[key]
+
+		// Drop instances that did not move.
+		var moved []sheetPlacement
+		for _, n := range news {
+			match := -1
+			for j, o := range olds {
+				if samePosition(o, n) {
+					match = j
+					break
+				}
+			}
+			if match < 0 {
+				moved = append(moved, n)
+				continue
+			}
+			diff.Unchanged++
+			olds = append(olds[:match:match], olds[match+1:]...)
+		}
+
+		for i, n := range moved {
+			if i < len(olds) {
+				diff.Moved = append(diff.Moved, PlacementChange{Label: n.p.Part.Label, FromSheet: olds[i].sheet, ToSheet: n.sheet})
+			} else {
+				diff.Added = append(diff.Added, PlacementChange{Label: n.p.Part.Label, FromSheet: -1, ToSheet: n.sheet})
+			}
+		}
+		for i := len(moved); i < len(olds); i++ {
+			diff.Removed = append(diff.Removed, PlacementChange{Label: olds[i].p.Part.Label, FromSheet: olds[i].sheet, ToSheet: -1})
+		}
+	}
+	return diff
+}
+
+// samePosition reports whether two placements sit at the same place on the
+// same sheet in the same orientation.
+func samePosition(a, b sheetPlacement) bool {
+	return a.sheet == b.sheet && a.p.Rotated == b.p.Rotated &&
+		math.Abs(a.p.X-b.p.X) < validateEpsilon && math.Abs(a.p.Y-b.p.Y) < validateEpsilon
+}
@@ -0,0 +1,47 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/piwi3910/SlabCut/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffLayouts(t *testing.T) {
+	stock := model.NewStockSheet("Sheet", 1000, 600, 2)
+	a := model.NewPart("A", 400, 300, 1)
+	b := model.NewPart("B", 200, 100, 2)
+	c := model.NewPart("C", 100, 100, 1)
+	d := model.NewPart("D", 150, 100, 1)
+
+	prev := model.OptimizeResult{Sheets: []model.SheetResult{
+		{Stock: stock, Placements: []model.Placement{
+			{Part: a, X: 10, Y: 10},
+			{Part: b, X: 413, Y: 10},
+			{Part: b, X: 413, Y: 113},
+			{Part: c, X: 10, Y: 313},
+		}},
+	}}
+	next := model.OptimizeResult{Sheets: []model.SheetResult{
+		{Stock: stock, Placements: []model.Placement{
+			{Part: a, X: 10, Y: 10},
+			{Part: b, X: 413, Y: 113},
+			{Part: d, X: 10, Y: 313},
+		}},
+		{Stock: stock, Placements: []model.Placement{
+			{Part: b, X: 10, Y: 10},
+		}},
+	}}
+
+	diff := DiffLayouts(prev, next)
+
+	assert.Equal(t, 2, diff.Unchanged)
+	assert.Equal(t, []PlacementChange{{Label: "B", FromSheet: 0, ToSheet: 1}}, diff.Moved)
+	assert.Equal(t, []PlacementChange{{Label: "D", FromSheet: -1, ToSheet: 0}}, diff.Added)
+	assert.Equal(t, []PlacementChange{{Label: "C", FromSheet: 0, ToSheet: -1}}, diff.Removed)
+	assert.True(t, diff.Changed())
+	assert.Equal(t, "1 moved, 1 added, 1 removed, 2 unchanged", diff.Summary())
+	assert.Equal(t, "B: sheet 1 -> sheet 2\nD: added to sheet 1\nC: removed from sheet 1", diff.Details())
+
+	assert.False(t, DiffLayouts(prev, prev).Changed())
+}
@@ -46,6 +46,7 @@ type geneticOptimizer struct {
 	config   GeneticConfig
 	parts    []model.Part
 	stocks   []model.StockSheet
+	fixed    []model.SheetResult // Sheets filled around their placements before using stocks
 	rng      *rand.Rand
 }
 
@@ -62,7 +63,7 @@ func newGeneticOptimizer(settings model.CutSettings, config GeneticConfig, parts
 
 // optimize runs the genetic algorithm and returns the best result.
 func (g *geneticOptimizer) optimize() model.OptimizeResult {
-	if len(g.parts) == 0 || len(g.stocks) == 0 {
+	if len(g.parts) == 0 || (len(g.stocks) == 0 && len(g.fixed) == 0) {
 		return model.OptimizeResult{}
 	}
 
@@ -232,7 +233,15 @@ func (g *geneticOptimizer) evaluate(c chromosome) float64 {
 	return fitness
 }
 
+// orderedPart is a part in chromosome order with its preferred orientation.
+type orderedPart struct {
+	part    model.Part
+	rotated bool
+}
+
 // decode converts a chromosome into an actual packing result using the guillotine packer.
+// Fixed sheets are filled first, around their existing placements, before
+// any stock from the pool is used.
 func (g *geneticOptimizer) decode(c chromosome) model.OptimizeResult {
 	// Build stock pool
 	var stockPool []model.StockSheet
@@ -247,13 +256,9 @@ func (g *geneticOptimizer) decode(c chromosome) model.OptimizeResult {
 	result := model.OptimizeResult{}
 
 	// Build the ordered list of parts from chromosome
-	type partPlacement struct {
-		part    model.Part
-		rotated bool
-	}
-	ordered := make([]partPlacement, len(c.genes))
+	ordered := make([]orderedPart, len(c.genes))
 	for i, gene := range c.genes {
-		ordered[i] = partPlacement{
+		ordered[i] = orderedPart{
 			part:    g.parts[gene.partIndex],
 			rotated: gene.rotated,
 		}
@@ -262,6 +267,22 @@ func (g *geneticOptimizer) decode(c chromosome) model.OptimizeResult {
 	remaining := ordered
 	opt := &Optimizer{Settings: g.settings}
 
+	for _, fixed := range g.fixed {
+		var fits, others []orderedPart
+		for _, pp := range remaining {
+			if stockCompatible(fixed.Stock, pp.part.Material, pp.part.Thickness, g.settings.ThicknessTolerance) {
+				fits = append(fits, pp)
+			} else {
+				others = append(others, pp)
+			}
+		}
+		sheet := model.SheetResult{Stock: fixed.Stock}
+		sheet.Placements = append(sheet.Placements, fixed.Placements...)
+		unplaced := g.packInto(&sheet, opt.newSheetPacker(fixed.Stock, fixed.Placements), fits)
+		result.Sheets = append(result.Sheets, sheet)
+		remaining = append(unplaced, others...)
+	}
+
 	for len(remaining) > 0 && len(stockPool) > 0 {
 		// Extract just the parts for stock selection
 		remainingParts := make([]model.Part, len(remaining))
@@ -278,122 +299,122 @@ func (g *geneticOptimizer) decode(c chromosome) model.OptimizeResult {
 		stockPool = append(stockPool[:bestStockIdx], stockPool[bestStockIdx+1:]...)
 
 		sheet := model.SheetResult{Stock: stock}
-		var unplaced []partPlacement
+		unplaced := g.packInto(&sheet, opt.newSheetPacker(stock, nil), remaining)
 
-		// Get stock tab configuration
-		tabConfig := stock.Tabs
-		if !tabConfig.Enabled {
-			tabConfig = g.settings.StockTabs
+		if len(sheet.Placements) > 0 {
+			result.Sheets = append(result.Sheets, sheet)
 		}
+		remaining = unplaced
+	}
 
-		freeRects := opt.calculateFreeRects(stock, tabConfig)
-		packer := newGuillotinePackerWithRects(freeRects, g.settings.KerfWidth)
+	// Collect unplaced parts
+	for _, pp := range remaining {
+		result.UnplacedParts = append(result.UnplacedParts, pp.part)
+	}
 
-		for _, pp := range remaining {
-			placed := false
-			var placedX, placedY float64
-			var placedRotated bool
-
-			// Check grain compatibility between part and stock sheet
-			canNormal, canRotated := model.CanPlaceWithGrain(pp.part.Grain, stock.Grain)
-
-			// When both orientations are allowed, use best-fit comparison
-			if canNormal && canRotated && pp.part.Width != pp.part.Height {
-				normalFit := packer.bestFit(pp.part.Width, pp.part.Height)
-				rotatedFit := packer.bestFit(pp.part.Height, pp.part.Width)
-
-				// Chromosome preference breaks ties; otherwise pick tighter fit
-				preferRotated := pp.rotated
-				if normalFit >= 0 && rotatedFit >= 0 {
-					if rotatedFit < normalFit {
-						preferRotated = true
-					} else if normalFit < rotatedFit {
-						preferRotated = false
-					}
-				} else if normalFit < 0 && rotatedFit >= 0 {
+	return result
+}
+
+// packInto places parts on a sheet in order using the given packer and
+// returns the parts that did not fit.
+func (g *geneticOptimizer) packInto(sheet *model.SheetResult, packer *guillotinePacker, parts []orderedPart) []orderedPart {
+	stock := sheet.Stock
+	var unplaced []orderedPart
+
+	for _, pp := range parts {
+		placed := false
+		var placedX, placedY float64
+		var placedRotated bool
+
+		// Check grain compatibility between part and stock sheet
+		canNormal, canRotated := model.CanPlaceWithGrain(pp.part.Grain, stock.Grain)
+
+		// When both orientations are allowed, use best-fit comparison
+		if canNormal && canRotated && pp.part.Width != pp.part.Height {
+			normalFit := packer.bestFit(pp.part.Width, pp.part.Height)
+			rotatedFit := packer.bestFit(pp.part.Height, pp.part.Width)
+
+			// Chromosome preference breaks ties; otherwise pick tighter fit
+			preferRotated := pp.rotated
+			if normalFit >= 0 && rotatedFit >= 0 {
+				if rotatedFit < normalFit {
 					preferRotated = true
-				} else if rotatedFit < 0 && normalFit >= 0 {
+				} else if normalFit < rotatedFit {
 					preferRotated = false
 				}
+			} else if normalFit < 0 && rotatedFit >= 0 {
+				preferRotated = true
+			} else if rotatedFit < 0 && normalFit >= 0 {
+				preferRotated = false
+			}
 
-				if preferRotated {
-					if ok, x, y := packer.insert(pp.part.Height, pp.part.Width); ok {
-						sheet.Placements = append(sheet.Placements, model.Placement{
-							Part: pp.part, X: x, Y: y, Rotated: true,
-						})
-						placed = true
-						placedX, placedY = x, y
-						placedRotated = true
-					}
-				}
-				if !placed {
-					if ok, x, y := packer.insert(pp.part.Width, pp.part.Height); ok {
-						sheet.Placements = append(sheet.Placements, model.Placement{
-							Part: pp.part, X: x, Y: y, Rotated: false,
-						})
-						placed = true
-						placedX, placedY = x, y
-						placedRotated = false
-					}
+			if preferRotated {
+				if ok, x, y := packer.insert(pp.part.Height, pp.part.Width); ok {
+					sheet.Placements = append(sheet.Placements, model.Placement{
+						Part: pp.part, X: x, Y: y, Rotated: true,
+					})
+					placed = true
+					placedX, placedY = x, y
+					placedRotated = true
 				}
-			} else {
-				// Grain-restricted or square parts: try preferred orientation first
-				tryRotatedFirst := pp.rotated && canRotated
-				if tryRotatedFirst {
-					if ok, x, y := packer.insert(pp.part.Height, pp.part.Width); ok {
-						sheet.Placements = append(sheet.Placements, model.Placement{
-							Part: pp.part, X: x, Y: y, Rotated: true,
-						})
-						placed = true
-						placedX, placedY = x, y
-						placedRotated = true
-					}
-				}
-				if !placed && canNormal {
-					if ok, x, y := packer.insert(pp.part.Width, pp.part.Height); ok {
-						sheet.Placements = append(sheet.Placements, model.Placement{
-							Part: pp.part, X: x, Y: y, Rotated: false,
-						})
-						placed = true
-						placedX, placedY = x, y
-						placedRotated = false
-					}
+			}
+			if !placed {
+				if ok, x, y := packer.insert(pp.part.Width, pp.part.Height); ok {
+					sheet.Placements = append(sheet.Placements, model.Placement{
+						Part: pp.part, X: x, Y: y, Rotated: false,
+					})
+					placed = true
+					placedX, placedY = x, y
+					placedRotated = false
 				}
-				if !placed && canRotated && !tryRotatedFirst {
-					if ok, x, y := packer.insert(pp.part.Height, pp.part.Width); ok {
-						sheet.Placements = append(sheet.Placements, model.Placement{
-							Part: pp.part, X: x, Y: y, Rotated: true,
-						})
-						placed = true
-						placedX, placedY = x, y
-						placedRotated = true
-					}
+			}
+		} else {
+			// Grain-restricted or square parts: try preferred orientation first
+			tryRotatedFirst := pp.rotated && canRotated
+			if tryRotatedFirst {
+				if ok, x, y := packer.insert(pp.part.Height, pp.part.Width); ok {
+					sheet.Placements = append(sheet.Placements, model.Placement{
+						Part: pp.part, X: x, Y: y, Rotated: true,
+					})
+					placed = true
+					placedX, placedY = x, y
+					placedRotated = true
 				}
 			}
-
-			// If part was placed and has interior cutouts, add cutout bounding
-			// rectangles as free space for nesting smaller parts inside
-			if placed && len(pp.part.Cutouts) > 0 {
-				addCutoutFreeRects(packer, pp.part, placedX, placedY, placedRotated, g.settings.KerfWidth)
+			if !placed && canNormal {
+				if ok, x, y := packer.insert(pp.part.Width, pp.part.Height); ok {
+					sheet.Placements = append(sheet.Placements, model.Placement{
+						Part: pp.part, X: x, Y: y, Rotated: false,
+					})
+					placed = true
+					placedX, placedY = x, y
+					placedRotated = false
+				}
 			}
-
-			if !placed {
-				unplaced = append(unplaced, pp)
+			if !placed && canRotated && !tryRotatedFirst {
+				if ok, x, y := packer.insert(pp.part.Height, pp.part.Width); ok {
+					sheet.Placements = append(sheet.Placements, model.Placement{
+						Part: pp.part, X: x, Y: y, Rotated: true,
+					})
+					placed = true
+					placedX, placedY = x, y
+					placedRotated = true
+				}
 			}
 		}
 
-		if len(sheet.Placements) > 0 {
-			result.Sheets = append(result.Sheets, sheet)
+		// If part was placed and has interior cutouts, add cutout bounding
+		// rectangles as free space for nesting smaller parts inside
+		if placed && len(pp.part.Cutouts) > 0 {
+			addCutoutFreeRects(packer, pp.part, placedX, placedY, placedRotated, g.settings.KerfWidth)
 		}
-		remaining = unplaced
-	}
 
-	// Collect unplaced parts
-	for _, pp := range remaining {
-		result.UnplacedParts = append(result.UnplacedParts, pp.part)
+		if !placed {
+			unplaced = append(unplaced, pp)
+		}
 	}
 
-	return result
+	return unplaced
 }
 
 // tournamentSelect picks the best individual from a random tournament.
@@ -492,6 +513,13 @@ func (g *geneticOptimizer) copyChromosome(c chromosome) chromosome {
 // OptimizeGenetic runs the genetic algorithm optimizer.
 // It expands parts by quantity, then uses the GA to find an optimal ordering.
 func OptimizeGenetic(settings model.CutSettings, parts []model.Part, stocks []model.StockSheet) model.OptimizeResult {
+	return optimizeGeneticAround(settings, parts, stocks, nil)
+}
+
+// optimizeGeneticAround runs the genetic algorithm with fixed sheets that are
+// filled around their existing placements before any stock is used. The
+// fixed sheets are always part of the result.
+func optimizeGeneticAround(settings model.CutSettings, parts []model.Part, stocks []model.StockSheet, fixed []model.SheetResult) model.OptimizeResult {
 	// Expand parts by quantity
 	var expanded []model.Part
 	for _, p := range parts {
@@ -502,8 +530,8 @@ func OptimizeGenetic(settings model.CutSettings, parts []model.Part, stocks []mo
 		}
 	}
 
-	if len(expanded) == 0 || len(stocks) == 0 {
-		return model.OptimizeResult{}
+	if len(expanded) == 0 || (len(stocks) == 0 && len(fixed) == 0) {
+		return model.OptimizeResult{Sheets: fixed}
 	}
 
 	config := DefaultGeneticConfig()
@@ -518,5 +546,6 @@ func OptimizeGenetic(settings model.CutSettings, parts []model.Part, stocks []mo
 	}
 
 	ga := newGeneticOptimizer(settings, config, expanded, stocks, 42)
+	ga.fixed = fixed
 	return ga.optimize()
 }
@@ -35,6 +35,7 @@ func TestGeneticOptimizerPlacesAllParts(t *testing.T) {
 	settings := makeTestSettings()
 
 	result := OptimizeGenetic(settings, parts, stocks)
+	assertValidLayout(t, settings, result)
 
 	// All parts should be placed (total quantity = 1+2+1 = 4)
 	totalPlaced := 0
@@ -57,6 +58,7 @@ func TestGeneticOptimizerEfficiency(t *testing.T) {
 	settings := makeTestSettings()
 
 	result := OptimizeGenetic(settings, parts, stocks)
+	assertValidLayout(t, settings, result)
 
 	eff := result.TotalEfficiency()
 	if eff <= 0 {
@@ -73,6 +75,7 @@ func TestGeneticOptimizerRespectsGrainDirection(t *testing.T) {
 	settings := makeTestSettings()
 
 	result := OptimizeGenetic(settings, parts, stocks)
+	assertValidLayout(t, settings, result)
 
 	for _, sheet := range result.Sheets {
 		for _, p := range sheet.Placements {
@@ -88,12 +91,14 @@ func TestGeneticOptimizerEmptyInput(t *testing.T) {
 
 	// No parts
 	result := OptimizeGenetic(settings, nil, makeTestStock())
+	assertValidLayout(t, settings, result)
 	if len(result.Sheets) != 0 {
 		t.Errorf("expected no sheets for empty parts, got %d", len(result.Sheets))
 	}
 
 	// No stocks
 	result = OptimizeGenetic(settings, makeTestParts(), nil)
+	assertValidLayout(t, settings, result)
 	if len(result.Sheets) != 0 {
 		t.Errorf("expected no sheets for empty stocks, got %d", len(result.Sheets))
 	}
@@ -120,9 +125,11 @@ func TestGeneticOptimizerBetterThanOrEqualToGreedy(t *testing.T) {
 	settings.Algorithm = model.AlgorithmGuillotine
 	opt := New(settings)
 	greedyResult := opt.Optimize(parts, stocks)
+	assertValidLayout(t, opt.Settings, greedyResult)
 
 	// Genetic result
 	geneticResult := OptimizeGenetic(settings, parts, stocks)
+	assertValidLayout(t, settings, geneticResult)
 
 	greedyPlaced := 0
 	for _, s := range greedyResult.Sheets {
@@ -147,6 +154,7 @@ func TestGeneticViaOptimizerDispatch(t *testing.T) {
 	// Use the Optimizer dispatch path
 	opt := New(settings)
 	result := opt.Optimize(parts, stocks)
+	assertValidLayout(t, opt.Settings, result)
 
 	totalPlaced := 0
 	for _, sheet := range result.Sheets {
@@ -209,6 +217,7 @@ func TestGeneticOptimizerPartTooLargeForStock(t *testing.T) {
 	settings := makeTestSettings()
 
 	result := OptimizeGenetic(settings, parts, stocks)
+	assertValidLayout(t, settings, result)
 
 	if len(result.UnplacedParts) != 1 {
 		t.Errorf("expected 1 unplaced part, got %d", len(result.UnplacedParts))
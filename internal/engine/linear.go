@@ -0,0 +1,144 @@
+package engine
+
+import (
+	"sort"
+
+	"github.com/piwi3910/SlabCut/internal/model"
+)
+
+// OptimizeLinear solves the 1D cutting-stock problem for linear parts using
+// the saw settings in Settings.Linear. Parts that are not linear are ignored.
+// As with sheets, parts and stocks with a material are matched by material,
+// and an empty material matches anything.
+//
+// Each new bar is chosen by trying every remaining stock length and keeping
+// the one that is filled most efficiently by a first-fit-decreasing pass.
+func (o *Optimizer) OptimizeLinear(parts []model.Part, stocks []model.LinearStock) model.LinearResult {
+	ls := o.Settings.Linear
+	result := model.LinearResult{Settings: ls}
+
+	for _, g := range groupLinearByMaterial(model.LinearParts(parts), stocks) {
+		var pieces []model.Part
+		for _, p := range g.parts {
+			for i := 0; i < p.Quantity; i++ {
+				cp := p
+				cp.Quantity = 1
+				pieces = append(pieces, cp)
+			}
+		}
+		sort.SliceStable(pieces, func(i, j int) bool {
+			return pieces[i].Width > pieces[j].Width
+		})
+
+		var pool []model.LinearStock
+		for _, s := range g.stocks {
+			for i := 0; i < s.Quantity; i++ {
+				cp := s
+				cp.Quantity = 1
+				pool = append(pool, cp)
+			}
+		}
+
+		for len(pieces) > 0 && len(pool) > 0 {
+			bestIdx := -1
+			var bestBar model.LinearBar
+			var bestRest []model.Part
+			bestScore := 0.0
+			tried := make(map[string]bool)
+			for i, s := range pool {
+				if tried[s.ID] {
+					continue
+				}
+				tried[s.ID] = true
+				bar, rest := fillBar(s, pieces, ls)
+				if len(bar.Cuts) == 0 {
+					continue
+				}
+				score := bar.UsedLength() / s.Length
+				if bestIdx < 0 || score > bestScore+1e-9 ||
+					(score > bestScore-1e-9 && s.Length < pool[bestIdx].Length) {
+					bestIdx, bestBar, bestRest, bestScore = i, bar, rest, score
+				}
+			}
+			if bestIdx < 0 {
+				break // Nothing left fits any remaining stock
+			}
+			result.Bars = append(result.Bars, bestBar)
+			pool = append(pool[:bestIdx], pool[bestIdx+1:]...)
+			pieces = bestRest
+		}
+		result.UnplacedParts = append(result.UnplacedParts, pieces...)
+	}
+	return result
+}
+
+// fillBar cuts pieces (sorted longest first) from a single bar, taking each
+// piece that still fits. It returns the bar and the pieces left over.
+func fillBar(stock model.LinearStock, pieces []model.Part, ls model.LinearSettings) (model.LinearBar, []model.Part) {
+	bar := model.LinearBar{Stock: stock}
+	end := stock.Length - ls.EndWaste
+	pos := end - stock.UsableLength(ls)
+
+	var rest []model.Part
+	for _, p := range pieces {
+		if p.Width > 0 && pos+p.Width <= end+1e-9 {
+			bar.Cuts = append(bar.Cuts, model.LinearCut{Part: p, Position: pos})
+			pos += p.Width + ls.Kerf
+		} else {
+			rest = append(rest, p)
+		}
+	}
+	return bar, rest
+}
+
+// linearGroup holds linear parts and stocks for a single material.
+type linearGroup struct {
+	parts  []model.Part
+	stocks []model.LinearStock
+}
+
+// groupLinearByMaterial mirrors groupByMaterial for linear stock.
+func groupLinearByMaterial(parts []model.Part, stocks []model.LinearStock) []linearGroup {
+	materialSet := make(map[string]bool)
+	for _, p := range parts {
+		if p.Material != "" {
+			materialSet[p.Material] = true
+		}
+	}
+	if len(materialSet) == 0 {
+		return []linearGroup{{parts: parts, stocks: stocks}}
+	}
+	materials := make([]string, 0, len(materialSet))
+	for m := range materialSet {
+		materials = append(materials, m)
+	}
+	sort.Strings(materials)
+
+	var groups []linearGroup
+	for _, mat := range materials {
+		var g linearGroup
+		for _, p := range parts {
+			if p.Material == mat {
+				g.parts = append(g.parts, p)
+			}
+		}
+		for _, s := range stocks {
+			if s.Material == mat || s.Material == "" {
+				g.stocks = append(g.stocks, s)
+			}
+		}
+		groups = append(groups, g)
+	}
+
+	var universal linearGroup
+	for _, p := range parts {
+		if p.Material == "" {
+			universal.parts = append(universal.parts, p)
+		}
+	}
+	if len(universal.parts) > 0 {
+		universal.stocks = stocks
+		groups = append(groups, universal)
+	}
+	return groups
+}
@@ -0,0 +1,95 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/piwi3910/SlabCut/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func linearPart(label string, length float64, qty int) model.Part {
+	p := model.NewPart(label, length, 45, qty)
+	p.Linear = true
+	return p
+}
+
+func linearSettings(kerf, trim, endWaste float64) model.CutSettings {
+	s := model.DefaultSettings()
+	s.Linear = model.LinearSettings{Kerf: kerf, Trim: trim, EndWaste: endWaste}
+	return s
+}
+
+func TestOptimizeLinear_PacksBars(t *testing.T) {
+	opt := New(linearSettings(0, 0, 0))
+	parts := []model.Part{linearPart("Rail", 1000, 4), linearPart("Stile", 500, 4)}
+	stocks := []model.LinearStock{model.NewLinearStock("Pine 3m", 3000, 10)}
+
+	result := opt.OptimizeLinear(parts, stocks)
+
+	assert.Empty(t, result.UnplacedParts)
+	require.Len(t, result.Bars, 2)
+	assert.InDelta(t, 100.0, result.TotalEfficiency(), 1e-9)
+}
+
+func TestOptimizeLinear_KerfTrimAndEndWaste(t *testing.T) {
+	opt := New(linearSettings(3, 10, 50))
+	parts := []model.Part{linearPart("Piece", 1000, 3)}
+	stocks := []model.LinearStock{model.NewLinearStock("Bar", 3000, 5)}
+
+	result := opt.OptimizeLinear(parts, stocks)
+
+	// Usable: 3000 - 10 trim - 3 kerf - 50 end = 2937, which fits two pieces with kerf.
+	require.Len(t, result.Bars, 2)
+	bar := result.Bars[0]
+	require.Len(t, bar.Cuts, 2)
+	assert.InDelta(t, 13.0, bar.Cuts[0].Position, 1e-9)
+	assert.InDelta(t, 1016.0, bar.Cuts[1].Position, 1e-9)
+	assert.InDelta(t, 3000.0-2019.0, bar.Remnant(result.Settings), 1e-9)
+}
+
+func TestOptimizeLinear_PrefersBetterFittingStock(t *testing.T) {
+	opt := New(linearSettings(0, 0, 0))
+	parts := []model.Part{linearPart("Post", 1200, 2)}
+	stocks := []model.LinearStock{
+		model.NewLinearStock("Long", 6000, 2),
+		model.NewLinearStock("Short", 2400, 2),
+	}
+
+	result := opt.OptimizeLinear(parts, stocks)
+
+	require.Len(t, result.Bars, 1)
+	assert.Equal(t, "Short", result.Bars[0].Stock.Label)
+}
+
+func TestOptimizeLinear_UnplacedAndMaterials(t *testing.T) {
+	opt := New(linearSettings(3, 0, 0))
+	oak := linearPart("Oak rail", 800, 1)
+	oak.Material = "Oak"
+	parts := []model.Part{linearPart("Too long", 5000, 1), oak}
+	pine := model.NewLinearStock("Pine", 2400, 1)
+	pine.Material = "Pine"
+	stocks := []model.LinearStock{pine}
+
+	result := opt.OptimizeLinear(parts, stocks)
+
+	assert.Empty(t, result.Bars)
+	assert.Len(t, result.UnplacedParts, 2)
+}
+
+func TestOptimize_SkipsLinearParts(t *testing.T) {
+	opt := New(model.DefaultSettings())
+	parts := []model.Part{model.NewPart("Panel", 500, 400, 1), linearPart("Rail", 900, 2)}
+	stocks := []model.StockSheet{model.NewStockSheet("Sheet", 2440, 1220, 1)}
+
+	result := opt.Optimize(parts, stocks)
+	assertValidLayout(t, opt.Settings, result)
+
+	require.Len(t, result.Sheets, 1)
+	assert.Len(t, result.Sheets[0].Placements, 1)
+	assert.Empty(t, result.UnplacedParts)
+
+	linear := opt.OptimizeLinear(parts, []model.LinearStock{model.NewLinearStock("Bar", 2400, 1)})
+	require.Len(t, linear.Bars, 1)
+	assert.Len(t, linear.Bars[0].Cuts, 2)
+}
@@ -0,0 +1,244 @@
+package engine
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/piwi3910/SlabCut/internal/model"
+)
+
+// SnapPosition adjusts the top-left corner of a placement being dragged to
+// (x, y). Each axis snaps independently to the nearest position within
+// tolerance mm that lines the placement up with the edge trim, aligns it
+// with a neighbour's edge, or leaves exactly one kerf to a neighbour.
+func SnapPosition(sheet model.SheetResult, idx int, x, y float64, settings model.CutSettings, tolerance float64) (float64, float64) {
+	if idx < 0 || idx >= len(sheet.Placements) {
+		return x, y
+	}
+	p := sheet.Placements[idx]
+	w, h := p.PlacedWidth(), p.PlacedHeight()
+	kerf := settings.KerfWidth
+	trim := settings.EdgeTrim
+
+	xs := []float64{trim, sheet.Stock.Width - trim - w}
+	ys := []float64{trim, sheet.Stock.Height - trim - h}
+	for j, other := range sheet.Placements {
+		if j == idx {
+			continue
+		}
+		ow, oh := other.PlacedWidth(), other.PlacedHeight()
+		xs = append(xs, other.X+ow+kerf, other.X-kerf-w, other.X, other.X+ow-w)
+		ys = append(ys, other.Y+oh+kerf, other.Y-kerf-h, other.Y, other.Y+oh-h)
+	}
+	return snapTo(x, xs, tolerance), snapTo(y, ys, tolerance)
+}
+
+// snapTo returns the candidate nearest to v if it is within tolerance,
+// otherwise v itself.
+func snapTo(v float64, candidates []float64, tolerance float64) float64 {
+	best, bestDist := v, tolerance
+	for _, c := range candidates {
+		if d := math.Abs(c - v); d <= bestDist {
+			best, bestDist = c, d
+		}
+	}
+	return best
+}
+
+// FindPosition returns the first free position for a part on a sheet in the
+// given orientation, treating the sheet's current placements and its tab,
+// clamp and defect zones as obstacles.
+func (o *Optimizer) FindPosition(sheet model.SheetResult, part model.Part, rotated bool) (x, y float64, ok bool) {
+	packer := o.newSheetPacker(sheet.Stock, sheet.Placements)
+	w, h := part.Width, part.Height
+	if rotated {
+		w, h = h, w
+	}
+	ok, x, y = packer.insert(w, h)
+	return x, y, ok
+}
+
+// MovePlacement moves placement idx of sheet from to the first free position
+// on sheet to, keeping its orientation if possible. The moved placement is
+// pinned. It returns an error if either index is invalid or the part does
+// not fit on the target sheet.
+func (o *Optimizer) MovePlacement(result *model.OptimizeResult, from, idx, to int) error {
+	if from < 0 || from >= len(result.Sheets) || to < 0 || to >= len(result.Sheets) {
+		return fmt.Errorf("invalid sheet index")
+	}
+	src := &result.Sheets[from]
+	if idx < 0 || idx >= len(src.Placements) {
+		return fmt.Errorf("invalid placement index %d", idx)
+	}
+	p := src.Placements[idx]
+	dst := result.Sheets[to]
+
+	canNormal, canRotated := model.CanPlaceWithGrain(p.Part.Grain, dst.Stock.Grain)
+	var orientations []bool
+	if p.Rotated {
+		orientations = []bool{true, false}
+	} else {
+		orientations = []bool{false, true}
+	}
+	for _, rotated := range orientations {
+		if (rotated && !canRotated) || (!rotated && !canNormal) {
+			continue
+		}
+		if x, y, ok := o.FindPosition(dst, p.Part, rotated); ok {
+			p.X, p.Y, p.Rotated, p.Pinned = x, y, rotated, true
+			src.Placements = append(src.Placements[:idx:idx], src.Placements[idx+1:]...)
+			result.Sheets[to].Placements = append(result.Sheets[to].Placements, p)
+			return nil
+		}
+	}
+	return fmt.Errorf("%s does not fit on sheet %d", p.Part.Label, to+1)
+}
+
+// OptimizeAround re-packs parts around the locked sheets and pinned
+// placements of a previous result, so that only changed and unlocked parts
+// move. Locked sheets are kept as they are and nothing is added to them.
+// Sheets holding pinned placements keep their stock and pinned placements,
+// and the other parts are packed into the space left on those sheets first,
+// with the configured algorithm, then onto fresh stock. Placements of parts
+// that were removed or resized are dropped, and stock quantities are reduced
+// by the sheets kept from the previous result.
+func (o *Optimizer) OptimizeAround(prev model.OptimizeResult, parts []model.Part, stocks []model.StockSheet) model.OptimizeResult {
+	parts = model.SheetParts(parts)
+	available := make(map[string]int)
+	for _, p := range parts {
+		available[partKey(p)] += p.Quantity
+	}
+
+	// Keep locked sheets and pinned placements whose parts are unchanged and
+	// still match the sheet's stock.
+	var kept []model.SheetResult
+	var open []int // Indexes into kept of sheets that take more parts
+	for _, sheet := range prev.Sheets {
+		var fixed []model.Placement
+		for _, p := range sheet.Placements {
+			if !stockCompatible(sheet.Stock, p.Part.Material, p.Part.Thickness, o.Settings.ThicknessTolerance) {
+				continue
+			}
+			if key := partKey(p.Part); (sheet.Locked || p.Pinned) && available[key] > 0 {
+				available[key]--
+				fixed = append(fixed, p)
+			}
+		}
+		if len(fixed) == 0 {
+			continue
+		}
+		if !sheet.Locked && sheet.Roll == nil {
+			open = append(open, len(kept))
+		}
+		kept = append(kept, model.SheetResult{Stock: sheet.Stock, Placements: fixed, Roll: sheet.Roll, Locked: sheet.Locked})
+	}
+
+	// Everything else is packed again.
+	var remaining []model.Part
+	for _, p := range parts {
+		key := partKey(p)
+		for ; available[key] > 0; available[key]-- {
+			cp := p
+			cp.Quantity = 1
+			remaining = append(remaining, cp)
+		}
+	}
+	sortPartsByArea(remaining)
+
+	result := model.OptimizeResult{Sheets: kept}
+	if len(open) > 0 && len(remaining) > 0 {
+		sheets := make([]model.SheetResult, len(open))
+		for i, idx := range open {
+			sheets[i] = kept[idx]
+		}
+		filled, unplaced := o.fillSheets(sheets, remaining)
+		for i, idx := range open {
+			result.Sheets[idx] = filled[i]
+		}
+		remaining = unplaced
+	}
+
+	if len(remaining) > 0 {
+		rest := o.Optimize(remaining, remainingStock(stocks, kept))
+		result.Sheets = append(result.Sheets, rest.Sheets...)
+		result.UnplacedParts = rest.UnplacedParts
+		result.Warnings = rest.Warnings
+	}
+	return result
+}
+
+// fillSheets packs parts into the space left on sheets around their existing
+// placements and returns the sheets in the same order, together with the
+// parts that did not fit. Each sheet only takes parts compatible with its
+// stock.
+func (o *Optimizer) fillSheets(sheets []model.SheetResult, parts []model.Part) ([]model.SheetResult, []model.Part) {
+	if o.Settings.Algorithm == model.AlgorithmGenetic {
+		filled := optimizeGeneticAround(o.Settings, parts, nil, sheets)
+		return filled.Sheets, filled.UnplacedParts
+	}
+
+	filled := make([]model.SheetResult, len(sheets))
+	for i, sheet := range sheets {
+		var fits, others []model.Part
+		for _, p := range parts {
+			if stockCompatible(sheet.Stock, p.Material, p.Thickness, o.Settings.ThicknessTolerance) {
+				fits = append(fits, p)
+			} else {
+				others = append(others, p)
+			}
+		}
+		packed, unplaced := o.packSheetBestStrategy(sheet.Stock, fits, sheet.Placements...)
+		filled[i] = packed
+		parts = append(unplaced, others...)
+		sortPartsByArea(parts)
+	}
+	return filled, parts
+}
+
+// partKey identifies a part across optimizer runs by its ID (or label when
+// it has none), size, grain, material and thickness, so that a part whose
+// size, grain or material changed is re-packed rather than kept. Outline
+// parts are keyed by outline area, which survives the rotation baked into
+// nested outlines.
+func partKey(p model.Part) string {
+	id := p.ID
+	if id == "" {
+		id = p.Label
+	}
+	if len(p.Outline) > 0 {
+		return fmt.Sprintf("%s|%.1f|%s|%s|%g", id, p.Outline.Area(), p.Grain, p.Material, p.Thickness)
+	}
+	return fmt.Sprintf("%s|%g|%g|%s|%s|%g", id, p.Width, p.Height, p.Grain, p.Material, p.Thickness)
+}
+
+// sortPartsByArea sorts parts largest first, the order the packers expect.
+func sortPartsByArea(parts []model.Part) {
+	sort.SliceStable(parts, func(i, j int) bool {
+		return parts[i].Width*parts[i].Height > parts[j].Width*parts[j].Height
+	})
+}
+
+// remainingStock returns stocks with one sheet deducted for each kept sheet
+// cut from them.
+func remainingStock(stocks []model.StockSheet, kept []model.SheetResult) []model.StockSheet {
+	out := make([]model.StockSheet, len(stocks))
+	copy(out, stocks)
+	for _, sheet := range kept {
+		for i := range out {
+			if out[i].Quantity > 0 && sameStock(out[i], sheet.Stock) {
+				out[i].Quantity--
+				break
+			}
+		}
+	}
+	return out
+}
+
+// sameStock reports whether a result sheet was cut from the given stock.
+func sameStock(stock, used model.StockSheet) bool {
+	if stock.ID != "" || used.ID != "" {
+		return stock.ID == used.ID
+	}
+	return stock.Label == used.Label && stock.Width == used.Width && stock.Height == used.Height
+}
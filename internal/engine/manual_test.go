@@ -0,0 +1,223 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/piwi3910/SlabCut/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapPosition(t *testing.T) {
+	settings := validateSettings()
+	sheet := model.SheetResult{
+		Stock: model.NewStockSheet("Sheet", 1000, 600, 1),
+		Placements: []model.Placement{
+			{Part: model.NewPart("A", 400, 300, 1), X: 10, Y: 10},
+			{Part: model.NewPart("B", 200, 100, 1), X: 500, Y: 200},
+		},
+	}
+
+	// Near A's right edge plus kerf and near the top trim
+	x, y := SnapPosition(sheet, 1, 416, 14, settings, 5)
+	assert.Equal(t, 413.0, x)
+	assert.Equal(t, 10.0, y)
+
+	// Near the bottom-right trim corner
+	x, y = SnapPosition(sheet, 1, 788, 487, settings, 5)
+	assert.Equal(t, 790.0, x)
+	assert.Equal(t, 490.0, y)
+
+	// Too far from anything to snap
+	x, y = SnapPosition(sheet, 1, 600, 400, settings, 5)
+	assert.Equal(t, 600.0, x)
+	assert.Equal(t, 400.0, y)
+}
+
+func TestFindPosition(t *testing.T) {
+	opt := New(validateSettings())
+	sheet := model.SheetResult{
+		Stock:      model.NewStockSheet("Sheet", 1000, 600, 1),
+		Placements: []model.Placement{{Part: model.NewPart("A", 400, 580, 1), X: 10, Y: 10}},
+	}
+
+	x, y, ok := opt.FindPosition(sheet, model.NewPart("B", 300, 200, 1), false)
+	require.True(t, ok)
+	sheet.Placements = append(sheet.Placements, model.Placement{Part: model.NewPart("B", 300, 200, 1), X: x, Y: y})
+	assertValidLayout(t, opt.Settings, model.OptimizeResult{Sheets: []model.SheetResult{sheet}})
+
+	_, _, ok = opt.FindPosition(sheet, model.NewPart("Huge", 700, 500, 1), false)
+	assert.False(t, ok)
+}
+
+func TestMovePlacement(t *testing.T) {
+	opt := New(validateSettings())
+	result := model.OptimizeResult{Sheets: []model.SheetResult{
+		{
+			Stock: model.NewStockSheet("One", 1000, 600, 1),
+			Placements: []model.Placement{
+				{Part: model.NewPart("A", 400, 300, 1), X: 10, Y: 10},
+				{Part: model.NewPart("B", 200, 100, 1), X: 413, Y: 10},
+			},
+		},
+		{
+			Stock:      model.NewStockSheet("Two", 1000, 600, 1),
+			Placements: []model.Placement{{Part: model.NewPart("C", 500, 500, 1), X: 10, Y: 10}},
+		},
+	}}
+
+	require.NoError(t, opt.MovePlacement(&result, 0, 1, 1))
+	require.Len(t, result.Sheets[0].Placements, 1)
+	require.Len(t, result.Sheets[1].Placements, 2)
+	moved := result.Sheets[1].Placements[1]
+	assert.Equal(t, "B", moved.Part.Label)
+	assert.True(t, moved.Pinned)
+	assertValidLayout(t, opt.Settings, result)
+
+	// Fill sheet two so that A no longer fits
+	result.Sheets[1].Placements = append(result.Sheets[1].Placements,
+		model.Placement{Part: model.NewPart("D", 474, 470, 1), X: moved.X, Y: moved.Y + 106})
+	assert.Error(t, opt.MovePlacement(&result, 0, 0, 1))
+	assert.Error(t, opt.MovePlacement(&result, 0, 5, 1))
+}
+
+func TestOptimizeAround_KeepsPinnedPlacements(t *testing.T) {
+	opt := New(validateSettings())
+	big := model.NewPart("Big", 600, 400, 1)
+	small := model.NewPart("Small", 200, 150, 4)
+	stocks := []model.StockSheet{model.NewStockSheet("Sheet", 1000, 600, 2)}
+
+	first := opt.Optimize([]model.Part{big, small}, stocks)
+	require.Len(t, first.Sheets, 1)
+
+	// Pin Big in the bottom-right corner by hand
+	for i, p := range first.Sheets[0].Placements {
+		if p.Part.Label == "Big" {
+			first.Sheets[0].Placements[i].X = 1000 - 10 - 600
+			first.Sheets[0].Placements[i].Y = 600 - 10 - 400
+			first.Sheets[0].Placements[i].Pinned = true
+		}
+	}
+
+	result := opt.OptimizeAround(first, []model.Part{big, small}, stocks)
+
+	assert.Empty(t, result.UnplacedParts)
+	require.NotEmpty(t, result.Sheets)
+	placed := 0
+	for _, sheet := range result.Sheets {
+		placed += len(sheet.Placements)
+	}
+	assert.Equal(t, 5, placed)
+	var found bool
+	for _, p := range result.Sheets[0].Placements {
+		if p.Part.Label == "Big" {
+			found = true
+			assert.Equal(t, 390.0, p.X)
+			assert.Equal(t, 190.0, p.Y)
+			assert.True(t, p.Pinned)
+		}
+	}
+	assert.True(t, found, "pinned placement should stay on its sheet")
+	assertValidLayout(t, opt.Settings, result)
+}
+
+func TestOptimizeAround_DropsRemovedPartsAndDeductsStock(t *testing.T) {
+	opt := New(validateSettings())
+	gone := model.NewPart("Gone", 900, 500, 1)
+	part := model.NewPart("Panel", 900, 500, 1)
+	stocks := []model.StockSheet{model.NewStockSheet("Sheet", 1000, 600, 2)}
+
+	prev := model.OptimizeResult{Sheets: []model.SheetResult{
+		{Stock: stocks[0], Placements: []model.Placement{{Part: gone, X: 10, Y: 10, Pinned: true}}},
+		{Stock: stocks[0], Placements: []model.Placement{{Part: part, X: 10, Y: 10, Pinned: true}}},
+	}}
+
+	result := opt.OptimizeAround(prev, []model.Part{part, model.NewPart("Extra", 900, 500, 2)}, stocks)
+
+	// Panel keeps its sheet, one Extra gets the last stock sheet, the other is unplaced
+	require.Len(t, result.Sheets, 2)
+	assert.Equal(t, "Panel", result.Sheets[0].Placements[0].Part.Label)
+	assert.Len(t, result.UnplacedParts, 1)
+}
+
+func TestOptimizeAround_RepacksPartsWithNewMaterial(t *testing.T) {
+	opt := New(validateSettings())
+	mdf := model.NewStockSheet("MDF", 1000, 600, 1)
+	mdf.Material = "MDF"
+	oak := model.NewStockSheet("Oak", 1000, 600, 1)
+	oak.Material = "Oak"
+	part := model.NewPart("Panel", 400, 300, 1)
+	part.Material = "MDF"
+
+	prev := model.OptimizeResult{Sheets: []model.SheetResult{
+		{Stock: mdf, Placements: []model.Placement{{Part: part, X: 10, Y: 10, Pinned: true}}, Locked: true},
+	}}
+
+	// The part is switched to oak after it was pinned on the MDF sheet
+	changed := part
+	changed.Material = "Oak"
+	result := opt.OptimizeAround(prev, []model.Part{changed}, []model.StockSheet{mdf, oak})
+
+	require.Len(t, result.Sheets, 1)
+	assert.Equal(t, "Oak", result.Sheets[0].Stock.Material)
+	assert.Empty(t, result.UnplacedParts)
+}
+
+func TestOptimizeAround_LockedSheetUntouched(t *testing.T) {
+	opt := New(validateSettings())
+	side := model.NewPart("Side", 400, 500, 2)
+	shelf := model.NewPart("Shelf", 300, 200, 4)
+	stocks := []model.StockSheet{model.NewStockSheet("Sheet", 1000, 600, 3)}
+
+	first := opt.Optimize([]model.Part{side, shelf}, stocks)
+	require.NotEmpty(t, first.Sheets)
+	first.Sheets[0].Locked = true
+	locked := first.Sheets[0]
+
+	// One shelf changes size; everything else stays the same
+	changed := shelf
+	changed.Width = 320
+	result := opt.OptimizeAround(first, []model.Part{side, changed}, stocks)
+
+	// The locked sheet keeps its sides where they were and loses the resized shelves
+	var sides []model.Placement
+	for _, p := range locked.Placements {
+		if p.Part.Label == "Side" {
+			sides = append(sides, p)
+		}
+	}
+	require.NotEmpty(t, result.Sheets)
+	assert.True(t, result.Sheets[0].Locked)
+	assert.Equal(t, sides, result.Sheets[0].Placements)
+
+	placed := 0
+	for _, sheet := range result.Sheets {
+		placed += len(sheet.Placements)
+	}
+	assert.Equal(t, 6, placed)
+	assert.Empty(t, result.UnplacedParts)
+	assertValidLayout(t, opt.Settings, result)
+}
+
+func TestOptimizeAround_Genetic(t *testing.T) {
+	settings := validateSettings()
+	settings.Algorithm = model.AlgorithmGenetic
+	opt := New(settings)
+	stocks := []model.StockSheet{model.NewStockSheet("Sheet", 1000, 600, 2)}
+	pinned := model.NewPart("Pinned", 300, 300, 1)
+	small := model.NewPart("Small", 200, 150, 6)
+
+	prev := model.OptimizeResult{Sheets: []model.SheetResult{
+		{Stock: stocks[0], Placements: []model.Placement{{Part: pinned, X: 500, Y: 150, Pinned: true}}},
+	}}
+	result := opt.OptimizeAround(prev, []model.Part{pinned, small}, stocks)
+
+	require.NotEmpty(t, result.Sheets)
+	assert.Empty(t, result.UnplacedParts)
+	first := result.Sheets[0]
+	require.Greater(t, len(first.Placements), 1, "free space around the pinned part should be used")
+	assert.Equal(t, "Pinned", first.Placements[0].Part.Label)
+	assert.Equal(t, 500.0, first.Placements[0].X)
+	assert.Equal(t, 150.0, first.Placements[0].Y)
+	assertValidLayout(t, settings, result)
+}
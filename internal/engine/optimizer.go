@@ -1,6 +1,7 @@
 package engine
 
 import (
+	"fmt"
 	"math"
 	"sort"
 
@@ -19,127 +20,181 @@ func New(settings model.CutSettings) *Optimizer {
 // Optimize takes parts and stock sheets, returns an optimized layout.
 // When parts and stocks have material types set, optimization is performed
 // per material group: parts with a specific material are only placed on
-// stocks of the same material. Parts or stocks with empty material are
-// treated as universal (compatible with anything).
+// stocks of the same material, and parts with a thickness only on stocks
+// within Settings.ThicknessTolerance of it. Parts or stocks with empty
+// material or zero thickness are treated as universal (compatible with
+// anything). Parts without compatible stock are returned unplaced and a
+// warning is added to the result. Linear parts are left to
+// OptimizeLinear and skipped here. Groups that include roll stock always use
+// the guillotine packer, which strip-packs rolls.
 func (o *Optimizer) Optimize(parts []model.Part, stocks []model.StockSheet) model.OptimizeResult {
-	// Group parts and stocks by material for multi-material optimization
-	groups := groupByMaterial(parts, stocks)
+	parts = model.SheetParts(parts)
+
+	// Group parts and stocks by material and thickness for multi-material optimization
+	groups := groupByMaterial(parts, stocks, o.Settings.ThicknessTolerance)
+
+	// Groups draw on one pool of sheets: a stock compatible with several
+	// groups is shared between them, not used in full by each
+	pool := newStockPool(stocks)
 
 	combined := model.OptimizeResult{}
 	for _, g := range groups {
+		if len(g.parts) > 0 && len(g.stocks) == 0 {
+			combined.Warnings = append(combined.Warnings, fmt.Sprintf(
+				"No compatible stock for %d part type(s) of %s", len(g.parts), describeGroup(g)))
+		}
+		available := pool.available(g.stocks)
 		var groupResult model.OptimizeResult
-		if o.Settings.Algorithm == model.AlgorithmGenetic {
-			groupResult = OptimizeGenetic(o.Settings, g.parts, g.stocks)
+		if o.Settings.Algorithm == model.AlgorithmGenetic && !hasRollStock(available) {
+			groupResult = OptimizeGenetic(o.Settings, g.parts, available)
 		} else {
-			groupResult = o.optimizeGuillotine(g.parts, g.stocks)
+			groupResult = o.optimizeGuillotine(g.parts, available)
 		}
+		pool.use(groupResult.Sheets)
 		combined.Sheets = append(combined.Sheets, groupResult.Sheets...)
 		combined.UnplacedParts = append(combined.UnplacedParts, groupResult.UnplacedParts...)
 	}
 	return combined
 }
 
-// materialGroup holds parts and stocks for a single material type.
+// materialGroup holds parts and stocks for a single material type and
+// thickness. A zero thickness means the parts accept any thickness.
 type materialGroup struct {
-	material string
-	parts    []model.Part
-	stocks   []model.StockSheet
-}
-
-// groupByMaterial splits parts and stocks into groups by material type.
-// Parts with an empty material can go on any stock, so they are added to
-// every group. Stocks with an empty material can accept any part, so they
-// are added to every group. If no materials are specified at all, everything
-// goes into one group.
-func groupByMaterial(parts []model.Part, stocks []model.StockSheet) []materialGroup {
-	// Collect all unique non-empty material names
-	materialSet := make(map[string]bool)
+	material  string
+	thickness float64
+	parts     []model.Part
+	stocks    []model.StockSheet
+}
+
+// groupByMaterial splits parts into groups by material type and thickness and
+// pairs each group with the stocks it may use. A stock is compatible when its
+// material matches (an empty material on either side matches anything) and
+// its thickness is within tolerance of the parts' thickness (a zero thickness
+// on either side matches anything). Groups are ordered by material, then
+// thickness, with parts without a material last. If no materials or
+// thicknesses are specified at all, everything goes into one group.
+func groupByMaterial(parts []model.Part, stocks []model.StockSheet, tolerance float64) []materialGroup {
+	type groupKey struct {
+		material  string
+		thickness float64
+	}
+	index := make(map[groupKey]int)
+	var groups []materialGroup
 	for _, p := range parts {
-		if p.Material != "" {
-			materialSet[p.Material] = true
+		key := groupKey{p.Material, p.Thickness}
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, materialGroup{material: key.material, thickness: key.thickness})
 		}
+		groups[i].parts = append(groups[i].parts, p)
 	}
-	for _, s := range stocks {
-		if s.Material != "" {
-			materialSet[s.Material] = true
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		a, b := groups[i], groups[j]
+		if (a.material == "") != (b.material == "") {
+			return b.material == ""
 		}
-	}
+		if a.material != b.material {
+			return a.material < b.material
+		}
+		return a.thickness < b.thickness
+	})
 
-	// If no materials specified, return a single group with everything
-	if len(materialSet) == 0 {
-		return []materialGroup{{parts: parts, stocks: stocks}}
+	for i := range groups {
+		g := &groups[i]
+		for _, s := range stocks {
+			if stockCompatible(s, g.material, g.thickness, tolerance) {
+				g.stocks = append(g.stocks, s)
+			}
+		}
 	}
-
-	materials := make([]string, 0, len(materialSet))
-	for m := range materialSet {
-		materials = append(materials, m)
+	if len(groups) == 0 {
+		return []materialGroup{{stocks: stocks}}
 	}
-	sort.Strings(materials)
+	return groups
+}
 
-	// Build groups
-	groups := make([]materialGroup, 0, len(materials))
-	var universalParts []model.Part
-	var universalStocks []model.StockSheet
+// stockPool counts the sheets of each stock left to the material groups.
+type stockPool map[stockID]int
 
-	for _, p := range parts {
-		if p.Material == "" {
-			universalParts = append(universalParts, p)
-		}
+// stockID identifies a stock across the copies the optimizers make of it.
+// The height is left out because a sheet cut from a roll has the length
+// taken off the roll as its height.
+type stockID struct {
+	id, material     string
+	width, thickness float64
+	roll             bool
+}
+
+func idOf(s model.StockSheet) stockID {
+	return stockID{s.ID, s.Material, s.Width, s.Thickness, s.Roll}
+}
+
+func newStockPool(stocks []model.StockSheet) stockPool {
+	pool := make(stockPool)
+	for _, s := range stocks {
+		pool[idOf(s)] += s.Quantity
 	}
+	return pool
+}
+
+// available returns the stocks that still have sheets left, with their
+// quantities cut down to what is left.
+func (pool stockPool) available(stocks []model.StockSheet) []model.StockSheet {
+	var out []model.StockSheet
+	seen := make(map[stockID]bool)
 	for _, s := range stocks {
-		if s.Material == "" {
-			universalStocks = append(universalStocks, s)
+		id := idOf(s)
+		if seen[id] || pool[id] <= 0 {
+			continue
 		}
+		seen[id] = true
+		s.Quantity = pool[id]
+		out = append(out, s)
 	}
+	return out
+}
 
-	for _, mat := range materials {
-		g := materialGroup{material: mat}
-		for _, p := range parts {
-			if p.Material == mat {
-				g.parts = append(g.parts, p)
-			}
-		}
-		for _, s := range stocks {
-			if s.Material == mat {
-				g.stocks = append(g.stocks, s)
-			}
-		}
-		// Universal stocks can be used by any material group
-		g.stocks = append(g.stocks, universalStocks...)
-		groups = append(groups, g)
+// use takes the sheets of a result out of the pool.
+func (pool stockPool) use(sheets []model.SheetResult) {
+	for _, sheet := range sheets {
+		pool[idOf(sheet.Stock)]--
 	}
+}
 
-	// If there are universal parts (no material), create a group for them
-	// using all stocks (universal stocks + all material stocks)
-	if len(universalParts) > 0 {
-		g := materialGroup{parts: universalParts}
-		g.stocks = append(g.stocks, stocks...)
-		groups = append(groups, g)
+// stockCompatible reports whether a stock sheet can be used for parts of the
+// given material and thickness.
+func stockCompatible(s model.StockSheet, material string, thickness, tolerance float64) bool {
+	if material != "" && s.Material != "" && s.Material != material {
+		return false
+	}
+	if thickness > 0 && s.Thickness > 0 && math.Abs(s.Thickness-thickness) > tolerance+1e-9 {
+		return false
 	}
+	return true
+}
 
-	return groups
+// describeGroup names a material group for warnings, e.g. "18 mm MDF".
+func describeGroup(g materialGroup) string {
+	name := g.material
+	if name == "" {
+		name = "any material"
+	}
+	if g.thickness > 0 {
+		return fmt.Sprintf("%g mm %s", g.thickness, name)
+	}
+	return name
 }
 
 // addCutoutFreeRects injects free rectangles into the packer for interior cutout
 // zones of a placed part. This allows smaller parts to be nested inside the
 // waste holes of larger parts, maximizing material utilization.
 func addCutoutFreeRects(packer *guillotinePacker, part model.Part, partX, partY float64, rotated bool, kerf float64) {
-	cutoutRects := part.CutoutBounds()
-	for _, cr := range cutoutRects {
-		// Transform cutout coordinates from part-local to sheet-absolute
-		var absX, absY, absW, absH float64
-		if rotated {
-			// When the part is rotated 90 degrees, X and Y swap
-			absX = partX + cr.Y
-			absY = partY + cr.X
-			absW = cr.Height
-			absH = cr.Width
-		} else {
-			absX = partX + cr.X
-			absY = partY + cr.Y
-			absW = cr.Width
-			absH = cr.Height
-		}
+	placed := model.Placement{Part: part, X: partX, Y: partY, Rotated: rotated}
+	for _, cr := range placed.CutoutRects() {
+		absX, absY, absW, absH := cr.X, cr.Y, cr.Width, cr.Height
 
 		// Shrink by kerf to account for the cut line around the cutout
 		absX += kerf
@@ -206,6 +261,7 @@ func (o *Optimizer) tryOutlineRotations(packer *guillotinePacker, sheet *model.S
 			// Create a copy of the part with the rotated outline and updated dimensions
 			placedPart := part
 			placedPart.Outline = c.outline
+			placedPart.Tabs = rotateTabs(part, c.angle)
 			placedPart.Width = c.width
 			placedPart.Height = c.height
 
@@ -221,6 +277,88 @@ func (o *Optimizer) tryOutlineRotations(packer *guillotinePacker, sheet *model.S
 	return false
 }
 
+// rotateTabs turns a part's hand-placed tabs with its outline. The tabs lie
+// on the outline, so rotating them together leaves the bounding box, and so
+// the outline's placement, unchanged.
+func rotateTabs(part model.Part, angle float64) []model.PartTab {
+	if len(part.Tabs) == 0 || angle == 0 {
+		return part.Tabs
+	}
+	pts := append(model.Outline(nil), part.Outline...)
+	for _, t := range part.Tabs {
+		pts = append(pts, model.Point2D{X: t.X, Y: t.Y})
+	}
+	rotated := pts.Rotate(angle)[len(part.Outline):]
+	tabs := make([]model.PartTab, len(rotated))
+	for i, pt := range rotated {
+		tabs[i] = model.PartTab{X: pt.X, Y: pt.Y}
+	}
+	return tabs
+}
+
+// nestingAngle returns the rotation tryOutlineRotations turned the part's
+// outline by to get the placed one, and false if none of the numRotations
+// angles does.
+func nestingAngle(part, placed model.Part, numRotations int) (float64, bool) {
+	if len(part.Outline) == 0 || len(part.Outline) != len(placed.Outline) {
+		return 0, false
+	}
+	if numRotations < 1 {
+		numRotations = 2
+	}
+	for i := 0; i < numRotations; i++ {
+		angle := float64(i) * math.Pi / float64(numRotations)
+		rotated := part.Outline.Rotate(angle)
+		same := true
+		for j, pt := range rotated {
+			if math.Abs(pt.X-placed.Outline[j].X) > 1e-6 || math.Abs(pt.Y-placed.Outline[j].Y) > 1e-6 {
+				same = false
+				break
+			}
+		}
+		if same {
+			return angle, true
+		}
+	}
+	return 0, false
+}
+
+// PlacedTabs returns the part's tabs turned with its outline to the angle
+// it was nested at in placed, and false if placed is not the part at one of
+// the numRotations nesting angles.
+func PlacedTabs(part, placed model.Part, numRotations int) ([]model.PartTab, bool) {
+	angle, ok := nestingAngle(part, placed, numRotations)
+	if !ok {
+		return nil, false
+	}
+	return rotateTabs(part, angle), true
+}
+
+// PartTabs turns the tabs of a nested placement back to the frame of the
+// part's own outline, the inverse of PlacedTabs, so tabs edited on a turned
+// placement can be kept on the part. It returns false if placed is not the
+// part at one of the numRotations nesting angles.
+func PartTabs(part, placed model.Part, numRotations int) ([]model.PartTab, bool) {
+	angle, ok := nestingAngle(part, placed, numRotations)
+	if !ok {
+		return nil, false
+	}
+	pts := append(model.Outline(nil), placed.Outline...)
+	for _, t := range placed.Tabs {
+		pts = append(pts, model.Point2D{X: t.X, Y: t.Y})
+	}
+	turned := pts.Rotate(-angle)
+
+	// Rotate moves the points to the origin; put the tabs back where the
+	// part's own outline lies
+	dx, dy := part.Outline[0].X-turned[0].X, part.Outline[0].Y-turned[0].Y
+	tabs := make([]model.PartTab, 0, len(placed.Tabs))
+	for _, pt := range turned[len(placed.Outline):] {
+		tabs = append(tabs, model.PartTab{X: pt.X + dx, Y: pt.Y + dy})
+	}
+	return tabs, true
+}
+
 // optimizeGuillotine uses a guillotine-based shelf algorithm with best-fit decreasing heuristic.
 func (o *Optimizer) optimizeGuillotine(parts []model.Part, stocks []model.StockSheet) model.OptimizeResult {
 	// Expand parts by quantity into individual placement candidates
@@ -265,7 +403,13 @@ func (o *Optimizer) optimizeGuillotine(parts []model.Part, stocks []model.StockS
 		stockPool = append(stockPool[:bestStockIdx], stockPool[bestStockIdx+1:]...)
 
 		// Try multiple rotation strategies and keep the best result for this sheet
-		bestSheet, bestUnplaced := o.packSheetBestStrategy(stock, remaining)
+		var bestSheet model.SheetResult
+		var bestUnplaced []model.Part
+		if stock.Roll {
+			bestSheet, bestUnplaced = o.packRoll(stock, remaining)
+		} else {
+			bestSheet, bestUnplaced = o.packSheetBestStrategy(stock, remaining)
+		}
 
 		if len(bestSheet.Placements) > 0 {
 			result.Sheets = append(result.Sheets, bestSheet)
@@ -287,7 +431,8 @@ const (
 )
 
 // packSheetBestStrategy tries multiple rotation strategies and returns the best result.
-func (o *Optimizer) packSheetBestStrategy(stock model.StockSheet, parts []model.Part) (model.SheetResult, []model.Part) {
+// Fixed placements are kept on the sheet and packed around.
+func (o *Optimizer) packSheetBestStrategy(stock model.StockSheet, parts []model.Part, fixed ...model.Placement) (model.SheetResult, []model.Part) {
 	strategies := []rotationStrategy{rotBestFit, rotAllNormal, rotAllRotated}
 
 	var bestSheet model.SheetResult
@@ -295,7 +440,7 @@ func (o *Optimizer) packSheetBestStrategy(stock model.StockSheet, parts []model.
 	bestPlaced := -1
 
 	for _, strat := range strategies {
-		sheet, unplaced := o.packSheet(stock, parts, strat)
+		sheet, unplaced := o.packSheet(stock, parts, strat, fixed...)
 		placed := len(sheet.Placements)
 		if placed > bestPlaced {
 			bestPlaced = placed
@@ -313,17 +458,13 @@ func (o *Optimizer) packSheetBestStrategy(stock model.StockSheet, parts []model.
 }
 
 // packSheet packs parts into a single stock sheet using the given rotation strategy.
-func (o *Optimizer) packSheet(stock model.StockSheet, parts []model.Part, strategy rotationStrategy) (model.SheetResult, []model.Part) {
+// Fixed placements are kept as they are and treated as obstacles.
+func (o *Optimizer) packSheet(stock model.StockSheet, parts []model.Part, strategy rotationStrategy, fixed ...model.Placement) (model.SheetResult, []model.Part) {
 	sheet := model.SheetResult{Stock: stock}
+	sheet.Placements = append(sheet.Placements, fixed...)
 	var unplaced []model.Part
 
-	tabConfig := stock.Tabs
-	if !tabConfig.Enabled {
-		tabConfig = o.Settings.StockTabs
-	}
-
-	freeRects := o.calculateFreeRects(stock, tabConfig)
-	packer := newGuillotinePackerWithRects(freeRects, o.Settings.KerfWidth)
+	packer := o.newSheetPacker(stock, fixed)
 
 	for _, part := range parts {
 		placed := false
@@ -453,6 +594,30 @@ func (o *Optimizer) packSheet(stock model.StockSheet, parts []model.Part, strate
 	return sheet, unplaced
 }
 
+// newSheetPacker creates a packer for a stock sheet with its tab, clamp and
+// defect zones excluded and the given placements already occupying space.
+func (o *Optimizer) newSheetPacker(stock model.StockSheet, occupied []model.Placement) *guillotinePacker {
+	tabConfig := stock.Tabs
+	if !tabConfig.Enabled {
+		tabConfig = o.Settings.StockTabs
+	}
+
+	freeRects := o.calculateFreeRects(stock, tabConfig)
+	packer := newGuillotinePackerWithRects(freeRects, o.Settings.KerfWidth)
+	packer.bottomLeft = stock.Roll
+	for _, p := range occupied {
+		packer.splitAroundPlacement(rect{
+			x: p.X, y: p.Y,
+			w: p.PlacedWidth() + o.Settings.KerfWidth,
+			h: p.PlacedHeight() + o.Settings.KerfWidth,
+		})
+		if len(p.Part.Cutouts) > 0 {
+			addCutoutFreeRects(packer, p.Part, p.X, p.Y, p.Rotated, o.Settings.KerfWidth)
+		}
+	}
+	return packer
+}
+
 // calculateFreeRects computes the initial free rectangles for packing,
 // accounting for edge trim and stock tab exclusion zones.
 func (o *Optimizer) calculateFreeRects(stock model.StockSheet, tabConfig model.StockTabConfig) []rect {
@@ -465,10 +630,40 @@ func (o *Optimizer) calculateFreeRects(stock model.StockSheet, tabConfig model.S
 	}
 
 	// Get exclusion zones from stock tabs
+	exclusions := stockTabZones(stock, tabConfig)
+
+	// Add clamp zone exclusions (always applied regardless of tab config)
+	for _, cz := range o.Settings.ClampZones {
+		exclusions = append(exclusions, model.TabZone{
+			X:      cz.X,
+			Y:      cz.Y,
+			Width:  cz.Width,
+			Height: cz.Height,
+		})
+	}
+
+	// Add this sheet's defects
+	for _, d := range stock.Defects {
+		exclusions = append(exclusions, d.ExclusionZones()...)
+	}
+
+	// If no exclusions at all, return the base rect directly
+	if len(exclusions) == 0 {
+		return []rect{baseRect}
+	}
+
+	// Subtract exclusions from base rect to get free rectangles
+	return o.subtractExclusions(baseRect, exclusions)
+}
+
+// stockTabZones returns the areas of a stock sheet reserved for holding tabs.
+// In simple mode each edge padding becomes a full-length strip; in advanced
+// mode the custom zones are used as they are.
+func stockTabZones(stock model.StockSheet, tabConfig model.StockTabConfig) []model.TabZone {
 	var exclusions []model.TabZone
 	if tabConfig.Enabled {
 		if tabConfig.AdvancedMode {
-			exclusions = tabConfig.CustomZones
+			exclusions = append(exclusions, tabConfig.CustomZones...)
 		} else {
 			// Simple mode: convert padding to exclusion zones
 			if tabConfig.TopPadding > 0 {
@@ -505,24 +700,7 @@ func (o *Optimizer) calculateFreeRects(stock model.StockSheet, tabConfig model.S
 			}
 		}
 	}
-
-	// Add clamp zone exclusions (always applied regardless of tab config)
-	for _, cz := range o.Settings.ClampZones {
-		exclusions = append(exclusions, model.TabZone{
-			X:      cz.X,
-			Y:      cz.Y,
-			Width:  cz.Width,
-			Height: cz.Height,
-		})
-	}
-
-	// If no exclusions at all, return the base rect directly
-	if len(exclusions) == 0 {
-		return []rect{baseRect}
-	}
-
-	// Subtract exclusions from base rect to get free rectangles
-	return o.subtractExclusions(baseRect, exclusions)
+	return exclusions
 }
 
 // subtractExclusions subtracts exclusion zones from a base rectangle,
@@ -679,6 +857,7 @@ func (o *Optimizer) selectBestStock(stocks []model.StockSheet, parts []model.Par
 	// Find stocks that can fit the largest part (considering rotation and grain)
 	var candidates []int
 	for i, stock := range stocks {
+		stock = o.stripStock(stock, parts)
 		uw := usableWidth(stock)
 		uh := usableHeight(stock)
 		kerf := o.Settings.KerfWidth
@@ -706,14 +885,21 @@ func (o *Optimizer) selectBestStock(stocks []model.StockSheet, parts []model.Par
 	}
 
 	// De-duplicate candidates by stock dimensions to avoid redundant trials.
-	// Multiple sheets of the same size would produce identical packing results.
+	// Multiple sheets of the same size would produce identical packing results,
+	// unless they have defects: those are each trialled, as the same size with
+	// its defects elsewhere can pack very differently.
 	type stockKey struct {
 		w, h float64
+		roll bool
 	}
 	seen := make(map[stockKey]bool)
 	var uniqueCandidates []int
 	for _, idx := range candidates {
-		key := stockKey{stocks[idx].Width, stocks[idx].Height}
+		if len(stocks[idx].Defects) > 0 {
+			uniqueCandidates = append(uniqueCandidates, idx)
+			continue
+		}
+		key := stockKey{stocks[idx].Width, stocks[idx].Height, stocks[idx].Roll}
 		if !seen[key] {
 			seen[key] = true
 			uniqueCandidates = append(uniqueCandidates, idx)
@@ -725,7 +911,7 @@ func (o *Optimizer) selectBestStock(stocks []model.StockSheet, parts []model.Par
 	bestScore := -1.0
 
 	for _, idx := range uniqueCandidates {
-		stock := stocks[idx]
+		stock := o.stripStock(stocks[idx], parts)
 		tabConfig := stock.Tabs
 		if !tabConfig.Enabled {
 			tabConfig = o.Settings.StockTabs
@@ -733,24 +919,32 @@ func (o *Optimizer) selectBestStock(stocks []model.StockSheet, parts []model.Par
 		freeRects := o.calculateFreeRects(stock, tabConfig)
 		packer := newGuillotinePackerWithRects(freeRects, o.Settings.KerfWidth)
 
-		placedArea := 0.0
+		packer.bottomLeft = stock.Roll
+
+		placedArea, end := 0.0, 0.0
 		for _, part := range parts {
 			placed := false
 			canNormal, canRotated := model.CanPlaceWithGrain(part.Grain, stock.Grain)
 			if canNormal {
-				if ok, _, _ := packer.insert(part.Width, part.Height); ok {
+				if ok, _, y := packer.insert(part.Width, part.Height); ok {
 					placedArea += part.Width * part.Height
+					end = math.Max(end, y+part.Height)
 					placed = true
 				}
 			}
 			if !placed && canRotated {
-				if ok, _, _ := packer.insert(part.Height, part.Width); ok {
+				if ok, _, y := packer.insert(part.Height, part.Width); ok {
 					placedArea += part.Width * part.Height
+					end = math.Max(end, y+part.Width)
 				}
 			}
 		}
 
 		stockArea := stock.Width * stock.Height
+		if stock.Roll {
+			// A roll is only charged for the length cut off it
+			stockArea = stock.Width * (end + o.Settings.KerfWidth + o.Settings.EdgeTrim)
+		}
 		if stockArea == 0 {
 			continue
 		}
@@ -773,6 +967,10 @@ func (o *Optimizer) selectBestStock(stocks []model.StockSheet, parts []model.Par
 type guillotinePacker struct {
 	freeRects []rect
 	kerf      float64
+
+	// bottomLeft places each piece as close to the top edge as possible
+	// instead of by best area fit. Used to strip-pack rolls.
+	bottomLeft bool
 }
 
 type rect struct {
@@ -805,8 +1003,9 @@ func (gp *guillotinePacker) insert(w, h float64) (bool, float64, float64) {
 
 	for i, r := range gp.freeRects {
 		if wk <= r.w+0.001 && hk <= r.h+0.001 {
-			areaFit := (r.w * r.h) - (w * h)
-			if bestIdx < 0 || areaFit < bestAreaFit {
+			areaFit := gp.fitScore(r, w, h)
+			if bestIdx < 0 || areaFit < bestAreaFit ||
+				(gp.bottomLeft && areaFit == bestAreaFit && r.x < gp.freeRects[bestIdx].x) {
 				bestIdx = i
 				bestAreaFit = areaFit
 			}
@@ -918,7 +1117,7 @@ func (gp *guillotinePacker) bestFit(w, h float64) float64 {
 
 	for _, r := range gp.freeRects {
 		if wk <= r.w+0.001 && hk <= r.h+0.001 {
-			areaFit := (r.w * r.h) - (w * h)
+			areaFit := gp.fitScore(r, w, h)
 			if best < 0 || areaFit < best {
 				best = areaFit
 			}
@@ -926,3 +1125,13 @@ func (gp *guillotinePacker) bestFit(w, h float64) float64 {
 	}
 	return best
 }
+
+// fitScore rates placing a w x h piece in free rect r; lower is better. It is
+// the wasted area of the rect, or in bottom-left mode the far edge of the
+// placed piece.
+func (gp *guillotinePacker) fitScore(r rect, w, h float64) float64 {
+	if gp.bottomLeft {
+		return r.y + h + gp.kerf
+	}
+	return (r.w * r.h) - (w * h)
+}
@@ -24,6 +24,7 @@ func TestOptimize_SingleStockSinglePart(t *testing.T) {
 	stocks := []model.StockSheet{model.NewStockSheet("Sheet", 1000, 600, 1)}
 
 	result := opt.Optimize(parts, stocks)
+	assertValidLayout(t, opt.Settings, result)
 
 	assert.Len(t, result.Sheets, 1)
 	assert.Len(t, result.UnplacedParts, 0)
@@ -47,6 +48,7 @@ func TestOptimize_MultipleStockSizes_SelectsSmallestAdequate(t *testing.T) {
 	}
 
 	result := opt.Optimize(parts, stocks)
+	assertValidLayout(t, opt.Settings, result)
 
 	require.Len(t, result.UnplacedParts, 0, "all parts should be placed")
 	require.GreaterOrEqual(t, len(result.Sheets), 1)
@@ -72,6 +74,7 @@ func TestOptimize_MultipleStockSizes_LargePartForcesLargeSheet(t *testing.T) {
 	}
 
 	result := opt.Optimize(parts, stocks)
+	assertValidLayout(t, opt.Settings, result)
 
 	require.Len(t, result.UnplacedParts, 0)
 	require.Len(t, result.Sheets, 1)
@@ -94,6 +97,7 @@ func TestOptimize_MultipleStockSizes_MixedUsage(t *testing.T) {
 	}
 
 	result := opt.Optimize(parts, stocks)
+	assertValidLayout(t, opt.Settings, result)
 
 	require.Len(t, result.UnplacedParts, 0, "all parts should be placed")
 	require.GreaterOrEqual(t, len(result.Sheets), 1)
@@ -123,6 +127,7 @@ func TestOptimize_AllPartsUnplaceable(t *testing.T) {
 	}
 
 	result := opt.Optimize(parts, stocks)
+	assertValidLayout(t, opt.Settings, result)
 
 	assert.Len(t, result.Sheets, 0, "no sheets should be used")
 	assert.Len(t, result.UnplacedParts, 1)
@@ -133,11 +138,13 @@ func TestOptimize_EmptyInputs(t *testing.T) {
 
 	// No parts
 	result := opt.Optimize(nil, []model.StockSheet{model.NewStockSheet("S", 1000, 500, 1)})
+	assertValidLayout(t, opt.Settings, result)
 	assert.Len(t, result.Sheets, 0)
 	assert.Len(t, result.UnplacedParts, 0)
 
 	// No stocks
 	result = opt.Optimize([]model.Part{model.NewPart("A", 100, 100, 1)}, nil)
+	assertValidLayout(t, opt.Settings, result)
 	assert.Len(t, result.Sheets, 0)
 	assert.Len(t, result.UnplacedParts, 1)
 }
@@ -150,6 +157,7 @@ func TestOptimize_QuantityExpansion(t *testing.T) {
 	stocks := []model.StockSheet{model.NewStockSheet("Sheet", 2440, 1220, 2)}
 
 	result := opt.Optimize(parts, stocks)
+	assertValidLayout(t, opt.Settings, result)
 
 	totalPlaced := 0
 	for _, sheet := range result.Sheets {
@@ -174,6 +182,7 @@ func TestOptimize_WithKerfAndEdgeTrim(t *testing.T) {
 	stocks := []model.StockSheet{model.NewStockSheet("Sheet", 1000, 500, 1)}
 
 	result := opt.Optimize(parts, stocks)
+	assertValidLayout(t, opt.Settings, result)
 
 	assert.Len(t, result.UnplacedParts, 0, "part should fit with kerf and edge trim")
 	assert.Len(t, result.Sheets, 1)
@@ -187,6 +196,7 @@ func TestOptimize_Rotation(t *testing.T) {
 	stocks := []model.StockSheet{model.NewStockSheet("Sheet", 500, 1000, 1)}
 
 	result := opt.Optimize(parts, stocks)
+	assertValidLayout(t, opt.Settings, result)
 
 	require.Len(t, result.UnplacedParts, 0, "part should fit when rotated")
 	require.Len(t, result.Sheets, 1)
@@ -204,6 +214,7 @@ func TestOptimize_GrainPreventsRotation(t *testing.T) {
 	stocks := []model.StockSheet{model.NewStockSheet("Sheet", 500, 1000, 1)}
 
 	result := opt.Optimize(parts, stocks)
+	assertValidLayout(t, opt.Settings, result)
 
 	assert.Len(t, result.UnplacedParts, 1, "grain-locked part should not fit")
 }
@@ -231,6 +242,21 @@ func TestSelectBestStock_TrialPackingPreference(t *testing.T) {
 	assert.Equal(t, 600.0, stocks[idx].Width, "trial packing should prefer the small sheet")
 }
 
+func TestSelectBestStock_TrialsEachDefectLayout(t *testing.T) {
+	// Same-size sheets with one defect each: only the second leaves room
+	// for the part, so both must be trialled
+	opt := New(defaultTestSettings())
+
+	centre := model.NewStockSheet("Centre", 1000, 600, 1)
+	centre.Defects = []model.Defect{model.NewRectDefect("Knot", 400, 250, 100, 100)}
+	corner := model.NewStockSheet("Corner", 1000, 600, 1)
+	corner.Defects = []model.Defect{model.NewRectDefect("Dent", 950, 550, 50, 50)}
+
+	parts := []model.Part{model.NewPart("A", 900, 500, 1)}
+	idx := opt.selectBestStock([]model.StockSheet{centre, corner}, parts)
+	assert.Equal(t, 1, idx, "the sheet with the defect in the corner should be chosen")
+}
+
 func TestSelectBestStock_NoCandidates(t *testing.T) {
 	opt := New(defaultTestSettings())
 
@@ -267,6 +293,7 @@ func TestOptimize_MultipleStockSizes_StockPoolDepletion(t *testing.T) {
 	}
 
 	result := opt.Optimize(parts, stocks)
+	assertValidLayout(t, opt.Settings, result)
 
 	require.Len(t, result.UnplacedParts, 0, "all parts should be placed")
 	assert.GreaterOrEqual(t, len(result.Sheets), 2, "should use at least 2 sheets")
@@ -280,6 +307,7 @@ func TestOptimize_Efficiency(t *testing.T) {
 	stocks := []model.StockSheet{model.NewStockSheet("Sheet", 1000, 1000, 1)}
 
 	result := opt.Optimize(parts, stocks)
+	assertValidLayout(t, opt.Settings, result)
 
 	require.Len(t, result.Sheets, 1)
 	assert.InDelta(t, 50.0, result.TotalEfficiency(), 0.1, "efficiency should be ~50%%")
@@ -325,6 +353,7 @@ func TestOptimize_StockGrainMatchesPart(t *testing.T) {
 	stock.Grain = model.GrainHorizontal
 
 	result := opt.Optimize([]model.Part{part}, []model.StockSheet{stock})
+	assertValidLayout(t, opt.Settings, result)
 
 	require.Len(t, result.UnplacedParts, 0, "part should be placed on matching grain stock")
 	require.Len(t, result.Sheets, 1)
@@ -341,6 +370,7 @@ func TestOptimize_StockGrainMismatchBlocksPlacement(t *testing.T) {
 	stock.Grain = model.GrainVertical
 
 	result := opt.Optimize([]model.Part{part}, []model.StockSheet{stock})
+	assertValidLayout(t, opt.Settings, result)
 
 	assert.Len(t, result.UnplacedParts, 1, "part should not be placed on mismatched grain stock")
 }
@@ -357,6 +387,7 @@ func TestOptimize_StockGrainNoneAllowsAnyPart(t *testing.T) {
 	stock.Grain = model.GrainNone
 
 	result := opt.Optimize([]model.Part{partH, partV}, []model.StockSheet{stock})
+	assertValidLayout(t, opt.Settings, result)
 
 	assert.Len(t, result.UnplacedParts, 0, "all parts should be placed on grain-none stock")
 }
@@ -371,6 +402,7 @@ func TestOptimize_StockGrainPreventsRotation(t *testing.T) {
 	stock.Grain = model.GrainHorizontal
 
 	result := opt.Optimize([]model.Part{part}, []model.StockSheet{stock})
+	assertValidLayout(t, opt.Settings, result)
 
 	assert.Len(t, result.UnplacedParts, 1, "grain-locked part should not fit when rotation is needed")
 }
@@ -385,6 +417,7 @@ func TestOptimize_NoGrainPartOnGrainStock(t *testing.T) {
 	stock.Grain = model.GrainHorizontal
 
 	result := opt.Optimize([]model.Part{part}, []model.StockSheet{stock})
+	assertValidLayout(t, opt.Settings, result)
 
 	require.Len(t, result.UnplacedParts, 0, "no-grain part should fit rotated on grain stock")
 	require.Len(t, result.Sheets, 1)
@@ -408,6 +441,7 @@ func TestOptimize_ClampZoneExclusion(t *testing.T) {
 	stocks := []model.StockSheet{model.NewStockSheet("Sheet", 1000, 600, 1)}
 
 	result := opt.Optimize(parts, stocks)
+	assertValidLayout(t, opt.Settings, result)
 
 	require.Len(t, result.UnplacedParts, 0, "part should still be placed")
 	require.Len(t, result.Sheets, 1)
@@ -435,6 +469,7 @@ func TestOptimize_ClampZoneBlocksPlacement(t *testing.T) {
 	stocks := []model.StockSheet{model.NewStockSheet("Sheet", 500, 500, 1)}
 
 	result := opt.Optimize(parts, stocks)
+	assertValidLayout(t, opt.Settings, result)
 
 	assert.Len(t, result.UnplacedParts, 1, "part should not fit in remaining space")
 }
@@ -456,6 +491,7 @@ func TestOptimize_MultipleClampZones(t *testing.T) {
 	stocks := []model.StockSheet{model.NewStockSheet("Sheet", 1000, 600, 1)}
 
 	result := opt.Optimize(parts, stocks)
+	assertValidLayout(t, opt.Settings, result)
 
 	require.Len(t, result.UnplacedParts, 0, "part should fit in space between clamps")
 	require.Len(t, result.Sheets, 1)
@@ -479,6 +515,7 @@ func TestOptimize_NoClampZones(t *testing.T) {
 	stocks := []model.StockSheet{model.NewStockSheet("Sheet", 1000, 600, 1)}
 
 	result := opt.Optimize(parts, stocks)
+	assertValidLayout(t, opt.Settings, result)
 
 	assert.Len(t, result.UnplacedParts, 0)
 	assert.Len(t, result.Sheets, 1)
@@ -495,7 +532,7 @@ func TestGroupByMaterial_NoMaterials(t *testing.T) {
 		model.NewStockSheet("Sheet1", 1000, 600, 1),
 	}
 
-	groups := groupByMaterial(parts, stocks)
+	groups := groupByMaterial(parts, stocks, 0)
 
 	require.Len(t, groups, 1, "should be single group when no materials")
 	assert.Len(t, groups[0].parts, 2)
@@ -511,7 +548,7 @@ func TestGroupByMaterial_SingleMaterial(t *testing.T) {
 	stockPly := model.NewStockSheet("Ply", 1000, 600, 1)
 	stockPly.Material = "Plywood"
 
-	groups := groupByMaterial([]model.Part{partA, partB}, []model.StockSheet{stockPly})
+	groups := groupByMaterial([]model.Part{partA, partB}, []model.StockSheet{stockPly}, 0)
 
 	require.Len(t, groups, 1, "single material should produce one group")
 	assert.Len(t, groups[0].parts, 2)
@@ -533,6 +570,7 @@ func TestGroupByMaterial_MultipleMaterials(t *testing.T) {
 	groups := groupByMaterial(
 		[]model.Part{partPly, partMDF},
 		[]model.StockSheet{stockPly, stockMDF},
+		0,
 	)
 
 	require.Len(t, groups, 2, "two materials should produce two groups")
@@ -557,6 +595,7 @@ func TestGroupByMaterial_UniversalParts(t *testing.T) {
 	groups := groupByMaterial(
 		[]model.Part{partUniversal, partPly},
 		[]model.StockSheet{stockPly},
+		0,
 	)
 
 	require.Len(t, groups, 2)
@@ -579,6 +618,7 @@ func TestGroupByMaterial_UniversalStocks(t *testing.T) {
 	groups := groupByMaterial(
 		[]model.Part{partPly, partMDF},
 		[]model.StockSheet{stockUniversal},
+		0,
 	)
 
 	require.Len(t, groups, 2)
@@ -605,6 +645,7 @@ func TestOptimize_MultiMaterial_SeparatePlacement(t *testing.T) {
 		[]model.Part{partPly, partMDF},
 		[]model.StockSheet{stockPly, stockMDF},
 	)
+	assertValidLayout(t, opt.Settings, result)
 
 	require.Len(t, result.UnplacedParts, 0, "all parts should be placed")
 	require.Len(t, result.Sheets, 2, "each material should use its own sheet")
@@ -632,6 +673,7 @@ func TestOptimize_MultiMaterial_WrongMaterialNotUsed(t *testing.T) {
 		[]model.Part{partPly},
 		[]model.StockSheet{stockMDF},
 	)
+	assertValidLayout(t, opt.Settings, result)
 
 	assert.Len(t, result.UnplacedParts, 1, "plywood part should not be placed on MDF stock")
 	assert.Len(t, result.Sheets, 0)
@@ -649,12 +691,107 @@ func TestOptimize_MultiMaterial_BackwardCompatible(t *testing.T) {
 	}
 
 	result := opt.Optimize(parts, stocks)
+	assertValidLayout(t, opt.Settings, result)
 
 	require.Len(t, result.UnplacedParts, 0, "all parts should be placed")
 	require.Len(t, result.Sheets, 1)
 	assert.Len(t, result.Sheets[0].Placements, 2)
 }
 
+func TestGroupByMaterial_Thickness(t *testing.T) {
+	part18 := model.NewPart("Side", 500, 300, 1)
+	part18.Material = "MDF"
+	part18.Thickness = 18
+	part12 := model.NewPart("Back", 500, 300, 1)
+	part12.Material = "MDF"
+	part12.Thickness = 12
+
+	stock18 := model.NewStockSheet("MDF18", 1000, 600, 1)
+	stock18.Material = "MDF"
+	stock18.Thickness = 18.3
+	stock12 := model.NewStockSheet("MDF12", 1000, 600, 1)
+	stock12.Material = "MDF"
+	stock12.Thickness = 12
+	stockAny := model.NewStockSheet("MDFAny", 1000, 600, 1)
+	stockAny.Material = "MDF"
+	stockAny.Thickness = 0
+
+	groups := groupByMaterial(
+		[]model.Part{part18, part12},
+		[]model.StockSheet{stock18, stock12, stockAny},
+		0.5,
+	)
+
+	require.Len(t, groups, 2)
+	assert.Equal(t, 12.0, groups[0].thickness)
+	require.Len(t, groups[0].stocks, 2)
+	assert.Equal(t, "MDF12", groups[0].stocks[0].Label)
+	assert.Equal(t, "MDFAny", groups[0].stocks[1].Label)
+	assert.Equal(t, 18.0, groups[1].thickness)
+	require.Len(t, groups[1].stocks, 2)
+	assert.Equal(t, "MDF18", groups[1].stocks[0].Label)
+
+	// A tighter tolerance rejects the 18.3 mm sheet for 18 mm parts.
+	groups = groupByMaterial([]model.Part{part18}, []model.StockSheet{stock18}, 0.2)
+	require.Len(t, groups, 1)
+	assert.Empty(t, groups[0].stocks)
+}
+
+func TestOptimize_GroupsShareStock(t *testing.T) {
+	opt := New(defaultTestSettings())
+
+	part18 := model.NewPart("Side", 500, 300, 1)
+	part18.Material = "MDF"
+	part18.Thickness = 18
+	part12 := model.NewPart("Back", 500, 300, 1)
+	part12.Material = "MDF"
+	part12.Thickness = 12
+
+	// One sheet of no set thickness serves both groups, but only once
+	stock := model.NewStockSheet("MDF", 1000, 600, 1)
+	stock.Material = "MDF"
+	stock.Thickness = 0
+
+	result := opt.Optimize([]model.Part{part18, part12}, []model.StockSheet{stock})
+	assert.Len(t, result.Sheets, 1, "the single sheet should be used once")
+	assert.Len(t, result.UnplacedParts, 1)
+}
+
+func TestOptimize_ThicknessMismatchNotUsed(t *testing.T) {
+	opt := New(defaultTestSettings())
+
+	part := model.NewPart("Side", 500, 300, 1)
+	part.Material = "MDF"
+	part.Thickness = 18
+	stock := model.NewStockSheet("MDF12", 1000, 600, 1)
+	stock.Material = "MDF"
+	stock.Thickness = 12
+
+	result := opt.Optimize([]model.Part{part}, []model.StockSheet{stock})
+	assertValidLayout(t, opt.Settings, result)
+
+	assert.Len(t, result.Sheets, 0)
+	require.Len(t, result.UnplacedParts, 1)
+	require.Len(t, result.Warnings, 1)
+	assert.Contains(t, result.Warnings[0], "18 mm MDF")
+}
+
+func TestOptimize_ThicknessWithinTolerance(t *testing.T) {
+	opt := New(defaultTestSettings())
+
+	part := model.NewPart("Side", 500, 300, 1)
+	part.Thickness = 18
+	stock := model.NewStockSheet("Sheet", 1000, 600, 1)
+	stock.Thickness = 18.4
+
+	result := opt.Optimize([]model.Part{part}, []model.StockSheet{stock})
+	assertValidLayout(t, opt.Settings, result)
+
+	assert.Len(t, result.UnplacedParts, 0)
+	assert.Empty(t, result.Warnings)
+	require.Len(t, result.Sheets, 1)
+}
+
 // ─── Interior Cutout Nesting Tests ─────────────────────────────
 
 func TestCutoutBounds(t *testing.T) {
@@ -723,6 +860,7 @@ func TestOptimize_CutoutNesting_SmallPartInsideCutout(t *testing.T) {
 	stocks := []model.StockSheet{model.NewStockSheet("Sheet", 500, 400, 1)}
 
 	result := opt.Optimize(parts, stocks)
+	assertValidLayout(t, opt.Settings, result)
 
 	require.Len(t, result.UnplacedParts, 0, "small part should nest inside cutout")
 	require.Len(t, result.Sheets, 1)
@@ -740,6 +878,7 @@ func TestOptimize_CutoutNesting_NoCutouts(t *testing.T) {
 	stocks := []model.StockSheet{model.NewStockSheet("Sheet", 1000, 600, 1)}
 
 	result := opt.Optimize(parts, stocks)
+	assertValidLayout(t, opt.Settings, result)
 
 	require.Len(t, result.UnplacedParts, 0)
 	require.Len(t, result.Sheets, 1)
@@ -768,6 +907,7 @@ func TestOptimize_CutoutNesting_TooSmallCutout(t *testing.T) {
 	stocks := []model.StockSheet{model.NewStockSheet("Sheet", 500, 400, 1)}
 
 	result := opt.Optimize(parts, stocks)
+	assertValidLayout(t, opt.Settings, result)
 
 	// The small part won't fit in the 20x20 cutout (too small)
 	assert.Len(t, result.UnplacedParts, 1, "part too large for cutout should be unplaced")
@@ -798,6 +938,7 @@ func TestOptimize_CutoutNesting_MultipleSmallParts(t *testing.T) {
 	stocks := []model.StockSheet{model.NewStockSheet("Sheet", 600, 400, 1)}
 
 	result := opt.Optimize(parts, stocks)
+	assertValidLayout(t, opt.Settings, result)
 
 	require.Len(t, result.UnplacedParts, 0, "small parts should nest inside cutout")
 	require.Len(t, result.Sheets, 1)
@@ -855,6 +996,7 @@ func TestMultiObjective_GeneticWithWasteWeight(t *testing.T) {
 
 	opt := New(s)
 	result := opt.Optimize(parts, stocks)
+	assertValidLayout(t, opt.Settings, result)
 	assert.Len(t, result.UnplacedParts, 0)
 	assert.Greater(t, len(result.Sheets), 0)
 }
@@ -878,6 +1020,7 @@ func TestMultiObjective_GeneticWithSheetWeight(t *testing.T) {
 
 	opt := New(s)
 	result := opt.Optimize(parts, stocks)
+	assertValidLayout(t, opt.Settings, result)
 	assert.Len(t, result.UnplacedParts, 0)
 	// With heavy sheet minimization weight, should use minimal sheets
 	assert.Equal(t, 1, len(result.Sheets))
@@ -1092,6 +1235,7 @@ func TestOptimize_OutlinePartMultipleRotations(t *testing.T) {
 
 	opt := New(s)
 	result := opt.Optimize(parts, stocks)
+	assertValidLayout(t, opt.Settings, result)
 	assert.Len(t, result.UnplacedParts, 0, "outline part should be placed")
 	assert.Len(t, result.Sheets, 1)
 }
@@ -1116,6 +1260,7 @@ func TestOptimize_OutlinePartWithGrainSkipsMultiRotation(t *testing.T) {
 
 	opt := New(s)
 	result := opt.Optimize(parts, stocks)
+	assertValidLayout(t, opt.Settings, result)
 	assert.Len(t, result.UnplacedParts, 0, "grain part should be placed via normal path")
 }
 
@@ -1136,6 +1281,7 @@ func TestOptimize_RotationReducesSheetCount(t *testing.T) {
 	stocks := []model.StockSheet{model.NewStockSheet("Sheet", 500, 500, 5)}
 
 	result := opt.Optimize(parts, stocks)
+	assertValidLayout(t, opt.Settings, result)
 
 	assert.Len(t, result.UnplacedParts, 0, "all parts should be placed")
 	assert.Len(t, result.Sheets, 1, "all 6 narrow parts should fit on one 500x500 sheet with rotation")
@@ -1166,6 +1312,7 @@ func TestOptimize_DefaultSettings_EndToEnd(t *testing.T) {
 
 	opt := New(settings)
 	result := opt.Optimize(parts, stocks)
+	assertValidLayout(t, opt.Settings, result)
 
 	// Basic result validation
 	require.NotEmpty(t, result.Sheets, "should produce at least one sheet")
@@ -1216,6 +1363,7 @@ func TestOptimize_GeneticDefaultSettings_EndToEnd(t *testing.T) {
 
 	opt := New(settings)
 	result := opt.Optimize(parts, stocks)
+	assertValidLayout(t, opt.Settings, result)
 
 	require.NotEmpty(t, result.Sheets, "should produce at least one sheet")
 	assert.Empty(t, result.UnplacedParts, "all parts should be placed")
@@ -1240,6 +1388,7 @@ func TestOptimize_DefaultSettings_GCodeGeneration(t *testing.T) {
 
 	opt := New(settings)
 	result := opt.Optimize(parts, stocks)
+	assertValidLayout(t, opt.Settings, result)
 	require.NotEmpty(t, result.Sheets)
 
 	// Verify the result can be used for GCode generation without panic
@@ -1249,3 +1398,76 @@ func TestOptimize_DefaultSettings_GCodeGeneration(t *testing.T) {
 		assert.Greater(t, sheet.Stock.Height, 0.0)
 	}
 }
+
+func TestOptimize_PreservesProductTags(t *testing.T) {
+	prod := model.NewProduct("Base Cabinet", 2)
+	prod.Parts = append(prod.Parts, model.NewPart("Side", 500, 300, 2))
+	proj := model.NewProject()
+	proj.Products = append(proj.Products, prod)
+
+	opt := New(defaultTestSettings())
+	result := opt.Optimize(proj.AllParts(), []model.StockSheet{model.NewStockSheet("Sheet", 2000, 1000, 1)})
+	assertValidLayout(t, opt.Settings, result)
+
+	require.Len(t, result.Sheets, 1)
+	require.Len(t, result.Sheets[0].Placements, 4)
+	for _, p := range result.Sheets[0].Placements {
+		assert.Equal(t, "Base Cabinet", p.Product())
+		assert.Equal(t, prod.ID, p.Part.ProductID)
+	}
+}
+
+func TestRotateTabs_FollowOutline(t *testing.T) {
+	part := model.Part{
+		Outline: model.Outline{{X: 0, Y: 0}, {X: 100, Y: 0}, {X: 100, Y: 50}, {X: 0, Y: 50}},
+		Tabs:    []model.PartTab{{X: 100, Y: 25}},
+	}
+	// Turned 90° counter-clockwise, the right edge of the 100x50 outline
+	// becomes the top edge of a 50x100 one.
+	tabs := rotateTabs(part, math.Pi/2)
+	require.Len(t, tabs, 1)
+	assert.InDelta(t, 25.0, tabs[0].X, 0.01)
+	assert.InDelta(t, 100.0, tabs[0].Y, 0.01)
+
+	assert.Equal(t, part.Tabs, rotateTabs(part, 0))
+}
+
+func TestPartTabs_SurviveReoptimization(t *testing.T) {
+	settings := defaultTestSettings()
+	settings.NestingRotations = 4
+	opt := New(settings)
+
+	part := model.NewPart("Shape", 100, 50, 1)
+	part.Outline = model.Outline{{X: 0, Y: 0}, {X: 100, Y: 0}, {X: 100, Y: 50}, {X: 0, Y: 50}}
+	// Only a quarter turn fits the narrow sheet
+	stocks := []model.StockSheet{model.NewStockSheet("Strip", 60, 200, 1)}
+
+	result := opt.Optimize([]model.Part{part}, stocks)
+	require.Len(t, result.Sheets, 1)
+	placed := result.Sheets[0].Placements[0].Part
+	require.InDelta(t, 50.0, placed.Width, 0.01, "the outline should be nested turned")
+
+	// A tab edited on the top edge of the turned placement is on the right
+	// edge of the part's own outline
+	placed.Tabs = []model.PartTab{{X: 25, Y: 100}}
+	tabs, ok := PartTabs(part, placed, settings.NestingRotations)
+	require.True(t, ok)
+	require.Len(t, tabs, 1)
+	assert.InDelta(t, 100.0, tabs[0].X, 0.01)
+	assert.InDelta(t, 25.0, tabs[0].Y, 0.01)
+
+	part.Tabs = tabs
+	again := opt.Optimize([]model.Part{part}, stocks)
+	require.Len(t, again.Sheets, 1)
+	got := again.Sheets[0].Placements[0].Part.Tabs
+	require.Len(t, got, 1)
+	assert.InDelta(t, 25.0, got[0].X, 0.01)
+	assert.InDelta(t, 100.0, got[0].Y, 0.01)
+
+	same, ok := PlacedTabs(part, again.Sheets[0].Placements[0].Part, settings.NestingRotations)
+	require.True(t, ok)
+	assert.Equal(t, got, same)
+
+	_, ok = PartTabs(model.NewPart("Plain", 100, 50, 1), placed, settings.NestingRotations)
+	assert.False(t, ok, "a part without an outline has no nesting angle")
+}
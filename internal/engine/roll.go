@@ -0,0 +1,101 @@
+package engine
+
+import (
+	"math"
+	"sort"
+
+	"github.com/piwi3910/SlabCut/internal/model"
+)
+
+// packRoll strip-packs parts onto roll stock, minimizing the length cut off
+// the roll. Several part orderings and rotation strategies are tried; the
+// layout that places the most parts in the shortest length wins. The returned
+// sheet's Stock.Height is the length consumed and its price is prorated from
+// the roll's price per metre.
+func (o *Optimizer) packRoll(stock model.StockSheet, parts []model.Part) (model.SheetResult, []model.Part) {
+	open := o.stripStock(stock, parts)
+
+	byLength := append([]model.Part(nil), parts...)
+	sort.SliceStable(byLength, func(i, j int) bool {
+		return math.Max(byLength[i].Width, byLength[i].Height) > math.Max(byLength[j].Width, byLength[j].Height)
+	})
+	byHeight := append([]model.Part(nil), parts...)
+	sort.SliceStable(byHeight, func(i, j int) bool {
+		return byHeight[i].Height > byHeight[j].Height
+	})
+	orderings := [][]model.Part{parts, byLength, byHeight}
+	strategies := []rotationStrategy{rotBestFit, rotAllNormal, rotAllRotated}
+
+	var bestSheet model.SheetResult
+	var bestUnplaced []model.Part
+	bestPlaced, bestLength := -1, 0.0
+	for _, ordered := range orderings {
+		for _, strat := range strategies {
+			sheet, unplaced := o.packSheet(open, ordered, strat)
+			placed := len(sheet.Placements)
+			length := o.rollUsedLength(sheet)
+			if placed > bestPlaced || (placed == bestPlaced && length < bestLength-0.001) {
+				bestSheet, bestUnplaced = sheet, unplaced
+				bestPlaced, bestLength = placed, length
+			}
+		}
+	}
+	if bestPlaced <= 0 {
+		return model.SheetResult{Stock: stock}, bestUnplaced
+	}
+
+	used := bestLength
+	if stock.Height > 0 {
+		used = math.Min(used, stock.Height)
+	}
+	bestSheet.Stock = stock
+	bestSheet.Stock.Height = used
+	bestSheet.Stock.PricePerSheet = stock.RollCost(used)
+	bestSheet.Roll = &model.RollUsage{MaxLength: stock.Height, UsedLength: used}
+	return bestSheet, bestUnplaced
+}
+
+// stripStock returns roll stock with a finite Height to pack into. An
+// unlimited roll is given enough length to lay every part end to end.
+func (o *Optimizer) stripStock(stock model.StockSheet, parts []model.Part) model.StockSheet {
+	if !stock.UnlimitedLength() {
+		return stock
+	}
+	length := 2 * o.Settings.EdgeTrim
+	for _, p := range parts {
+		length += math.Max(p.Width, p.Height) + o.Settings.KerfWidth
+	}
+	stock.Height = length
+	return stock
+}
+
+// rollUsedLength returns the length of roll needed for a layout: the far edge
+// of the last part plus the kerf of the cross cut, the edge trim and any
+// bottom stock tab padding.
+func (o *Optimizer) rollUsedLength(sheet model.SheetResult) float64 {
+	if len(sheet.Placements) == 0 {
+		return 0
+	}
+	var end float64
+	for _, p := range sheet.Placements {
+		end = math.Max(end, p.Y+p.PlacedHeight())
+	}
+	tabConfig := sheet.Stock.Tabs
+	if !tabConfig.Enabled {
+		tabConfig = o.Settings.StockTabs
+	}
+	if tabConfig.Enabled && !tabConfig.AdvancedMode {
+		end += tabConfig.BottomPadding
+	}
+	return end + o.Settings.KerfWidth + o.Settings.EdgeTrim
+}
+
+// hasRollStock reports whether any stock is a roll.
+func hasRollStock(stocks []model.StockSheet) bool {
+	for _, s := range stocks {
+		if s.Roll {
+			return true
+		}
+	}
+	return false
+}
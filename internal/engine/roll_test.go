@@ -0,0 +1,82 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/piwi3910/SlabCut/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func rollSettings() model.CutSettings {
+	s := model.DefaultSettings()
+	s.KerfWidth = 0
+	s.EdgeTrim = 0
+	s.StockTabs.Enabled = false
+	return s
+}
+
+func TestOptimize_UnlimitedRollMinimizesLength(t *testing.T) {
+	opt := New(rollSettings())
+	roll := model.NewRollStock("Laminate 1300", 1300, 0, 1)
+	roll.PricePerMeter = 20
+	parts := []model.Part{model.NewPart("Panel", 600, 400, 6)}
+
+	result := opt.Optimize(parts, []model.StockSheet{roll})
+	assertValidLayout(t, opt.Settings, result)
+
+	assert.Empty(t, result.UnplacedParts)
+	require.Len(t, result.Sheets, 1)
+	sheet := result.Sheets[0]
+	require.NotNil(t, sheet.Roll)
+	assert.True(t, sheet.Roll.Unlimited())
+	// Two 600 wide parts per row across the roll, three rows of 400
+	assert.InDelta(t, 1200.0, sheet.Roll.UsedLength, 0.001)
+	assert.InDelta(t, 1200.0, sheet.Stock.Height, 0.001)
+	assert.InDelta(t, 24.0, sheet.Stock.PricePerSheet, 0.001)
+	for _, p := range sheet.Placements {
+		assert.LessOrEqual(t, p.X+p.PlacedWidth(), roll.Width+0.001)
+	}
+}
+
+func TestOptimize_LimitedRollReportsRemainderAndOffcut(t *testing.T) {
+	opt := New(rollSettings())
+	roll := model.NewRollStock("Veneer", 1000, 5000, 1)
+	parts := []model.Part{model.NewPart("Strip", 700, 300, 2)}
+	parts[0].Grain = model.GrainVertical
+	roll.Grain = model.GrainVertical
+
+	result := opt.Optimize(parts, []model.StockSheet{roll})
+	assertValidLayout(t, opt.Settings, result)
+
+	require.Len(t, result.Sheets, 1)
+	usage := result.Sheets[0].Roll
+	require.NotNil(t, usage)
+	assert.InDelta(t, 600.0, usage.UsedLength, 0.001)
+	assert.InDelta(t, 4400.0, usage.RemainingLength(), 0.001)
+	offcuts := model.DetectAllOffcuts(result, opt.Settings.KerfWidth)
+	require.NotEmpty(t, offcuts)
+	assert.InDelta(t, 300.0, offcuts[0].Width, 0.001)
+	assert.InDelta(t, 600.0, offcuts[0].Height, 0.001)
+
+	rest, ok := result.Sheets[0].RemainingRoll()
+	require.True(t, ok)
+	assert.True(t, rest.Roll)
+	assert.InDelta(t, 4400.0, rest.Height, 0.001)
+}
+
+func TestOptimize_RollTooShortLeavesPartsUnplaced(t *testing.T) {
+	opt := New(rollSettings())
+	roll := model.NewRollStock("Remnant", 1000, 500, 1)
+	parts := []model.Part{model.NewPart("Long", 200, 800, 1), model.NewPart("Wide", 900, 400, 1)}
+	parts[0].Grain = model.GrainVertical
+	roll.Grain = model.GrainVertical
+
+	result := opt.Optimize(parts, []model.StockSheet{roll})
+	assertValidLayout(t, opt.Settings, result)
+
+	require.Len(t, result.Sheets, 1)
+	require.Len(t, result.UnplacedParts, 1)
+	assert.Equal(t, "Long", result.UnplacedParts[0].Label)
+	assert.InDelta(t, 400.0, result.Sheets[0].Roll.UsedLength, 0.001)
+}
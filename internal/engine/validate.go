@@ -0,0 +1,195 @@
+package engine
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/piwi3910/SlabCut/internal/model"
+)
+
+// validateEpsilon absorbs floating point noise when comparing positions.
+const validateEpsilon = 0.01
+
+// ViolationKind classifies a problem found in a layout.
+type ViolationKind string
+
+const (
+	ViolationOverlap     ViolationKind = "overlap"       // Two placements overlap
+	ViolationOutOfBounds ViolationKind = "out_of_bounds" // Placement extends beyond the sheet
+	ViolationEdgeTrim    ViolationKind = "edge_trim"     // Placement extends into the edge trim
+	ViolationKerf        ViolationKind = "kerf"          // Placements are closer than the kerf width
+	ViolationGrain       ViolationKind = "grain"         // Placement orientation breaks the grain constraint
+	ViolationTabZone     ViolationKind = "tab_zone"      // Placement intrudes into a stock holding tab zone
+	ViolationClampZone   ViolationKind = "clamp_zone"    // Placement intrudes into a clamp zone
+	ViolationDefect      ViolationKind = "defect"        // Placement covers a stock defect
+)
+
+// Violation describes one problem with a placement in a layout.
+type Violation struct {
+	Kind       ViolationKind
+	SheetIndex int    // 0-based index of the sheet in the result
+	Placement  int    // Index of the offending placement on the sheet
+	Other      int    // Index of the second placement for overlap/kerf, -1 otherwise
+	Message    string // Human-readable description
+}
+
+// String returns the violation message prefixed with its sheet number.
+func (v Violation) String() string {
+	return fmt.Sprintf("Sheet %d: %s", v.SheetIndex+1, v.Message)
+}
+
+// Validate checks every sheet of a result against the cut settings and
+// returns the problems found: overlapping placements, placements beyond the
+// sheet or inside the edge trim, gaps narrower than the kerf, orientations
+// that break grain constraints, and placements intruding into tab, clamp or
+// defect zones. Parts nested inside another part's cutout are allowed as long
+// as they keep a kerf from the cutout edge. It works on any result, including
+// hand-edited and imported layouts; an empty slice means the layout is valid.
+func Validate(result model.OptimizeResult, settings model.CutSettings) []Violation {
+	var violations []Violation
+	for i, sheet := range result.Sheets {
+		violations = append(violations, validateSheet(i, sheet, settings)...)
+	}
+	return violations
+}
+
+// placedRect is a placement's footprint in sheet coordinates.
+type placedRect struct {
+	x, y, w, h float64
+	cutouts    []rect
+}
+
+func placementRect(p model.Placement) placedRect {
+	r := placedRect{x: p.X, y: p.Y, w: p.PlacedWidth(), h: p.PlacedHeight()}
+	for _, c := range p.CutoutRects() {
+		r.cutouts = append(r.cutouts, rect{x: c.X, y: c.Y, w: c.Width, h: c.Height})
+	}
+	return r
+}
+
+func validateSheet(sheetIdx int, sheet model.SheetResult, settings model.CutSettings) []Violation {
+	var violations []Violation
+	add := func(kind ViolationKind, idx, other int, format string, args ...interface{}) {
+		violations = append(violations, Violation{
+			Kind:       kind,
+			SheetIndex: sheetIdx,
+			Placement:  idx,
+			Other:      other,
+			Message:    fmt.Sprintf(format, args...),
+		})
+	}
+
+	stock := sheet.Stock
+	trim := settings.EdgeTrim
+	tabConfig := stock.Tabs
+	if !tabConfig.Enabled {
+		tabConfig = settings.StockTabs
+	}
+	tabZones := stockTabZones(stock, tabConfig)
+	var defectZones []model.TabZone
+	for _, d := range stock.Defects {
+		defectZones = append(defectZones, d.ExclusionZones()...)
+	}
+
+	rects := make([]placedRect, len(sheet.Placements))
+	for i, p := range sheet.Placements {
+		r := placementRect(p)
+		rects[i] = r
+		label := p.Part.Label
+
+		if r.x < -validateEpsilon || r.y < -validateEpsilon ||
+			r.x+r.w > stock.Width+validateEpsilon || r.y+r.h > stock.Height+validateEpsilon {
+			add(ViolationOutOfBounds, i, -1, "%s at (%.1f, %.1f) extends beyond the %.0f x %.0f sheet",
+				label, r.x, r.y, stock.Width, stock.Height)
+		} else if trim > 0 && (r.x < trim-validateEpsilon || r.y < trim-validateEpsilon ||
+			r.x+r.w > stock.Width-trim+validateEpsilon || r.y+r.h > stock.Height-trim+validateEpsilon) {
+			add(ViolationEdgeTrim, i, -1, "%s at (%.1f, %.1f) extends into the %.1f mm edge trim",
+				label, r.x, r.y, trim)
+		}
+
+		if canNormal, canRotated := model.CanPlaceWithGrain(p.Part.Grain, stock.Grain); (p.Rotated && !canRotated) || (!p.Rotated && !canNormal) {
+			orientation := "unrotated"
+			if p.Rotated {
+				orientation = "rotated"
+			}
+			add(ViolationGrain, i, -1, "%s is %s, breaking its %s grain on a %s grain sheet",
+				label, orientation, p.Part.Grain, stock.Grain)
+		}
+
+		for _, z := range tabZones {
+			if zoneOverlaps(z, r) {
+				add(ViolationTabZone, i, -1, "%s intrudes into the tab zone at (%.0f, %.0f)", label, z.X, z.Y)
+			}
+		}
+		for _, cz := range settings.ClampZones {
+			if zoneOverlaps(cz.ToTabZone(), r) {
+				add(ViolationClampZone, i, -1, "%s intrudes into clamp zone %q", label, cz.Label)
+			}
+		}
+		for _, z := range defectZones {
+			if zoneOverlaps(z, r) {
+				add(ViolationDefect, i, -1, "%s covers a defect at (%.0f, %.0f)", label, z.X, z.Y)
+				break
+			}
+		}
+	}
+
+	kerf := settings.KerfWidth
+	for i := range rects {
+		for j := i + 1; j < len(rects); j++ {
+			a, b := rects[i], rects[j]
+			labelA, labelB := sheet.Placements[i].Part.Label, sheet.Placements[j].Part.Label
+
+			// A part nested in the other's cutout only needs a kerf from the cutout edge.
+			if c, ok := nestedCutout(a, b); ok {
+				if insetFrom(c, b) < kerf-validateEpsilon {
+					add(ViolationKerf, j, i, "%s is closer than the %.1f mm kerf to the cutout in %s", labelB, kerf, labelA)
+				}
+				continue
+			}
+			if c, ok := nestedCutout(b, a); ok {
+				if insetFrom(c, a) < kerf-validateEpsilon {
+					add(ViolationKerf, i, j, "%s is closer than the %.1f mm kerf to the cutout in %s", labelA, kerf, labelB)
+				}
+				continue
+			}
+
+			gapX := math.Max(b.x-(a.x+a.w), a.x-(b.x+b.w))
+			gapY := math.Max(b.y-(a.y+a.h), a.y-(b.y+b.h))
+			switch {
+			case gapX < -validateEpsilon && gapY < -validateEpsilon:
+				add(ViolationOverlap, i, j, "%s overlaps %s", labelA, labelB)
+			case kerf > 0 && gapX < kerf-validateEpsilon && gapY < kerf-validateEpsilon:
+				add(ViolationKerf, i, j, "%s and %s are %.1f mm apart, less than the %.1f mm kerf",
+					labelA, labelB, math.Max(0, math.Max(gapX, gapY)), kerf)
+			}
+		}
+	}
+
+	return violations
+}
+
+// zoneOverlaps reports whether a zone and a placement share any area.
+func zoneOverlaps(z model.TabZone, r placedRect) bool {
+	return z.X < r.x+r.w-validateEpsilon && z.X+z.Width > r.x+validateEpsilon &&
+		z.Y < r.y+r.h-validateEpsilon && z.Y+z.Height > r.y+validateEpsilon
+}
+
+// nestedCutout returns the cutout of outer that fully contains inner.
+func nestedCutout(outer, inner placedRect) (rect, bool) {
+	for _, c := range outer.cutouts {
+		if inner.x >= c.x-validateEpsilon && inner.y >= c.y-validateEpsilon &&
+			inner.x+inner.w <= c.x+c.w+validateEpsilon && inner.y+inner.h <= c.y+c.h+validateEpsilon {
+			return c, true
+		}
+	}
+	return rect{}, false
+}
+
+// insetFrom returns the smallest distance from inner to the edges of c.
+func insetFrom(c rect, inner placedRect) float64 {
+	return math.Min(
+		math.Min(inner.x-c.x, c.x+c.w-(inner.x+inner.w)),
+		math.Min(inner.y-c.y, c.y+c.h-(inner.y+inner.h)),
+	)
+}
@@ -0,0 +1,158 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/piwi3910/SlabCut/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// assertValidLayout fails the test when the optimizer produced a layout that
+// Validate rejects.
+func assertValidLayout(t *testing.T, settings model.CutSettings, result model.OptimizeResult) {
+	t.Helper()
+	for _, v := range Validate(result, settings) {
+		t.Errorf("invalid layout: %s", v)
+	}
+}
+
+func validateSettings() model.CutSettings {
+	s := model.DefaultSettings()
+	s.KerfWidth = 3
+	s.EdgeTrim = 10
+	s.StockTabs.Enabled = false
+	return s
+}
+
+func singleSheetResult(stock model.StockSheet, placements ...model.Placement) model.OptimizeResult {
+	return model.OptimizeResult{Sheets: []model.SheetResult{{Stock: stock, Placements: placements}}}
+}
+
+func violationKinds(vs []Violation) []ViolationKind {
+	kinds := make([]ViolationKind, len(vs))
+	for i, v := range vs {
+		kinds[i] = v.Kind
+	}
+	return kinds
+}
+
+func TestValidate_ValidLayout(t *testing.T) {
+	stock := model.NewStockSheet("Sheet", 1000, 600, 1)
+	result := singleSheetResult(stock,
+		model.Placement{Part: model.NewPart("A", 400, 300, 1), X: 10, Y: 10},
+		model.Placement{Part: model.NewPart("B", 400, 300, 1), X: 413, Y: 10},
+		model.Placement{Part: model.NewPart("C", 100, 200, 1), X: 10, Y: 313, Rotated: true},
+	)
+
+	assert.Empty(t, Validate(result, validateSettings()))
+}
+
+func TestValidate_Overlap(t *testing.T) {
+	stock := model.NewStockSheet("Sheet", 1000, 600, 1)
+	result := singleSheetResult(stock,
+		model.Placement{Part: model.NewPart("A", 400, 300, 1), X: 10, Y: 10},
+		model.Placement{Part: model.NewPart("B", 400, 300, 1), X: 300, Y: 100},
+	)
+
+	vs := Validate(result, validateSettings())
+	require.Len(t, vs, 1)
+	assert.Equal(t, ViolationOverlap, vs[0].Kind)
+	assert.Equal(t, 0, vs[0].Placement)
+	assert.Equal(t, 1, vs[0].Other)
+	assert.Contains(t, vs[0].String(), "Sheet 1")
+}
+
+func TestValidate_KerfGap(t *testing.T) {
+	stock := model.NewStockSheet("Sheet", 1000, 600, 1)
+	result := singleSheetResult(stock,
+		model.Placement{Part: model.NewPart("A", 400, 300, 1), X: 10, Y: 10},
+		model.Placement{Part: model.NewPart("B", 400, 300, 1), X: 411, Y: 10},
+	)
+
+	vs := Validate(result, validateSettings())
+	assert.Equal(t, []ViolationKind{ViolationKerf}, violationKinds(vs))
+}
+
+func TestValidate_BoundsAndEdgeTrim(t *testing.T) {
+	stock := model.NewStockSheet("Sheet", 1000, 600, 1)
+	result := singleSheetResult(stock,
+		model.Placement{Part: model.NewPart("Outside", 400, 300, 1), X: 700, Y: 10},
+		model.Placement{Part: model.NewPart("InTrim", 100, 100, 1), X: 5, Y: 400},
+	)
+
+	vs := Validate(result, validateSettings())
+	assert.Equal(t, []ViolationKind{ViolationOutOfBounds, ViolationEdgeTrim}, violationKinds(vs))
+}
+
+func TestValidate_Grain(t *testing.T) {
+	stock := model.NewStockSheet("Sheet", 1000, 600, 1)
+	stock.Grain = model.GrainHorizontal
+	part := model.NewPart("Door", 400, 200, 1)
+	part.Grain = model.GrainHorizontal
+
+	valid := singleSheetResult(stock, model.Placement{Part: part, X: 10, Y: 10})
+	assert.Empty(t, Validate(valid, validateSettings()))
+
+	rotated := singleSheetResult(stock, model.Placement{Part: part, X: 10, Y: 10, Rotated: true})
+	assert.Equal(t, []ViolationKind{ViolationGrain}, violationKinds(Validate(rotated, validateSettings())))
+}
+
+func TestValidate_TabClampAndDefectZones(t *testing.T) {
+	settings := validateSettings()
+	settings.StockTabs = model.StockTabConfig{Enabled: true, TopPadding: 50}
+	settings.ClampZones = []model.ClampZone{{Label: "Front-left", X: 0, Y: 500, Width: 100, Height: 100}}
+
+	stock := model.NewStockSheet("Sheet", 1000, 600, 1)
+	stock.Defects = []model.Defect{model.NewRectDefect("Knot", 800, 300, 20, 20)}
+	result := singleSheetResult(stock,
+		model.Placement{Part: model.NewPart("Top", 200, 100, 1), X: 300, Y: 20},
+		model.Placement{Part: model.NewPart("Clamped", 200, 100, 1), X: 50, Y: 450},
+		model.Placement{Part: model.NewPart("Knotty", 100, 100, 1), X: 750, Y: 250},
+	)
+
+	vs := Validate(result, settings)
+	assert.Equal(t, []ViolationKind{ViolationTabZone, ViolationClampZone, ViolationDefect}, violationKinds(vs))
+	assert.Contains(t, vs[1].Message, "Front-left")
+}
+
+func TestValidate_NestedInCutout(t *testing.T) {
+	frame := model.NewPart("Frame", 500, 400, 1)
+	frame.Cutouts = []model.Outline{{{X: 100, Y: 100}, {X: 400, Y: 100}, {X: 400, Y: 300}, {X: 100, Y: 300}}}
+	stock := model.NewStockSheet("Sheet", 1000, 600, 1)
+
+	nested := singleSheetResult(stock,
+		model.Placement{Part: frame, X: 10, Y: 10},
+		model.Placement{Part: model.NewPart("Inner", 200, 100, 1), X: 160, Y: 160},
+	)
+	assert.Empty(t, Validate(nested, validateSettings()))
+
+	tight := singleSheetResult(stock,
+		model.Placement{Part: frame, X: 10, Y: 10},
+		model.Placement{Part: model.NewPart("Inner", 200, 100, 1), X: 111, Y: 160},
+	)
+	assert.Equal(t, []ViolationKind{ViolationKerf}, violationKinds(Validate(tight, validateSettings())))
+}
+
+func TestValidate_OptimizerOutputs(t *testing.T) {
+	parts := []model.Part{
+		model.NewPart("Side", 720, 560, 4),
+		model.NewPart("Shelf", 564, 500, 6),
+		model.NewPart("Door", 396, 716, 4),
+		model.NewPart("Drawer", 450, 150, 8),
+	}
+	parts[2].Grain = model.GrainVertical
+	stocks := []model.StockSheet{model.NewStockSheet("Sheet", 2440, 1220, 10)}
+	stocks[0].Grain = model.GrainVertical
+	stocks[0].Defects = []model.Defect{model.NewRectDefect("Knot", 1200, 600, 30, 30)}
+
+	for _, algorithm := range []model.Algorithm{model.AlgorithmGuillotine, model.AlgorithmGenetic} {
+		settings := model.DefaultSettings()
+		settings.Algorithm = algorithm
+		settings.ClampZones = []model.ClampZone{{Label: "Clamp", X: 0, Y: 0, Width: 150, Height: 150}}
+
+		result := New(settings).Optimize(parts, stocks)
+		require.NotEmpty(t, result.Sheets, "algorithm %s", algorithm)
+		assertValidLayout(t, settings, result)
+	}
+}
@@ -15,6 +15,7 @@ import (
 // LabelInfo holds the data encoded into each part label's QR code.
 type LabelInfo struct {
 	PartLabel  string  `json:"label"`
+	Product    string  `json:"product,omitempty"`
 	Width      float64 `json:"width_mm"`
 	Height     float64 `json:"height_mm"`
 	SheetIndex int     `json:"sheet"`
@@ -55,6 +56,7 @@ func ExportLabels(path string, result model.OptimizeResult) error {
 		for _, p := range sheet.Placements {
 			labels = append(labels, LabelInfo{
 				PartLabel:  p.Part.Label,
+				Product:    p.Product(),
 				Width:      p.Part.Width,
 				Height:     p.Part.Height,
 				SheetIndex: sheetIdx + 1,
@@ -146,11 +148,14 @@ func renderLabel(pdf *fpdf.Fpdf, x, y float64, info LabelInfo) error {
 	dims := fmt.Sprintf("%.0f x %.0f mm", info.Width, info.Height)
 	pdf.CellFormat(textW, 3.5, dims, "", 1, "L", false, 0, "")
 
-	// Sheet and position info
+	// Sheet and position info, prefixed by the product for sorting after cutting
 	pdf.SetFont("Helvetica", "", 6)
 	pdf.SetTextColor(100, 100, 100)
 	pdf.SetXY(textX, y+labelPadding+9)
 	sheetInfo := fmt.Sprintf("Sheet %d @ (%.0f, %.0f)", info.SheetIndex, info.X, info.Y)
+	if info.Product != "" {
+		sheetInfo = info.Product + " | " + sheetInfo
+	}
 	pdf.CellFormat(textW, 3, sheetInfo, "", 1, "L", false, 0, "")
 
 	// Rotation indicator
@@ -175,6 +180,7 @@ func CollectLabelInfos(result model.OptimizeResult) []LabelInfo {
 		for _, p := range sheet.Placements {
 			labels = append(labels, LabelInfo{
 				PartLabel:  p.Part.Label,
+				Product:    p.Product(),
 				Width:      p.Part.Width,
 				Height:     p.Part.Height,
 				SheetIndex: sheetIdx + 1,
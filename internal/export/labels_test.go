@@ -131,6 +131,24 @@ func TestCollectLabelInfos(t *testing.T) {
 	}
 }
 
+func TestCollectLabelInfos_Product(t *testing.T) {
+	result := buildLabelsTestResult()
+	result.Sheets[0].Placements[0].Part.ProductName = "Base Cabinet"
+
+	labels := CollectLabelInfos(result)
+	if labels[0].Product != "Base Cabinet" {
+		t.Errorf("expected product 'Base Cabinet', got %q", labels[0].Product)
+	}
+	if labels[1].Product != "" {
+		t.Errorf("expected no product for loose part, got %q", labels[1].Product)
+	}
+
+	path := filepath.Join(t.TempDir(), "labels.pdf")
+	if err := ExportLabels(path, result); err != nil {
+		t.Fatalf("ExportLabels returned error: %v", err)
+	}
+}
+
 func TestLabelInfo_JSONRoundTrip(t *testing.T) {
 	info := LabelInfo{
 		PartLabel:  "Test Part",
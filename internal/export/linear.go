@@ -0,0 +1,174 @@
+package export
+
+import (
+	"fmt"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/piwi3910/SlabCut/internal/model"
+)
+
+// Linear cut diagram layout (A4 landscape in mm).
+const (
+	linearBarHeight  = 10.0
+	linearBarSpacing = 22.0
+	linearInfoWidth  = 55.0
+)
+
+// ExportLinearPDF generates cut diagrams for a 1D cutting plan: one row per
+// bar showing each part to scale with its length, followed by a buy list.
+func ExportLinearPDF(path string, result model.LinearResult) error {
+	if len(result.Bars) == 0 {
+		return fmt.Errorf("no bars to export")
+	}
+
+	pdf := fpdf.New("L", "mm", "A4", "")
+	pdf.SetAutoPageBreak(false, marginBottom)
+
+	var maxLen float64
+	for _, b := range result.Bars {
+		if b.Stock.Length > maxLen {
+			maxLen = b.Stock.Length
+		}
+	}
+	drawW := pageWidth - marginLeft - marginRight - linearInfoWidth
+	scale := drawW / maxLen
+
+	y := pageHeight // Force a new page on the first bar
+	for i, bar := range result.Bars {
+		if y+linearBarSpacing > pageHeight-marginBottom {
+			pdf.AddPage()
+			renderLinearHeader(pdf, result)
+			y = drawAreaTop + 5
+		}
+		renderLinearBar(pdf, bar, result.Settings, i+1, scale, y)
+		y += linearBarSpacing
+	}
+
+	pdf.AddPage()
+	renderLinearSummary(pdf, result)
+
+	return pdf.OutputFileAndClose(path)
+}
+
+// renderLinearHeader draws the page title and overall statistics.
+func renderLinearHeader(pdf *fpdf.Fpdf, result model.LinearResult) {
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetFont("Helvetica", "B", 14)
+	pdf.SetXY(marginLeft, marginTop)
+	pdf.CellFormat(pageWidth-marginLeft-marginRight, headerHeight, "Linear Cut List", "", 0, "L", false, 0, "")
+
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.SetXY(marginLeft, marginTop+headerHeight)
+	stats := fmt.Sprintf("Bars: %d | Efficiency: %.1f%% | Kerf: %.1f mm | Trim: %.1f mm | End waste: %.1f mm",
+		len(result.Bars), result.TotalEfficiency(), result.Settings.Kerf, result.Settings.Trim, result.Settings.EndWaste)
+	pdf.CellFormat(pageWidth-marginLeft-marginRight, 5, stats, "", 0, "L", false, 0, "")
+}
+
+// renderLinearBar draws one bar with its cuts to scale at vertical position y.
+func renderLinearBar(pdf *fpdf.Fpdf, bar model.LinearBar, ls model.LinearSettings, num int, scale, y float64) {
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetFont("Helvetica", "B", 9)
+	pdf.SetXY(marginLeft, y)
+	pdf.CellFormat(linearInfoWidth-3, 5, fmt.Sprintf("#%d %s", num, bar.Stock.Label), "", 0, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 8)
+	pdf.SetXY(marginLeft, y+5)
+	pdf.CellFormat(linearInfoWidth-3, 4, fmt.Sprintf("%.0f mm, %.1f%% used", bar.Stock.Length, bar.Efficiency()), "", 0, "L", false, 0, "")
+
+	x0 := marginLeft + linearInfoWidth
+
+	// Whole bar as offcut background
+	pdf.SetDrawColor(0, 0, 0)
+	pdf.SetLineWidth(0.3)
+	pdf.SetFillColor(230, 230, 230)
+	pdf.Rect(x0, y, bar.Stock.Length*scale, linearBarHeight, "FD")
+	if ls.Trim > 0 {
+		drawHatchPattern(pdf, x0, y, ls.Trim*scale, linearBarHeight)
+	}
+	if ls.EndWaste > 0 {
+		drawHatchPattern(pdf, x0+(bar.Stock.Length-ls.EndWaste)*scale, y, ls.EndWaste*scale, linearBarHeight)
+	}
+	pdf.SetDrawColor(0, 0, 0)
+	pdf.SetLineWidth(0.3)
+
+	for i, c := range bar.Cuts {
+		col := partColors[i%len(partColors)]
+		x := x0 + c.Position*scale
+		w := c.Part.Width * scale
+		pdf.SetFillColor(col.R, col.G, col.B)
+		pdf.Rect(x, y, w, linearBarHeight, "FD")
+
+		pdf.SetFont("Helvetica", "", 7)
+		label := c.Part.Label
+		if pdf.GetStringWidth(label) > w-1 {
+			label = ""
+		}
+		pdf.SetXY(x, y+1)
+		pdf.CellFormat(w, 4, label, "", 0, "C", false, 0, "")
+		pdf.SetXY(x, y+linearBarHeight)
+		pdf.CellFormat(w, 4, fmt.Sprintf("%.0f", c.Part.Width), "", 0, "C", false, 0, "")
+	}
+
+	if rem := bar.Remnant(ls); rem > 0 {
+		pdf.SetFont("Helvetica", "I", 7)
+		pdf.SetXY(x0+(bar.Stock.Length-rem)*scale, y+linearBarHeight+4)
+		pdf.CellFormat(rem*scale, 4, fmt.Sprintf("offcut %.0f", rem), "", 0, "C", false, 0, "")
+	}
+}
+
+// renderLinearSummary draws the buy list and any parts that did not fit.
+func renderLinearSummary(pdf *fpdf.Fpdf, result model.LinearResult) {
+	renderLinearHeader(pdf, result)
+
+	y := drawAreaTop + 5
+	pdf.SetFont("Helvetica", "B", 12)
+	pdf.SetXY(marginLeft, y)
+	pdf.CellFormat(100, 7, "Buy List", "", 0, "L", false, 0, "")
+	y += 9
+
+	colWidths := []float64{80, 30, 20, 30}
+	headers := []string{"Stock", "Length", "Bars", "Cost"}
+	pdf.SetFont("Helvetica", "B", 9)
+	pdf.SetFillColor(230, 230, 230)
+	x := marginLeft
+	for i, h := range headers {
+		pdf.SetXY(x, y)
+		pdf.CellFormat(colWidths[i], 6, h, "1", 0, "C", true, 0, "")
+		x += colWidths[i]
+	}
+	y += 6
+
+	pdf.SetFont("Helvetica", "", 9)
+	for _, bc := range result.BarsByStock() {
+		cells := []string{
+			bc.Stock.Label,
+			fmt.Sprintf("%.0f mm", bc.Stock.Length),
+			fmt.Sprintf("%d", bc.Count),
+			fmt.Sprintf("%.2f", bc.Cost),
+		}
+		x = marginLeft
+		for i, c := range cells {
+			pdf.SetXY(x, y)
+			pdf.CellFormat(colWidths[i], 6, c, "1", 0, "L", false, 0, "")
+			x += colWidths[i]
+		}
+		y += 6
+	}
+
+	if len(result.UnplacedParts) > 0 {
+		y += 8
+		pdf.SetFont("Helvetica", "B", 11)
+		pdf.SetTextColor(200, 0, 0)
+		pdf.SetXY(marginLeft, y)
+		pdf.CellFormat(100, 6, fmt.Sprintf("Unplaced parts (%d)", len(result.UnplacedParts)), "", 0, "L", false, 0, "")
+		pdf.SetTextColor(0, 0, 0)
+		pdf.SetFont("Helvetica", "", 9)
+		for _, p := range result.UnplacedParts {
+			y += 5
+			if y > pageHeight-marginBottom {
+				break
+			}
+			pdf.SetXY(marginLeft+5, y)
+			pdf.CellFormat(150, 5, fmt.Sprintf("%s (%.0f mm)", p.Label, p.Width), "", 0, "L", false, 0, "")
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/piwi3910/SlabCut/internal/model"
+)
+
+func TestExportLinearPDF_CreatesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "linear.pdf")
+
+	stock := model.NewLinearStock("Oak 2.4m", 2400, 3)
+	result := model.LinearResult{
+		Settings: model.DefaultLinearSettings(),
+		Bars: []model.LinearBar{{
+			Stock: stock,
+			Cuts: []model.LinearCut{
+				{Part: model.NewPart("Rail", 900, 45, 1), Position: 13},
+				{Part: model.NewPart("Stile", 700, 45, 1), Position: 916},
+			},
+		}},
+		UnplacedParts: []model.Part{model.NewPart("Beam", 3000, 90, 1)},
+	}
+
+	if err := ExportLinearPDF(path, result); err != nil {
+		t.Fatalf("ExportLinearPDF returned error: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("PDF file was not created: %v", err)
+	}
+	if info.Size() < 500 {
+		t.Errorf("PDF file seems too small: %d bytes", info.Size())
+	}
+}
+
+func TestExportLinearPDF_NoBars(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty.pdf")
+	if err := ExportLinearPDF(path, model.LinearResult{}); err == nil {
+		t.Fatal("expected error for empty result, got nil")
+	}
+}
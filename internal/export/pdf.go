@@ -7,6 +7,7 @@ import (
 	"math"
 
 	"github.com/go-pdf/fpdf"
+	"github.com/piwi3910/SlabCut/internal/gcode"
 	"github.com/piwi3910/SlabCut/internal/model"
 )
 
@@ -41,8 +42,9 @@ const (
 )
 
 // ExportPDF generates a PDF document containing the cut optimization results.
-// Each sheet result is rendered on its own page with a visual layout diagram,
-// followed by a summary page with overall statistics.
+// Each distinct sheet layout is rendered once on its own page with a visual
+// layout diagram and the number of sheets cut with it, followed by a summary
+// page with overall statistics.
 func ExportPDF(path string, result model.OptimizeResult, settings model.CutSettings) error {
 	if len(result.Sheets) == 0 {
 		return fmt.Errorf("no sheets to export")
@@ -51,10 +53,10 @@ func ExportPDF(path string, result model.OptimizeResult, settings model.CutSetti
 	pdf := fpdf.New("L", "mm", "A4", "")
 	pdf.SetAutoPageBreak(false, marginBottom)
 
-	// Render each sheet on its own page
-	for i, sheet := range result.Sheets {
+	// Render each sheet pattern on its own page
+	for i, pattern := range result.Patterns() {
 		pdf.AddPage()
-		renderSheetPage(pdf, sheet, settings, i+1)
+		renderSheetPage(pdf, pattern, settings, i+1)
 	}
 
 	// Summary page
@@ -64,19 +66,27 @@ func ExportPDF(path string, result model.OptimizeResult, settings model.CutSetti
 	return pdf.OutputFileAndClose(path)
 }
 
-// renderSheetPage draws a single sheet result on the current PDF page.
-func renderSheetPage(pdf *fpdf.Fpdf, sheet model.SheetResult, settings model.CutSettings, sheetNum int) {
+// renderSheetPage draws a sheet pattern on the current PDF page. A pattern
+// used by a single sheet is titled with its sheet number.
+func renderSheetPage(pdf *fpdf.Fpdf, pattern model.SheetPattern, settings model.CutSettings, patternNum int) {
+	sheet := pattern.Sheet
+
 	// Title
 	pdf.SetFont("Helvetica", "B", 14)
 	pdf.SetXY(marginLeft, marginTop)
-	title := fmt.Sprintf("Sheet %d: %s (%.0f x %.0f mm)", sheetNum, sheet.Stock.Label, sheet.Stock.Width, sheet.Stock.Height)
-	pdf.CellFormat(pageWidth-marginLeft-marginRight, headerHeight, title, "", 0, "L", false, 0, "")
+	pdf.CellFormat(pageWidth-marginLeft-marginRight, headerHeight, patternTitle(pattern, patternNum), "", 0, "L", false, 0, "")
 
 	// Stats line
 	pdf.SetFont("Helvetica", "", 10)
 	pdf.SetXY(marginLeft, marginTop+headerHeight)
 	stats := fmt.Sprintf("Parts: %d | Used area: %.0f mm² | Total area: %.0f mm² | Efficiency: %.1f%%",
 		len(sheet.Placements), sheet.UsedArea(), sheet.TotalArea(), sheet.Efficiency())
+	if sheet.Roll != nil {
+		stats += " | " + sheet.Roll.String()
+	}
+	if pattern.Count() > 1 {
+		stats += " | " + patternRuns(pattern, settings)
+	}
 	pdf.CellFormat(pageWidth-marginLeft-marginRight, 5, stats, "", 0, "L", false, 0, "")
 
 	// Calculate drawing area
@@ -104,6 +114,9 @@ func renderSheetPage(pdf *fpdf.Fpdf, sheet model.SheetResult, settings model.Cut
 	// Draw stock holding tab exclusion zones
 	drawStockTabs(pdf, sheet.Stock, settings, scale, offsetX, offsetY)
 
+	// Draw sheet defects
+	drawDefects(pdf, sheet.Stock.Defects, scale, offsetX, offsetY)
+
 	// Draw placed parts
 	for i, p := range sheet.Placements {
 		col := partColors[i%len(partColors)]
@@ -144,6 +157,9 @@ func renderSheetPage(pdf *fpdf.Fpdf, sheet model.SheetResult, settings model.Cut
 		}
 	}
 
+	// Draw part holding tabs
+	drawPartTabs(pdf, sheet, settings, scale, offsetX, offsetY)
+
 	// Dimension annotations along the edges
 	drawDimensionAnnotations(pdf, sheet.Stock, scale, offsetX, offsetY, canvasW, canvasH)
 
@@ -151,6 +167,72 @@ func renderSheetPage(pdf *fpdf.Fpdf, sheet model.SheetResult, settings model.Cut
 	drawPartsLegend(pdf, sheet, offsetY+canvasH+5)
 }
 
+// patternTitle returns the page title for a sheet pattern, e.g.
+// "Pattern 1 x 6 (sheets 1, 2, 3, 4, 5, 6): Plywood (2440 x 1220 mm)".
+func patternTitle(pattern model.SheetPattern, patternNum int) string {
+	stock := pattern.Sheet.Stock
+	if pattern.Count() == 1 {
+		return fmt.Sprintf("Sheet %d: %s (%.0f x %.0f mm)", pattern.Sheets[0]+1, stock.Label, stock.Width, stock.Height)
+	}
+	return fmt.Sprintf("Pattern %d x %d (sheets %s): %s (%.0f x %.0f mm)",
+		patternNum, pattern.Count(), pattern.SheetNumbers(), stock.Label, stock.Width, stock.Height)
+}
+
+// patternRuns describes how often a pattern is cut, e.g. "Cut 6 times" or
+// "Stacked: 2 runs of up to 4 sheets".
+func patternRuns(pattern model.SheetPattern, settings model.CutSettings) string {
+	if !settings.StackCutting {
+		return fmt.Sprintf("Cut %d times", pattern.Count())
+	}
+	return fmt.Sprintf("Stacked: %d run(s) of up to %d sheets", pattern.Runs(settings), pattern.StackSize(settings))
+}
+
+// drawPartTabs marks where the final pass leaves holding tabs on each part:
+// an orange bar along the toolpath, tapered for trapezoid tabs and pointed
+// for triangular ones.
+func drawPartTabs(pdf *fpdf.Fpdf, sheet model.SheetResult, settings model.CutSettings, scale, offsetX, offsetY float64) {
+	gen := gcode.New(settings)
+	const markerDepth = 1.2 // marker thickness across the toolpath, in page mm
+
+	pdf.SetFillColor(255, 165, 0)
+	pdf.SetDrawColor(160, 90, 0)
+	pdf.SetLineWidth(0.1)
+	for _, p := range sheet.Placements {
+		for _, m := range gen.PartTabs(p) {
+			cx := offsetX + m.X*scale
+			cy := offsetY + m.Y*scale
+			// Half extents along and across the toolpath
+			ax, ay := m.DX*m.Width*scale/2, m.DY*m.Width*scale/2
+			nx, ny := -m.DY*markerDepth/2, m.DX*markerDepth/2
+
+			var pts []fpdf.PointType
+			switch m.Shape {
+			case model.TabShapeTriangular:
+				pts = []fpdf.PointType{
+					{X: cx - ax - nx, Y: cy - ay - ny},
+					{X: cx + ax - nx, Y: cy + ay - ny},
+					{X: cx + nx, Y: cy + ny},
+				}
+			case model.TabShapeTrapezoid:
+				pts = []fpdf.PointType{
+					{X: cx - ax - nx, Y: cy - ay - ny},
+					{X: cx + ax - nx, Y: cy + ay - ny},
+					{X: cx + ax/2 + nx, Y: cy + ay/2 + ny},
+					{X: cx - ax/2 + nx, Y: cy - ay/2 + ny},
+				}
+			default:
+				pts = []fpdf.PointType{
+					{X: cx - ax - nx, Y: cy - ay - ny},
+					{X: cx + ax - nx, Y: cy + ay - ny},
+					{X: cx + ax + nx, Y: cy + ay + ny},
+					{X: cx - ax + nx, Y: cy - ay + ny},
+				}
+			}
+			pdf.Polygon(pts, "FD")
+		}
+	}
+}
+
 // drawStockTabs renders the stock sheet holding tab exclusion zones.
 func drawStockTabs(pdf *fpdf.Fpdf, stock model.StockSheet, settings model.CutSettings, scale, offsetX, offsetY float64) {
 	tabConfig := stock.Tabs
@@ -209,6 +291,34 @@ func drawStockTabs(pdf *fpdf.Fpdf, stock model.StockSheet, settings model.CutSet
 	pdf.SetTextColor(0, 0, 0)
 }
 
+// drawDefects draws the damaged areas of a sheet as filled polygons.
+func drawDefects(pdf *fpdf.Fpdf, defects []model.Defect, scale, offsetX, offsetY float64) {
+	for _, d := range defects {
+		if len(d.Outline) < 3 {
+			continue
+		}
+		points := make([]fpdf.PointType, len(d.Outline))
+		for i, p := range d.Outline {
+			points[i] = fpdf.PointType{X: offsetX + p.X*scale, Y: offsetY + p.Y*scale}
+		}
+		pdf.SetFillColor(150, 110, 170)
+		pdf.SetDrawColor(90, 40, 120)
+		pdf.SetLineWidth(0.3)
+		pdf.Polygon(points, "FD")
+
+		label := d.Label
+		if label == "" {
+			label = "DEFECT"
+		}
+		min, _ := d.Outline.BoundingBox()
+		pdf.SetFont("Helvetica", "B", 6)
+		pdf.SetTextColor(255, 255, 255)
+		pdf.SetXY(offsetX+min.X*scale+1, offsetY+min.Y*scale+1)
+		pdf.CellFormat(pdf.GetStringWidth(label), 3, label, "", 0, "L", false, 0, "")
+	}
+	pdf.SetTextColor(0, 0, 0)
+}
+
 // drawHatchPattern draws diagonal lines inside a rectangle to indicate exclusion zones.
 func drawHatchPattern(pdf *fpdf.Fpdf, x, y, w, h float64) {
 	pdf.SetDrawColor(200, 0, 0)
@@ -270,6 +380,9 @@ func drawPartsLegend(pdf *fpdf.Fpdf, sheet model.SheetResult, startY float64) {
 	for i, p := range sheet.Placements {
 		col := partColors[i%len(partColors)]
 		label := fmt.Sprintf("%s (%.0fx%.0f)", p.Part.Label, p.Part.Width, p.Part.Height)
+		if p.Product() != "" {
+			label = p.Product() + ": " + label
+		}
 		if p.Rotated {
 			label += " R"
 		}
@@ -306,6 +419,7 @@ func renderSummaryPage(pdf *fpdf.Fpdf, result model.OptimizeResult, settings mod
 	pdf.Line(marginLeft, marginTop+12, pageWidth-marginRight, marginTop+12)
 
 	y := marginTop + 18
+	patterns := result.Patterns()
 
 	// Overall statistics
 	pdf.SetFont("Helvetica", "B", 12)
@@ -318,6 +432,7 @@ func renderSummaryPage(pdf *fpdf.Fpdf, result model.OptimizeResult, settings mod
 		value string
 	}{
 		{"Total Sheets Used", fmt.Sprintf("%d", len(result.Sheets))},
+		{"Sheet Patterns", fmt.Sprintf("%d", len(patterns))},
 		{"Overall Efficiency", fmt.Sprintf("%.1f%%", result.TotalEfficiency())},
 		{"Total Parts Placed", fmt.Sprintf("%d", countParts(result))},
 		{"Unplaced Parts", fmt.Sprintf("%d", len(result.UnplacedParts))},
@@ -335,15 +450,15 @@ func renderSummaryPage(pdf *fpdf.Fpdf, result model.OptimizeResult, settings mod
 
 	y += 5
 
-	// Per-sheet breakdown table
+	// Per-pattern breakdown table
 	pdf.SetFont("Helvetica", "B", 12)
 	pdf.SetXY(marginLeft, y)
 	pdf.CellFormat(100, 7, "Sheet Breakdown", "", 0, "L", false, 0, "")
 	y += 9
 
 	// Table header
-	colWidths := []float64{20, 60, 50, 50, 35, 50}
-	headers := []string{"Sheet", "Stock", "Dimensions", "Parts", "Efficiency", "Used / Total Area"}
+	colWidths := []float64{20, 60, 45, 35, 25, 30, 50}
+	headers := []string{"Pattern", "Stock", "Dimensions", "Parts", "Count", "Efficiency", "Used / Total Area"}
 
 	pdf.SetFont("Helvetica", "B", 9)
 	pdf.SetFillColor(230, 230, 230)
@@ -357,13 +472,15 @@ func renderSummaryPage(pdf *fpdf.Fpdf, result model.OptimizeResult, settings mod
 
 	// Table rows
 	pdf.SetFont("Helvetica", "", 9)
-	for i, sheet := range result.Sheets {
+	for i, pattern := range patterns {
+		sheet := pattern.Sheet
 		xPos = marginLeft
 		rowData := []string{
 			fmt.Sprintf("%d", i+1),
 			sheet.Stock.Label,
 			fmt.Sprintf("%.0f x %.0f mm", sheet.Stock.Width, sheet.Stock.Height),
 			fmt.Sprintf("%d", len(sheet.Placements)),
+			fmt.Sprintf("%d", pattern.Count()),
 			fmt.Sprintf("%.1f%%", sheet.Efficiency()),
 			fmt.Sprintf("%.0f / %.0f mm²", sheet.UsedArea(), sheet.TotalArea()),
 		}
@@ -403,6 +520,9 @@ func renderSummaryPage(pdf *fpdf.Fpdf, result model.OptimizeResult, settings mod
 		}
 	}
 
+	// Product breakdown so parts can be sorted back into assemblies
+	y = drawProductSummary(pdf, result, y)
+
 	// Cut settings summary
 	y += 8
 	pdf.SetFont("Helvetica", "B", 12)
@@ -437,6 +557,54 @@ func renderSummaryPage(pdf *fpdf.Fpdf, result model.OptimizeResult, settings mod
 	pdf.CellFormat(pageWidth-marginLeft-marginRight, 4, "Generated by CNCCalculator - CNC Cut List Optimizer", "", 0, "C", false, 0, "")
 }
 
+// drawProductSummary lists, for every product, how many pieces were placed
+// and on which sheets. Nothing is drawn when no part belongs to a product.
+func drawProductSummary(pdf *fpdf.Fpdf, result model.OptimizeResult, y float64) float64 {
+	ids, groups := model.ProductPlacements(result)
+	if len(ids) == 0 || (len(ids) == 1 && ids[0] == "") {
+		return y
+	}
+
+	y += 8
+	pdf.SetFont("Helvetica", "B", 12)
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetXY(marginLeft, y)
+	pdf.CellFormat(100, 7, "Products", "", 0, "L", false, 0, "")
+	y += 9
+
+	pdf.SetFont("Helvetica", "", 9)
+	for _, id := range ids {
+		label := groups[id][0].Product()
+		if id == "" {
+			label = "(loose parts)"
+		}
+		pdf.SetXY(marginLeft+5, y)
+		pdf.CellFormat(70, 5, label+":", "", 0, "L", false, 0, "")
+		pdf.CellFormat(150, 5, fmt.Sprintf("%d piece(s) on sheet(s) %s",
+			len(groups[id]), productSheets(result, id)), "", 0, "L", false, 0, "")
+		y += 5
+	}
+	return y
+}
+
+// productSheets returns a comma-separated list of the sheet numbers that hold
+// parts of the product with the given ID.
+func productSheets(result model.OptimizeResult, productID string) string {
+	var list string
+	for i, sheet := range result.Sheets {
+		for _, p := range sheet.Placements {
+			if p.Part.ProductID == productID {
+				if list != "" {
+					list += ", "
+				}
+				list += fmt.Sprintf("%d", i+1)
+				break
+			}
+		}
+	}
+	return list
+}
+
 // labelFontSize returns an appropriate font size based on the rectangle dimensions.
 func labelFontSize(w, h float64) float64 {
 	minDim := math.Min(w, h)
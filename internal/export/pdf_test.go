@@ -300,3 +300,58 @@ func TestLabelFontSize(t *testing.T) {
 		}
 	}
 }
+
+func TestProductSheets(t *testing.T) {
+	result := buildTestResult()
+	result.Sheets[0].Placements[0].Part.ProductID = "base"
+	result.Sheets[0].Placements[0].Part.ProductName = "Base Cabinet"
+	result.Sheets[1].Placements[0].Part.ProductID = "base"
+	result.Sheets[1].Placements[0].Part.ProductName = "Base Cabinet"
+	result.Sheets[0].Placements[1].Part.ProductID = "wall"
+	result.Sheets[0].Placements[1].Part.ProductName = "Wall Cabinet"
+
+	if got := productSheets(result, "base"); got != "1, 2" {
+		t.Errorf("productSheets(Base Cabinet) = %q, want %q", got, "1, 2")
+	}
+	if got := productSheets(result, "wall"); got != "1" {
+		t.Errorf("productSheets(Wall Cabinet) = %q, want %q", got, "1")
+	}
+
+	path := filepath.Join(t.TempDir(), "products.pdf")
+	if err := ExportPDF(path, result, buildTestSettings()); err != nil {
+		t.Fatalf("ExportPDF with products returned error: %v", err)
+	}
+}
+
+func TestPatternTitleAndRuns(t *testing.T) {
+	result := buildTestResult()
+	result.Sheets = append(result.Sheets, result.Sheets[0], result.Sheets[0])
+	patterns := result.Patterns()
+	if len(patterns) != 2 {
+		t.Fatalf("expected 2 patterns, got %d", len(patterns))
+	}
+
+	want := "Pattern 1 x 3 (sheets 1, 3, 4): Plywood 2440x1220 (2440 x 1220 mm)"
+	if got := patternTitle(patterns[0], 1); got != want {
+		t.Errorf("patternTitle = %q, want %q", got, want)
+	}
+	if got := patternTitle(patterns[1], 2); got != "Sheet 2: MDF 1200x600 (1200 x 600 mm)" {
+		t.Errorf("single-sheet patternTitle = %q", got)
+	}
+
+	settings := buildTestSettings()
+	if got := patternRuns(patterns[0], settings); got != "Cut 3 times" {
+		t.Errorf("patternRuns = %q", got)
+	}
+	settings.StackCutting = true
+	settings.MaxStackHeight = 40
+	settings.CutDepth = 18
+	if got := patternRuns(patterns[0], settings); got != "Stacked: 2 run(s) of up to 2 sheets" {
+		t.Errorf("stacked patternRuns = %q", got)
+	}
+
+	path := filepath.Join(t.TempDir(), "patterns.pdf")
+	if err := ExportPDF(path, result, settings); err != nil {
+		t.Fatalf("ExportPDF with patterns returned error: %v", err)
+	}
+}
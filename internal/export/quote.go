@@ -0,0 +1,185 @@
+package export
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/piwi3910/SlabCut/internal/model"
+)
+
+// Quote page layout constants (A4 portrait in mm).
+const (
+	quotePageWidth  = 210.0
+	quotePageHeight = 297.0
+	quoteMargin     = 15.0
+	quoteRowHeight  = 6.0
+)
+
+// ExportQuotePDF generates a customer-facing quote for a priced job. Line
+// prices include the margin; internal cost components are not shown.
+func ExportQuotePDF(path, projectName string, quote model.Quote) error {
+	if len(quote.Lines) == 0 && len(quote.Settings.Hardware) == 0 {
+		return fmt.Errorf("quote has no line items")
+	}
+
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetAutoPageBreak(false, quoteMargin)
+	pdf.AddPage()
+
+	qs := quote.Settings
+	contentW := quotePageWidth - 2*quoteMargin
+
+	// Title
+	pdf.SetFont("Helvetica", "B", 20)
+	pdf.SetXY(quoteMargin, quoteMargin)
+	pdf.CellFormat(contentW, 10, "Quotation", "", 0, "L", false, 0, "")
+
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.SetXY(quoteMargin, quoteMargin)
+	pdf.CellFormat(contentW, 5, "Date: "+time.Now().Format("2006-01-02"), "", 0, "R", false, 0, "")
+	if qs.Reference != "" {
+		pdf.SetXY(quoteMargin, quoteMargin+5)
+		pdf.CellFormat(contentW, 5, "Reference: "+qs.Reference, "", 0, "R", false, 0, "")
+	}
+
+	pdf.SetDrawColor(0, 0, 0)
+	pdf.SetLineWidth(0.5)
+	pdf.Line(quoteMargin, quoteMargin+13, quotePageWidth-quoteMargin, quoteMargin+13)
+
+	y := quoteMargin + 18
+	pdf.SetFont("Helvetica", "", 10)
+	if qs.CustomerName != "" {
+		pdf.SetXY(quoteMargin, y)
+		pdf.CellFormat(contentW, 5, "Customer: "+qs.CustomerName, "", 0, "L", false, 0, "")
+		y += 6
+	}
+	if projectName != "" {
+		pdf.SetXY(quoteMargin, y)
+		pdf.CellFormat(contentW, 5, "Project: "+projectName, "", 0, "L", false, 0, "")
+		y += 6
+	}
+	y += 4
+
+	// Line item table
+	colWidths := []float64{90, 20, 35, 35}
+	headers := []string{"Description", "Qty", "Unit Price", "Amount"}
+	y = drawQuoteTableHeader(pdf, y, colWidths, headers)
+
+	factor := quote.MarginFactor()
+	row := 0
+	addRow := func(desc string, qty int, amount float64) {
+		if y > quotePageHeight-80 {
+			pdf.AddPage()
+			y = drawQuoteTableHeader(pdf, quoteMargin, colWidths, headers)
+		}
+		unit := 0.0
+		if qty > 0 {
+			unit = amount / float64(qty)
+		}
+		cells := []string{
+			desc,
+			fmt.Sprintf("%d", qty),
+			formatMoney(qs.Currency, unit),
+			formatMoney(qs.Currency, amount),
+		}
+		aligns := []string{"L", "C", "R", "R"}
+
+		if row%2 == 0 {
+			pdf.SetFillColor(245, 245, 245)
+		} else {
+			pdf.SetFillColor(255, 255, 255)
+		}
+		x := quoteMargin
+		for i, cell := range cells {
+			pdf.SetXY(x, y)
+			pdf.CellFormat(colWidths[i], quoteRowHeight, cell, "1", 0, aligns[i], true, 0, "")
+			x += colWidths[i]
+		}
+		y += quoteRowHeight
+		row++
+	}
+
+	pdf.SetFont("Helvetica", "", 9)
+	for _, line := range quote.Lines {
+		addRow(line.Label, line.Quantity, line.Cost()*factor)
+	}
+	for _, h := range qs.Hardware {
+		addRow(h.Name, h.Quantity, h.Total()*factor)
+	}
+
+	// Material for sheets without any placed parts is not part of any line.
+	var lineCost float64
+	for _, line := range quote.Lines {
+		lineCost += line.Cost()
+	}
+	if other := quote.Cost - lineCost - quote.HardwareCost; other > 0.005 {
+		addRow("Additional material", 1, other*factor)
+	}
+
+	// Totals
+	y += 4
+	totals := []struct {
+		label string
+		value float64
+		bold  bool
+	}{
+		{"Subtotal", quote.Net, false},
+		{fmt.Sprintf("Tax (%.1f%%)", qs.TaxPercent), quote.Tax, false},
+		{"Total", quote.Total, true},
+	}
+	labelX := quoteMargin + colWidths[0] + colWidths[1]
+	for _, t := range totals {
+		style := ""
+		if t.bold {
+			style = "B"
+		}
+		pdf.SetFont("Helvetica", style, 10)
+		pdf.SetXY(labelX, y)
+		pdf.CellFormat(colWidths[2], quoteRowHeight, t.label, "", 0, "R", false, 0, "")
+		pdf.CellFormat(colWidths[3], quoteRowHeight, formatMoney(qs.Currency, t.value), "", 0, "R", false, 0, "")
+		y += quoteRowHeight + 1
+	}
+
+	if qs.Notes != "" {
+		y += 8
+		pdf.SetFont("Helvetica", "B", 10)
+		pdf.SetXY(quoteMargin, y)
+		pdf.CellFormat(contentW, 6, "Notes", "", 0, "L", false, 0, "")
+		y += 7
+		pdf.SetFont("Helvetica", "", 9)
+		pdf.SetXY(quoteMargin, y)
+		pdf.MultiCell(contentW, 5, qs.Notes, "", "L", false)
+	}
+
+	// Footer
+	pdf.SetFont("Helvetica", "I", 8)
+	pdf.SetTextColor(120, 120, 120)
+	pdf.SetXY(quoteMargin, quotePageHeight-quoteMargin)
+	pdf.CellFormat(contentW, 4, "Generated by CNCCalculator - CNC Cut List Optimizer", "", 0, "C", false, 0, "")
+
+	return pdf.OutputFileAndClose(path)
+}
+
+// drawQuoteTableHeader draws the line item table header at y and returns the
+// y position of the first row.
+func drawQuoteTableHeader(pdf *fpdf.Fpdf, y float64, colWidths []float64, headers []string) float64 {
+	pdf.SetFont("Helvetica", "B", 9)
+	pdf.SetFillColor(230, 230, 230)
+	x := quoteMargin
+	for i, header := range headers {
+		pdf.SetXY(x, y)
+		pdf.CellFormat(colWidths[i], quoteRowHeight, header, "1", 0, "C", true, 0, "")
+		x += colWidths[i]
+	}
+	pdf.SetFont("Helvetica", "", 9)
+	return y + quoteRowHeight
+}
+
+// formatMoney formats an amount with the quote currency.
+func formatMoney(currency string, v float64) string {
+	if currency == "" {
+		return fmt.Sprintf("%.2f", v)
+	}
+	return fmt.Sprintf("%s %.2f", currency, v)
+}
@@ -0,0 +1,54 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/piwi3910/SlabCut/internal/model"
+)
+
+func TestExportQuotePDF_CreatesFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "quote.pdf")
+
+	qs := model.DefaultQuoteSettings()
+	qs.CustomerName = "Jane Doe"
+	qs.Reference = "Q-2024-001"
+	qs.Notes = "Valid for 30 days."
+	qs.Hardware = append(qs.Hardware, model.NewHardwareItem("Hinge", 2.5, 8))
+
+	result := buildTestResult()
+	for i := range result.Sheets {
+		result.Sheets[i].Stock.PricePerSheet = 45
+	}
+	quote := model.BuildQuote(result, buildTestSettings(), qs)
+
+	if err := ExportQuotePDF(path, "Kitchen", quote); err != nil {
+		t.Fatalf("ExportQuotePDF returned error: %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("PDF file was not created: %v", err)
+	}
+	if info.Size() < 500 {
+		t.Errorf("PDF file seems too small: %d bytes", info.Size())
+	}
+}
+
+func TestExportQuotePDF_EmptyQuote(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty.pdf")
+	if err := ExportQuotePDF(path, "", model.Quote{}); err == nil {
+		t.Fatal("expected error for empty quote, got nil")
+	}
+}
+
+func TestFormatMoney(t *testing.T) {
+	if got := formatMoney("EUR", 12.5); got != "EUR 12.50" {
+		t.Errorf("formatMoney = %q, want %q", got, "EUR 12.50")
+	}
+	if got := formatMoney("", 3); got != "3.00" {
+		t.Errorf("formatMoney = %q, want %q", got, "3.00")
+	}
+}
@@ -7,6 +7,10 @@ import (
 	"github.com/piwi3910/SlabCut/internal/model"
 )
 
+// collisionStep is the spacing in mm of the tool positions checked along
+// cuts and rapids.
+const collisionStep = 5.0
+
 // CheckDustShoeCollisions analyzes the optimization result and detects potential
 // collisions between the dust shoe and clamp/fixture zones. The dust shoe is
 // modeled as a circle centered on the tool with diameter DustShoeWidth. A collision
@@ -14,8 +18,11 @@ import (
 // the tool is at safe Z height during rapid moves or at cutting depth during cuts.
 //
 // Collision checking examines:
-// 1. Cutting positions around each part perimeter (tool center + tool radius offset)
-// 2. Rapid move positions (approach to each part from safe Z)
+// 1. Tool positions every collisionStep mm along each part's toolpath and at
+// its drill holes
+// 2. The rapids between parts, in the order the generator cuts them, unless
+// the generator lifts over or routes around clamps (ClampAwareRetract or
+// RouteAroundClamps)
 //
 // A collision is reported when the distance from the tool center to the nearest
 // clamp zone edge is less than dustShoeRadius + clearance.
@@ -27,24 +34,22 @@ func CheckDustShoeCollisions(result model.OptimizeResult, settings model.CutSett
 	dustShoeRadius := settings.DustShoeWidth / 2.0
 	clearance := settings.DustShoeClearance
 	effectiveRadius := dustShoeRadius + clearance
-	toolRadius := settings.ToolDiameter / 2.0
+	checkRapids := !settings.ClampAwareRetract && !settings.RouteAroundClamps
+	g := New(settings)
 
 	var collisions []model.DustShoeCollision
 
 	for sheetIdx, sheet := range result.Sheets {
-		for partIdx, placement := range sheet.Placements {
-			// Get the tool path positions for this part's perimeter cut
-			positions := partCutPositions(placement, toolRadius)
-
-			for _, pos := range positions {
-				for _, cz := range settings.ClampZones {
+		check := func(partIdx int, label string, positions []toolPosition) {
+			for _, cz := range settings.ClampZones {
+				for _, pos := range positions {
 					dist := distanceToClampZone(pos.x, pos.y, cz)
 					if dist < effectiveRadius {
 						collisions = append(collisions, model.DustShoeCollision{
 							SheetIndex:  sheetIdx,
 							SheetLabel:  sheet.Stock.Label,
 							ClampLabel:  cz.Label,
-							PartLabel:   placement.Part.Label,
+							PartLabel:   label,
 							PartIndex:   partIdx,
 							ToolX:       pos.x,
 							ToolY:       pos.y,
@@ -57,6 +62,20 @@ func CheckDustShoeCollisions(result model.OptimizeResult, settings model.CutSett
 				}
 			}
 		}
+
+		for partIdx, placement := range sheet.Placements {
+			check(partIdx, placement.Part.Label, g.partCutPositions(placement))
+		}
+		if !checkRapids {
+			continue
+		}
+
+		// Rapids from each part to the first cut of the next
+		cuts, _ := g.cutOrder(sheet)
+		for i := 1; i < len(cuts); i++ {
+			c := cuts[i]
+			check(layoutIndex(sheet, c.Placement), c.Part.Label, rapidPositions(g.cutStart(cuts[i-1]), g.firstCut(c)))
+		}
 	}
 
 	return deduplicateCollisions(collisions)
@@ -68,38 +87,88 @@ type toolPosition struct {
 	isCut bool // true = during cutting, false = during rapid move
 }
 
-// partCutPositions returns the key positions the tool center will visit
-// when cutting around a rectangular part's perimeter. The tool cuts outside
-// the part boundary, offset by the tool radius.
-func partCutPositions(p model.Placement, toolRadius float64) []toolPosition {
-	pw := p.PlacedWidth()
-	ph := p.PlacedHeight()
-
-	// Tool center positions around the part perimeter (outside cut)
-	x0 := p.X - toolRadius
-	y0 := p.Y - toolRadius
-	x1 := p.X + pw + toolRadius
-	y1 := p.Y + ph + toolRadius
-
-	// Sample corner positions and midpoints of each side
-	positions := []toolPosition{
-		// Corners (most likely to collide with clamps at sheet edges)
-		{x0, y0, true},
-		{x1, y0, true},
-		{x1, y1, true},
-		{x0, y1, true},
-		// Side midpoints
-		{(x0 + x1) / 2, y0, true},
-		{x1, (y0 + y1) / 2, true},
-		{(x0 + x1) / 2, y1, true},
-		{x0, (y0 + y1) / 2, true},
-		// Part center (rapid approach position)
-		{p.X + pw/2, p.Y + ph/2, false},
+// partCutPositions returns the positions the tool center visits while
+// cutting a part: its drill holes and points every collisionStep mm along
+// its toolpath, which runs outside the part offset by the tool radius.
+func (g *Generator) partCutPositions(p model.Placement) []toolPosition {
+	var positions []toolPosition
+	for _, h := range p.DrillPositions() {
+		positions = append(positions, toolPosition{h.X, h.Y, true})
 	}
 
+	var path []model.Point2D
+	if len(p.Part.Outline) >= 3 {
+		path = g.outlineToolpath(p, 0).pts
+	} else {
+		x0, y0, x1, y1 := g.rectToolpath(p, 0)
+		for _, c := range rectCorners(x0, y0, x1, y1) {
+			path = append(path, model.Point2D{X: c[0], Y: c[1]})
+		}
+	}
+	for i, a := range path {
+		for _, pt := range samplePoints(a, path[(i+1)%len(path)]) {
+			positions = append(positions, toolPosition{pt.X, pt.Y, true})
+		}
+	}
 	return positions
 }
 
+// rapidPositions returns points every collisionStep mm along a rapid.
+func rapidPositions(from, to model.Point2D) []toolPosition {
+	var positions []toolPosition
+	for _, pt := range samplePoints(from, to) {
+		positions = append(positions, toolPosition{pt.X, pt.Y, false})
+	}
+	return append(positions, toolPosition{to.X, to.Y, false})
+}
+
+// samplePoints returns a and points every collisionStep mm towards b,
+// stopping short of b.
+func samplePoints(a, b model.Point2D) []model.Point2D {
+	length := math.Hypot(b.X-a.X, b.Y-a.Y)
+	n := int(math.Ceil(length / collisionStep))
+	if n < 1 {
+		n = 1
+	}
+	pts := make([]model.Point2D, n)
+	for k := 0; k < n; k++ {
+		f := float64(k) / float64(n)
+		pts[k] = model.Point2D{X: a.X + (b.X-a.X)*f, Y: a.Y + (b.Y-a.Y)*f}
+	}
+	return pts
+}
+
+// firstCut returns where the tool first goes down on a part: its first
+// drill hole, or else the start of its perimeter.
+func (g *Generator) firstCut(c partCut) model.Point2D {
+	if holes := c.DrillPositions(); len(holes) > 0 {
+		return model.Point2D{X: holes[0].X, Y: holes[0].Y}
+	}
+	return g.cutStart(c)
+}
+
+// cutStart returns the point a part's perimeter starts and ends at.
+func (g *Generator) cutStart(c partCut) model.Point2D {
+	if len(c.Part.Outline) >= 3 {
+		pts := g.outlineToolpath(c.Placement, 0).pts
+		return pts[c.entry%len(pts)]
+	}
+	x0, y0, x1, y1 := g.rectToolpath(c.Placement, 0)
+	corner := rectCorners(x0, y0, x1, y1)[c.entry%4]
+	return model.Point2D{X: corner[0], Y: corner[1]}
+}
+
+// layoutIndex returns the index of a placement among the sheet's
+// placements. Placements never share a position, so it is matched by that.
+func layoutIndex(sheet model.SheetResult, p model.Placement) int {
+	for i, q := range sheet.Placements {
+		if q.X == p.X && q.Y == p.Y && q.Part.ID == p.Part.ID {
+			return i
+		}
+	}
+	return -1
+}
+
 // distanceToClampZone computes the minimum distance from a point (px, py)
 // to the boundary of a clamp zone rectangle. Returns 0 if the point is
 // inside the zone, positive if outside.
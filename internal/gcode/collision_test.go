@@ -1,6 +1,7 @@
 package gcode
 
 import (
+	"math"
 	"testing"
 
 	"github.com/piwi3910/SlabCut/internal/model"
@@ -205,17 +206,69 @@ func TestPartCutPositions(t *testing.T) {
 		X:    50,
 		Y:    50,
 	}
+	settings := model.DefaultSettings()
+	settings.ToolDiameter = 6.0
 
-	positions := partCutPositions(p, 3.0)
-	require.Len(t, positions, 9, "should have 4 corners + 4 midpoints + 1 center")
+	positions := New(settings).partCutPositions(p)
+	// 206 + 106 + 206 + 106 mm of toolpath, sampled every 5mm
+	require.Len(t, positions, 42+22+42+22)
 
 	// First position should be tool offset from part origin
 	assert.InDelta(t, 47.0, positions[0].x, 0.001) // 50 - 3.0
 	assert.InDelta(t, 47.0, positions[0].y, 0.001) // 50 - 3.0
-	assert.True(t, positions[0].isCut)
+	for _, pos := range positions {
+		assert.True(t, pos.isCut)
+		onX := math.Abs(pos.x-47) < 1e-6 || math.Abs(pos.x-253) < 1e-6
+		onY := math.Abs(pos.y-47) < 1e-6 || math.Abs(pos.y-153) < 1e-6
+		assert.True(t, onX || onY, "position (%.1f, %.1f) should be on the toolpath", pos.x, pos.y)
+	}
+}
+
+func TestCheckDustShoeCollisions_LongSideNearClamp(t *testing.T) {
+	settings := model.DefaultSettings()
+	settings.DustShoeEnabled = true
+	settings.DustShoeWidth = 40.0
+	settings.DustShoeClearance = 2.0
+	settings.ToolDiameter = 6.0
+	// A small clamp next to the middle of a long side, far from its corners
+	// and midpoint
+	settings.ClampZones = []model.ClampZone{
+		{Label: "Side", X: 230, Y: 115, Width: 10, Height: 10, ZHeight: 25},
+	}
+	result := model.OptimizeResult{Sheets: []model.SheetResult{{
+		Stock:      model.NewStockSheet("Sheet", 1000, 600, 1),
+		Placements: []model.Placement{{Part: model.NewPart("Long", 800, 100, 1), X: 0, Y: 0}},
+	}}}
+
+	collisions := CheckDustShoeCollisions(result, settings)
+	require.Len(t, collisions, 1)
+	assert.True(t, collisions[0].IsDuringCut)
+}
+
+func TestCheckDustShoeCollisions_Rapids(t *testing.T) {
+	settings := model.DefaultSettings()
+	settings.DustShoeEnabled = true
+	settings.DustShoeWidth = 40.0
+	settings.DustShoeClearance = 2.0
+	settings.ToolDiameter = 6.0
+	// A clamp between two parts, clear of both cuts
+	settings.ClampZones = []model.ClampZone{
+		{Label: "Between", X: 480, Y: 0, Width: 40, Height: 40, ZHeight: 25},
+	}
+	result := model.OptimizeResult{Sheets: []model.SheetResult{{
+		Stock: model.NewStockSheet("Sheet", 1000, 600, 1),
+		Placements: []model.Placement{
+			{Part: model.NewPart("Left", 100, 100, 1), X: 100, Y: 0},
+			{Part: model.NewPart("Right", 100, 100, 1), X: 800, Y: 0},
+		},
+	}}}
+
+	collisions := CheckDustShoeCollisions(result, settings)
+	require.NotEmpty(t, collisions, "the rapid from Left to Right crosses the clamp")
+	assert.False(t, collisions[0].IsDuringCut)
+	assert.Equal(t, "Right", collisions[0].PartLabel)
+	assert.Equal(t, 1, collisions[0].PartIndex)
 
-	// Last position should be center (rapid approach)
-	assert.InDelta(t, 150.0, positions[8].x, 0.001) // 50 + 200/2
-	assert.InDelta(t, 100.0, positions[8].y, 0.001) // 50 + 100/2
-	assert.False(t, positions[8].isCut)
+	settings.RouteAroundClamps = true
+	assert.Empty(t, CheckDustShoeCollisions(result, settings), "routed rapids avoid the clamp")
 }
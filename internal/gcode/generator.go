@@ -4,28 +4,54 @@ import (
 	"fmt"
 	"math"
 	"sort"
-	"strings"
 
 	"github.com/piwi3910/SlabCut/internal/model"
 )
 
 // Generator produces GCode from an optimized sheet layout.
 type Generator struct {
-	Settings model.CutSettings
-	profile  model.GCodeProfile
+	Settings  model.CutSettings
+	profile   model.GCodeProfile
+	post      *post
+	postErr   error // why the profile's own template could not be used
+	renderErr error // first post-processor block that failed to render
+	stage     cutStage
 }
 
+// New returns a generator for the settings' GCode profile. A profile whose
+// template does not parse falls back to the Generic template, with a warning
+// in every program.
 func New(settings model.CutSettings) *Generator {
-	return &Generator{
+	g := &Generator{
 		Settings: settings,
 		profile:  model.GetProfile(settings.GCodeProfile),
 	}
+	g.post, g.postErr = compilePost(g.profile)
+	if g.postErr != nil {
+		generic := model.GetProfile("Generic")
+		generic.CommentPrefix, generic.CommentSuffix = g.profile.CommentPrefix, g.profile.CommentSuffix
+		g.post, _ = compilePost(generic)
+	}
+	return g
 }
 
 // GenerateSheet produces GCode for a single sheet's placements.
 // If the stock sheet has a non-zero Thickness, it overrides CutDepth so that
 // multi-pass calculations are based on the actual material being cut.
 func (g *Generator) GenerateSheet(sheet model.SheetResult, sheetIndex int) string {
+	return g.generateSheet(sheet, sheetIndex, "")
+}
+
+// generateSheet produces GCode for a sheet, with an optional note as the
+// first line of the job description.
+func (g *Generator) generateSheet(sheet model.SheetResult, sheetIndex int, note string) string {
+	m := g.newMachine()
+	g.writeSheet(m, sheet, sheetIndex, note)
+	return m.String()
+}
+
+// writeSheet writes a sheet's program to m.
+func (g *Generator) writeSheet(m *machine, sheet model.SheetResult, sheetIndex int, note string) {
 	// Use stock thickness as cut depth when available
 	origCutDepth := g.Settings.CutDepth
 	if sheet.Stock.Thickness > 0 {
@@ -33,25 +59,48 @@ func (g *Generator) GenerateSheet(sheet model.SheetResult, sheetIndex int) strin
 	}
 	defer func() { g.Settings.CutDepth = origCutDepth }()
 
-	var b strings.Builder
+	m.job = g.jobVars(sheet, sheetIndex)
+
+	g.writeHeader(m, sheet, sheetIndex, note)
 
-	g.writeHeader(&b, sheet, sheetIndex)
+	cuts, notes := g.cutOrder(sheet)
+	for _, n := range notes {
+		m.WriteString(g.comment(n))
+	}
+	m.headerDone()
+
+	if g.twoStageActive() {
+		g.writeTwoStage(m, cuts)
+	} else {
+		for i, c := range cuts {
+			g.writePart(m, c, i+1)
+		}
+	}
 
+	g.writeFooter(m)
+}
+
+// cutOrder returns the sheet's parts in the order they are cut, each with
+// the vertex its perimeter starts at, and notes on how the order was found.
+func (g *Generator) cutOrder(sheet model.SheetResult) ([]partCut, []string) {
 	placements := sheet.Placements
+	cuts := cutsOf(placements)
+	var notes []string
 	if g.Settings.StructuralOrdering && len(placements) > 1 {
-		placements = g.structuralOrderPlacements(placements, sheet.Stock.Width, sheet.Stock.Height)
-		b.WriteString(g.comment("Cut ordering: structural integrity (center-out)"))
+		cuts = cutsOf(g.structuralOrderPlacements(placements, sheet.Stock.Width, sheet.Stock.Height))
+		notes = append(notes, "Cut ordering: structural integrity (center-out)")
 	} else if g.Settings.OptimizeToolpath && len(placements) > 1 {
-		placements = g.orderPlacements(placements)
-		b.WriteString(g.comment("Toolpath ordering: nearest-neighbor optimization"))
+		var savings RapidSavings
+		cuts, savings = g.orderPlacements(placements)
+		notes = append(notes, fmt.Sprintf("Toolpath ordering: %.0fmm of rapids, %.0fmm (%.0f%%) less than layout order",
+			savings.After, savings.Saved(), savings.Percent()))
 	}
 
-	for i, placement := range placements {
-		g.writePart(&b, placement, i+1)
+	if g.Settings.SmallPartStrategy && len(placements) > 1 {
+		cuts = g.smallPartsFirst(cuts)
+		notes = append(notes, fmt.Sprintf("Small parts first (under %.0f mm²)", g.Settings.SmallPartArea))
 	}
-
-	g.writeFooter(&b)
-	return b.String()
+	return cuts, notes
 }
 
 // GenerateAll produces one GCode string per sheet.
@@ -63,52 +112,43 @@ func (g *Generator) GenerateAll(result model.OptimizeResult) []string {
 	return codes
 }
 
-// orderPlacements reorders placements using a nearest-neighbor heuristic to
-// minimize total rapid travel distance. Starting from the origin (0,0), each
-// subsequent placement is chosen as the one closest to the previous placement's
-// center. This reduces machine idle time and total cycle time.
-func (g *Generator) orderPlacements(placements []model.Placement) []model.Placement {
-	n := len(placements)
-	if n <= 1 {
-		return placements
-	}
-
-	// Work on a copy to avoid modifying the original
-	remaining := make([]model.Placement, n)
-	copy(remaining, placements)
-	ordered := make([]model.Placement, 0, n)
+// PatternProgram is the GCode for one sheet pattern, run once for every
+// sheet cut with the pattern.
+type PatternProgram struct {
+	Pattern   model.SheetPattern
+	Code      string
+	Extension string // file extension of the profile, e.g. ".nc"
+}
 
-	// Start from origin (0, 0)
-	curX, curY := 0.0, 0.0
+// FileName returns a file name for the program, e.g. "sheet1.gcode" for a
+// single sheet or "sheet1_x6.gcode" for a pattern starting at sheet 1 that
+// is cut six times.
+func (pp PatternProgram) FileName() string {
+	ext := pp.Extension
+	if ext == "" {
+		ext = ".gcode"
+	}
+	first := pp.Pattern.Sheets[0] + 1
+	if pp.Pattern.Count() == 1 {
+		return fmt.Sprintf("sheet%d%s", first, ext)
+	}
+	return fmt.Sprintf("sheet%d_x%d%s", first, pp.Pattern.Count(), ext)
+}
 
-	for len(remaining) > 0 {
-		bestIdx := 0
-		bestDist := math.MaxFloat64
-
-		for i, p := range remaining {
-			// Use the center of the placement as the reference point
-			cx := p.X + p.PlacedWidth()/2.0
-			cy := p.Y + p.PlacedHeight()/2.0
-			dx := cx - curX
-			dy := cy - curY
-			dist := math.Sqrt(dx*dx + dy*dy)
-			if dist < bestDist {
-				bestDist = dist
-				bestIdx = i
-			}
+// GeneratePatterns produces one GCode program per sheet pattern, so that
+// identical sheets share a single program. Programs for repeated patterns
+// start their job description with the repeat count and sheet numbers.
+func (g *Generator) GeneratePatterns(result model.OptimizeResult) []PatternProgram {
+	var programs []PatternProgram
+	for _, pattern := range result.Patterns() {
+		var note string
+		if pattern.Count() > 1 {
+			note = fmt.Sprintf("Pattern: run %d times, for sheets %s", pattern.Count(), pattern.SheetNumbers())
 		}
-
-		chosen := remaining[bestIdx]
-		ordered = append(ordered, chosen)
-		curX = chosen.X + chosen.PlacedWidth()/2.0
-		curY = chosen.Y + chosen.PlacedHeight()/2.0
-
-		// Remove chosen from remaining
-		remaining[bestIdx] = remaining[len(remaining)-1]
-		remaining = remaining[:len(remaining)-1]
+		code := g.generateSheet(pattern.Sheet, pattern.Sheets[0]+1, note)
+		programs = append(programs, PatternProgram{Pattern: pattern, Code: code, Extension: g.profile.Extension()})
 	}
-
-	return ordered
+	return programs
 }
 
 // structuralOrderPlacements reorders placements to maintain structural integrity
@@ -199,8 +239,9 @@ func placementDistance(a, b model.Placement) float64 {
 }
 
 // TotalRapidDistance calculates the total rapid travel distance for a sequence
-// of placements, starting from the origin (0,0). This is useful for comparing
-// ordered vs unordered toolpaths.
+// of placements, starting from the origin (0,0) and going from centre to
+// centre. Generator.RapidSavings measures the travel between the actual start
+// points of the toolpaths before and after ordering.
 func TotalRapidDistance(placements []model.Placement) float64 {
 	if len(placements) == 0 {
 		return 0
@@ -219,87 +260,96 @@ func TotalRapidDistance(placements []model.Placement) float64 {
 	return total
 }
 
-func (g *Generator) writeHeader(b *strings.Builder, sheet model.SheetResult, idx int) {
-	p := g.profile
-
-	// Write file header comment
-	b.WriteString(p.CommentPrefix)
-	b.WriteString(fmt.Sprintf(" CNCCalculator GCode — Sheet %d (%s)\n", idx, sheet.Stock.Label))
-	b.WriteString(p.CommentPrefix)
-	b.WriteString(fmt.Sprintf(" Stock: %.1f x %.1f mm\n", sheet.Stock.Width, sheet.Stock.Height))
-	b.WriteString(p.CommentPrefix)
-	b.WriteString(fmt.Sprintf(" Parts: %d, Efficiency: %.1f%%\n", len(sheet.Placements), sheet.Efficiency()))
-	b.WriteString(p.CommentPrefix)
-	b.WriteString(fmt.Sprintf(" Tool: %.1fmm, Feed: %.0f mm/min, Plunge: %.0f mm/min\n",
-		g.Settings.ToolDiameter, g.Settings.FeedRate, g.Settings.PlungeRate))
-	numPasses := int(math.Ceil(g.Settings.CutDepth / g.Settings.PassDepth))
-	b.WriteString(p.CommentPrefix)
-	b.WriteString(fmt.Sprintf(" Depth: %.1fmm in %.1fmm passes (%d passes)\n", g.Settings.CutDepth, g.Settings.PassDepth, numPasses))
-	b.WriteString(p.CommentPrefix)
-	b.WriteString(fmt.Sprintf(" Profile: %s\n", p.Name))
-	b.WriteString("\n")
-
-	// Write startup codes
-	for _, code := range p.StartCode {
-		b.WriteString(code + "\n")
-	}
-
-	// Spindle start
-	if p.SpindleStart != "" {
-		b.WriteString(fmt.Sprintf(p.SpindleStart+"\n", g.Settings.SpindleSpeed))
-	}
-
-	// Initial safe Z retract
-	b.WriteString(fmt.Sprintf("%s X%s Y%s\n", p.RapidMove, g.format(0), g.format(0)))
-	b.WriteString(fmt.Sprintf("%s Z%s\n", p.RapidMove, g.format(g.Settings.SafeZ)))
-
-	b.WriteString("\n")
-}
-
-func (g *Generator) writeFooter(b *strings.Builder) {
-	p := g.profile
-
-	b.WriteString("\n")
-	b.WriteString(p.CommentPrefix + " === Job complete ===\n")
-
-	// Write end codes
-	for _, code := range p.EndCode {
-		// Replace [SafeZ] placeholder
-		code = strings.ReplaceAll(code, "[SafeZ]", g.format(g.Settings.SafeZ))
-		b.WriteString(code + "\n")
-	}
-
-	// Spindle stop
-	if p.SpindleStop != "" {
-		b.WriteString(p.SpindleStop + "\n")
-	}
+// jobVars returns the job variables for a sheet's post-processor blocks.
+func (g *Generator) jobVars(sheet model.SheetResult, idx int) PostVars {
+	s := g.Settings
+	return PostVars{
+		Program:        idx,
+		Sheet:          idx,
+		SheetLabel:     sheet.Stock.Label,
+		StockWidth:     sheet.Stock.Width,
+		StockHeight:    sheet.Stock.Height,
+		StockThickness: sheet.Stock.Thickness,
+		Parts:          len(sheet.Placements),
+		Profile:        g.profile.Name,
+		Units:          g.profile.Units,
+		Tool:           1,
+		ToolDiameter:   s.ToolDiameter,
+		SpindleSpeed:   s.SpindleSpeed,
+		FeedRate:       s.FeedRate,
+		PlungeRate:     s.PlungeRate,
+		SafeZ:          s.SafeZ,
+		CutDepth:       s.CutDepth,
+		PassDepth:      s.PassDepth,
+		Passes:         int(math.Ceil(s.CutDepth / s.PassDepth)),
+		ReturnZ:        g.format(s.SafeZ),
+	}
+}
+
+// writeHeader writes the header block, which carries the job description
+// in Banner, and the tool change block, then moves to the origin at SafeZ.
+func (g *Generator) writeHeader(m *machine, sheet model.SheetResult, idx int, note string) {
+	s := g.Settings
+	if note != "" {
+		m.job.Banner = append(m.job.Banner, note)
+	}
+	m.job.Banner = append(m.job.Banner,
+		fmt.Sprintf("CNCCalculator GCode — Sheet %d (%s)", idx, sheet.Stock.Label),
+		fmt.Sprintf("Stock: %.1f x %.1f mm", sheet.Stock.Width, sheet.Stock.Height),
+		fmt.Sprintf("Parts: %d, Efficiency: %.1f%%", len(sheet.Placements), sheet.Efficiency()),
+		fmt.Sprintf("Tool: %.1fmm, Feed: %.0f mm/min, Plunge: %.0f mm/min", s.ToolDiameter, s.FeedRate, s.PlungeRate),
+		fmt.Sprintf("Depth: %.1fmm in %.1fmm passes (%d passes)", s.CutDepth, s.PassDepth, m.job.Passes),
+		fmt.Sprintf("Profile: %s", g.profile.Name),
+	)
+	m.block(model.BlockHeader, PostVars{})
+	if g.postErr != nil {
+		m.WriteString(g.comment(fmt.Sprintf("WARNING: profile %q template unusable (%v), using Generic", g.profile.Name, g.postErr)))
+	}
+	if g.post.defined[model.BlockToolChange] {
+		m.block(model.BlockToolChange, PostVars{})
+	}
+
+	// Initial safe Z retract, before any move in XY
+	m.retract()
+	m.rapid(0, 0)
+
+	m.WriteString("\n")
+}
+
+// writeFooter writes the footer block. Its ReturnZ is the height at which
+// the tool can return to the origin, which may clear a clamp on the way.
+func (g *Generator) writeFooter(m *machine) {
+	m.WriteString("\n")
+	m.WriteString(g.comment("=== Job complete ==="))
+
+	m.job.ReturnZ = g.format(m.clearance(0, 0))
+	m.block(model.BlockFooter, PostVars{})
 }
 
 // writePlunge generates the plunge entry at position (x, y) to the given depth
 // using the configured plunge strategy (direct, ramp, or helix).
 // The tool is assumed to already be at (x, y) at safe Z height.
-func (g *Generator) writePlunge(b *strings.Builder, x, y, depth float64) {
+func (g *Generator) writePlunge(m *machine, x, y, depth float64) {
 	switch g.Settings.PlungeType {
 	case model.PlungeRamp:
-		g.writeRampPlunge(b, x, y, depth)
+		g.writeRampPlunge(m, x, y, depth)
 	case model.PlungeHelix:
-		g.writeHelixPlunge(b, x, y, depth)
+		g.writeHelixPlunge(m, x, y, depth)
 	default:
-		g.writeDirectPlunge(b, depth)
+		g.writeDirectPlunge(m, depth)
 	}
 }
 
 // writeDirectPlunge performs a standard straight-down plunge.
-func (g *Generator) writeDirectPlunge(b *strings.Builder, depth float64) {
-	b.WriteString(fmt.Sprintf("%s Z%s F%s\n", g.profile.FeedMove,
-		g.format(-depth), g.format(g.Settings.PlungeRate)))
+func (g *Generator) writeDirectPlunge(m *machine, depth float64) {
+	m.feedZ(-depth, g.Settings.PlungeRate)
 }
 
 // writeRampPlunge generates a linear ramp entry. The tool moves forward along X
 // while simultaneously descending to the target depth, creating an angled entry
 // that reduces axial load on the tool. The ramp length is calculated from the
 // configured ramp angle and the depth to plunge.
-func (g *Generator) writeRampPlunge(b *strings.Builder, x, y, depth float64) {
+func (g *Generator) writeRampPlunge(m *machine, x, y, depth float64) {
 	angle := g.Settings.RampAngle
 	if angle <= 0 {
 		angle = 3.0 // Default 3 degrees
@@ -311,26 +361,24 @@ func (g *Generator) writeRampPlunge(b *strings.Builder, x, y, depth float64) {
 	// Calculate ramp length from angle: length = depth / tan(angle)
 	rampLength := depth / math.Tan(angle*math.Pi/180.0)
 
-	b.WriteString(g.comment(fmt.Sprintf("Ramp plunge entry (%.1f deg, length=%.2fmm)", angle, rampLength)))
+	m.WriteString(g.comment(fmt.Sprintf("Ramp plunge entry (%.1f deg, length=%.2fmm)", angle, rampLength)))
 
 	// Rapid to safe Z at current position
-	b.WriteString(fmt.Sprintf("%s Z%s\n", g.profile.RapidMove, g.format(0)))
+	m.rapidZ(0)
 
 	// Ramp down: move forward along X while descending
 	rampEndX := x + rampLength
-	b.WriteString(fmt.Sprintf("%s X%s Y%s Z%s F%s\n", g.profile.FeedMove,
-		g.format(rampEndX), g.format(y), g.format(-depth), g.format(g.Settings.PlungeRate)))
+	m.feedXYZ(rampEndX, y, -depth, g.Settings.PlungeRate)
 
 	// Move back to original X at cut depth
-	b.WriteString(fmt.Sprintf("%s X%s Y%s F%s\n", g.profile.FeedMove,
-		g.format(x), g.format(y), g.format(g.Settings.FeedRate)))
+	m.feedAt(x, y, g.Settings.FeedRate)
 }
 
 // writeHelixPlunge generates a helical plunge entry. The tool descends in a
 // circular helix pattern, distributing the cutting force over a larger area
 // and reducing heat buildup. The helix diameter and depth per revolution are
 // configurable.
-func (g *Generator) writeHelixPlunge(b *strings.Builder, x, y, depth float64) {
+func (g *Generator) writeHelixPlunge(m *machine, x, y, depth float64) {
 	diameter := g.Settings.HelixDiameter
 	if diameter <= 0 {
 		diameter = g.Settings.ToolDiameter // Default to tool diameter
@@ -352,13 +400,12 @@ func (g *Generator) writeHelixPlunge(b *strings.Builder, x, y, depth float64) {
 		numRevolutions = 1
 	}
 
-	b.WriteString(g.comment(fmt.Sprintf("Helix plunge entry (dia=%.1fmm, %.1f rev)", diameter, numRevolutions)))
+	m.WriteString(g.comment(fmt.Sprintf("Helix plunge entry (dia=%.1fmm, %.1f rev)", diameter, numRevolutions)))
 
 	// Move to helix start position (center + radius offset in X)
 	helixStartX := x + radius
-	b.WriteString(fmt.Sprintf("%s X%s Y%s\n", g.profile.RapidMove,
-		g.format(helixStartX), g.format(y)))
-	b.WriteString(fmt.Sprintf("%s Z%s\n", g.profile.RapidMove, g.format(0)))
+	m.rapid(helixStartX, y)
+	m.rapidZ(0)
 
 	// Generate helix revolutions using G2/G3 arcs with Z descent
 	// I offset = distance from current position to center in X
@@ -378,152 +425,95 @@ func (g *Generator) writeHelixPlunge(b *strings.Builder, x, y, depth float64) {
 			currentDepth = depth
 		}
 		// Full circle arc back to the same XY position, but lower in Z
-		b.WriteString(fmt.Sprintf("%s X%s Y%s Z%s I%s J%s F%s\n",
-			arcCmd,
-			g.format(helixStartX), g.format(y), g.format(-currentDepth),
-			g.format(iOffset), g.format(jOffset),
-			g.format(g.Settings.PlungeRate)))
+		m.helix(arcCmd, helixStartX, y, -currentDepth, iOffset, jOffset, g.Settings.PlungeRate)
 	}
 
 	// Move back to the original position at cut depth
-	b.WriteString(fmt.Sprintf("%s X%s Y%s F%s\n", g.profile.FeedMove,
-		g.format(x), g.format(y), g.format(g.Settings.FeedRate)))
-}
-
-func (g *Generator) writePart(b *strings.Builder, p model.Placement, partNum int) {
+	m.feedAt(x, y, g.Settings.FeedRate)
+}
+
+// writePart cuts a part's drill holes and its perimeter, starting the
+// perimeter at the cut's entry vertex. Small parts are cut with their own
+// onion skin and tabs; in the second stage of a two-stage cut the holes are
+// already drilled.
+func (g *Generator) writePart(m *machine, c partCut, partNum int) {
+	p := c.Placement
+	m.startPart(partNum, p.Part.Label)
+	if g.isSmallPart(p) {
+		orig := g.Settings
+		g.Settings = g.smallPartSettings()
+		defer func() { g.Settings = orig }()
+		m.WriteString(g.comment(fmt.Sprintf("Small part (%.0f mm²): extra hold-down", partArea(p))))
+	}
+	// Drill while the part is still held by the surrounding sheet.
+	if g.stage != stageFree {
+		g.writeDrills(m, p)
+	}
 	if len(p.Part.Outline) > 0 {
-		g.writeOutlinePart(b, p, partNum)
+		g.writeOutlinePart(m, p, c.entry, partNum)
 	} else {
-		g.writeRectPart(b, p, partNum)
+		g.writeRectPart(m, p, c.entry, partNum)
 	}
 }
 
-// writeOutlinePart generates GCode that follows the actual part outline
-// instead of a rectangular perimeter.
-func (g *Generator) writeOutlinePart(b *strings.Builder, p model.Placement, partNum int) {
-	toolR := g.Settings.ToolDiameter / 2.0
-
-	b.WriteString(g.comment(fmt.Sprintf("--- Part %d: %s (%.1f x %.1f, outline)%s ---",
-		partNum, p.Part.Label, p.Part.Width, p.Part.Height,
-		rotatedStr(p.Rotated))))
-
-	// Build the toolpath: offset each outline point outward by tool radius
-	outline := g.offsetOutline(p.Part.Outline, toolR)
-
-	// Translate outline to placement position on the stock sheet
-	translated := make(model.Outline, len(outline))
-	for i, pt := range outline {
-		translated[i] = model.Point2D{X: pt.X + p.X, Y: pt.Y + p.Y}
-	}
-
-	if len(translated) < 3 {
-		b.WriteString(g.comment("WARNING: outline has fewer than 3 points, skipping"))
+// writeDrills bores the part's drill holes. Holes no wider than the tool are
+// plunged in pecks of PassDepth, with the post-processor's drill cycle when
+// it has one; wider holes are pocketed with concentric circles, stepping
+// inward by the tool radius, at each pass depth.
+func (g *Generator) writeDrills(m *machine, p model.Placement) {
+	holes := p.DrillPositions()
+	if len(holes) == 0 {
 		return
 	}
+	toolR := g.Settings.ToolDiameter / 2.0
+	m.WriteString(g.comment(fmt.Sprintf("Drilling: %s (%d holes)", p.Part.Label, len(holes))))
 
-	numPasses := int(math.Ceil(g.Settings.CutDepth / g.Settings.PassDepth))
-
-	for pass := 1; pass <= numPasses; pass++ {
-		depth := float64(pass) * g.Settings.PassDepth
-		if depth > g.Settings.CutDepth {
-			depth = g.Settings.CutDepth
+	for _, h := range holes {
+		depth := math.Min(h.Depth, g.Settings.CutDepth)
+		if depth <= 0 {
+			continue
 		}
-		isFinalPass := pass == numPasses
-
-		// Apply onion skin on final pass
-		effectiveDepth, skinApplied := g.applyOnionSkin(depth, isFinalPass)
-		if skinApplied {
-			b.WriteString(g.comment(fmt.Sprintf("Onion skin: leaving %.2fmm skin", g.Settings.OnionSkinDepth)))
+		numPasses := int(math.Ceil(depth / g.Settings.PassDepth))
+		m.rapid(h.X, h.Y)
+
+		ringR := h.Diameter/2.0 - toolR
+		if ringR <= 0 {
+			if h.Diameter < g.Settings.ToolDiameter {
+				m.WriteString(g.comment(fmt.Sprintf("WARNING: %.1fmm hole is smaller than the %.1fmm tool",
+					h.Diameter, g.Settings.ToolDiameter)))
+			}
+			if g.post.defined[model.BlockDrill] {
+				m.drill(-depth, 0, g.Settings.PassDepth, g.Settings.PlungeRate)
+				m.retract()
+				continue
+			}
+			for pass := 1; pass <= numPasses; pass++ {
+				d := math.Min(float64(pass)*g.Settings.PassDepth, depth)
+				m.feedZ(-d, g.Settings.PlungeRate)
+				m.rapidZ(0)
+			}
+			m.retract()
+			continue
 		}
 
-		b.WriteString(g.comment(fmt.Sprintf("Pass %d/%d, depth=%.2fmm", pass, numPasses, effectiveDepth)))
-
-		// Rapid to first point
-		b.WriteString(fmt.Sprintf("%s X%s Y%s\n", g.profile.RapidMove,
-			g.format(translated[0].X), g.format(translated[0].Y)))
-		// Plunge using configured strategy
-		g.writePlunge(b, translated[0].X, translated[0].Y, effectiveDepth)
-
-		// Follow outline
-		for i := 1; i < len(translated); i++ {
-			b.WriteString(fmt.Sprintf("%s X%s Y%s F%s\n", g.profile.FeedMove,
-				g.format(translated[i].X), g.format(translated[i].Y),
-				g.format(g.Settings.FeedRate)))
+		arcCmd := "G2"
+		if g.Settings.UseClimb {
+			arcCmd = "G3"
 		}
-		// Close the loop back to the first point
-		b.WriteString(fmt.Sprintf("%s X%s Y%s F%s\n", g.profile.FeedMove,
-			g.format(translated[0].X), g.format(translated[0].Y),
-			g.format(g.Settings.FeedRate)))
-
-		// Retract
-		b.WriteString(fmt.Sprintf("%s Z%s\n", g.profile.RapidMove, g.format(g.Settings.SafeZ)))
-	}
-
-	// Onion skin cleanup pass for outline parts
-	if g.onionSkinActive() && g.Settings.OnionSkinCleanup {
-		fullDepth := g.Settings.CutDepth
-		b.WriteString(g.comment("Onion skin cleanup pass"))
-		b.WriteString(g.comment(fmt.Sprintf("Cleanup depth=%.2fmm (removing %.2fmm skin)",
-			fullDepth, g.Settings.OnionSkinDepth)))
-
-		b.WriteString(fmt.Sprintf("%s X%s Y%s\n", g.profile.RapidMove,
-			g.format(translated[0].X), g.format(translated[0].Y)))
-		g.writePlunge(b, translated[0].X, translated[0].Y, fullDepth)
-
-		for i := 1; i < len(translated); i++ {
-			b.WriteString(fmt.Sprintf("%s X%s Y%s F%s\n", g.profile.FeedMove,
-				g.format(translated[i].X), g.format(translated[i].Y),
-				g.format(g.Settings.FeedRate)))
-		}
-		b.WriteString(fmt.Sprintf("%s X%s Y%s F%s\n", g.profile.FeedMove,
-			g.format(translated[0].X), g.format(translated[0].Y),
-			g.format(g.Settings.FeedRate)))
-		b.WriteString(fmt.Sprintf("%s Z%s\n", g.profile.RapidMove, g.format(g.Settings.SafeZ)))
-	}
-
-	b.WriteString("\n")
-}
-
-// offsetOutline creates a simple outward offset of the outline by the given
-// distance. For each vertex, it computes the average outward normal of the
-// two adjacent edges and shifts the vertex along that normal.
-func (g *Generator) offsetOutline(outline model.Outline, dist float64) model.Outline {
-	n := len(outline)
-	if n < 3 {
-		return outline
-	}
-
-	result := make(model.Outline, n)
-	for i := 0; i < n; i++ {
-		prev := outline[(i-1+n)%n]
-		curr := outline[i]
-		next := outline[(i+1)%n]
-
-		// Edge vectors
-		e1x := curr.X - prev.X
-		e1y := curr.Y - prev.Y
-		e2x := next.X - curr.X
-		e2y := next.Y - curr.Y
-
-		// Outward normals (perpendicular, pointing left of travel direction)
-		n1x, n1y := normalize(-e1y, e1x)
-		n2x, n2y := normalize(-e2y, e2x)
-
-		// Average normal
-		nx := (n1x + n2x) / 2
-		ny := (n1y + n2y) / 2
-		nLen := math.Sqrt(nx*nx + ny*ny)
-		if nLen > 1e-9 {
-			nx /= nLen
-			ny /= nLen
-		}
-
-		result[i] = model.Point2D{
-			X: curr.X + nx*dist,
-			Y: curr.Y + ny*dist,
+		for pass := 1; pass <= numPasses; pass++ {
+			d := math.Min(float64(pass)*g.Settings.PassDepth, depth)
+			m.feed(h.X, h.Y)
+			m.feedZ(-d, g.Settings.PlungeRate)
+			for r := math.Min(toolR, ringR); ; r = math.Min(r+toolR, ringR) {
+				m.feedAt(h.X+r, h.Y, g.Settings.FeedRate)
+				m.arc(arcCmd, h.X+r, h.Y, -r, 0, g.Settings.FeedRate)
+				if r >= ringR {
+					break
+				}
+			}
 		}
+		m.retract()
 	}
-	return result
 }
 
 // onionSkinActive returns true if onion skinning is enabled and the skin depth is valid.
@@ -544,94 +534,146 @@ func (g *Generator) applyOnionSkin(depth float64, isFinalPass bool) (float64, bo
 	return skinDepth, true
 }
 
-func (g *Generator) writeRectPart(b *strings.Builder, p model.Placement, partNum int) {
-	toolR := g.Settings.ToolDiameter / 2.0
-
-	// The part rectangle in stock coordinates
-	pw := p.PlacedWidth()
-	ph := p.PlacedHeight()
-
-	// Offset for tool radius (cut outside the part perimeter)
-	x0 := p.X - toolR
-	y0 := p.Y - toolR
-	x1 := p.X + pw + toolR
-	y1 := p.Y + ph + toolR
-
-	b.WriteString(g.comment(fmt.Sprintf("--- Part %d: %s (%.1f x %.1f)%s ---",
+// writeRectPart cuts a rectangular part's perimeter, starting at the given
+// corner counting from the bottom-left towards the bottom-right.
+func (g *Generator) writeRectPart(m *machine, p model.Placement, corner int, partNum int) {
+	m.WriteString(g.comment(fmt.Sprintf("--- Part %d: %s (%.1f x %.1f)%s ---",
 		partNum, p.Part.Label, p.Part.Width, p.Part.Height,
 		rotatedStr(p.Rotated))))
 
-	numPasses := int(math.Ceil(g.Settings.CutDepth / g.Settings.PassDepth))
-
-	// Generate tabs info
-	tabs := g.calculateTabs(p)
+	shape := p.Part.TabShapeOrDefault(g.Settings)
 
-	hasLeadIn := g.Settings.LeadInRadius > 0
-	hasLeadOut := g.Settings.LeadOutRadius > 0
+	// Roughing passes cut outside the part perimeter, offset for the tool
+	// radius and any finishing allowance
+	allowance := g.finishAllowance()
+	x0, y0, x1, y1 := g.rectToolpath(p, allowance)
+	tabs := g.calculateTabs(p, allowance)
+	if allowance > 0 {
+		m.WriteString(g.comment(fmt.Sprintf("Roughing: leaving %.2fmm for finishing", allowance)))
+	}
 
-	for pass := 1; pass <= numPasses; pass++ {
-		depth := float64(pass) * g.Settings.PassDepth
-		if depth > g.Settings.CutDepth {
-			depth = g.Settings.CutDepth
-		}
+	depths, from, to := g.passDepths()
+	numPasses := len(depths)
+	for i := from; i < to; i++ {
+		pass, depth := i+1, depths[i]
 		isFinalPass := pass == numPasses
+		m.startPass()
 
 		// Apply onion skin on final pass
 		effectiveDepth, skinApplied := g.applyOnionSkin(depth, isFinalPass)
 		if skinApplied {
-			b.WriteString(g.comment(fmt.Sprintf("Onion skin: leaving %.2fmm skin", g.Settings.OnionSkinDepth)))
+			m.WriteString(g.comment(fmt.Sprintf("Onion skin: leaving %.2fmm skin", g.Settings.OnionSkinDepth)))
 		}
 
-		b.WriteString(g.comment(fmt.Sprintf("Pass %d/%d, depth=%.2fmm", pass, numPasses, effectiveDepth)))
-
-		if hasLeadIn {
-			// Lead-in arc: rapid to arc start, plunge, then arc onto the perimeter
-			g.writeLeadIn(b, x0, y0, effectiveDepth)
-		} else {
-			// Rapid to start (top-left corner, slightly outside)
-			b.WriteString(fmt.Sprintf("%s X%s Y%s\n", g.profile.RapidMove, g.format(x0), g.format(y0)))
-			g.writePlunge(b, x0, y0, effectiveDepth)
-		}
+		m.WriteString(g.comment(fmt.Sprintf("Pass %d/%d, depth=%.2fmm", pass, numPasses, effectiveDepth)))
 
-		// Cut rectangle perimeter (clockwise for climb milling)
-		if isFinalPass && g.Settings.PartTabsPerSide > 0 {
-			g.writePerimeterWithTabs(b, x0, y0, x1, y1, effectiveDepth, tabs)
-		} else {
-			g.writePerimeter(b, x0, y0, x1, y1)
+		var passTabs []Tab
+		if isFinalPass {
+			passTabs = tabs
 		}
+		g.writeRectLoop(m, x0, y0, x1, y1, corner, effectiveDepth, passTabs, shape, false)
+	}
 
-		if hasLeadOut {
-			// Lead-out arc: arc away from perimeter, then retract
-			g.writeLeadOut(b, x0, y0)
-		}
+	if g.stage == stageSkin {
+		m.WriteString("\n")
+		return
+	}
 
-		// Retract between passes
-		b.WriteString(fmt.Sprintf("%s Z%s\n", g.profile.RapidMove, g.format(g.Settings.SafeZ)))
+	// Finishing passes on the part perimeter
+	reverse := false
+	if allowance > 0 {
+		x0, y0, x1, y1 = g.rectToolpath(p, 0)
+		tabs = g.calculateTabs(p, 0)
+		reverse = g.Settings.FinishReverse
+		g.writeFinishingPasses(m, func(depth float64, final bool) {
+			var passTabs []Tab
+			if final {
+				passTabs = tabs
+			}
+			g.writeRectLoop(m, x0, y0, x1, y1, corner, depth, passTabs, shape, reverse)
+		})
 	}
 
 	// Onion skin cleanup pass: cut through the remaining skin at full depth
 	if g.onionSkinActive() && g.Settings.OnionSkinCleanup {
 		fullDepth := g.Settings.CutDepth
-		b.WriteString(g.comment("Onion skin cleanup pass"))
-		b.WriteString(g.comment(fmt.Sprintf("Cleanup depth=%.2fmm (removing %.2fmm skin)",
+		m.startPass()
+		m.WriteString(g.comment("Onion skin cleanup pass"))
+		m.WriteString(g.comment(fmt.Sprintf("Cleanup depth=%.2fmm (removing %.2fmm skin)",
 			fullDepth, g.Settings.OnionSkinDepth)))
+		g.writeRectLoop(m, x0, y0, x1, y1, corner, fullDepth, nil, shape, reverse)
+	}
 
-		if hasLeadIn {
-			g.writeLeadIn(b, x0, y0, fullDepth)
-		} else {
-			b.WriteString(fmt.Sprintf("%s X%s Y%s\n", g.profile.RapidMove, g.format(x0), g.format(y0)))
-			g.writePlunge(b, x0, y0, fullDepth)
-		}
+	m.WriteString("\n")
+}
 
-		g.writePerimeter(b, x0, y0, x1, y1)
+// writeRectLoop cuts one loop of a rectangular toolpath at the given depth
+// from the given corner, lifting over any tabs, and retracts to safe Z.
+// Lead arcs are only laid out for the bottom-left corner.
+func (g *Generator) writeRectLoop(m *machine, x0, y0, x1, y1 float64, corner int, depth float64, tabs []Tab, shape model.TabShape, reverse bool) {
+	start := rectCorners(x0, y0, x1, y1)[corner]
+	if g.Settings.LeadInRadius > 0 {
+		// Lead-in arc: rapid to arc start, plunge, then arc onto the perimeter
+		g.writeLeadIn(m, start[0], start[1], depth, reverse)
+	} else {
+		// Rapid to the start corner, slightly outside the part
+		m.rapid(start[0], start[1])
+		g.writePlunge(m, start[0], start[1], depth)
+	}
 
-		if hasLeadOut {
-			g.writeLeadOut(b, x0, y0)
-		}
-		b.WriteString(fmt.Sprintf("%s Z%s\n", g.profile.RapidMove, g.format(g.Settings.SafeZ)))
+	if len(tabs) > 0 {
+		g.writePerimeterWithTabs(m, x0, y0, x1, y1, corner, depth, tabs, shape, reverse)
+	} else {
+		g.writePerimeter(m, x0, y0, x1, y1, corner, reverse)
+	}
+
+	if g.Settings.LeadOutRadius > 0 {
+		// Lead-out arc: arc away from perimeter, then retract
+		g.writeLeadOut(m, start[0], start[1], reverse)
 	}
 
-	b.WriteString("\n")
+	// Retract between passes
+	m.retract()
+}
+
+// finishAllowance returns the radial stock the roughing passes leave for
+// the finishing pass, or 0 when there is no finishing pass.
+func (g *Generator) finishAllowance() float64 {
+	if !g.Settings.FinishingPass {
+		return 0
+	}
+	return math.Max(g.Settings.FinishAllowance, 0)
+}
+
+// writeFinishingPasses cuts the finishing passes at the finishing feed
+// rate, stepping down by FinishStepDown or at full depth in one pass. The
+// last pass leaves the onion skin and the holding tabs.
+func (g *Generator) writeFinishingPasses(m *machine, loop func(depth float64, final bool)) {
+	if g.Settings.FinishFeedRate > 0 {
+		origFeed := g.Settings.FeedRate
+		g.Settings.FeedRate = g.Settings.FinishFeedRate
+		defer func() { g.Settings.FeedRate = origFeed }()
+	}
+
+	step := g.Settings.FinishStepDown
+	if step <= 0 || step > g.Settings.CutDepth {
+		step = g.Settings.CutDepth
+	}
+	depths := stepDepths(0, g.Settings.CutDepth, step)
+	numPasses := len(depths)
+	for i, depth := range depths {
+		pass := i + 1
+		isFinalPass := pass == numPasses
+		m.startPass()
+
+		effectiveDepth, skinApplied := g.applyOnionSkin(depth, isFinalPass)
+		if skinApplied {
+			m.WriteString(g.comment(fmt.Sprintf("Onion skin: leaving %.2fmm skin", g.Settings.OnionSkinDepth)))
+		}
+		m.WriteString(g.comment(fmt.Sprintf("Finishing pass %d/%d, depth=%.2fmm, feed=%.0f mm/min",
+			pass, numPasses, effectiveDepth, g.Settings.FeedRate)))
+		loop(effectiveDepth, isFinalPass)
+	}
 }
 
 // writeLeadIn generates an arc approach to the perimeter start point (x0, y0).
@@ -644,7 +686,9 @@ func (g *Generator) writeRectPart(b *strings.Builder, p model.Placement, partNum
 // tool sweeps into the cut direction smoothly.
 //
 // For conventional milling (counter-clockwise perimeter), we use G2 (clockwise arc).
-func (g *Generator) writeLeadIn(b *strings.Builder, x0, y0, depth float64) {
+// A reversed perimeter starts up the left edge instead, so the arc is
+// mirrored across the corner's diagonal.
+func (g *Generator) writeLeadIn(m *machine, x0, y0, depth float64, reverse bool) {
 	r := g.Settings.LeadInRadius
 	angle := g.Settings.LeadInAngle * math.Pi / 180.0
 
@@ -652,33 +696,35 @@ func (g *Generator) writeLeadIn(b *strings.Builder, x0, y0, depth float64) {
 	// For a clockwise (climb) perimeter starting at (x0,y0) moving right,
 	// the arc center is directly below the start point (negative Y direction).
 	// The arc start is offset from the center by the radius at the approach angle.
-	centerX := x0
-	centerY := y0 - r
+	cdx, cdy := 0.0, -r
 
 	// Arc start position: offset from center by radius at the approach angle
 	// Angle is measured from the line connecting center to the perimeter point.
-	arcStartX := centerX - r*math.Sin(angle)
-	arcStartY := centerY + r - r*math.Cos(angle)
+	sdx, sdy := -r*math.Sin(angle), -r*math.Cos(angle)
+
+	arcCmd := "G3" // Counter-clockwise for climb milling lead-in
+	if !g.Settings.UseClimb {
+		arcCmd = "G2" // Clockwise for conventional milling lead-in
+	}
+	if reverse {
+		cdx, cdy = cdy, cdx
+		sdx, sdy = sdy, sdx
+		arcCmd = oppositeArc(arcCmd)
+	}
+	arcStartX, arcStartY := x0+sdx, y0+sdy
 
 	// I, J are relative offsets from arc start to arc center
-	iOffset := centerX - arcStartX
-	jOffset := centerY - arcStartY
+	iOffset := cdx - sdx
+	jOffset := cdy - sdy
 
-	b.WriteString(g.comment("Lead-in arc"))
+	m.WriteString(g.comment("Lead-in arc"))
 	// Rapid to arc start position
-	b.WriteString(fmt.Sprintf("%s X%s Y%s\n", g.profile.RapidMove, g.format(arcStartX), g.format(arcStartY)))
+	m.rapid(arcStartX, arcStartY)
 	// Plunge to cut depth
-	b.WriteString(fmt.Sprintf("%s Z%s F%s\n", g.profile.FeedMove, g.format(-depth), g.format(g.Settings.PlungeRate)))
+	m.feedZ(-depth, g.Settings.PlungeRate)
 
 	// Arc to perimeter start point
-	arcCmd := "G3" // Counter-clockwise for climb milling lead-in
-	if !g.Settings.UseClimb {
-		arcCmd = "G2" // Clockwise for conventional milling lead-in
-	}
-	b.WriteString(fmt.Sprintf("%s X%s Y%s I%s J%s F%s\n",
-		arcCmd, g.format(x0), g.format(y0),
-		g.format(iOffset), g.format(jOffset),
-		g.format(g.Settings.FeedRate)))
+	m.arc(arcCmd, x0, y0, iOffset, jOffset, g.Settings.FeedRate)
 }
 
 // writeLeadOut generates an arc exit from the perimeter end point (x0, y0).
@@ -686,55 +732,72 @@ func (g *Generator) writeLeadIn(b *strings.Builder, x0, y0, depth float64) {
 // the motion away from the cut, providing a smooth exit that prevents dwell marks.
 //
 // The arc mirrors the lead-in geometry, curving away from the perimeter.
-func (g *Generator) writeLeadOut(b *strings.Builder, x0, y0 float64) {
+func (g *Generator) writeLeadOut(m *machine, x0, y0 float64, reverse bool) {
 	r := g.Settings.LeadOutRadius
 	angle := g.Settings.LeadInAngle * math.Pi / 180.0
 
 	// Arc center is at the perimeter end point (same as start) offset inward.
-	centerX := x0
-	centerY := y0 - r
+	cdx, cdy := 0.0, -r
 
 	// Arc end position: mirror of lead-in start
-	arcEndX := centerX - r*math.Sin(angle)
-	arcEndY := centerY + r - r*math.Cos(angle)
-
-	// I, J are relative offsets from current position (x0, y0) to arc center
-	iOffset := centerX - x0
-	jOffset := centerY - y0
+	edx, edy := -r*math.Sin(angle), -r*math.Cos(angle)
 
-	b.WriteString(g.comment("Lead-out arc"))
 	arcCmd := "G3" // Counter-clockwise for climb milling lead-out
 	if !g.Settings.UseClimb {
 		arcCmd = "G2" // Clockwise for conventional milling lead-out
 	}
-	b.WriteString(fmt.Sprintf("%s X%s Y%s I%s J%s F%s\n",
-		arcCmd, g.format(arcEndX), g.format(arcEndY),
-		g.format(iOffset), g.format(jOffset),
-		g.format(g.Settings.FeedRate)))
+	if reverse {
+		cdx, cdy = cdy, cdx
+		edx, edy = edy, edx
+		arcCmd = oppositeArc(arcCmd)
+	}
+
+	// I, J are relative offsets from current position (x0, y0) to arc center
+	m.WriteString(g.comment("Lead-out arc"))
+	m.arc(arcCmd, x0+edx, y0+edy, cdx, cdy, g.Settings.FeedRate)
+}
+
+// oppositeArc returns the arc command turning the other way.
+func oppositeArc(cmd string) string {
+	if cmd == "G2" {
+		return "G3"
+	}
+	return "G2"
 }
 
-func (g *Generator) writePerimeter(b *strings.Builder, x0, y0, x1, y1 float64) {
-	p := g.profile
+// writePerimeter cuts the rectangular toolpath from the start corner back
+// to it, relieving corners on the way. Reversed, it runs the corners in the
+// opposite order.
+func (g *Generator) writePerimeter(m *machine, x0, y0, x1, y1 float64, start int, reverse bool) {
 	toolR := g.Settings.ToolDiameter / 2.0
 
 	// Corner points in clockwise order: bottom-left, bottom-right, top-right, top-left
-	corners := [4][2]float64{
-		{x0, y0}, {x1, y0}, {x1, y1}, {x0, y1},
-	}
+	corners := rectCorners(x0, y0, x1, y1)
 	// Previous corner directions (coming from) - used for T-bone calculation
 	// Corner 0 (x0,y0): coming from left side (x0,y1)->(x0,y0), going to bottom (x0,y0)->(x1,y0)
 	// Corner 1 (x1,y0): coming from bottom, going to right side
 	// Corner 2 (x1,y1): coming from right side, going to top
 	// Corner 3 (x0,y1): coming from top, going to left side
+	step := 1
+	if reverse {
+		step = 3
+	}
 
-	b.WriteString(fmt.Sprintf("%s X%s Y%s F%s\n", p.FeedMove, g.format(x1), g.format(y0), g.format(g.Settings.FeedRate)))
-	g.writeCornerOvercut(b, corners[1][0], corners[1][1], toolR, 1)
-	b.WriteString(fmt.Sprintf("%s X%s Y%s\n", p.FeedMove, g.format(x1), g.format(y1)))
-	g.writeCornerOvercut(b, corners[2][0], corners[2][1], toolR, 2)
-	b.WriteString(fmt.Sprintf("%s X%s Y%s\n", p.FeedMove, g.format(x0), g.format(y1)))
-	g.writeCornerOvercut(b, corners[3][0], corners[3][1], toolR, 3)
-	b.WriteString(fmt.Sprintf("%s X%s Y%s\n", p.FeedMove, g.format(x0), g.format(y0)))
-	g.writeCornerOvercut(b, corners[0][0], corners[0][1], toolR, 0)
+	for i := 1; i <= 4; i++ {
+		c := (start + i*step) % 4
+		if i == 1 {
+			m.feedAt(corners[c][0], corners[c][1], g.Settings.FeedRate)
+		} else {
+			m.feed(corners[c][0], corners[c][1])
+		}
+		g.writeCornerOvercut(m, corners[c][0], corners[c][1], toolR, c)
+	}
+}
+
+// rectCorners returns the corners of a rectangular toolpath in cutting
+// order: bottom-left, bottom-right, top-right, top-left.
+func rectCorners(x0, y0, x1, y1 float64) [4][2]float64 {
+	return [4][2]float64{{x0, y0}, {x1, y0}, {x1, y1}, {x0, y1}}
 }
 
 // writeCornerOvercut generates a corner relief cut at the given corner position.
@@ -743,7 +806,7 @@ func (g *Generator) writePerimeter(b *strings.Builder, x0, y0, x1, y1 float64) {
 // The cornerIndex (0-3) indicates which corner of the rectangle (CW from bottom-left):
 //
 //	0 = bottom-left, 1 = bottom-right, 2 = top-right, 3 = top-left
-func (g *Generator) writeCornerOvercut(b *strings.Builder, cx, cy, toolR float64, cornerIndex int) {
+func (g *Generator) writeCornerOvercut(m *machine, cx, cy, toolR float64, cornerIndex int) {
 	overcutType := g.Settings.CornerOvercut
 	if overcutType == model.CornerOvercutNone || overcutType == "" {
 		return
@@ -794,15 +857,13 @@ func (g *Generator) writeCornerOvercut(b *strings.Builder, cx, cy, toolR float64
 	}
 
 	// Move into the overcut position and back
-	b.WriteString(fmt.Sprintf("%s X%s Y%s F%s\n", g.profile.FeedMove,
-		g.format(cx+dx), g.format(cy+dy), g.format(g.Settings.FeedRate)))
-	b.WriteString(fmt.Sprintf("%s X%s Y%s\n", g.profile.FeedMove,
-		g.format(cx), g.format(cy)))
+	m.feedAt(cx+dx, cy+dy, g.Settings.FeedRate)
+	m.feed(cx, cy)
 }
 
 // comment wraps text in the profile's comment syntax.
 func (g *Generator) comment(text string) string {
-	return g.profile.CommentPrefix + " " + text + g.profile.CommentSuffix + "\n"
+	return commentLine(g.profile, text) + "\n"
 }
 
 // format formats a coordinate according to the profile's decimal places.
@@ -811,112 +872,6 @@ func (g *Generator) format(v float64) string {
 	return fmt.Sprintf(format, v)
 }
 
-// Tab represents a holding tab position along the perimeter.
-type Tab struct {
-	side     int     // 0=bottom, 1=right, 2=top, 3=left
-	startPos float64 // distance along that side
-}
-
-func (g *Generator) calculateTabs(p model.Placement) []Tab {
-	if g.Settings.PartTabsPerSide <= 0 {
-		return nil
-	}
-
-	pw := p.PlacedWidth() + g.Settings.ToolDiameter
-	ph := p.PlacedHeight() + g.Settings.ToolDiameter
-
-	var tabs []Tab
-	for side := 0; side < 4; side++ {
-		var length float64
-		if side == 0 || side == 2 {
-			length = pw
-		} else {
-			length = ph
-		}
-		spacing := length / float64(g.Settings.PartTabsPerSide+1)
-		for t := 1; t <= g.Settings.PartTabsPerSide; t++ {
-			tabs = append(tabs, Tab{
-				side:     side,
-				startPos: spacing * float64(t),
-			})
-		}
-	}
-	return tabs
-}
-
-func (g *Generator) writePerimeterWithTabs(b *strings.Builder, x0, y0, x1, y1, depth float64, tabs []Tab) {
-	tabDepth := depth - g.Settings.PartTabHeight
-	if tabDepth < 0 {
-		tabDepth = 0
-	}
-	tw := g.Settings.PartTabWidth
-
-	// Side 0: bottom (x0,y0) -> (x1,y0)
-	g.writeSideWithTabs(b, x0, y0, x1, y0, true, depth, tabDepth, tw, g.tabsForSide(tabs, 0))
-	// Side 1: right (x1,y0) -> (x1,y1)
-	g.writeSideWithTabs(b, x1, y0, x1, y1, false, depth, tabDepth, tw, g.tabsForSide(tabs, 1))
-	// Side 2: top (x1,y1) -> (x0,y1)
-	g.writeSideWithTabs(b, x1, y1, x0, y1, true, depth, tabDepth, tw, g.tabsForSide(tabs, 2))
-	// Side 3: left (x0,y1) -> (x0,y0)
-	g.writeSideWithTabs(b, x0, y1, x0, y0, false, depth, tabDepth, tw, g.tabsForSide(tabs, 3))
-}
-
-func (g *Generator) tabsForSide(tabs []Tab, side int) []Tab {
-	var result []Tab
-	for _, t := range tabs {
-		if t.side == side {
-			result = append(result, t)
-		}
-	}
-	return result
-}
-
-func (g *Generator) writeSideWithTabs(b *strings.Builder, x0, y0, x1, y1 float64, isHoriz bool,
-	cutDepth, tabDepth, tabWidth float64, tabs []Tab) {
-
-	if len(tabs) == 0 {
-		b.WriteString(fmt.Sprintf("%s X%s Y%s F%s\n", g.profile.FeedMove, g.format(x1), g.format(y1), g.format(g.Settings.FeedRate)))
-		return
-	}
-
-	dx := x1 - x0
-	dy := y1 - y0
-	length := math.Sqrt(dx*dx + dy*dy)
-	if length < 0.001 {
-		return
-	}
-	nx := dx / length
-	ny := dy / length
-
-	// Walk along the side, raising Z for tabs
-	cursor := 0.0
-	for _, tab := range tabs {
-		tabStart := tab.startPos - tabWidth/2
-		tabEnd := tab.startPos + tabWidth/2
-
-		// Cut to tab start
-		if tabStart > cursor {
-			px := x0 + nx*tabStart
-			py := y0 + ny*tabStart
-			b.WriteString(fmt.Sprintf("%s X%s Y%s F%s\n", g.profile.FeedMove, g.format(px), g.format(py), g.format(g.Settings.FeedRate)))
-		}
-
-		// Raise to tab height
-		b.WriteString(fmt.Sprintf("%s Z%s\n", g.profile.FeedMove, g.format(-tabDepth)))
-		// Traverse tab
-		px := x0 + nx*tabEnd
-		py := y0 + ny*tabEnd
-		b.WriteString(fmt.Sprintf("%s X%s Y%s\n", g.profile.FeedMove, g.format(px), g.format(py)))
-		// Plunge back down
-		b.WriteString(fmt.Sprintf("%s Z%s\n", g.profile.FeedMove, g.format(-cutDepth)))
-
-		cursor = tabEnd
-	}
-
-	// Finish to end of side
-	b.WriteString(fmt.Sprintf("%s X%s Y%s F%s\n", g.profile.FeedMove, g.format(x1), g.format(y1), g.format(g.Settings.FeedRate)))
-}
-
 func rotatedStr(r bool) string {
 	if r {
 		return " [rotated]"
@@ -142,14 +142,14 @@ func TestLeadInArcGeometry(t *testing.T) {
 	settings.LeadInAngle = 90.0
 	gen := New(settings)
 
-	var b strings.Builder
+	m := gen.newMachine()
 	toolR := settings.ToolDiameter / 2.0
 	p := newTestPlacement()
 	x0 := p.X - toolR
 	y0 := p.Y - toolR
 
-	gen.writeLeadIn(&b, x0, y0, 6.0)
-	output := b.String()
+	gen.writeLeadIn(m, x0, y0, 6.0, false)
+	output := m.String()
 
 	// The arc start should be offset from the perimeter start
 	// With radius=5 and angle=90, arcStartX = x0 - 5*sin(90) = x0 - 5
@@ -160,7 +160,7 @@ func TestLeadInArcGeometry(t *testing.T) {
 	arcStartXStr := gen.format(expectedArcStartX)
 	arcStartYStr := gen.format(expectedArcStartY)
 
-	rapidLine := gen.profile.RapidMove + " X" + arcStartXStr + " Y" + arcStartYStr
+	rapidLine := "G0 X" + arcStartXStr + " Y" + arcStartYStr
 	if !strings.Contains(output, rapidLine) {
 		t.Errorf("expected rapid to arc start position %q in output:\n%s", rapidLine, output)
 	}
@@ -179,14 +179,14 @@ func TestLeadOutArcGeometry(t *testing.T) {
 	settings.LeadInAngle = 90.0
 	gen := New(settings)
 
-	var b strings.Builder
+	m := gen.newMachine()
 	toolR := settings.ToolDiameter / 2.0
 	p := newTestPlacement()
 	x0 := p.X - toolR
 	y0 := p.Y - toolR
 
-	gen.writeLeadOut(&b, x0, y0)
-	output := b.String()
+	gen.writeLeadOut(m, x0, y0, false)
+	output := m.String()
 
 	// Should contain a G3 arc command
 	if !strings.Contains(output, "G3") {
@@ -380,15 +380,15 @@ func TestToolpathOrdering_ReducesDistance(t *testing.T) {
 		{Part: model.Part{ID: "p3", Label: "C", Width: 50, Height: 50, Quantity: 1}, X: 450, Y: 0},
 	}
 
-	originalDist := TotalRapidDistance(placements)
-
 	settings := newTestSettings()
 	gen := New(settings)
-	ordered := gen.orderPlacements(placements)
-	orderedDist := TotalRapidDistance(ordered)
+	savings := gen.RapidSavings(placements)
 
-	if orderedDist > originalDist {
-		t.Errorf("ordered distance (%.2f) should not exceed original distance (%.2f)", orderedDist, originalDist)
+	if savings.After > savings.Before {
+		t.Errorf("ordered distance (%.2f) should not exceed original distance (%.2f)", savings.After, savings.Before)
+	}
+	if savings.Saved() <= 0 {
+		t.Errorf("expected reordering the zigzag to save travel, got %+v", savings)
 	}
 }
 
@@ -590,7 +590,7 @@ func TestCornerOvercut_Dogbone(t *testing.T) {
 	// With dogbone overcuts, there should be additional G1 moves at each corner.
 	// Each corner gets a move-out + move-back = 2 extra G1 moves.
 	// 4 corners * 2 moves = 8 extra G1 feed moves beyond the standard 4 perimeter moves.
-	feedMoveCount := strings.Count(code, gen.profile.FeedMove)
+	feedMoveCount := strings.Count(code, "G1 ")
 
 	// Standard rect: plunge(1) + 4 perimeter + retract = 5 G1 moves
 	// With dogbone: plunge(1) + 4 perimeter + 8 overcut = 13 G1 moves (plus Z moves)
@@ -598,7 +598,7 @@ func TestCornerOvercut_Dogbone(t *testing.T) {
 	settingsNoOvercut := newTestSettings()
 	genNoOvercut := New(settingsNoOvercut)
 	codeNoOvercut := genNoOvercut.GenerateSheet(newTestSheet(), 1)
-	feedMoveCountNoOvercut := strings.Count(codeNoOvercut, gen.profile.FeedMove)
+	feedMoveCountNoOvercut := strings.Count(codeNoOvercut, "G1 ")
 
 	if feedMoveCount <= feedMoveCountNoOvercut {
 		t.Errorf("expected more feed moves with dogbone overcuts (%d) than without (%d)",
@@ -617,8 +617,8 @@ func TestCornerOvercut_Tbone(t *testing.T) {
 	genNoOvercut := New(settingsNoOvercut)
 	codeNoOvercut := genNoOvercut.GenerateSheet(newTestSheet(), 1)
 
-	feedMoveCount := strings.Count(code, gen.profile.FeedMove)
-	feedMoveCountNoOvercut := strings.Count(codeNoOvercut, gen.profile.FeedMove)
+	feedMoveCount := strings.Count(code, "G1 ")
+	feedMoveCountNoOvercut := strings.Count(codeNoOvercut, "G1 ")
 
 	if feedMoveCount <= feedMoveCountNoOvercut {
 		t.Errorf("expected more feed moves with T-bone overcuts (%d) than without (%d)",
@@ -823,6 +823,140 @@ func TestDefaultSettings_OnionSkin(t *testing.T) {
 	}
 }
 
+// --- Finishing Pass Tests ---
+
+func newFinishingSettings() model.CutSettings {
+	settings := newTestSettings()
+	settings.FinishingPass = true
+	settings.FinishAllowance = 0.5
+	settings.FinishFeedRate = 600
+	return settings
+}
+
+func TestFinishingPass_Disabled(t *testing.T) {
+	code := New(newTestSettings()).GenerateSheet(newTestSheet(), 1)
+
+	if strings.Contains(code, "Finishing pass") || strings.Contains(code, "Roughing:") {
+		t.Error("expected no finishing pass when disabled")
+	}
+	if !strings.Contains(code, "G0 X7.000 Y7.000") {
+		t.Error("expected the toolpath offset by the tool radius only")
+	}
+}
+
+func TestFinishingPass_RoughingLeavesAllowance(t *testing.T) {
+	code := New(newFinishingSettings()).GenerateSheet(newTestSheet(), 1)
+
+	// Part at (10,10) 100x50, tool radius 3, allowance 0.5
+	for _, want := range []string{
+		"Roughing: leaving 0.50mm for finishing",
+		"G0 X6.500 Y6.500",
+		"G1 X113.500 Y6.500 F1000.000",
+		"Finishing pass 1/1, depth=6.00mm, feed=600 mm/min",
+		"G0 X7.000 Y7.000",
+		"G1 X113.000 Y7.000 F600.000",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("expected %q in:\n%s", want, code)
+		}
+	}
+	rough := strings.Index(code, "G1 X113.500 Y6.500")
+	finish := strings.Index(code, "Finishing pass 1/1")
+	if rough > finish {
+		t.Error("expected the roughing pass before the finishing pass")
+	}
+}
+
+func TestFinishingPass_FeedRateRestored(t *testing.T) {
+	settings := newFinishingSettings()
+	gen := New(settings)
+	gen.GenerateSheet(newTestSheet(), 1)
+
+	if gen.Settings.FeedRate != settings.FeedRate {
+		t.Errorf("expected feed rate %.0f restored after finishing, got %.0f", settings.FeedRate, gen.Settings.FeedRate)
+	}
+}
+
+func TestFinishingPass_ZeroFeedUsesFeedRate(t *testing.T) {
+	settings := newFinishingSettings()
+	settings.FinishFeedRate = 0
+	code := New(settings).GenerateSheet(newTestSheet(), 1)
+
+	if !strings.Contains(code, "feed=1000 mm/min") {
+		t.Errorf("expected finishing at the cutting feed rate, got:\n%s", code)
+	}
+}
+
+func TestFinishingPass_StepDown(t *testing.T) {
+	settings := newFinishingSettings()
+	settings.FinishStepDown = 2.5
+	code := New(settings).GenerateSheet(newTestSheet(), 1)
+
+	for _, want := range []string{
+		"Finishing pass 1/3, depth=2.50mm",
+		"Finishing pass 2/3, depth=5.00mm",
+		"Finishing pass 3/3, depth=6.00mm",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("expected %q in:\n%s", want, code)
+		}
+	}
+}
+
+func TestFinishingPass_Reverse(t *testing.T) {
+	settings := newFinishingSettings()
+	settings.FinishReverse = true
+	code := New(settings).GenerateSheet(newTestSheet(), 1)
+
+	finish := code[strings.Index(code, "Finishing pass"):]
+	if !strings.Contains(finish, "G1 X7.000 Y63.000 F600.000\nG1 X113.000 Y63.000") {
+		t.Errorf("expected the finishing pass to run up the left side first, got:\n%s", finish)
+	}
+}
+
+func TestFinishingPass_TabsOnFinalPassOnly(t *testing.T) {
+	settings := newFinishingSettings()
+	settings.FinishStepDown = 3
+	settings.FinishReverse = true
+	settings.PartTabsPerSide = 1
+	code := New(settings).GenerateSheet(newTestSheet(), 1)
+
+	finish := code[strings.Index(code, "Finishing pass 1/2"):]
+	first, final := finish[:strings.Index(finish, "Finishing pass 2/2")], finish[strings.Index(finish, "Finishing pass 2/2"):]
+	if strings.Contains(first, "Z-4.000") {
+		t.Error("expected no tabs on the first finishing pass")
+	}
+	// Reversed, the left side is cut first, lifting over its tab at Y31..39
+	if !strings.Contains(final, "G1 X7.000 Y31.000 F600.000\nG1 Z-4.000\nG1 X7.000 Y39.000") {
+		t.Errorf("expected a tab on the left side of the final finishing pass, got:\n%s", final)
+	}
+	if n := strings.Count(final, "G1 Z-4.000"); n != 4 {
+		t.Errorf("expected 4 tabs on the final finishing pass, got %d", n)
+	}
+}
+
+func TestFinishingPass_OnionSkinOnFinalPass(t *testing.T) {
+	settings := newFinishingSettings()
+	settings.FinishStepDown = 3
+	settings.OnionSkinEnabled = true
+	settings.OnionSkinDepth = 0.2
+	code := New(settings).GenerateSheet(newTestSheet(), 1)
+
+	if !strings.Contains(code, "Finishing pass 1/2, depth=3.00mm") || !strings.Contains(code, "Finishing pass 2/2, depth=5.80mm") {
+		t.Errorf("expected onion skin on the final finishing pass only, got:\n%s", code)
+	}
+}
+
+func TestDefaultSettings_FinishingPass(t *testing.T) {
+	s := model.DefaultSettings()
+	if s.FinishingPass {
+		t.Error("expected default FinishingPass to be false")
+	}
+	if s.FinishAllowance != 0.5 {
+		t.Errorf("expected default FinishAllowance to be 0.5, got %f", s.FinishAllowance)
+	}
+}
+
 // --- Structural Cut Ordering Tests ---
 
 func TestStructuralOrder_InteriorFirst(t *testing.T) {
@@ -1032,3 +1166,65 @@ func TestStructuralOrder_CommentInGCode(t *testing.T) {
 		t.Error("expected structural integrity comment in GCode output")
 	}
 }
+
+func TestGenerateSheet_DrillsBeforeProfile(t *testing.T) {
+	s := newTestSettings()
+	s.CutDepth = 18
+	s.PassDepth = 6
+	gen := New(s)
+
+	p := newTestPlacement()
+	p.Part.Width = 300
+	p.Part.Height = 400
+	p.Part.Drills = []model.DrillHole{
+		{X: 37, Y: 82, Diameter: 5, Depth: 10},
+		{X: 22.5, Y: 100, Diameter: 35, Depth: 13},
+	}
+	sheet := model.SheetResult{
+		Stock:      model.NewStockSheet("Test", 1000, 1000, 1),
+		Placements: []model.Placement{p},
+	}
+	code := gen.GenerateSheet(sheet, 1)
+
+	drillIdx := strings.Index(code, "Drilling: TestPart (2 holes)")
+	partIdx := strings.Index(code, "--- Part 1")
+	if drillIdx < 0 || partIdx < 0 || drillIdx > partIdx {
+		t.Fatal("expected drilling to come before the part profile")
+	}
+	if !strings.Contains(code, "G0 X47.000 Y92.000") {
+		t.Error("expected rapid to the shelf pin hole position")
+	}
+	if !strings.Contains(code, "G1 Z-10.000 F300.000") {
+		t.Error("expected shelf pin hole drilled to 10mm")
+	}
+	if !strings.Contains(code, "I-14.500 J0.000") {
+		t.Error("expected hinge cup pocketed out to its full radius")
+	}
+	if strings.Contains(code, "Z-18.000 F300.000\nG0 Z0.000") {
+		t.Error("holes should not be drilled deeper than requested")
+	}
+}
+
+func TestGeneratePatterns_OneProgramPerPattern(t *testing.T) {
+	gen := New(newTestSettings())
+	other := newTestSheet()
+	other.Placements[0].X = 200
+	result := model.OptimizeResult{Sheets: []model.SheetResult{newTestSheet(), other, newTestSheet(), newTestSheet()}}
+
+	programs := gen.GeneratePatterns(result)
+	if len(programs) != 2 {
+		t.Fatalf("expected 2 programs, got %d", len(programs))
+	}
+	if got := programs[0].FileName(); got != "sheet1_x3.gcode" {
+		t.Errorf("FileName = %q, want sheet1_x3.gcode", got)
+	}
+	if got := programs[1].FileName(); got != "sheet2.gcode" {
+		t.Errorf("FileName = %q, want sheet2.gcode", got)
+	}
+	if !strings.HasPrefix(programs[0].Code, "; Pattern: run 3 times, for sheets 1, 3, 4\n") {
+		t.Errorf("expected a repeat comment at the top, got %q", strings.SplitN(programs[0].Code, "\n", 2)[0])
+	}
+	if strings.Contains(programs[1].Code, "Pattern:") {
+		t.Error("a single sheet needs no repeat comment")
+	}
+}
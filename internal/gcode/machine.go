@@ -0,0 +1,333 @@
+package gcode
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/piwi3910/SlabCut/internal/model"
+)
+
+// machine writes motion commands while tracking where they leave the tool.
+// Every rapid in XY goes through rapid, which first lifts the tool to the
+// clearance height for the move, so no writer can drag the bit across the
+// sheet or a clamp by forgetting to retract.
+type machine struct {
+	strings.Builder
+	g       *Generator
+	job     PostVars // job variables for post-processor blocks
+	line    int      // last N-word written
+	x, y, z float64
+	xyKnown bool // false until the first move in XY
+	zKnown  bool // false until the first move in Z
+	skip    bool // true while skipping the cuts before a restart point
+	restart *restarter
+}
+
+// WriteString writes s to the program, unless the machine is skipping to a
+// restart point.
+func (m *machine) WriteString(s string) (int, error) {
+	if m.skip {
+		return len(s), nil
+	}
+	return m.Builder.WriteString(s)
+}
+
+// newMachine returns a machine whose tool position is not yet known.
+func (g *Generator) newMachine() *machine {
+	return &machine{g: g, job: g.jobVars(model.SheetResult{}, 0)}
+}
+
+// rapid moves the tool to (x, y) at rapid speed. With RouteAroundClamps it
+// goes around any clamp in the way. When the tool is below the clearance
+// height for a move, or its height is unknown, it retracts first; after
+// rising above a clamp it comes back down to SafeZ.
+func (m *machine) rapid(x, y float64) {
+	if via := m.detour(x, y); len(via) > 0 {
+		m.WriteString(m.g.comment("Rapid routed around clamps"))
+		for _, p := range via {
+			m.rapidTo(p.X, p.Y)
+		}
+	}
+	m.rapidTo(x, y)
+}
+
+// rapidTo moves the tool straight to (x, y) at rapid speed, at the
+// clearance height for the move.
+func (m *machine) rapidTo(x, y float64) {
+	clear := m.clearance(x, y)
+	if !m.zKnown || m.z < clear {
+		m.rapidZ(clear)
+	}
+	m.block(model.BlockRapid, PostVars{X: m.g.format(x), Y: m.g.format(y)})
+	m.x, m.y, m.xyKnown = x, y, true
+	if safeZ := m.g.Settings.SafeZ; m.z > safeZ && clear > safeZ {
+		m.rapidZ(safeZ)
+	}
+}
+
+// rapidZ moves the tool straight up or down at rapid speed.
+func (m *machine) rapidZ(z float64) {
+	m.block(model.BlockRapid, PostVars{Z: m.g.format(z)})
+	m.z, m.zKnown = z, true
+}
+
+// retract lifts the tool to SafeZ.
+func (m *machine) retract() {
+	m.rapidZ(m.g.Settings.SafeZ)
+}
+
+// feed cuts to (x, y) at the modal feed rate.
+func (m *machine) feed(x, y float64) {
+	m.block(model.BlockLinear, PostVars{X: m.g.format(x), Y: m.g.format(y)})
+	m.x, m.y, m.xyKnown = x, y, true
+}
+
+// feedAt cuts to (x, y) at the given feed rate.
+func (m *machine) feedAt(x, y, rate float64) {
+	m.block(model.BlockLinear, PostVars{X: m.g.format(x), Y: m.g.format(y), F: m.g.format(rate)})
+	m.x, m.y, m.xyKnown = x, y, true
+}
+
+// feedZ moves the tool straight up or down at the given feed rate, or at the
+// modal feed rate when rate is 0.
+func (m *machine) feedZ(z, rate float64) {
+	m.block(model.BlockLinear, PostVars{Z: m.g.format(z), F: m.rate(rate)})
+	m.z, m.zKnown = z, true
+}
+
+// feedXYZ cuts to (x, y, z) at the given feed rate, or at the modal feed
+// rate when rate is 0.
+func (m *machine) feedXYZ(x, y, z, rate float64) {
+	m.block(model.BlockLinear, PostVars{
+		X: m.g.format(x), Y: m.g.format(y), Z: m.g.format(z), F: m.rate(rate)})
+	m.x, m.y, m.z, m.xyKnown, m.zKnown = x, y, z, true, true
+}
+
+// arc cuts a G2/G3 arc to (x, y) about the centre at offset (i, j) from the
+// current position.
+func (m *machine) arc(cmd string, x, y, i, j, rate float64) {
+	m.block(model.BlockArc, PostVars{
+		X: m.g.format(x), Y: m.g.format(y),
+		I: m.g.format(i), J: m.g.format(j), F: m.g.format(rate),
+		Clockwise: cmd == "G2",
+	})
+	m.x, m.y, m.xyKnown = x, y, true
+}
+
+// helix cuts a G2/G3 arc to (x, y) while moving to z.
+func (m *machine) helix(cmd string, x, y, z, i, j, rate float64) {
+	m.block(model.BlockArc, PostVars{
+		X: m.g.format(x), Y: m.g.format(y), Z: m.g.format(z),
+		I: m.g.format(i), J: m.g.format(j), F: m.g.format(rate),
+		Clockwise: cmd == "G2",
+	})
+	m.x, m.y, m.z, m.xyKnown, m.zKnown = x, y, z, true, true
+}
+
+// drill bores a hole at the current position to z with the post-processor's
+// canned cycle, pecking by peck and retracting to r between pecks. The
+// cycle leaves the tool at a height only the controller knows.
+func (m *machine) drill(z, r, peck, rate float64) {
+	m.block(model.BlockDrill, PostVars{
+		X: m.g.format(m.x), Y: m.g.format(m.y), Z: m.g.format(z),
+		R: m.g.format(r), Q: m.g.format(peck), F: m.g.format(rate),
+	})
+	m.zKnown = false
+}
+
+// rate formats a feed rate, or returns "" for the modal feed rate.
+func (m *machine) rate(rate float64) string {
+	if rate > 0 {
+		return m.g.format(rate)
+	}
+	return ""
+}
+
+// block renders a post-processor block with the job variables and writes
+// it, numbering lines when the post-processor asks for it. A block that
+// fails to render is written as a comment so the problem shows in the
+// program, and the first such error is kept for SampleProgram. Nothing is
+// written while skipping to a restart point.
+func (m *machine) block(name string, v PostVars) {
+	if m.skip {
+		return
+	}
+	job := m.job
+	job.X, job.Y, job.Z, job.I, job.J = v.X, v.Y, v.Z, v.I, v.J
+	job.F, job.R, job.Q, job.Clockwise = v.F, v.R, v.Q, v.Clockwise
+	lines, err := m.g.post.render(name, job)
+	if err != nil {
+		if m.g.renderErr == nil {
+			m.g.renderErr = err
+		}
+		m.WriteString(m.g.comment("ERROR: " + err.Error()))
+		return
+	}
+	for _, line := range lines {
+		if m.g.post.numbered(line) {
+			m.line += m.g.post.lineStep
+			line = fmt.Sprintf("N%d %s", m.line, line)
+		}
+		m.WriteString(line + "\n")
+	}
+}
+
+// clampObstacle is a clamp zone grown by the reach of the tool or dust
+// shoe, and the height a rapid must keep to pass over it.
+type clampObstacle struct {
+	x0, y0, x1, y1 float64
+	top            float64
+}
+
+// obstacles returns the clamps a rapid at SafeZ could hit: those that stand
+// above the sheet, grown by the tool radius or, with a dust shoe, by its
+// radius and clearance. Without ClampAwareRetract or RouteAroundClamps
+// clamps are ignored.
+func (m *machine) obstacles() []clampObstacle {
+	s := m.g.Settings
+	if !s.ClampAwareRetract && !s.RouteAroundClamps {
+		return nil
+	}
+	reach := s.ToolDiameter / 2
+	if s.DustShoeEnabled {
+		reach = math.Max(reach, s.DustShoeWidth/2+s.DustShoeClearance)
+	}
+	var obs []clampObstacle
+	for _, cz := range s.ClampZones {
+		if cz.ZHeight <= 0 {
+			continue
+		}
+		obs = append(obs, clampObstacle{
+			x0: cz.X - reach, y0: cz.Y - reach,
+			x1: cz.X + cz.Width + reach, y1: cz.Y + cz.Height + reach,
+			top: cz.ZHeight + s.SafeZ,
+		})
+	}
+	return obs
+}
+
+// hit reports whether the segment from (x0, y0) to (x1, y1) passes over
+// the obstacle.
+func (o clampObstacle) hit(x0, y0, x1, y1 float64) bool {
+	return segmentHitsRect(x0, y0, x1, y1, o.x0, o.y0, o.x1, o.y1)
+}
+
+// clearance returns the lowest height at which the tool may rapid from its
+// current position to (x, y): SafeZ, or SafeZ above the top of every clamp
+// the tool or dust shoe could pass over. When the current position is
+// unknown every clamp counts.
+func (m *machine) clearance(x, y float64) float64 {
+	clear := m.g.Settings.SafeZ
+	for _, o := range m.obstacles() {
+		if o.top > clear && (!m.xyKnown || o.hit(m.x, m.y, x, y)) {
+			clear = o.top
+		}
+	}
+	return clear
+}
+
+// detour returns the shortest way at SafeZ from the current position to
+// (x, y) around the clamps in the way, as the points to rapid through
+// before (x, y). It is empty when RouteAroundClamps is off, the way is
+// clear, or there is no way round inside the stock sheet, in which case the
+// rapid lifts over the clamps instead.
+func (m *machine) detour(x, y float64) []model.Point2D {
+	if !m.g.Settings.RouteAroundClamps || !m.xyKnown {
+		return nil
+	}
+	obs := m.obstacles()
+	clear := func(a, b model.Point2D) bool {
+		for _, o := range obs {
+			if o.hit(a.X, a.Y, b.X, b.Y) {
+				return false
+			}
+		}
+		return true
+	}
+	from, to := model.Point2D{X: m.x, Y: m.y}, model.Point2D{X: x, Y: y}
+	if clear(from, to) || !clear(from, from) || !clear(to, to) {
+		return nil
+	}
+
+	// Shortest path through the corners of the obstacles, pushed just
+	// outside them, by Dijkstra's algorithm on the visibility graph
+	const margin = 1.0
+	w, h := m.job.StockWidth, m.job.StockHeight
+	nodes := []model.Point2D{from, to}
+	for _, o := range obs {
+		for _, c := range rectCorners(o.x0-margin, o.y0-margin, o.x1+margin, o.y1+margin) {
+			p := model.Point2D{X: c[0], Y: c[1]}
+			if w > 0 && h > 0 && (p.X < 0 || p.Y < 0 || p.X > w || p.Y > h) {
+				continue
+			}
+			if clear(p, p) {
+				nodes = append(nodes, p)
+			}
+		}
+	}
+	n := len(nodes)
+	dist := make([]float64, n)
+	prev := make([]int, n)
+	done := make([]bool, n)
+	for i := range dist {
+		dist[i], prev[i] = math.Inf(1), -1
+	}
+	dist[0] = 0
+	for {
+		u := -1
+		for i := 0; i < n; i++ {
+			if !done[i] && !math.IsInf(dist[i], 1) && (u < 0 || dist[i] < dist[u]) {
+				u = i
+			}
+		}
+		if u < 0 {
+			return nil
+		}
+		if u == 1 {
+			break
+		}
+		done[u] = true
+		for v := 0; v < n; v++ {
+			if done[v] || !clear(nodes[u], nodes[v]) {
+				continue
+			}
+			if d := dist[u] + math.Hypot(nodes[v].X-nodes[u].X, nodes[v].Y-nodes[u].Y); d < dist[v] {
+				dist[v], prev[v] = d, u
+			}
+		}
+	}
+
+	var via []model.Point2D
+	for v := prev[1]; v > 0; v = prev[v] {
+		via = append([]model.Point2D{nodes[v]}, via...)
+	}
+	return via
+}
+
+// segmentHitsRect reports whether the segment from (x0, y0) to (x1, y1)
+// touches the rectangle [rx0, rx1] x [ry0, ry1].
+func segmentHitsRect(x0, y0, x1, y1, rx0, ry0, rx1, ry1 float64) bool {
+	// Liang-Barsky clipping of the segment against the rectangle
+	t0, t1 := 0.0, 1.0
+	dx, dy := x1-x0, y1-y0
+	for _, c := range [][2]float64{{-dx, x0 - rx0}, {dx, rx1 - x0}, {-dy, y0 - ry0}, {dy, ry1 - y0}} {
+		p, q := c[0], c[1]
+		if p == 0 {
+			if q < 0 {
+				return false
+			}
+			continue
+		}
+		t := q / p
+		if p < 0 {
+			t0 = math.Max(t0, t)
+		} else {
+			t1 = math.Min(t1, t)
+		}
+		if t0 > t1 {
+			return false
+		}
+	}
+	return true
+}
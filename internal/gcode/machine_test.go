@@ -0,0 +1,224 @@
+package gcode
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/piwi3910/SlabCut/internal/model"
+)
+
+// newSafetySheet returns a sheet with a rectangular part, an outline part
+// and a drilled part spread around a clamp in the middle of the sheet.
+func newSafetySheet() model.SheetResult {
+	sheet := newTestSheet()
+	sheet.Stock.Width, sheet.Stock.Height = 600, 400
+
+	drilled := newTestPlacement()
+	drilled.X, drilled.Y = 400, 250
+	drilled.Part.Drills = []model.DrillHole{
+		{X: 20, Y: 20, Diameter: 5, Depth: 10},
+		{X: 60, Y: 25, Diameter: 15, Depth: 6},
+	}
+	outline := newLShapePlacement()
+	outline.X, outline.Y = 420, 20
+
+	sheet.Placements = append(sheet.Placements, outline, drilled)
+	return sheet
+}
+
+// assertSafeRapids checks that every rapid in XY starts at or above SafeZ,
+// and with clamp-aware retracts, at or above SafeZ over any clamp it passes.
+func assertSafeRapids(t *testing.T, name, code string, settings model.CutSettings) {
+	t.Helper()
+	profile := model.GetProfile(settings.GCodeProfile)
+	// The parser starts at Z0, so a rapid before any retract is caught.
+	for i, mv := range ParseGCode(code) {
+		if mv.Type != MoveRapid || (mv.FromX == mv.ToX && mv.FromY == mv.ToY) {
+			continue
+		}
+		need := settings.SafeZ
+		if settings.ClampAwareRetract {
+			for _, cz := range settings.ClampZones {
+				r := settings.ToolDiameter / 2
+				if segmentHitsRect(mv.FromX, mv.FromY, mv.ToX, mv.ToY,
+					cz.X-r, cz.Y-r, cz.X+cz.Width+r, cz.Y+cz.Height+r) && cz.ZHeight+settings.SafeZ > need {
+					need = cz.ZHeight + settings.SafeZ
+				}
+			}
+		}
+		if mv.FromZ < need-1e-6 {
+			t.Errorf("%s [%s]: move %d rapids from (%.3f, %.3f) to (%.3f, %.3f) at Z%.3f, below %.3f",
+				name, profile.Name, i, mv.FromX, mv.FromY, mv.ToX, mv.ToY, mv.FromZ, need)
+			return
+		}
+	}
+}
+
+func TestGenerateSheet_RapidsAlwaysAtClearance(t *testing.T) {
+	clamp := model.ClampZone{Label: "Middle", X: 250, Y: 150, Width: 60, Height: 60, ZHeight: 40}
+
+	for _, profile := range model.GetProfileNames() {
+		for _, plunge := range []model.PlungeType{model.PlungeDirect, model.PlungeRamp, model.PlungeHelix} {
+			for _, leads := range []bool{false, true} {
+				for _, tabs := range []model.TabShape{"", model.TabShapeRectangular, model.TabShapeTriangular} {
+					for _, onion := range []bool{false, true} {
+						for _, clampAware := range []bool{false, true} {
+							s := newTestSettings()
+							s.GCodeProfile = profile
+							s.CutDepth, s.PassDepth = 12, 4
+							s.PlungeType = plunge
+							if leads {
+								s.LeadInRadius, s.LeadOutRadius = 5, 5
+							}
+							if tabs != "" {
+								s.PartTabsPerSide = 1
+								s.PartTabShape = tabs
+							}
+							s.OnionSkinEnabled, s.OnionSkinCleanup = onion, onion
+							s.CornerOvercut = model.CornerOvercutDogbone
+							s.OptimizeToolpath = true
+							s.ClampZones = []model.ClampZone{clamp}
+							s.ClampAwareRetract = clampAware
+
+							name := fmt.Sprintf("plunge=%v leads=%v tabs=%q onion=%v clamps=%v",
+								plunge, leads, tabs, onion, clampAware)
+							code := New(s).GenerateSheet(newSafetySheet(), 1)
+							assertSafeRapids(t, name, code, s)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestGenerateSheet_RetractsBeforeFirstRapid(t *testing.T) {
+	code := New(newTestSettings()).GenerateSheet(newTestSheet(), 1)
+	retract := strings.Index(code, "G0 Z5.000")
+	home := strings.Index(code, "G0 X0.000 Y0.000")
+	if retract < 0 || home < 0 || retract > home {
+		t.Errorf("expected the header to retract before moving to the origin:\n%s", code)
+	}
+}
+
+func TestMachine_RapidRetractsFirst(t *testing.T) {
+	gen := New(newTestSettings())
+	m := gen.newMachine()
+	m.rapidZ(5)
+	m.feedZ(-6, 300)
+	m.rapid(50, 50)
+	if got, want := m.String(), "G0 Z5.000\nG1 Z-6.000 F300.000\nG0 Z5.000\nG0 X50.000 Y50.000\n"; got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+
+	m = gen.newMachine()
+	m.rapid(10, 10)
+	if !strings.HasPrefix(m.String(), "G0 Z5.000\n") {
+		t.Errorf("expected a retract when the height is unknown, got\n%s", m.String())
+	}
+}
+
+func TestMachine_ClampAwareClearance(t *testing.T) {
+	s := newTestSettings()
+	s.ClampZones = []model.ClampZone{{X: 100, Y: 0, Width: 50, Height: 50, ZHeight: 30}}
+	s.ClampAwareRetract = true
+	gen := New(s)
+
+	m := gen.newMachine()
+	m.rapidZ(5)
+	m.feedAt(20, 20, 1000)
+	m.rapid(300, 20) // passes over the clamp
+	want := "G0 Z5.000\nG1 X20.000 Y20.000 F1000.000\nG0 Z35.000\nG0 X300.000 Y20.000\nG0 Z5.000\n"
+	if got := m.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+
+	m = gen.newMachine()
+	m.rapidZ(5)
+	m.feedAt(20, 200, 1000)
+	m.rapid(300, 200) // clear of the clamp
+	if strings.Contains(m.String(), "Z35.000") {
+		t.Errorf("expected no extra lift away from the clamp, got\n%s", m.String())
+	}
+
+	s.ClampAwareRetract = false
+	m = New(s).newMachine()
+	m.rapidZ(5)
+	m.rapid(300, 20)
+	if strings.Contains(m.String(), "Z35.000") {
+		t.Error("clamp heights should be ignored unless ClampAwareRetract is set")
+	}
+}
+
+func TestMachine_RoutesAroundClamps(t *testing.T) {
+	s := newTestSettings()
+	s.ClampZones = []model.ClampZone{{X: 100, Y: 0, Width: 50, Height: 50, ZHeight: 30}}
+	s.RouteAroundClamps = true
+	gen := New(s)
+
+	m := gen.newMachine()
+	m.job.StockWidth, m.job.StockHeight = 600, 400
+	m.rapidZ(5)
+	m.feedAt(20, 20, 1000)
+	m.rapid(300, 20) // the clamp is in the way
+	want := "G0 Z5.000\nG1 X20.000 Y20.000 F1000.000\n; Rapid routed around clamps\n" +
+		"G0 X96.000 Y54.000\nG0 X154.000 Y54.000\nG0 X300.000 Y20.000\n"
+	if got := m.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+	for _, mv := range ParseGCode(m.String()) {
+		if mv.Type == MoveRapid && mv.FromZ > 5 {
+			t.Errorf("expected the routed rapid to stay at SafeZ, got a move from Z%.3f", mv.FromZ)
+		}
+	}
+
+	// A clamp across the whole sheet leaves no way round: lift over it
+	s.ClampZones[0].Height = 400
+	m = New(s).newMachine()
+	m.job.StockWidth, m.job.StockHeight = 600, 400
+	m.rapidZ(5)
+	m.feedAt(20, 20, 1000)
+	m.rapid(300, 20)
+	if strings.Contains(m.String(), "routed") || !strings.Contains(m.String(), "G0 Z35.000") {
+		t.Errorf("expected a lift over the clamp when there is no way round, got\n%s", m.String())
+	}
+}
+
+func TestGenerateSheet_RoutedRapidsMissClamps(t *testing.T) {
+	s := newTestSettings()
+	s.OptimizeToolpath = true
+	s.RouteAroundClamps = true
+	s.ClampZones = []model.ClampZone{{Label: "Middle", X: 250, Y: 150, Width: 60, Height: 60, ZHeight: 40}}
+	code := New(s).GenerateSheet(newSafetySheet(), 1)
+
+	cz := s.ClampZones[0]
+	r := s.ToolDiameter / 2
+	for _, mv := range ParseGCode(code) {
+		if mv.Type == MoveRapid && mv.FromZ <= s.SafeZ+1e-6 && (mv.FromX != mv.ToX || mv.FromY != mv.ToY) &&
+			segmentHitsRect(mv.FromX, mv.FromY, mv.ToX, mv.ToY, cz.X-r, cz.Y-r, cz.X+cz.Width+r, cz.Y+cz.Height+r) {
+			t.Errorf("rapid from (%.1f, %.1f) to (%.1f, %.1f) crosses the clamp at SafeZ",
+				mv.FromX, mv.FromY, mv.ToX, mv.ToY)
+		}
+	}
+}
+
+func TestSegmentHitsRect(t *testing.T) {
+	tests := []struct {
+		name           string
+		x0, y0, x1, y1 float64
+		want           bool
+	}{
+		{"crosses", 0, 5, 20, 5, true},
+		{"inside", 4, 4, 6, 6, true},
+		{"misses", 0, 20, 20, 20, false},
+		{"stops short", 0, 5, 3, 5, false},
+		{"diagonal miss", 0, 6, 6, 0, false},
+		{"point", 5, 5, 5, 5, true},
+	}
+	for _, tt := range tests {
+		if got := segmentHitsRect(tt.x0, tt.y0, tt.x1, tt.y1, 4, 4, 10, 10); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,387 @@
+package gcode
+
+import (
+	"math"
+	"time"
+
+	"github.com/piwi3910/SlabCut/internal/model"
+)
+
+// maxEntries caps the start points tried on an outline part.
+const maxEntries = 8
+
+// partCut is a placement in cutting order and the toolpath vertex its
+// perimeter starts at: a corner of a rectangular part, counting from the
+// bottom-left towards the bottom-right, or a vertex of an outline part's
+// toolpath.
+type partCut struct {
+	model.Placement
+	entry int
+}
+
+// cutsOf returns the placements in their order, each starting at its first
+// toolpath vertex.
+func cutsOf(placements []model.Placement) []partCut {
+	cuts := make([]partCut, len(placements))
+	for i, p := range placements {
+		cuts[i] = partCut{Placement: p}
+	}
+	return cuts
+}
+
+// placementsOf returns the placements of the cuts, in order.
+func placementsOf(cuts []partCut) []model.Placement {
+	placements := make([]model.Placement, len(cuts))
+	for i, c := range cuts {
+		placements[i] = c.Placement
+	}
+	return placements
+}
+
+// RapidSavings compares the rapid travel between parts in layout order with
+// the travel after toolpath ordering.
+type RapidSavings struct {
+	Before float64 // mm, layout order with every part starting at its first vertex
+	After  float64 // mm, optimized order and start points
+}
+
+// Saved returns the rapid travel saved in mm.
+func (s RapidSavings) Saved() float64 {
+	return s.Before - s.After
+}
+
+// Percent returns the rapid travel saved as a percentage of Before.
+func (s RapidSavings) Percent() float64 {
+	if s.Before <= 0 {
+		return 0
+	}
+	return 100 * s.Saved() / s.Before
+}
+
+// RapidSavings reports how much rapid travel toolpath ordering saves on the
+// placements of a sheet.
+func (g *Generator) RapidSavings(placements []model.Placement) RapidSavings {
+	_, savings := g.orderPlacements(placements)
+	return savings
+}
+
+// entryPoint is a place where a part's toolpath can start. Every pass of
+// the perimeter starts and ends there, so the rapid to the next part only
+// depends on the entry points chosen for both parts.
+type entryPoint struct {
+	vertex int           // toolpath vertex the perimeter starts at
+	in     model.Point2D // first point cut: the first drill hole or the perimeter start
+	start  model.Point2D // perimeter start, where the tool leaves the part
+	inner  float64       // rapid from the last drill hole to the perimeter start
+}
+
+// entryPoints returns the places the part's toolpath can start: any corner
+// of a rectangular part, and up to maxEntries vertices of an outline part
+// that keep the tool clear of its tabs. Lead arcs and ramp plunges are laid
+// out for the usual start only, so with either the part keeps it.
+func (g *Generator) entryPoints(p model.Placement) []entryPoint {
+	g = g.forPart(p)
+	fixed := g.Settings.LeadInRadius > 0 || g.Settings.LeadOutRadius > 0 || g.Settings.PlungeType == model.PlungeRamp
+	type start struct {
+		vertex int
+		pt     model.Point2D
+	}
+	var starts []start
+	if len(p.Part.Outline) >= 3 {
+		path := g.outlineToolpath(p, 0)
+		n := len(path.pts)
+		step := 1
+		if fixed {
+			step = n
+		} else if n > maxEntries {
+			step = int(math.Ceil(float64(n) / maxEntries))
+		}
+		tabs := g.outlineTabs(path, p)
+		for i := 0; i < n; i += step {
+			if i == 0 || !path.inTab(i, tabs, g.Settings.ToolDiameter/2.0) {
+				starts = append(starts, start{i, path.pts[i]})
+			}
+		}
+	} else {
+		x0, y0, x1, y1 := g.rectToolpath(p, 0)
+		for i, c := range rectCorners(x0, y0, x1, y1) {
+			starts = append(starts, start{i, model.Point2D{X: c[0], Y: c[1]}})
+			if fixed {
+				break
+			}
+		}
+	}
+
+	holes := p.DrillPositions()
+	entries := make([]entryPoint, len(starts))
+	for i, s := range starts {
+		e := entryPoint{vertex: s.vertex, in: s.pt, start: s.pt}
+		if len(holes) > 0 {
+			first, last := holes[0], holes[len(holes)-1]
+			e.in = model.Point2D{X: first.X, Y: first.Y}
+			e.inner = math.Hypot(s.pt.X-last.X, s.pt.Y-last.Y)
+		}
+		entries[i] = e
+	}
+	return entries
+}
+
+// orderer searches for a cutting order with short rapids between parts.
+type orderer struct {
+	entries [][]entryPoint // entry points of each part
+	after   [][]int        // after[i] lists the parts that must be cut before part i
+}
+
+// newOrderer collects the entry points of the placements and the order
+// they must keep: parts nested in a cutout of another part are cut before
+// it, and with the small-part strategy small parts come first.
+func (g *Generator) newOrderer(placements []model.Placement) *orderer {
+	n := len(placements)
+	o := &orderer{entries: make([][]entryPoint, n), after: make([][]int, n)}
+	for i, p := range placements {
+		o.entries[i] = g.entryPoints(p)
+	}
+	for i, p := range placements {
+		for j, q := range placements {
+			if i == j {
+				continue
+			}
+			if insideCutout(q, p) || (g.isSmallPart(q) && !g.isSmallPart(p)) {
+				o.after[i] = append(o.after[i], j)
+			}
+		}
+	}
+	return o
+}
+
+// insideCutout reports whether placement a lies within a cutout of b.
+func insideCutout(a, b model.Placement) bool {
+	ax0, ay0 := a.X, a.Y
+	ax1, ay1 := a.X+a.PlacedWidth(), a.Y+a.PlacedHeight()
+	for _, c := range b.CutoutRects() {
+		x, y, w, h := c.X, c.Y, c.Width, c.Height
+		if ax0 >= x-1e-6 && ay0 >= y-1e-6 && ax1 <= x+w+1e-6 && ay1 <= y+h+1e-6 {
+			return true
+		}
+	}
+	return false
+}
+
+// valid reports whether the order keeps every precedence constraint.
+func (o *orderer) valid(order []int) bool {
+	pos := make([]int, len(order))
+	for k, i := range order {
+		pos[i] = k
+	}
+	for i, before := range o.after {
+		for _, j := range before {
+			if pos[j] > pos[i] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// travel returns the rapid travel from the origin through the parts in
+// order, entering each at the given entry point index.
+func (o *orderer) travel(order, choice []int) float64 {
+	total := 0.0
+	cur := model.Point2D{}
+	for k, i := range order {
+		e := o.entries[i][choice[k]]
+		total += math.Hypot(e.in.X-cur.X, e.in.Y-cur.Y) + e.inner
+		cur = e.start
+	}
+	return total
+}
+
+// cost returns the shortest rapid travel through the parts in order over
+// every choice of entry points, found by dynamic programming. When choice
+// is not nil it receives the entry point index used for each part.
+func (o *orderer) cost(order, choice []int) float64 {
+	n := len(order)
+	if n == 0 {
+		return 0
+	}
+	best := make([][]float64, n)
+	from := make([][]int, n)
+	for k, i := range order {
+		entries := o.entries[i]
+		best[k] = make([]float64, len(entries))
+		from[k] = make([]int, len(entries))
+		for e, ep := range entries {
+			if k == 0 {
+				best[k][e] = math.Hypot(ep.in.X, ep.in.Y) + ep.inner
+				continue
+			}
+			best[k][e] = math.MaxFloat64
+			for pe, prev := range o.entries[order[k-1]] {
+				d := best[k-1][pe] + math.Hypot(ep.in.X-prev.start.X, ep.in.Y-prev.start.Y) + ep.inner
+				if d < best[k][e] {
+					best[k][e], from[k][e] = d, pe
+				}
+			}
+		}
+	}
+
+	last := 0
+	for e := range best[n-1] {
+		if best[n-1][e] < best[n-1][last] {
+			last = e
+		}
+	}
+	if choice != nil {
+		for k, e := n-1, last; k >= 0; k-- {
+			choice[k] = e
+			e = from[k][e]
+		}
+	}
+	return best[n-1][last]
+}
+
+// greedy builds a starting order by always cutting next the part whose
+// nearest entry point is closest, among the parts whose predecessors are
+// all cut.
+func (o *orderer) greedy() []int {
+	n := len(o.entries)
+	done := make([]bool, n)
+	order := make([]int, 0, n)
+	cur := model.Point2D{}
+	for len(order) < n {
+		bestPart, bestEntry, bestDist := -1, 0, math.MaxFloat64
+		for i := 0; i < n; i++ {
+			if done[i] || !o.ready(i, done) {
+				continue
+			}
+			for e, ep := range o.entries[i] {
+				if d := math.Hypot(ep.in.X-cur.X, ep.in.Y-cur.Y) + ep.inner; d < bestDist {
+					bestPart, bestEntry, bestDist = i, e, d
+				}
+			}
+		}
+		if bestPart < 0 {
+			// Cyclic constraints; cut the rest in layout order
+			for i := 0; i < n; i++ {
+				if !done[i] {
+					order = append(order, i)
+					done[i] = true
+				}
+			}
+			break
+		}
+		order = append(order, bestPart)
+		done[bestPart] = true
+		cur = o.entries[bestPart][bestEntry].start
+	}
+	return order
+}
+
+// ready reports whether every part that must be cut before part i is done.
+func (o *orderer) ready(i int, done []bool) bool {
+	for _, j := range o.after[i] {
+		if !done[j] {
+			return false
+		}
+	}
+	return true
+}
+
+// improve shortens the order with 2-opt (reversing a run of parts) and
+// Or-opt (moving a run of up to three parts elsewhere) moves until neither
+// finds an improvement or the deadline passes. Moves that break a
+// precedence constraint are skipped.
+func (o *orderer) improve(order []int, deadline time.Time) []int {
+	n := len(order)
+	best := o.cost(order, nil)
+	try := func(cand []int) bool {
+		if !o.valid(cand) {
+			return false
+		}
+		if c := o.cost(cand, nil); c < best-1e-9 {
+			copy(order, cand)
+			best = c
+			return true
+		}
+		return false
+	}
+
+	cand := make([]int, n)
+	for improved := true; improved; {
+		improved = false
+
+		// 2-opt
+		for i := 0; i < n-1 && !improved; i++ {
+			if time.Now().After(deadline) {
+				return order
+			}
+			for j := i + 1; j < n && !improved; j++ {
+				copy(cand, order)
+				for a, b := i, j; a < b; a, b = a+1, b-1 {
+					cand[a], cand[b] = cand[b], cand[a]
+				}
+				improved = try(cand)
+			}
+		}
+
+		// Or-opt
+		for length := 1; length <= 3 && !improved; length++ {
+			for i := 0; i+length <= n && !improved; i++ {
+				if time.Now().After(deadline) {
+					return order
+				}
+				run := append([]int(nil), order[i:i+length]...)
+				rest := append(append([]int(nil), order[:i]...), order[i+length:]...)
+				for at := 0; at <= len(rest) && !improved; at++ {
+					if at == i {
+						continue
+					}
+					cand = append(append(append(cand[:0], rest[:at]...), run...), rest[at:]...)
+					improved = try(cand)
+				}
+			}
+		}
+	}
+	return order
+}
+
+// orderPlacements finds a cutting order and a start point for every part
+// that keep the rapids between parts short. It starts from a greedy
+// nearest-neighbour tour from the origin and improves it with 2-opt and
+// Or-opt moves for up to ToolpathOrderTime milliseconds, keeping nested
+// parts before the parts around them and, with the small-part strategy,
+// small parts first. It also reports the rapid travel saved over the layout
+// order.
+func (g *Generator) orderPlacements(placements []model.Placement) ([]partCut, RapidSavings) {
+	n := len(placements)
+	if n == 0 {
+		return nil, RapidSavings{}
+	}
+	o := g.newOrderer(placements)
+
+	layout := make([]int, n)
+	for i := range layout {
+		layout[i] = i
+	}
+	savings := RapidSavings{Before: o.travel(layout, make([]int, n))}
+
+	budget := time.Duration(g.Settings.ToolpathOrderTime) * time.Millisecond
+	if budget <= 0 {
+		budget = 200 * time.Millisecond
+	}
+	order := o.improve(o.greedy(), time.Now().Add(budget))
+
+	// The layout order with the best start points may still win when the
+	// time budget ran out early
+	if o.valid(layout) && o.cost(layout, nil) < o.cost(order, nil) {
+		order = layout
+	}
+	choice := make([]int, n)
+	savings.After = o.cost(order, choice)
+
+	cuts := make([]partCut, n)
+	for k, i := range order {
+		cuts[k] = partCut{Placement: placements[i], entry: o.entries[i][choice[k]].vertex}
+	}
+	return cuts, savings
+}
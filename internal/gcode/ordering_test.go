@@ -0,0 +1,150 @@
+package gcode
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/piwi3910/SlabCut/internal/model"
+)
+
+func newOrderingPlacement(label string, x, y, w, h float64) model.Placement {
+	return model.Placement{
+		Part: model.Part{ID: label, Label: label, Width: w, Height: h, Quantity: 1},
+		X:    x,
+		Y:    y,
+	}
+}
+
+func TestOrdering_TwoOptUncrossesTour(t *testing.T) {
+	// A row of parts laid out out of order: greedy alone walks back and forth
+	placements := []model.Placement{
+		newOrderingPlacement("A", 600, 0, 50, 50),
+		newOrderingPlacement("B", 0, 0, 50, 50),
+		newOrderingPlacement("C", 900, 0, 50, 50),
+		newOrderingPlacement("D", 300, 0, 50, 50),
+	}
+	gen := New(newTestSettings())
+	cuts, savings := gen.orderPlacements(placements)
+
+	var got []string
+	for _, c := range cuts {
+		got = append(got, c.Part.Label)
+	}
+	if strings.Join(got, "") != "BDAC" {
+		t.Errorf("expected the row cut left to right, got %v", got)
+	}
+	if savings.Saved() <= 0 || savings.Percent() <= 0 {
+		t.Errorf("expected rapid travel savings, got %+v", savings)
+	}
+}
+
+func TestOrdering_PicksNearestCorner(t *testing.T) {
+	// Every loop ends where it started, so A is best cut from its top-left
+	// corner, facing the bottom-left corner of B
+	placements := []model.Placement{
+		newOrderingPlacement("B", 0, 300, 100, 100),
+		newOrderingPlacement("A", 0, 0, 100, 100),
+	}
+	gen := New(newTestSettings())
+	cuts, _ := gen.orderPlacements(placements)
+
+	if cuts[0].Part.Label != "A" || cuts[0].entry != 3 {
+		t.Errorf("expected A first from its top-left corner, got %s from corner %d", cuts[0].Part.Label, cuts[0].entry)
+	}
+	if cuts[1].entry != 0 {
+		t.Errorf("expected B entered at its bottom-left corner, got corner %d", cuts[1].entry)
+	}
+}
+
+func TestOrdering_StartCornerInGCode(t *testing.T) {
+	settings := newTestSettings()
+	gen := New(settings)
+	p := newOrderingPlacement("P", 100, 100, 100, 50)
+	m := gen.newMachine()
+	gen.writePart(m, partCut{Placement: p, entry: 2}, 1)
+
+	// Corner 2 is the top-right corner of the toolpath, offset by the tool radius
+	if !strings.Contains(m.String(), "G0 X203.000 Y153.000") {
+		t.Errorf("expected the cut to start at the top-right corner, got:\n%s", m.String())
+	}
+}
+
+func TestOrdering_NestedPartsBeforeOuter(t *testing.T) {
+	frame := newOrderingPlacement("Frame", 0, 0, 400, 400)
+	frame.Part.Cutouts = []model.Outline{{{X: 100, Y: 100}, {X: 300, Y: 100}, {X: 300, Y: 300}, {X: 100, Y: 300}}}
+	inner := newOrderingPlacement("Inner", 150, 150, 50, 50)
+
+	gen := New(newTestSettings())
+	cuts, _ := gen.orderPlacements([]model.Placement{frame, inner})
+	if cuts[0].Part.Label != "Inner" {
+		t.Errorf("expected the part nested in the cutout cut first, got %s", cuts[0].Part.Label)
+	}
+}
+
+func TestOrdering_SmallPartsFirst(t *testing.T) {
+	settings := newSmallPartSettings()
+	placements := []model.Placement{
+		newOrderingPlacement("Large", 0, 0, 200, 200),
+		newOrderingPlacement("Small", 800, 800, 40, 30),
+	}
+	cuts, _ := New(settings).orderPlacements(placements)
+	if cuts[0].Part.Label != "Small" {
+		t.Errorf("expected the small part cut first despite the travel, got %s", cuts[0].Part.Label)
+	}
+}
+
+func TestOrdering_LeadArcsKeepUsualStart(t *testing.T) {
+	settings := newTestSettings()
+	settings.LeadInRadius = 5
+	gen := New(settings)
+	if got := len(gen.entryPoints(newOrderingPlacement("A", 0, 0, 100, 50))); got != 1 {
+		t.Errorf("expected a single entry point with lead arcs, got %d", got)
+	}
+	if got := len(gen.entryPoints(newLShapePlacement())); got != 1 {
+		t.Errorf("expected a single entry point on an outline with lead arcs, got %d", got)
+	}
+}
+
+func TestOrdering_OutlineTabsStayPut(t *testing.T) {
+	settings := newTestSettings()
+	settings.PartTabsPerSide = 1
+	settings.PartTabWidth = 8
+	settings.PartTabHeight = 2
+	gen := New(settings)
+	p := newLShapePlacement()
+
+	path, tabs := gen.outlineCut(p, 0, 0)
+	shifted, shiftedTabs := gen.outlineCut(p, 0, 2)
+	if len(tabs) != len(shiftedTabs) {
+		t.Fatalf("expected %d tabs from any start, got %d", len(tabs), len(shiftedTabs))
+	}
+	for i := range tabs {
+		pt, _, _ := path.pointAt((tabs[i].start + tabs[i].end) / 2)
+		found := false
+		for _, st := range shiftedTabs {
+			q, _, _ := shifted.pointAt((st.start + st.end) / 2)
+			if dist := (pt.X-q.X)*(pt.X-q.X) + (pt.Y-q.Y)*(pt.Y-q.Y); dist < 1e-6 {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("tab at (%.2f, %.2f) moved when the cut starts at another vertex", pt.X, pt.Y)
+		}
+	}
+}
+
+func TestOrdering_GCodeReportsSavings(t *testing.T) {
+	settings := newTestSettings()
+	settings.OptimizeToolpath = true
+	sheet := model.SheetResult{
+		Stock: model.StockSheet{Width: 1000, Height: 500},
+		Placements: []model.Placement{
+			newOrderingPlacement("A", 800, 0, 50, 50),
+			newOrderingPlacement("B", 0, 0, 50, 50),
+		},
+	}
+	code := New(settings).GenerateSheet(sheet, 1)
+	if !strings.Contains(code, "less than layout order") {
+		t.Errorf("expected the rapid travel savings in the program, got:\n%s", code)
+	}
+}
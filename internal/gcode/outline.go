@@ -0,0 +1,640 @@
+package gcode
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/piwi3910/SlabCut/internal/model"
+)
+
+const (
+	// maxTabTurn is the largest change of direction, in degrees, that a
+	// holding tab may span on an outline.
+	maxTabTurn = 15.0
+	// maxMiter caps how far a sharp corner of the toolpath is pushed out,
+	// in multiples of the offset distance.
+	maxMiter = 4.0
+)
+
+// outlinePath is the closed toolpath around an outline part, in stock
+// coordinates.
+type outlinePath struct {
+	pts     model.Outline // Toolpath vertices; the path closes back to pts[0]
+	corners model.Outline // Part vertex each toolpath vertex was offset from
+	concave []bool        // Whether the part is concave at the vertex
+	ccw     bool          // Whether the path runs counter-clockwise
+}
+
+// tabSpan is a holding tab on an outline toolpath, given as arc lengths from
+// the start of the path.
+type tabSpan struct {
+	start, end float64
+}
+
+// writeOutlinePart generates GCode that follows the actual part outline
+// instead of a rectangular perimeter. Holding tabs are spread along the
+// perimeter on the final pass, lead-in/out arcs join the path tangentially,
+// and corner overcuts are cut at concave vertices. Every loop starts at the
+// given toolpath vertex.
+func (g *Generator) writeOutlinePart(m *machine, p model.Placement, entry int, partNum int) {
+	m.WriteString(g.comment(fmt.Sprintf("--- Part %d: %s (%.1f x %.1f, outline)%s ---",
+		partNum, p.Part.Label, p.Part.Width, p.Part.Height,
+		rotatedStr(p.Rotated))))
+
+	if len(p.Part.Outline) < 3 {
+		m.WriteString(g.comment("WARNING: outline has fewer than 3 points, skipping"))
+		return
+	}
+
+	hasLeadIn := g.Settings.LeadInRadius > 0
+	hasLeadOut := g.Settings.LeadOutRadius > 0
+	profile := tabProfile(p.Part.TabShapeOrDefault(g.Settings), g.Settings.PartTabWidth)
+
+	// Roughing passes leave the finishing allowance outside the outline
+	allowance := g.finishAllowance()
+	path, tabs := g.outlineCut(p, allowance, entry)
+	if len(tabs) > 0 {
+		m.WriteString(g.comment(fmt.Sprintf("Holding tabs: %d", len(tabs))))
+	}
+	if allowance > 0 {
+		m.WriteString(g.comment(fmt.Sprintf("Roughing: leaving %.2fmm for finishing", allowance)))
+	}
+
+	depths, from, to := g.passDepths()
+	numPasses := len(depths)
+	for i := from; i < to; i++ {
+		pass, depth := i+1, depths[i]
+		isFinalPass := pass == numPasses
+		m.startPass()
+
+		// Apply onion skin on final pass
+		effectiveDepth, skinApplied := g.applyOnionSkin(depth, isFinalPass)
+		if skinApplied {
+			m.WriteString(g.comment(fmt.Sprintf("Onion skin: leaving %.2fmm skin", g.Settings.OnionSkinDepth)))
+		}
+
+		m.WriteString(g.comment(fmt.Sprintf("Pass %d/%d, depth=%.2fmm", pass, numPasses, effectiveDepth)))
+
+		var passTabs []tabSpan
+		if isFinalPass {
+			passTabs = tabs
+		}
+		g.writeOutlineLoop(m, path, effectiveDepth, passTabs, profile, hasLeadIn, hasLeadOut)
+	}
+
+	if g.stage == stageSkin {
+		m.WriteString("\n")
+		return
+	}
+
+	// Finishing passes on the outline itself
+	if allowance > 0 {
+		path, tabs = g.outlineCut(p, 0, entry)
+		if g.Settings.FinishReverse {
+			path = path.reversed()
+			tabs = g.outlineTabs(path, p)
+		}
+		g.writeFinishingPasses(m, func(depth float64, final bool) {
+			var passTabs []tabSpan
+			if final {
+				passTabs = tabs
+			}
+			g.writeOutlineLoop(m, path, depth, passTabs, profile, hasLeadIn, hasLeadOut)
+		})
+	}
+
+	// Onion skin cleanup pass for outline parts
+	if g.onionSkinActive() && g.Settings.OnionSkinCleanup {
+		fullDepth := g.Settings.CutDepth
+		m.startPass()
+		m.WriteString(g.comment("Onion skin cleanup pass"))
+		m.WriteString(g.comment(fmt.Sprintf("Cleanup depth=%.2fmm (removing %.2fmm skin)",
+			fullDepth, g.Settings.OnionSkinDepth)))
+		g.writeOutlineLoop(m, path, fullDepth, nil, profile, hasLeadIn, hasLeadOut)
+	}
+
+	m.WriteString("\n")
+}
+
+// outlineToolpath returns the toolpath around an outline part, offset by the
+// tool radius plus allowance. With lead arcs it starts on the longest edge
+// so the arcs meet it tangentially.
+func (g *Generator) outlineToolpath(p model.Placement, allowance float64) outlinePath {
+	path := g.newOutlinePath(p.Part.Outline.Translate(p.X, p.Y), g.Settings.ToolDiameter/2.0+allowance)
+	if g.Settings.LeadInRadius > 0 || g.Settings.LeadOutRadius > 0 {
+		path = path.startOnLongestEdge()
+	}
+	return path
+}
+
+// outlineCut returns the toolpath around an outline part restarted at the
+// entry vertex, and its holding tabs. The tabs are placed from the usual
+// start of the path, so they stay put whichever vertex the cut starts at.
+func (g *Generator) outlineCut(p model.Placement, allowance float64, entry int) (outlinePath, []tabSpan) {
+	path := g.outlineToolpath(p, allowance)
+	tabs := g.outlineTabs(path, p)
+	if entry <= 0 || entry >= len(path.pts) {
+		return path, tabs
+	}
+	shift, perimeter := path.vertexAt(entry), path.length()
+	shifted := make([]tabSpan, len(tabs))
+	for i, t := range tabs {
+		start := math.Mod(t.start-shift+perimeter, perimeter)
+		shifted[i] = tabSpan{start: start, end: start + t.end - t.start}
+	}
+	sort.Slice(shifted, func(i, j int) bool { return shifted[i].start < shifted[j].start })
+	return path.startAt(entry), shifted
+}
+
+// startAt returns the path restarted at vertex i.
+func (p outlinePath) startAt(i int) outlinePath {
+	n := len(p.pts)
+	out := outlinePath{ccw: p.ccw}
+	for k := 0; k < n; k++ {
+		j := (i + k) % n
+		out.pts = append(out.pts, p.pts[j])
+		out.corners = append(out.corners, p.corners[j])
+		out.concave = append(out.concave, p.concave[j])
+	}
+	return out
+}
+
+// vertexAt returns the distance along the path to vertex i.
+func (p outlinePath) vertexAt(i int) float64 {
+	var s float64
+	for k := 0; k < i; k++ {
+		a, c := p.pts[k], p.pts[k+1]
+		s += math.Hypot(c.X-a.X, c.Y-a.Y)
+	}
+	return s
+}
+
+// inTab reports whether the tool would touch one of the tabs if it plunged
+// at vertex i.
+func (p outlinePath) inTab(i int, tabs []tabSpan, toolR float64) bool {
+	s := p.vertexAt(i)
+	for _, t := range tabs {
+		if s > t.start-toolR && s < t.end+toolR {
+			return true
+		}
+	}
+	return false
+}
+
+// reversed returns the path run the other way round from the same start.
+func (p outlinePath) reversed() outlinePath {
+	n := len(p.pts)
+	out := outlinePath{ccw: !p.ccw}
+	for k := 0; k < n; k++ {
+		i := (n - k) % n
+		out.pts = append(out.pts, p.pts[i])
+		out.corners = append(out.corners, p.corners[i])
+		out.concave = append(out.concave, p.concave[i])
+	}
+	return out
+}
+
+// writeOutlineLoop cuts one full loop of the toolpath at the given depth,
+// following the tab profile over the tabs, and retracts to safe Z.
+func (g *Generator) writeOutlineLoop(m *machine, path outlinePath, depth float64, tabs []tabSpan,
+	profile []tabPoint, leadIn, leadOut bool) {
+
+	pts := path.pts
+	start := pts[0]
+	if leadIn {
+		g.writeOutlineLeadIn(m, path, depth)
+	} else {
+		m.rapid(start.X, start.Y)
+		g.writePlunge(m, start.X, start.Y, depth)
+	}
+
+	tabDepth := math.Max(depth-g.Settings.PartTabHeight, 0)
+	liftAt := func(s float64) float64 {
+		for _, t := range tabs {
+			if s > t.start && s < t.end {
+				return tabLift(profile, s-t.start)
+			}
+		}
+		return 0
+	}
+
+	// Feed to each point, leaving out the feed rate and unchanged axes over tabs
+	moveTo := func(pt model.Point2D, z float64) {
+		if z == -depth && m.z == -depth {
+			m.feedAt(pt.X, pt.Y, g.Settings.FeedRate)
+		} else {
+			g.writeTabMove(m, pt.X, pt.Y, z)
+		}
+	}
+
+	n := len(pts)
+	s := 0.0
+	for i := 0; i < n; i++ {
+		from, to := pts[i], pts[(i+1)%n]
+		length := math.Hypot(to.X-from.X, to.Y-from.Y)
+
+		// Follow the profile of every tab corner within this segment
+		for _, t := range tabs {
+			for _, tp := range profile {
+				at := t.start + tp.at
+				if at < s || at >= s+length || length == 0 {
+					continue
+				}
+				f := (at - s) / length
+				moveTo(model.Point2D{X: from.X + (to.X-from.X)*f, Y: from.Y + (to.Y-from.Y)*f},
+					tabZ(depth, tabDepth, tp.lift))
+			}
+		}
+		s += length
+		lift := liftAt(s)
+		moveTo(to, tabZ(depth, tabDepth, lift))
+
+		if j := (i + 1) % n; path.concave[j] && lift == 0 {
+			g.writeOutlineOvercut(m, path, j)
+		}
+	}
+
+	if leadOut {
+		g.writeOutlineLeadOut(m, path)
+	}
+	m.retract()
+}
+
+// newOutlinePath offsets a part outline (in stock coordinates) outward by
+// the tool radius and records which vertices are concave.
+func (g *Generator) newOutlinePath(outline model.Outline, toolR float64) outlinePath {
+	path := outlinePath{
+		pts:     g.offsetOutline(outline, toolR),
+		corners: outline,
+		concave: make([]bool, len(outline)),
+		ccw:     signedArea(outline) > 0,
+	}
+	n := len(outline)
+	for i := range outline {
+		prev, curr, next := outline[(i-1+n)%n], outline[i], outline[(i+1)%n]
+		cross := (curr.X-prev.X)*(next.Y-curr.Y) - (curr.Y-prev.Y)*(next.X-curr.X)
+		path.concave[i] = (path.ccw && cross < -1e-9) || (!path.ccw && cross > 1e-9)
+	}
+	return path
+}
+
+// startOnLongestEdge returns the path restarted at the middle of its longest
+// edge.
+func (p outlinePath) startOnLongestEdge() outlinePath {
+	n := len(p.pts)
+	longest, best := 0, -1.0
+	for i := 0; i < n; i++ {
+		a, c := p.pts[i], p.pts[(i+1)%n]
+		if l := math.Hypot(c.X-a.X, c.Y-a.Y); l > best {
+			longest, best = i, l
+		}
+	}
+	a, c := p.pts[longest], p.pts[(longest+1)%n]
+	mid := model.Point2D{X: (a.X + c.X) / 2, Y: (a.Y + c.Y) / 2}
+
+	out := outlinePath{ccw: p.ccw}
+	out.pts = append(out.pts, mid)
+	out.corners = append(out.corners, mid)
+	out.concave = append(out.concave, false)
+	for k := 1; k <= n; k++ {
+		i := (longest + k) % n
+		out.pts = append(out.pts, p.pts[i])
+		out.corners = append(out.corners, p.corners[i])
+		out.concave = append(out.concave, p.concave[i])
+	}
+	return out
+}
+
+// outwardNormal returns the unit normal pointing away from the part for
+// travel in direction (dx, dy) along the path.
+func (p outlinePath) outwardNormal(dx, dy float64) (float64, float64) {
+	if p.ccw {
+		return normalize(dy, -dx)
+	}
+	return normalize(-dy, dx)
+}
+
+// outlineTabs places holding tabs along the toolpath: at the part's
+// hand-placed tabs when it has any, otherwise PartTabSpacing apart when set,
+// or else PartTabsPerSide*4 of them spread evenly. Spread tabs are kept off
+// corners, tight arcs and the start of the path, and each one is moved to
+// the nearest stretch where it fits.
+func (g *Generator) outlineTabs(path outlinePath, p model.Placement) []tabSpan {
+	if len(p.Part.Tabs) > 0 {
+		return g.placeOutlineTabs(path, p)
+	}
+	pts := path.pts
+	n := len(pts)
+	lengths := make([]float64, n)
+	var perimeter float64
+	for i := 0; i < n; i++ {
+		a, c := pts[i], pts[(i+1)%n]
+		lengths[i] = math.Hypot(c.X-a.X, c.Y-a.Y)
+		perimeter += lengths[i]
+	}
+
+	count := g.Settings.PartTabsPerSide * 4
+	if g.Settings.PartTabSpacing > 0 {
+		count = int(perimeter / g.Settings.PartTabSpacing)
+	}
+	tw := g.Settings.PartTabWidth
+	if count <= 0 || tw <= 0 || perimeter <= 0 {
+		return nil
+	}
+
+	// Split the path into smooth stretches at corners and tight arcs.
+	minRadius := 2 * math.Max(tw, g.Settings.ToolDiameter)
+	breaks := []float64{0}
+	s := 0.0
+	for i := 0; i < n; i++ {
+		s += lengths[i]
+		j := (i + 1) % n
+		in, out := lengths[i], lengths[j]
+		if j == 0 || in == 0 || out == 0 {
+			continue
+		}
+		a, c, d := pts[i], pts[j], pts[(j+1)%n]
+		turn := math.Abs(angleBetween(c.X-a.X, c.Y-a.Y, d.X-c.X, d.Y-c.Y))
+		if turn*180/math.Pi > maxTabTurn || (turn > 1e-9 && (in+out)/2/turn < minRadius) {
+			breaks = append(breaks, s)
+		}
+	}
+	breaks = append(breaks, perimeter)
+
+	// Tab centres must keep clear of the stretch ends.
+	margin := tw/2 + g.Settings.ToolDiameter/2
+	type interval struct{ lo, hi float64 }
+	var usable []interval
+	for i := 1; i < len(breaks); i++ {
+		if lo, hi := breaks[i-1]+margin, breaks[i]-margin; hi >= lo {
+			usable = append(usable, interval{lo, hi})
+		}
+	}
+
+	var tabs []tabSpan
+	minGap := tw + g.Settings.ToolDiameter
+	for k := 0; k < count; k++ {
+		target := (float64(k) + 0.5) * perimeter / float64(count)
+		best, bestDist := 0.0, math.Inf(1)
+		for _, iv := range usable {
+			c := math.Max(iv.lo, math.Min(iv.hi, target))
+			if d := math.Abs(c - target); d < bestDist {
+				best, bestDist = c, d
+			}
+		}
+		if math.IsInf(bestDist, 1) {
+			break
+		}
+		clash := false
+		for _, t := range tabs {
+			if math.Abs((t.start+t.end)/2-best) < minGap {
+				clash = true
+				break
+			}
+		}
+		if !clash {
+			tabs = append(tabs, tabSpan{start: best - tw/2, end: best + tw/2})
+		}
+	}
+	sort.Slice(tabs, func(i, j int) bool { return tabs[i].start < tabs[j].start })
+	return tabs
+}
+
+// placeOutlineTabs moves each hand-placed tab onto the nearest point of the
+// toolpath, dropping tabs that would overlap.
+func (g *Generator) placeOutlineTabs(path outlinePath, p model.Placement) []tabSpan {
+	tw := g.Settings.PartTabWidth
+	perimeter := path.length()
+	if tw <= 0 || perimeter < tw {
+		return nil
+	}
+	var centres []float64
+	for _, pt := range p.TabPositions() {
+		c := math.Max(tw/2, math.Min(perimeter-tw/2, path.arcLengthAt(pt)))
+		centres = append(centres, c)
+	}
+	sort.Float64s(centres)
+
+	var tabs []tabSpan
+	for _, c := range centres {
+		if n := len(tabs); n > 0 && c-tw/2 < tabs[n-1].end {
+			continue
+		}
+		tabs = append(tabs, tabSpan{start: c - tw/2, end: c + tw/2})
+	}
+	return tabs
+}
+
+// length returns the length of the closed toolpath.
+func (p outlinePath) length() float64 {
+	var total float64
+	for i := range p.pts {
+		a, c := p.pts[i], p.pts[(i+1)%len(p.pts)]
+		total += math.Hypot(c.X-a.X, c.Y-a.Y)
+	}
+	return total
+}
+
+// arcLengthAt returns the distance along the toolpath to the point on it
+// nearest to pt.
+func (p outlinePath) arcLengthAt(pt model.Point2D) float64 {
+	best, bestDist := 0.0, math.Inf(1)
+	s := 0.0
+	for i := range p.pts {
+		a, c := p.pts[i], p.pts[(i+1)%len(p.pts)]
+		dx, dy := c.X-a.X, c.Y-a.Y
+		l := math.Hypot(dx, dy)
+		t := 0.0
+		if l > 0 {
+			t = math.Max(0, math.Min(1, ((pt.X-a.X)*dx+(pt.Y-a.Y)*dy)/(l*l)))
+		}
+		if d := math.Hypot(pt.X-(a.X+dx*t), pt.Y-(a.Y+dy*t)); d < bestDist {
+			best, bestDist = s+l*t, d
+		}
+		s += l
+	}
+	return best
+}
+
+// pointAt returns the toolpath point at distance s from its start and the
+// unit direction of travel there.
+func (p outlinePath) pointAt(s float64) (model.Point2D, float64, float64) {
+	for i := range p.pts {
+		a, c := p.pts[i], p.pts[(i+1)%len(p.pts)]
+		l := math.Hypot(c.X-a.X, c.Y-a.Y)
+		if s <= l || i == len(p.pts)-1 {
+			f := 0.0
+			if l > 0 {
+				f = math.Min(s/l, 1)
+			}
+			dx, dy := normalize(c.X-a.X, c.Y-a.Y)
+			return model.Point2D{X: a.X + (c.X-a.X)*f, Y: a.Y + (c.Y-a.Y)*f}, dx, dy
+		}
+		s -= l
+	}
+	return p.pts[0], 0, 0
+}
+
+// writeOutlineLeadIn rapids to the start of an arc outside the part and
+// arcs onto the first toolpath point, tangent to the first edge.
+func (g *Generator) writeOutlineLeadIn(m *machine, path outlinePath, depth float64) {
+	s, next := path.pts[0], path.pts[1%len(path.pts)]
+	cx, cy, ux, uy, arcCmd := g.leadArc(path, s, next, g.Settings.LeadInRadius)
+	theta := g.leadAngle()
+	if arcCmd == "G2" {
+		theta = -theta
+	}
+	// Start the arc theta before the path start along the circle
+	ax, ay := rotate(ux, uy, -theta)
+	startX, startY := cx+ax, cy+ay
+
+	m.WriteString(g.comment("Lead-in arc"))
+	m.rapid(startX, startY)
+	m.feedZ(-depth, g.Settings.PlungeRate)
+	m.arc(arcCmd, s.X, s.Y, cx-startX, cy-startY, g.Settings.FeedRate)
+}
+
+// writeOutlineLeadOut arcs away from the part after the loop closes,
+// continuing tangent to the last edge.
+func (g *Generator) writeOutlineLeadOut(m *machine, path outlinePath) {
+	s, next := path.pts[0], path.pts[1%len(path.pts)]
+	cx, cy, ux, uy, arcCmd := g.leadArc(path, s, next, g.Settings.LeadOutRadius)
+	theta := g.leadAngle()
+	if arcCmd == "G2" {
+		theta = -theta
+	}
+	ex, ey := rotate(ux, uy, theta)
+
+	m.WriteString(g.comment("Lead-out arc"))
+	m.arc(arcCmd, cx+ex, cy+ey, cx-s.X, cy-s.Y, g.Settings.FeedRate)
+}
+
+// leadArc returns the centre of a lead arc of radius r that touches the path
+// at s, heading towards next, from outside the part. It also returns the
+// vector from the centre to s and the arc command that travels in the path
+// direction at s.
+func (g *Generator) leadArc(path outlinePath, s, next model.Point2D, r float64) (cx, cy, ux, uy float64, arcCmd string) {
+	dx, dy := normalize(next.X-s.X, next.Y-s.Y)
+	nx, ny := path.outwardNormal(dx, dy)
+	cx, cy = s.X+nx*r, s.Y+ny*r
+	ux, uy = s.X-cx, s.Y-cy
+	// Counter-clockwise motion about the centre moves along (-uy, ux)
+	arcCmd = "G2"
+	if -uy*dx+ux*dy > 0 {
+		arcCmd = "G3"
+	}
+	return cx, cy, ux, uy, arcCmd
+}
+
+// leadAngle returns the lead arc sweep in radians, LeadInAngle or 90°.
+func (g *Generator) leadAngle() float64 {
+	angle := g.Settings.LeadInAngle
+	if angle <= 0 {
+		angle = 90
+	}
+	return angle * math.Pi / 180.0
+}
+
+// writeOutlineOvercut cuts corner relief at the concave toolpath vertex i:
+// a dogbone moves toward the part corner until the tool touches it, a T-bone
+// moves along the incoming edge instead.
+func (g *Generator) writeOutlineOvercut(m *machine, path outlinePath, i int) {
+	overcutType := g.Settings.CornerOvercut
+	if overcutType == model.CornerOvercutNone || overcutType == "" {
+		return
+	}
+	toolR := g.Settings.ToolDiameter / 2.0
+	n := len(path.pts)
+	v, corner, prev := path.pts[i], path.corners[i], path.pts[(i-1+n)%n]
+
+	var tx, ty float64
+	switch overcutType {
+	case model.CornerOvercutDogbone:
+		dist := math.Hypot(corner.X-v.X, corner.Y-v.Y) - toolR
+		if dist <= 0 {
+			return
+		}
+		dx, dy := normalize(corner.X-v.X, corner.Y-v.Y)
+		tx, ty = v.X+dx*dist, v.Y+dy*dist
+	case model.CornerOvercutTbone:
+		dx, dy := normalize(v.X-prev.X, v.Y-prev.Y)
+		dist := (corner.X-v.X)*dx + (corner.Y-v.Y)*dy
+		if dist <= 0 {
+			return
+		}
+		tx, ty = v.X+dx*dist, v.Y+dy*dist
+	default:
+		return
+	}
+
+	m.feedAt(tx, ty, g.Settings.FeedRate)
+	m.feed(v.X, v.Y)
+}
+
+// offsetOutline offsets the outline outward by dist, whatever its winding.
+// Each vertex moves along the bisector of its adjacent edge normals far
+// enough for both edges to end up dist from the part; very sharp corners are
+// capped at maxMiter times dist.
+func (g *Generator) offsetOutline(outline model.Outline, dist float64) model.Outline {
+	n := len(outline)
+	if n < 3 {
+		return outline
+	}
+	ccw := signedArea(outline) > 0
+	outward := func(ex, ey float64) (float64, float64) {
+		if ccw {
+			return normalize(ey, -ex)
+		}
+		return normalize(-ey, ex)
+	}
+
+	result := make(model.Outline, n)
+	for i := 0; i < n; i++ {
+		prev := outline[(i-1+n)%n]
+		curr := outline[i]
+		next := outline[(i+1)%n]
+
+		n1x, n1y := outward(curr.X-prev.X, curr.Y-prev.Y)
+		n2x, n2y := outward(next.X-curr.X, next.Y-curr.Y)
+
+		// Average normal; its length is the cosine of half the turn angle
+		nx := (n1x + n2x) / 2
+		ny := (n1y + n2y) / 2
+		nLen := math.Sqrt(nx*nx + ny*ny)
+		offset := dist
+		if nLen > 1e-9 {
+			nx /= nLen
+			ny /= nLen
+			offset = math.Min(dist/nLen, dist*maxMiter)
+		}
+
+		result[i] = model.Point2D{
+			X: curr.X + nx*offset,
+			Y: curr.Y + ny*offset,
+		}
+	}
+	return result
+}
+
+// signedArea returns the shoelace area of a polygon: positive when its
+// vertices run counter-clockwise.
+func signedArea(o model.Outline) float64 {
+	var area float64
+	for i := range o {
+		j := (i + 1) % len(o)
+		area += o[i].X*o[j].Y - o[j].X*o[i].Y
+	}
+	return area / 2
+}
+
+// angleBetween returns the signed angle in radians from vector a to vector b.
+func angleBetween(ax, ay, bx, by float64) float64 {
+	return math.Atan2(ax*by-ay*bx, ax*bx+ay*by)
+}
+
+// rotate turns the vector (x, y) by the given angle in radians.
+func rotate(x, y, angle float64) (float64, float64) {
+	sin, cos := math.Sincos(angle)
+	return x*cos - y*sin, x*sin + y*cos
+}
@@ -0,0 +1,181 @@
+package gcode
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/piwi3910/SlabCut/internal/model"
+)
+
+// newLShapePlacement returns an L-shaped outline part with one concave
+// corner at (50, 50).
+func newLShapePlacement() model.Placement {
+	return model.Placement{
+		Part: model.Part{
+			ID:     "l1",
+			Label:  "LShape",
+			Width:  100,
+			Height: 100,
+			Outline: model.Outline{
+				{X: 0, Y: 0}, {X: 100, Y: 0}, {X: 100, Y: 50},
+				{X: 50, Y: 50}, {X: 50, Y: 100}, {X: 0, Y: 100},
+			},
+		},
+		X: 10,
+		Y: 10,
+	}
+}
+
+func generateOutline(settings model.CutSettings, p model.Placement) string {
+	gen := New(settings)
+	m := gen.newMachine()
+	gen.writeOutlinePart(m, p, 0, 1)
+	return m.String()
+}
+
+func TestOffsetOutline_IgnoresWinding(t *testing.T) {
+	gen := New(newTestSettings())
+	ccw := model.Outline{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}}
+	cw := model.Outline{{X: 0, Y: 0}, {X: 0, Y: 10}, {X: 10, Y: 10}, {X: 10, Y: 0}}
+
+	for name, o := range map[string]model.Outline{"ccw": ccw, "cw": cw} {
+		min, max := o.BoundingBox()
+		off := gen.offsetOutline(o, 3)
+		offMin, offMax := off.BoundingBox()
+		if math.Abs(offMin.X-(min.X-3)) > 1e-6 || math.Abs(offMax.Y-(max.Y+3)) > 1e-6 {
+			t.Errorf("%s: expected outline grown by 3mm, got %v..%v", name, offMin, offMax)
+		}
+	}
+}
+
+func TestOutlinePart_Tabs(t *testing.T) {
+	settings := newTestSettings()
+	settings.PartTabsPerSide = 1
+	settings.PartTabWidth = 8
+	settings.PartTabHeight = 2
+	code := generateOutline(settings, newLShapePlacement())
+
+	if !strings.Contains(code, "Holding tabs: 4") {
+		t.Errorf("expected 4 tabs on the outline, got:\n%s", code)
+	}
+	if got := strings.Count(code, "G1 Z-4.000\n"); got != 4 {
+		t.Errorf("expected 4 lifts to tab height, got %d", got)
+	}
+
+	settings.PartTabsPerSide = 0
+	settings.PartTabSpacing = 100
+	code = generateOutline(settings, newLShapePlacement())
+	if !strings.Contains(code, "Holding tabs: 4") {
+		t.Errorf("expected tabs every 100mm along a ~440mm toolpath, got:\n%s", code)
+	}
+}
+
+func TestOutlineTabs_AvoidCorners(t *testing.T) {
+	settings := newTestSettings()
+	settings.PartTabSpacing = 20
+	settings.PartTabWidth = 8
+	gen := New(settings)
+	p := newLShapePlacement()
+	path := gen.outlineToolpath(p, 0)
+	tabs := gen.outlineTabs(path, p)
+	if len(tabs) == 0 {
+		t.Fatal("expected tabs")
+	}
+
+	// Arc length of every toolpath corner
+	var corners []float64
+	s := 0.0
+	for i := range path.pts {
+		a, c := path.pts[i], path.pts[(i+1)%len(path.pts)]
+		s += math.Hypot(c.X-a.X, c.Y-a.Y)
+		corners = append(corners, s)
+	}
+	for _, tab := range tabs {
+		for _, c := range corners {
+			if tab.start < c+settings.ToolDiameter/2 && tab.end > c-settings.ToolDiameter/2 {
+				t.Errorf("tab %.1f-%.1f too close to corner at %.1f", tab.start, tab.end, c)
+			}
+		}
+	}
+	for i := 1; i < len(tabs); i++ {
+		if tabs[i].start < tabs[i-1].end {
+			t.Errorf("tabs overlap: %v", tabs)
+		}
+	}
+}
+
+func TestOutlinePart_Dogbone(t *testing.T) {
+	settings := newTestSettings()
+	settings.CornerOvercut = model.CornerOvercutDogbone
+	code := generateOutline(settings, newLShapePlacement())
+
+	// The concave toolpath vertex sits at (63, 63); the dogbone moves
+	// towards the part corner at (60, 60) until the tool touches it.
+	d := (math.Sqrt(18) - 3) / math.Sqrt(2)
+	want := "G1 X" + New(settings).format(63-d) + " Y" + New(settings).format(63-d)
+	if !strings.Contains(code, want) {
+		t.Errorf("expected dogbone move %q, got:\n%s", want, code)
+	}
+
+	settings.CornerOvercut = model.CornerOvercutNone
+	plain := generateOutline(settings, newLShapePlacement())
+	if strings.Count(code, "G1 X")-strings.Count(plain, "G1 X") != 2 {
+		t.Error("expected exactly one dogbone for the single concave corner")
+	}
+}
+
+func TestOutlinePart_LeadInOut(t *testing.T) {
+	settings := newTestSettings()
+	settings.LeadInRadius = 5
+	settings.LeadOutRadius = 5
+	settings.LeadInAngle = 90
+	code := generateOutline(settings, newLShapePlacement())
+
+	if !strings.Contains(code, "Lead-in arc") || !strings.Contains(code, "Lead-out arc") {
+		t.Fatalf("expected lead arcs, got:\n%s", code)
+	}
+	// The longest edge runs along the bottom from (7, 7) to (113, 7), so the
+	// path starts at X60 Y7 and the lead-in comes from below.
+	if !strings.Contains(code, "G0 X55.000 Y2.000") {
+		t.Errorf("expected lead-in to start outside the part, got:\n%s", code)
+	}
+	if !strings.Contains(code, "G2 X60.000 Y7.000 I5.000") {
+		t.Errorf("expected a clockwise lead-in arc onto the path, got:\n%s", code)
+	}
+	if !strings.Contains(code, "G2 X65.000 Y2.000 I0.000 J-5.000") {
+		t.Errorf("expected the lead-out to curve away from the part, got:\n%s", code)
+	}
+}
+
+func TestOutlinePart_FinishingPass(t *testing.T) {
+	settings := newTestSettings()
+	settings.FinishingPass = true
+	settings.FinishAllowance = 0.5
+	settings.FinishFeedRate = 600
+	code := generateOutline(settings, newLShapePlacement())
+
+	rough := code[:strings.Index(code, "Finishing pass")]
+	finish := code[strings.Index(code, "Finishing pass"):]
+	if !strings.Contains(rough, "G0 X6.500 Y6.500") || !strings.Contains(rough, "G1 X113.500 Y6.500 F1000.000") {
+		t.Errorf("expected roughing 0.5mm outside the finished toolpath, got:\n%s", rough)
+	}
+	if !strings.Contains(finish, "G0 X7.000 Y7.000") || !strings.Contains(finish, "G1 X113.000 Y7.000 F600.000") {
+		t.Errorf("expected finishing on the part outline at the finishing feed, got:\n%s", finish)
+	}
+}
+
+func TestOutlinePart_FinishingPassReversed(t *testing.T) {
+	settings := newTestSettings()
+	settings.FinishingPass = true
+	settings.FinishAllowance = 0.5
+	settings.FinishReverse = true
+	settings.CornerOvercut = model.CornerOvercutDogbone
+	code := generateOutline(settings, newLShapePlacement())
+
+	finish := code[strings.Index(code, "Finishing pass"):]
+	want := "G1 X7.000 Y113.000 F1000.000\nG1 X63.000 Y113.000 F1000.000\nG1 X63.000 Y63.000 F1000.000\nG1 X62.121 Y62.121"
+	if !strings.Contains(finish, want) {
+		t.Errorf("expected the reversed finishing pass with its dogbone, got:\n%s", finish)
+	}
+}
@@ -26,11 +26,14 @@ type GCodeMove struct {
 	ToY      float64
 	ToZ      float64
 	FeedRate float64
+	Line     int // line of the program the move is on, counting from 0
 }
 
 // ParseGCode parses a GCode string into a slice of structured moves.
 // It tracks absolute position state and classifies each G0/G1 command
-// by its movement characteristics (rapid, feed, plunge, retract).
+// by its movement characteristics (rapid, feed, plunge, retract). Line
+// numbers are ignored, and ShopBot jog (J2, J3, JZ) and move (M2, M3, MZ)
+// commands are read as rapids and feeds.
 func ParseGCode(code string) []GCodeMove {
 	var moves []GCodeMove
 
@@ -41,15 +44,16 @@ func ParseGCode(code string) []GCodeMove {
 	lines := strings.Split(code, "\n")
 
 	coordRe := regexp.MustCompile(`([XYZF])([-]?\d+\.?\d*)`)
+	lineNumberRe := regexp.MustCompile(`^[Nn]\d+\s*`)
 
-	for _, line := range lines {
+	for n, line := range lines {
 		line = strings.TrimSpace(line)
 		if line == "" {
 			continue
 		}
 
-		// Strip inline comments (semicolon or parenthetical)
-		if idx := strings.Index(line, ";"); idx >= 0 {
+		// Strip inline comments (semicolon, ShopBot apostrophe or parenthetical)
+		if idx := strings.IndexAny(line, ";'"); idx >= 0 {
 			line = line[:idx]
 		}
 		if idx := strings.Index(line, "("); idx >= 0 {
@@ -57,42 +61,76 @@ func ParseGCode(code string) []GCodeMove {
 				line = line[:idx] + line[end+1:]
 			}
 		}
-		line = strings.TrimSpace(line)
+		line = strings.TrimSpace(lineNumberRe.ReplaceAllString(line, ""))
 		if line == "" {
 			continue
 		}
 
-		// Determine command type
 		isRapid := false
 		isFeed := false
 		upper := strings.ToUpper(line)
-		if strings.HasPrefix(upper, "G0 ") || strings.HasPrefix(upper, "G00 ") || upper == "G0" || upper == "G00" {
-			isRapid = true
-		} else if strings.HasPrefix(upper, "G1 ") || strings.HasPrefix(upper, "G01 ") || upper == "G1" || upper == "G01" {
-			isFeed = true
-		}
+		newX, newY, newZ, newFeed := curX, curY, curZ, curFeed
 
-		if !isRapid && !isFeed {
-			continue
-		}
+		if cmd, args, ok := strings.Cut(upper, ","); ok {
+			// ShopBot native syntax: a two-letter command and comma-separated values
+			vals := strings.Split(args, ",")
+			set := func(i int, v *float64) {
+				if i < len(vals) {
+					if f, err := strconv.ParseFloat(strings.TrimSpace(vals[i]), 64); err == nil {
+						*v = f
+					}
+				}
+			}
+			switch strings.TrimSpace(cmd) {
+			case "J2", "M2":
+				set(0, &newX)
+				set(1, &newY)
+			case "J3", "M3":
+				set(0, &newX)
+				set(1, &newY)
+				set(2, &newZ)
+			case "JZ", "MZ":
+				set(0, &newZ)
+			case "MS":
+				// Move speed in units per second
+				var perSec float64
+				set(0, &perSec)
+				curFeed = perSec * 60
+				continue
+			default:
+				continue
+			}
+			isRapid = cmd[0] == 'J'
+			isFeed = !isRapid
+		} else {
+			// Determine command type
+			if strings.HasPrefix(upper, "G0 ") || strings.HasPrefix(upper, "G00 ") || upper == "G0" || upper == "G00" {
+				isRapid = true
+			} else if strings.HasPrefix(upper, "G1 ") || strings.HasPrefix(upper, "G01 ") || upper == "G1" || upper == "G01" {
+				isFeed = true
+			}
 
-		// Parse coordinates from this line
-		newX, newY, newZ, newFeed := curX, curY, curZ, curFeed
-		matches := coordRe.FindAllStringSubmatch(upper, -1)
-		for _, m := range matches {
-			val, err := strconv.ParseFloat(m[2], 64)
-			if err != nil {
+			if !isRapid && !isFeed {
 				continue
 			}
-			switch m[1] {
-			case "X":
-				newX = val
-			case "Y":
-				newY = val
-			case "Z":
-				newZ = val
-			case "F":
-				newFeed = val
+
+			// Parse coordinates from this line
+			matches := coordRe.FindAllStringSubmatch(upper, -1)
+			for _, m := range matches {
+				val, err := strconv.ParseFloat(m[2], 64)
+				if err != nil {
+					continue
+				}
+				switch m[1] {
+				case "X":
+					newX = val
+				case "Y":
+					newY = val
+				case "Z":
+					newZ = val
+				case "F":
+					newFeed = val
+				}
 			}
 		}
 
@@ -108,6 +146,7 @@ func ParseGCode(code string) []GCodeMove {
 			ToY:      newY,
 			ToZ:      newZ,
 			FeedRate: newFeed,
+			Line:     n,
 		})
 
 		curX, curY, curZ, curFeed = newX, newY, newZ, newFeed
@@ -245,3 +245,63 @@ func TestParseGCode_NegativeCoordinates(t *testing.T) {
 		t.Errorf("expected to (-3,-3), got (%.3f, %.3f)", moves[0].ToX, moves[0].ToY)
 	}
 }
+
+func TestParseGCode_LineNumbers(t *testing.T) {
+	moves := ParseGCode("N10 G0 X10.000 Y20.000\nN20 G1 Z-5.000 F300.000\n")
+	if len(moves) != 2 {
+		t.Fatalf("expected 2 moves, got %d", len(moves))
+	}
+	if moves[0].ToX != 10 || moves[0].ToY != 20 {
+		t.Errorf("expected rapid to (10,20), got (%.3f, %.3f)", moves[0].ToX, moves[0].ToY)
+	}
+	if moves[1].Type != MovePlunge || moves[1].ToZ != -5 {
+		t.Errorf("expected plunge to Z-5, got %+v", moves[1])
+	}
+}
+
+func TestParseGCode_ShopBot(t *testing.T) {
+	code := `' ShopBot program
+SA
+J2,10.000,20.000
+MS,25.000,8.333
+MZ,-6.000
+M2,110.000,20.000
+M3,110.000,70.000,-4.000
+JZ,5.000
+C7
+`
+	moves := ParseGCode(code)
+	if len(moves) != 5 {
+		t.Fatalf("expected 5 moves, got %d", len(moves))
+	}
+	want := []struct {
+		typ     MoveType
+		x, y, z float64
+	}{
+		{MoveRapid, 10, 20, 0},
+		{MovePlunge, 10, 20, -6},
+		{MoveFeed, 110, 20, -6},
+		{MoveFeed, 110, 70, -4},
+		{MoveRetract, 110, 70, 5},
+	}
+	for i, w := range want {
+		m := moves[i]
+		if m.Type != w.typ || m.ToX != w.x || m.ToY != w.y || m.ToZ != w.z {
+			t.Errorf("move %d = %+v, want %+v", i, m, w)
+		}
+	}
+	if moves[2].FeedRate != 1500 {
+		t.Errorf("expected MS speed in mm/s to give 1500 mm/min, got %.1f", moves[2].FeedRate)
+	}
+}
+
+func TestParseGCode_SourceLines(t *testing.T) {
+	code := "; header\nG0 X10 Y20\n\nG1 Z-3 F300\n"
+	moves := ParseGCode(code)
+	if len(moves) != 2 {
+		t.Fatalf("expected 2 moves, got %d", len(moves))
+	}
+	if moves[0].Line != 1 || moves[1].Line != 3 {
+		t.Errorf("expected moves on lines 1 and 3, got %d and %d", moves[0].Line, moves[1].Line)
+	}
+}
@@ -0,0 +1,171 @@
+package gcode
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/piwi3910/SlabCut/internal/model"
+)
+
+// PostVars holds the values a post-processor template can use. Job values
+// are set for every block; the move values are formatted coordinates and
+// are empty when a move leaves that word out.
+type PostVars struct {
+	// Job
+	Program        int      // Program number (the sheet number)
+	Sheet          int      // Sheet number, 1-based
+	SheetLabel     string   // Label of the stock sheet
+	StockWidth     float64  // mm
+	StockHeight    float64  // mm
+	StockThickness float64  // mm
+	Parts          int      // Number of parts on the sheet
+	Banner         []string // Job description lines, for {{comment}}
+	Profile        string   // Profile name
+	Units          string   // "mm" or "inches"
+	Tool           int      // Tool number
+	ToolDiameter   float64  // mm
+	SpindleSpeed   int      // RPM
+	FeedRate       float64  // mm/min
+	PlungeRate     float64  // mm/min
+	SafeZ          float64  // mm
+	CutDepth       float64  // mm
+	PassDepth      float64  // mm
+	Passes         int      // Depth passes per part
+	ReturnZ        string   // Height at which the footer can return to the origin
+
+	// Move
+	X, Y, Z   string
+	I, J      string // Arc centre offset from the start point
+	F         string // Feed rate, empty when modal
+	R         string // Drill cycle retract plane
+	Q         string // Drill cycle peck depth
+	Clockwise bool   // Arc direction: G2 when true, G3 when false
+}
+
+// post is a compiled post-processor template.
+type post struct {
+	blocks   map[string]*template.Template
+	defined  map[string]bool // blocks with any text
+	lineStep int
+	comment  string // comment prefix; comment lines get no line number
+}
+
+// compilePost parses every block of the profile's post-processor template.
+func compilePost(profile model.GCodeProfile) (*post, error) {
+	t := profile.Template()
+	funcs := template.FuncMap{
+		"comment": func(text string) string {
+			return commentLine(profile, text)
+		},
+		"num": func(v float64) string {
+			return fmt.Sprintf("%.*f", profile.DecimalPlaces, v)
+		},
+		"perSecond": func(rate any) (string, error) {
+			var v float64
+			switch r := rate.(type) {
+			case float64:
+				v = r
+			case string:
+				f, err := strconv.ParseFloat(r, 64)
+				if err != nil {
+					return "", fmt.Errorf("perSecond: %w", err)
+				}
+				v = f
+			default:
+				return "", fmt.Errorf("perSecond: unsupported value %v", rate)
+			}
+			return fmt.Sprintf("%.*f", profile.DecimalPlaces, v/60), nil
+		},
+	}
+	p := &post{
+		blocks:   make(map[string]*template.Template),
+		defined:  make(map[string]bool),
+		lineStep: t.LineNumberStep,
+		comment:  profile.CommentPrefix,
+	}
+	for _, b := range t.Blocks() {
+		tmpl, err := template.New(b.Name).Funcs(funcs).Parse(b.Text)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s block: %w", b.Name, err)
+		}
+		p.blocks[b.Name] = tmpl
+		p.defined[b.Name] = strings.TrimSpace(b.Text) != ""
+	}
+	for _, name := range []string{model.BlockRapid, model.BlockLinear, model.BlockArc} {
+		if !p.defined[name] {
+			return nil, fmt.Errorf("%s block is empty", name)
+		}
+	}
+	return p, nil
+}
+
+// commentLine wraps text in the profile's comment syntax. Where comments
+// are closed, as with Fanuc's parentheses, the delimiters are replaced in
+// the text so they cannot end the comment early or nest.
+func commentLine(profile model.GCodeProfile, text string) string {
+	if profile.CommentSuffix != "" {
+		text = strings.NewReplacer(profile.CommentPrefix, "[", profile.CommentSuffix, "]").Replace(text)
+	}
+	return profile.CommentPrefix + " " + text + profile.CommentSuffix
+}
+
+// render executes the named block and returns its non-blank lines.
+func (p *post) render(name string, v PostVars) ([]string, error) {
+	var sb strings.Builder
+	if err := p.blocks[name].Execute(&sb, v); err != nil {
+		return nil, fmt.Errorf("failed to render %s block: %w", name, err)
+	}
+	var lines []string
+	for _, line := range strings.Split(sb.String(), "\n") {
+		if line = strings.TrimRight(line, " \t\r"); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, nil
+}
+
+// numbered reports whether a rendered line gets an N-word: every line but
+// comments, program delimiters and program numbers, when line numbers are
+// on.
+func (p *post) numbered(line string) bool {
+	if p.lineStep <= 0 || line == "%" || strings.HasPrefix(line, "O") {
+		return false
+	}
+	return p.comment == "" || !strings.HasPrefix(line, p.comment)
+}
+
+// ValidateProfile checks that a profile's post-processor template parses
+// and renders a sample program.
+func ValidateProfile(profile model.GCodeProfile) error {
+	_, err := SampleProgram(profile)
+	return err
+}
+
+// SampleProgram renders a short program with the profile: one part with a
+// drill hole and lead arcs, so every block of the template is used.
+func SampleProgram(profile model.GCodeProfile) (string, error) {
+	p, err := compilePost(profile)
+	if err != nil {
+		return "", err
+	}
+	settings := model.DefaultSettings()
+	settings.LeadInRadius = 5
+	settings.LeadOutRadius = 5
+	g := &Generator{Settings: settings, profile: profile, post: p}
+
+	part := model.NewPart("Sample", 200, 100, 1)
+	part.Drills = []model.DrillHole{{X: 100, Y: 50, Diameter: settings.ToolDiameter, Depth: 10}}
+	stock := model.NewStockSheet("Sample Sheet", 600, 400, 1)
+	stock.Thickness = 2 * settings.PassDepth
+	sheet := model.SheetResult{
+		Stock:      stock,
+		Placements: []model.Placement{{Part: part, X: 50, Y: 50}},
+	}
+	code := g.GenerateSheet(sheet, 1)
+	if g.renderErr != nil {
+		return code, g.renderErr
+	}
+	return code, nil
+}
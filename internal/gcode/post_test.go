@@ -0,0 +1,131 @@
+package gcode
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/piwi3910/SlabCut/internal/model"
+)
+
+// withCustomProfile makes profile available to New for the rest of the test.
+func withCustomProfile(t *testing.T, profile model.GCodeProfile) model.CutSettings {
+	t.Helper()
+	model.CustomProfiles = []model.GCodeProfile{profile}
+	t.Cleanup(func() { model.CustomProfiles = nil })
+	settings := newTestSettings()
+	settings.GCodeProfile = profile.Name
+	return settings
+}
+
+func TestPost_LegacyProfileMatchesTemplate(t *testing.T) {
+	legacy := model.GCodeProfile{
+		Name:          "Legacy",
+		StartCode:     []string{"G90", "G21"},
+		SpindleStart:  "M3 S%d",
+		SpindleStop:   "M5",
+		RapidMove:     "G0",
+		FeedMove:      "G1",
+		EndCode:       []string{"G0 Z[SafeZ]", "G0 X0 Y0", "M2"},
+		CommentPrefix: ";",
+		DecimalPlaces: 3,
+	}
+	templated := legacy
+	templated.Name = "Templated"
+	templated.Post = model.PostTemplate{
+		Header: model.PostBanner + "G90\nG21\nM3 S{{.SpindleSpeed}}",
+		Rapid:  model.PostG0,
+		Linear: model.PostG1,
+		Arc:    model.PostG2G3,
+		Footer: "G0 Z{{.ReturnZ}}\nG0 X0 Y0\nM2\nM5",
+	}
+
+	sheet := newTestSheet()
+	code := New(withCustomProfile(t, legacy)).GenerateSheet(sheet, 1)
+	want := New(withCustomProfile(t, templated)).GenerateSheet(sheet, 1)
+	code = strings.ReplaceAll(code, "Profile: Legacy", "Profile: Templated")
+	if code != want {
+		t.Errorf("legacy profile output differs from its template:\n%s\nwant\n%s", code, want)
+	}
+	if !strings.Contains(code, "M3 S12000\n") || !strings.HasSuffix(code, "G0 Z5.000\nG0 X0 Y0\nM2\nM5\n") {
+		t.Errorf("unexpected header or footer:\n%s", code)
+	}
+}
+
+func TestPost_JobVariablesAndFunctions(t *testing.T) {
+	profile := model.NewCustomProfile("Fanuc-ish")
+	profile.CommentPrefix, profile.CommentSuffix = "(", ")"
+	profile.Post.Header = "%\nO{{printf \"%04d\" .Program}}\n{{comment .SheetLabel}}\nT{{.Tool}} M6\nS{{.SpindleSpeed}} M3\nG43 H{{.Tool}} Z{{num .SafeZ}}"
+	profile.Post.Footer = "M30\n%"
+	code := New(withCustomProfile(t, profile)).GenerateSheet(newTestSheet(), 7)
+
+	for _, want := range []string{"%\nO0007\n( TestStock)\nT1 M6\nS12000 M3\nG43 H1 Z5.000\n", "M30\n%\n"} {
+		if !strings.Contains(code, want) {
+			t.Errorf("expected %q in:\n%s", want, code)
+		}
+	}
+}
+
+func TestPost_LineNumbers(t *testing.T) {
+	profile := model.NewCustomProfile("Numbered")
+	profile.Post.Header = "%\n" + profile.Post.Header
+	profile.Post.LineNumberStep = 10
+	code := New(withCustomProfile(t, profile)).GenerateSheet(newTestSheet(), 1)
+
+	lines := strings.Split(strings.TrimSpace(code), "\n")
+	if lines[0] != "%" {
+		t.Errorf("program delimiter should not be numbered, got %q", lines[0])
+	}
+	next := 10
+	for _, line := range lines[1:] {
+		if line == "" || strings.HasPrefix(line, ";") {
+			continue
+		}
+		want := "N" + strconv.Itoa(next) + " "
+		if !strings.HasPrefix(line, want) {
+			t.Fatalf("expected %q to start with %q", line, want)
+		}
+		next += 10
+	}
+}
+
+func TestPost_DrillCycle(t *testing.T) {
+	profile := model.NewCustomProfile("Canned")
+	profile.Post.Drill = model.PostG83Cycle
+	settings := withCustomProfile(t, profile)
+	sheet := newTestSheet()
+	sheet.Placements[0].Part.Drills = []model.DrillHole{{X: 20, Y: 20, Diameter: settings.ToolDiameter, Depth: 5}}
+	code := New(settings).GenerateSheet(sheet, 1)
+
+	want := "G83 X30.000 Y30.000 Z-5.000 R0.000 Q6.000 F300.000\nG80\nG0 Z5.000\n"
+	if !strings.Contains(code, want) {
+		t.Errorf("expected drill cycle %q in:\n%s", want, code)
+	}
+	if strings.Contains(code, "G1 Z-5.000 F300.000\nG0 Z0.000") {
+		t.Error("holes should not be pecked with linear moves when the profile has a drill cycle")
+	}
+}
+
+func TestPost_InvalidTemplateFallsBack(t *testing.T) {
+	profile := model.NewCustomProfile("Broken")
+	profile.Post.Rapid = "G0{{if .X}} X{{.X}}"
+	code := New(withCustomProfile(t, profile)).GenerateSheet(newTestSheet(), 1)
+
+	if !strings.Contains(code, `WARNING: profile "Broken" template unusable`) {
+		t.Errorf("expected a fallback warning in:\n%s", code)
+	}
+	if !strings.Contains(code, "G0 X0.000 Y0.000\n") {
+		t.Error("expected the Generic template to be used")
+	}
+	if err := ValidateProfile(profile); err == nil {
+		t.Error("expected ValidateProfile to reject the template")
+	}
+}
+
+func TestPost_RenderErrorReported(t *testing.T) {
+	profile := model.NewCustomProfile("Typo")
+	profile.Post.Linear = "G1 X{{.Xpos}}"
+	if err := ValidateProfile(profile); err == nil || !strings.Contains(err.Error(), "linear") {
+		t.Errorf("expected a linear block render error, got %v", err)
+	}
+}
@@ -0,0 +1,138 @@
+package gcode
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/piwi3910/SlabCut/internal/model"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// goldenPath returns the golden file for a built-in profile's sample program.
+func goldenPath(p model.GCodeProfile) string {
+	slug := strings.NewReplacer(" / ", "_", " ", "_").Replace(strings.ToLower(p.Name))
+	return filepath.Join("testdata", "golden", slug+p.Extension())
+}
+
+func TestBuiltInProfiles_Golden(t *testing.T) {
+	for _, p := range model.GCodeProfiles {
+		t.Run(p.Name, func(t *testing.T) {
+			code, err := SampleProgram(p)
+			if err != nil {
+				t.Fatal(err)
+			}
+			path := goldenPath(p)
+			if *updateGolden {
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(code), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("missing golden file (run go test -update): %v", err)
+			}
+			if code != string(want) {
+				t.Errorf("sample program differs from %s:\n%s", path, code)
+			}
+		})
+	}
+}
+
+func TestBuiltInProfiles_PreviewParsesMoves(t *testing.T) {
+	for _, p := range model.GCodeProfiles {
+		code, err := SampleProgram(p)
+		if err != nil {
+			t.Fatalf("%s: %v", p.Name, err)
+		}
+		moves := ParseGCode(code)
+		var feeds int
+		for _, m := range moves {
+			if m.Type == MoveFeed {
+				feeds++
+			}
+		}
+		// Two passes around the part, four sides each
+		if feeds < 8 {
+			t.Errorf("%s: expected the preview to find the cutting moves, got %d", p.Name, feeds)
+		}
+	}
+}
+
+func TestBuiltInProfiles_Fanuc(t *testing.T) {
+	code, err := SampleProgram(model.GetProfile("Fanuc / Haas"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(code, "%\nO0001\n( CNCCalculator GCode") {
+		t.Errorf("expected %% and a program number before the comments, got:\n%s", code[:60])
+	}
+	if !strings.HasSuffix(code, " M30\n%\n") {
+		t.Errorf("expected the program to end with M30 and %%")
+	}
+	for _, line := range strings.Split(strings.TrimSpace(code), "\n") {
+		switch {
+		case line == "", line == "%", strings.HasPrefix(line, "O"), strings.HasPrefix(line, "("):
+		case !strings.HasPrefix(line, "N"):
+			t.Errorf("expected an N-word on %q", line)
+		}
+		if strings.Contains(line, ";") {
+			t.Errorf("unexpected semicolon comment %q", line)
+		}
+	}
+	if !strings.Contains(code, " G98 G83 ") {
+		t.Error("expected holes to use the G83 peck cycle")
+	}
+}
+
+func TestBuiltInProfiles_ShopBot(t *testing.T) {
+	p := model.GetProfile("ShopBot")
+	if p.Extension() != ".sbp" {
+		t.Errorf("expected .sbp programs, got %s", p.Extension())
+	}
+	code, err := SampleProgram(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range strings.Split(code, "\n") {
+		if strings.HasPrefix(line, "G") || strings.HasPrefix(line, "M3 ") {
+			t.Errorf("unexpected G-code word in native ShopBot output: %q", line)
+		}
+	}
+	for _, want := range []string{"SA\n", "TR,18000\nC6\n", "MS,25.000,25.000\n", "CG,,", "JZ,5.000\nJ2,0,0\nC7\nEND\n"} {
+		if !strings.Contains(code, want) {
+			t.Errorf("expected %q in:\n%s", want, code)
+		}
+	}
+}
+
+func TestBuiltInProfiles_ToolChange(t *testing.T) {
+	for _, name := range []string{"Masso", "UCCNC", "Centroid", "Carbide Motion", "Fanuc / Haas"} {
+		code, err := SampleProgram(model.GetProfile(name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(code, "M6") {
+			t.Errorf("%s: expected a tool change", name)
+		}
+		if strings.Index(code, "M6") > strings.Index(code, "S18000") {
+			t.Errorf("%s: expected the tool change before the spindle start", name)
+		}
+	}
+}
+
+func TestGeneratePatterns_ProfileExtension(t *testing.T) {
+	settings := newTestSettings()
+	settings.GCodeProfile = "UCCNC"
+	result := model.OptimizeResult{Sheets: []model.SheetResult{newTestSheet()}}
+	programs := New(settings).GeneratePatterns(result)
+	if got := programs[0].FileName(); got != "sheet1.tap" {
+		t.Errorf("FileName = %q, want sheet1.tap", got)
+	}
+}
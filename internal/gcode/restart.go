@@ -0,0 +1,136 @@
+package gcode
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/piwi3910/SlabCut/internal/model"
+)
+
+// RestartPoint is a place a sheet's program can be resumed from: the start
+// of one pass over a part. Pass 1 includes the part's drilling.
+type RestartPoint struct {
+	Part  int    // Part number in cutting order, as in the part comments
+	Pass  int    // Pass over the part, counting every loop in both stages of a two-stage cut
+	Label string // Part label
+	Line  int    // Line of the full program the pass starts on, counting from 0
+}
+
+// String describes the restart point for comments and dialogs.
+func (r RestartPoint) String() string {
+	return fmt.Sprintf("part %d (%s), pass %d", r.Part, r.Label, r.Pass)
+}
+
+// RestartPointAt returns the restart point of the pass that a program line
+// belongs to: the last one starting on or before the line. It returns false
+// for lines before the first part, such as the header.
+func RestartPointAt(points []RestartPoint, line int) (RestartPoint, bool) {
+	var found RestartPoint
+	ok := false
+	for _, r := range points {
+		if r.Line > line {
+			break
+		}
+		found, ok = r, true
+	}
+	return found, ok
+}
+
+// restarter follows a machine through the parts and passes of a sheet,
+// listing where each pass starts and, when restarting, keeping the machine
+// silent until the restart point.
+type restarter struct {
+	from    RestartPoint // Point to resume from; Part 0 only lists the points
+	points  []RestartPoint
+	passes  map[int]int // Passes started so far, by part number
+	part    int
+	label   string
+	pending bool // The part's first pass was marked before its drilling
+	found   bool
+
+	lines, counted int // Lines written up to counted bytes of the program
+
+	homeX, homeY, homeZ float64 // Where the header left the tool
+}
+
+// startPart marks the start of a part's first pass, before its drilling. A
+// part met again in the second stage of a two-stage cut carries on counting
+// its passes.
+func (m *machine) startPart(partNum int, label string) {
+	r := m.restart
+	if r == nil {
+		return
+	}
+	r.part, r.label, r.pending = partNum, label, false
+	if r.passes[partNum] == 0 {
+		m.markPass()
+		r.pending = true
+	}
+}
+
+// startPass marks the start of a loop around the current part. The first
+// loop of a part belongs to the pass startPart marked.
+func (m *machine) startPass() {
+	if r := m.restart; r != nil {
+		if r.pending {
+			r.pending = false
+			return
+		}
+		m.markPass()
+	}
+}
+
+// markPass records the next pass of the current part and resumes output
+// when it is the restart point. The resumed program continues from where
+// the header left the tool, so the next rapid lifts clear of every clamp on
+// its way to the cut.
+func (m *machine) markPass() {
+	r := m.restart
+	r.lines += strings.Count(m.String()[r.counted:], "\n")
+	r.counted = m.Len()
+	r.passes[r.part]++
+	point := RestartPoint{Part: r.part, Pass: r.passes[r.part], Label: r.label, Line: r.lines}
+	r.points = append(r.points, point)
+
+	if m.skip && point.Part == r.from.Part && point.Pass == r.from.Pass {
+		m.skip, r.found = false, true
+		m.x, m.y, m.z = r.homeX, r.homeY, r.homeZ
+		m.WriteString(m.g.comment("Restart from " + point.String()))
+	}
+}
+
+// headerDone notes where the header left the tool and, when restarting,
+// silences the machine until the restart point.
+func (m *machine) headerDone() {
+	if r := m.restart; r != nil {
+		r.homeX, r.homeY, r.homeZ = m.x, m.y, m.z
+		m.skip = r.from.Part > 0
+	}
+}
+
+// RestartPoints returns every point the sheet's program can be resumed
+// from, in the order they are cut.
+func (g *Generator) RestartPoints(sheet model.SheetResult, sheetIndex int) []RestartPoint {
+	m := g.newMachine()
+	m.restart = &restarter{passes: map[int]int{}}
+	g.writeSheet(m, sheet, sheetIndex, "")
+	return m.restart.points
+}
+
+// GenerateRestart produces the sheet's program resumed from a part and
+// pass, for carrying on after a broken bit or a stopped job. It has the
+// full header with the spindle start, then approaches the restart point at
+// a safe height and skips every cut before it.
+func (g *Generator) GenerateRestart(sheet model.SheetResult, sheetIndex int, from RestartPoint) (string, error) {
+	if from.Part < 1 || from.Pass < 1 {
+		return "", fmt.Errorf("invalid restart point: part %d, pass %d", from.Part, from.Pass)
+	}
+	m := g.newMachine()
+	m.restart = &restarter{from: from, passes: map[int]int{}}
+	note := fmt.Sprintf("RESTART from part %d, pass %d", from.Part, from.Pass)
+	g.writeSheet(m, sheet, sheetIndex, note)
+	if !m.restart.found {
+		return "", fmt.Errorf("sheet %d has no pass %d of part %d", sheetIndex, from.Pass, from.Part)
+	}
+	return m.String(), nil
+}
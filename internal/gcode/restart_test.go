@@ -0,0 +1,132 @@
+package gcode
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/piwi3910/SlabCut/internal/model"
+)
+
+// newRestartSheet returns a sheet of two parts cut in three passes each.
+func newRestartSheet() (model.CutSettings, model.SheetResult) {
+	settings := newTestSettings()
+	settings.PassDepth = 2
+	sheet := newTestSheet()
+	second := newTestPlacement()
+	second.Part.ID, second.Part.Label = "test2", "Second"
+	second.X = 200
+	sheet.Placements = append(sheet.Placements, second)
+	return settings, sheet
+}
+
+func TestRestartPoints_EveryPassOfEveryPart(t *testing.T) {
+	settings, sheet := newRestartSheet()
+	gen := New(settings)
+	points := gen.RestartPoints(sheet, 1)
+	if len(points) != 6 {
+		t.Fatalf("expected 3 passes for each of 2 parts, got %v", points)
+	}
+
+	lines := strings.Split(gen.GenerateSheet(sheet, 1), "\n")
+	if p := points[3]; p.Part != 2 || p.Pass != 1 || p.Label != "Second" {
+		t.Errorf("expected the fourth point at part 2 pass 1, got %+v", p)
+	} else if !strings.Contains(lines[p.Line], "--- Part 2: Second") {
+		t.Errorf("expected part 2 to start at its part comment, got %q", lines[p.Line])
+	}
+	if p := points[4]; !strings.Contains(lines[p.Line], "Pass 2/3") {
+		t.Errorf("expected part 2 pass 2 to start at its pass comment, got %q", lines[p.Line])
+	}
+}
+
+func TestRestartPointAt(t *testing.T) {
+	points := []RestartPoint{{Part: 1, Pass: 1, Line: 10}, {Part: 1, Pass: 2, Line: 20}}
+	if _, ok := RestartPointAt(points, 5); ok {
+		t.Error("expected no restart point in the header")
+	}
+	if p, ok := RestartPointAt(points, 25); !ok || p.Pass != 2 {
+		t.Errorf("expected pass 2 for a line after it starts, got %+v", p)
+	}
+}
+
+func TestGenerateRestart_SkipsEarlierCuts(t *testing.T) {
+	settings, sheet := newRestartSheet()
+	code, err := New(settings).GenerateRestart(sheet, 1, RestartPoint{Part: 2, Pass: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{"M3 S12000", "Restart from part 2 (Second), pass 2", "Pass 3/3", "Job complete"} {
+		if !strings.Contains(code, want) {
+			t.Errorf("expected %q in the restart program, got:\n%s", want, code)
+		}
+	}
+	if strings.Contains(code, "--- Part") || strings.Count(code, "Pass 2/3") != 1 {
+		t.Errorf("expected every cut before part 2 pass 2 skipped, got:\n%s", code)
+	}
+
+	// The first cut comes after a rapid at safe Z from the origin
+	moves := ParseGCode(code)
+	for _, mv := range moves {
+		if mv.Type == MovePlunge {
+			break
+		}
+		if mv.ToZ < settings.SafeZ {
+			t.Fatalf("expected the approach at safe Z, got a move to Z%.3f", mv.ToZ)
+		}
+	}
+}
+
+func TestGenerateRestart_FirstPassDrills(t *testing.T) {
+	settings, sheet := newRestartSheet()
+	sheet.Placements[1].Part.Drills = []model.DrillHole{{X: 20, Y: 20, Diameter: 5, Depth: 6}}
+	gen := New(settings)
+
+	code, err := gen.GenerateRestart(sheet, 1, RestartPoint{Part: 2, Pass: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(code, "Drilling: Second") {
+		t.Errorf("expected a restart at pass 1 to drill the part, got:\n%s", code)
+	}
+
+	code, _ = gen.GenerateRestart(sheet, 1, RestartPoint{Part: 2, Pass: 2})
+	if strings.Contains(code, "Drilling") {
+		t.Errorf("expected a restart at pass 2 to leave the holes, got:\n%s", code)
+	}
+}
+
+func TestGenerateRestart_TwoStageCountsBothStages(t *testing.T) {
+	settings, sheet := newRestartSheet()
+	settings.TwoStageCut = true
+	settings.TwoStageSkin = 1
+	gen := New(settings)
+
+	// Three passes down to the skin, then one through it
+	var passes []int
+	for _, p := range gen.RestartPoints(sheet, 1) {
+		if p.Part == 1 {
+			passes = append(passes, p.Pass)
+		}
+	}
+	if len(passes) != 4 {
+		t.Fatalf("expected 4 passes of part 1 across both stages, got %v", passes)
+	}
+
+	code, err := gen.GenerateRestart(sheet, 1, RestartPoint{Part: 1, Pass: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(code, "Stage 1") || !strings.Contains(code, "Restart from part 1 (TestPart), pass 4") {
+		t.Errorf("expected the restart in the second stage, got:\n%s", code)
+	}
+}
+
+func TestGenerateRestart_UnknownPoint(t *testing.T) {
+	settings, sheet := newRestartSheet()
+	gen := New(settings)
+	for _, from := range []RestartPoint{{Part: 3, Pass: 1}, {Part: 1, Pass: 4}, {}} {
+		if _, err := gen.GenerateRestart(sheet, 1, from); err == nil {
+			t.Errorf("expected an error restarting from part %d pass %d", from.Part, from.Pass)
+		}
+	}
+}
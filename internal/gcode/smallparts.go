@@ -0,0 +1,134 @@
+package gcode
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/piwi3910/SlabCut/internal/model"
+)
+
+// cutStage selects which depth passes writePart cuts in a two-stage cut.
+type cutStage int
+
+const (
+	stageAll  cutStage = iota // every pass
+	stageSkin                 // the passes down to the skin, which free no part
+	stageFree                 // the passes through the skin, which free the part
+)
+
+// isSmallPart reports whether the small-part strategy applies to a
+// placement: its area is below SmallPartArea. Outline parts are measured by
+// their outline rather than their bounding box.
+func (g *Generator) isSmallPart(p model.Placement) bool {
+	return g.Settings.SmallPartStrategy && partArea(p) < g.Settings.SmallPartArea
+}
+
+// partArea returns the area of a part in mm².
+func partArea(p model.Placement) float64 {
+	if len(p.Part.Outline) >= 3 {
+		return p.Part.Outline.Area()
+	}
+	return p.Part.Width * p.Part.Height
+}
+
+// smallPartsFirst moves the small parts to the front, keeping the order
+// within the small and the other parts, so they are cut while the sheet
+// around them still holds vacuum.
+func (g *Generator) smallPartsFirst(cuts []partCut) []partCut {
+	ordered := make([]partCut, len(cuts))
+	copy(ordered, cuts)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return g.isSmallPart(ordered[i].Placement) && !g.isSmallPart(ordered[j].Placement)
+	})
+	return ordered
+}
+
+// smallPartSettings returns the settings a small part is cut with: its own
+// onion skin and number of tabs per side, where those are set.
+func (g *Generator) smallPartSettings() model.CutSettings {
+	s := g.Settings
+	if s.SmallPartOnionSkin > 0 {
+		s.OnionSkinEnabled = true
+		s.OnionSkinDepth = math.Max(s.OnionSkinDepth, s.SmallPartOnionSkin)
+	}
+	if s.SmallPartTabs > s.PartTabsPerSide {
+		s.PartTabsPerSide = s.SmallPartTabs
+		s.PartTabSpacing = 0
+	}
+	return s
+}
+
+// forPart returns the generator that plans a part's toolpath: a copy with
+// the small-part settings for small parts, otherwise g itself.
+func (g *Generator) forPart(p model.Placement) *Generator {
+	if !g.isSmallPart(p) {
+		return g
+	}
+	small := *g
+	small.Settings = g.smallPartSettings()
+	return &small
+}
+
+// twoStageActive returns true if the two-stage cut is enabled and leaves a
+// skin thinner than the cut.
+func (g *Generator) twoStageActive() bool {
+	return g.Settings.TwoStageCut && g.Settings.TwoStageSkin > 0 && g.Settings.TwoStageSkin < g.Settings.CutDepth
+}
+
+// writeTwoStage cuts every part down to the two-stage skin, then cuts
+// through the skin part by part, so that no part comes free while others
+// are still being cut.
+func (g *Generator) writeTwoStage(m *machine, cuts []partCut) {
+	defer func() { g.stage = stageAll }()
+
+	m.WriteString(g.comment(fmt.Sprintf("Stage 1: all parts down to a %.2fmm skin", g.Settings.TwoStageSkin)))
+	m.WriteString("\n")
+	g.stage = stageSkin
+	for i, c := range cuts {
+		g.writePart(m, c, i+1)
+	}
+
+	m.WriteString(g.comment("Stage 2: cutting through the skin"))
+	m.WriteString("\n")
+	g.stage = stageFree
+	for i, c := range cuts {
+		g.writePart(m, c, i+1)
+	}
+}
+
+// passDepths returns the depth of every roughing pass and the range
+// [from, to) of them the current stage cuts. In a two-stage cut the passes
+// stop at the skin and further passes cut through it.
+func (g *Generator) passDepths() (depths []float64, from, to int) {
+	cut, step := g.Settings.CutDepth, g.Settings.PassDepth
+	if !g.twoStageActive() {
+		depths = stepDepths(0, cut, step)
+		return depths, 0, len(depths)
+	}
+	top := cut - g.Settings.TwoStageSkin
+	depths = stepDepths(0, top, step)
+	split := len(depths)
+	depths = append(depths, stepDepths(top, cut, step)...)
+	switch g.stage {
+	case stageSkin:
+		return depths, 0, split
+	case stageFree:
+		return depths, split, len(depths)
+	}
+	return depths, 0, len(depths)
+}
+
+// stepDepths returns the depths from top down to bottom, step apart; the
+// last one is bottom. It returns none without a step or a depth to cut.
+func stepDepths(top, bottom, step float64) []float64 {
+	if step <= 0 || bottom <= top {
+		return nil
+	}
+	n := int(math.Ceil((bottom - top) / step))
+	depths := make([]float64, 0, n)
+	for i := 1; i <= n; i++ {
+		depths = append(depths, math.Min(top+float64(i)*step, bottom))
+	}
+	return depths
+}
@@ -0,0 +1,168 @@
+package gcode
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/piwi3910/SlabCut/internal/model"
+)
+
+// newSmallPartSheet returns the test sheet with a 40x30 part added at
+// (200, 100) after the 100x50 test part.
+func newSmallPartSheet() model.SheetResult {
+	sheet := newTestSheet()
+	sheet.Placements = append(sheet.Placements, model.Placement{
+		Part: model.Part{ID: "small1", Label: "SmallPart", Width: 40, Height: 30, Quantity: 1},
+		X:    200,
+		Y:    100,
+	})
+	return sheet
+}
+
+func newSmallPartSettings() model.CutSettings {
+	settings := newTestSettings()
+	settings.SmallPartStrategy = true
+	settings.SmallPartArea = 2000
+	settings.SmallPartOnionSkin = 0.3
+	settings.SmallPartTabs = 1
+	return settings
+}
+
+func TestSmallParts_CutFirst(t *testing.T) {
+	code := New(newSmallPartSettings()).GenerateSheet(newSmallPartSheet(), 1)
+
+	small := strings.Index(code, "--- Part 1: SmallPart")
+	large := strings.Index(code, "--- Part 2: TestPart")
+	if small < 0 || large < 0 {
+		t.Fatalf("expected the small part cut first, got:\n%s", code)
+	}
+	if !strings.Contains(code, "Small parts first (under 2000 mm²)") {
+		t.Error("expected a small part ordering comment")
+	}
+}
+
+func TestSmallParts_DisabledKeepsOrder(t *testing.T) {
+	settings := newSmallPartSettings()
+	settings.SmallPartStrategy = false
+	code := New(settings).GenerateSheet(newSmallPartSheet(), 1)
+
+	if !strings.Contains(code, "--- Part 1: TestPart") || strings.Contains(code, "Small part") {
+		t.Errorf("expected no small part handling when disabled, got:\n%s", code)
+	}
+}
+
+func TestSmallParts_ExtraSkinAndTabsOnlyOnSmallParts(t *testing.T) {
+	code := New(newSmallPartSettings()).GenerateSheet(newSmallPartSheet(), 1)
+
+	large := code[strings.Index(code, "--- Part 2: TestPart"):]
+	small := code[strings.Index(code, "--- Part 1: SmallPart"):strings.Index(code, "--- Part 2: TestPart")]
+	if !strings.Contains(small, "Onion skin: leaving 0.30mm skin") || strings.Count(small, "G1 Z-3.700") != 4 {
+		t.Errorf("expected onion skin and 4 tabs on the small part, got:\n%s", small)
+	}
+	if strings.Contains(large, "Onion skin") || strings.Contains(large, "G1 Z-4.000") {
+		t.Errorf("expected no onion skin or tabs on the large part, got:\n%s", large)
+	}
+}
+
+func TestSmallParts_OutlineArea(t *testing.T) {
+	settings := newSmallPartSettings()
+	settings.SmallPartArea = 8000
+	gen := New(settings)
+	p := newLShapePlacement() // 100x100 bounding box, 7500 mm² outline
+	if !gen.isSmallPart(p) {
+		t.Error("expected an outline part to be measured by its outline area")
+	}
+	p.Part.Outline = nil
+	if gen.isSmallPart(p) {
+		t.Error("expected a 100x100 rectangle not to be small")
+	}
+}
+
+func TestSmallParts_PartTabsMatchGCode(t *testing.T) {
+	gen := New(newSmallPartSettings())
+	sheet := newSmallPartSheet()
+	if got := len(gen.PartTabs(sheet.Placements[1])); got != 4 {
+		t.Errorf("expected 4 tab marks on the small part, got %d", got)
+	}
+	if got := len(gen.PartTabs(sheet.Placements[0])); got != 0 {
+		t.Errorf("expected no tab marks on the large part, got %d", got)
+	}
+}
+
+func TestTwoStageCut_FreesNoPartUntilAllAtSkin(t *testing.T) {
+	settings := newTestSettings()
+	settings.CutDepth = 12
+	settings.PassDepth = 6
+	settings.TwoStageCut = true
+	settings.TwoStageSkin = 0.5
+	code := New(settings).GenerateSheet(newSmallPartSheet(), 1)
+
+	stage2 := strings.Index(code, "Stage 2: cutting through the skin")
+	if stage2 < 0 {
+		t.Fatalf("expected two stages, got:\n%s", code)
+	}
+	first, second := code[:stage2], code[stage2:]
+	if strings.Contains(first, "Z-12.000") {
+		t.Error("expected no pass through the skin in the first stage")
+	}
+	if strings.Count(first, "Pass 2/3, depth=11.50mm") != 2 {
+		t.Errorf("expected both parts cut to the skin in the first stage, got:\n%s", first)
+	}
+	if strings.Count(second, "Pass 3/3, depth=12.00mm") != 2 || strings.Contains(second, "Pass 1/3") {
+		t.Errorf("expected only the pass through the skin in the second stage, got:\n%s", second)
+	}
+}
+
+func TestTwoStageCut_DrillsOnce(t *testing.T) {
+	settings := newTestSettings()
+	settings.TwoStageCut = true
+	sheet := newTestSheet()
+	sheet.Placements[0].Part.Drills = []model.DrillHole{{X: 20, Y: 20, Diameter: settings.ToolDiameter, Depth: 5}}
+	code := New(settings).GenerateSheet(sheet, 1)
+
+	if n := strings.Count(code, "Drilling:"); n != 1 {
+		t.Errorf("expected the holes drilled once, got %d", n)
+	}
+}
+
+func TestTwoStageCut_SkinThickerThanCutIgnored(t *testing.T) {
+	settings := newTestSettings()
+	settings.TwoStageCut = true
+	settings.TwoStageSkin = 10
+	code := New(settings).GenerateSheet(newTestSheet(), 1)
+
+	if strings.Contains(code, "Stage 1") {
+		t.Error("expected no two-stage cut when the skin is thicker than the cut")
+	}
+}
+
+func TestStepDepths(t *testing.T) {
+	got := stepDepths(5.5, 18, 6)
+	want := []float64{11.5, 17.5, 18}
+	if len(got) != len(want) {
+		t.Fatalf("stepDepths = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("stepDepths = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestStepDepths_NoStepOrDepth(t *testing.T) {
+	for _, c := range []struct{ top, bottom, step float64 }{{0, 18, 0}, {0, 18, -1}, {0, -3, 6}} {
+		if got := stepDepths(c.top, c.bottom, c.step); len(got) != 0 {
+			t.Errorf("stepDepths(%v, %v, %v) = %v, want none", c.top, c.bottom, c.step, got)
+		}
+	}
+}
+
+func TestGenerateSheet_ZeroPassDepth(t *testing.T) {
+	// Typing "0.5" into the pass depth passes through 0 on every refresh
+	for _, settings := range []model.CutSettings{newTestSettings(), newSmallPartSettings()} {
+		settings.PassDepth = 0
+		New(settings).GenerateSheet(newSmallPartSheet(), 1)
+		settings.PassDepth, settings.CutDepth = 6, -1
+		New(settings).GenerateSheet(newSmallPartSheet(), 1)
+	}
+}
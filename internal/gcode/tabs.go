@@ -0,0 +1,289 @@
+package gcode
+
+import (
+	"math"
+	"sort"
+
+	"github.com/piwi3910/SlabCut/internal/model"
+)
+
+// Tab represents a holding tab position along the perimeter.
+type Tab struct {
+	side     int     // 0=bottom, 1=right, 2=top, 3=left
+	startPos float64 // distance along that side to the tab centre
+}
+
+// TabMark is a holding tab left by the final pass, for drawing in previews
+// and reports.
+type TabMark struct {
+	X, Y   float64 // Tab centre on the toolpath, in stock coordinates
+	DX, DY float64 // Unit direction of the toolpath through the tab
+	Width  float64 // Length of the tab along the toolpath
+	Shape  model.TabShape
+}
+
+// tabPoint is a corner of a tab's side profile: the distance from the start
+// of the tab and how far up the tab height the tool is there, from 0 to 1.
+type tabPoint struct {
+	at, lift float64
+}
+
+// tabProfile returns the side profile of a tab. Trapezoid tabs ramp over a
+// quarter of the tab width at each end; triangular tabs peak in the middle.
+func tabProfile(shape model.TabShape, width float64) []tabPoint {
+	switch shape {
+	case model.TabShapeTrapezoid:
+		ramp := width / 4
+		return []tabPoint{{0, 0}, {ramp, 1}, {width - ramp, 1}, {width, 0}}
+	case model.TabShapeTriangular:
+		return []tabPoint{{0, 0}, {width / 2, 1}, {width, 0}}
+	default:
+		return []tabPoint{{0, 0}, {0, 1}, {width, 1}, {width, 0}}
+	}
+}
+
+// tabLift returns how far up the tab height the profile is at distance at
+// from the start of the tab.
+func tabLift(profile []tabPoint, at float64) float64 {
+	for k := 1; k < len(profile); k++ {
+		a, c := profile[k-1], profile[k]
+		if at > c.at || c.at == a.at {
+			continue
+		}
+		if at < a.at {
+			return 0
+		}
+		return a.lift + (c.lift-a.lift)*(at-a.at)/(c.at-a.at)
+	}
+	return 0
+}
+
+// PartTabs returns the holding tabs the final pass leaves on a part.
+func (g *Generator) PartTabs(p model.Placement) []TabMark {
+	g = g.forPart(p)
+	shape := p.Part.TabShapeOrDefault(g.Settings)
+	tw := g.Settings.PartTabWidth
+	var marks []TabMark
+
+	if len(p.Part.Outline) >= 3 {
+		path := g.outlineToolpath(p, 0)
+		for _, t := range g.outlineTabs(path, p) {
+			pt, dx, dy := path.pointAt((t.start + t.end) / 2)
+			marks = append(marks, TabMark{X: pt.X, Y: pt.Y, DX: dx, DY: dy, Width: tw, Shape: shape})
+		}
+		return marks
+	}
+
+	x0, y0, x1, y1 := g.rectToolpath(p, 0)
+	for _, t := range g.calculateTabs(p, 0) {
+		m := TabMark{Width: tw, Shape: shape}
+		switch t.side {
+		case 0:
+			m.X, m.Y, m.DX = x0+t.startPos, y0, 1
+		case 1:
+			m.X, m.Y, m.DY = x1, y0+t.startPos, 1
+		case 2:
+			m.X, m.Y, m.DX = x1-t.startPos, y1, -1
+		default:
+			m.X, m.Y, m.DY = x0, y1-t.startPos, -1
+		}
+		marks = append(marks, m)
+	}
+	return marks
+}
+
+// rectToolpath returns the corners of the toolpath around a rectangular
+// part, offset outward by the tool radius plus the allowance left for a
+// finishing pass.
+func (g *Generator) rectToolpath(p model.Placement, allowance float64) (x0, y0, x1, y1 float64) {
+	off := g.Settings.ToolDiameter/2.0 + allowance
+	return p.X - off, p.Y - off, p.X + p.PlacedWidth() + off, p.Y + p.PlacedHeight() + off
+}
+
+// calculateTabs places tabs on the sides of a rectangular part's toolpath:
+// at the part's hand-placed tabs when it has any, otherwise PartTabsPerSide
+// spread evenly along every side.
+func (g *Generator) calculateTabs(p model.Placement, allowance float64) []Tab {
+	if len(p.Part.Tabs) > 0 {
+		return g.placeRectTabs(p, allowance)
+	}
+	if g.Settings.PartTabsPerSide <= 0 {
+		return nil
+	}
+
+	pw := p.PlacedWidth() + g.Settings.ToolDiameter + 2*allowance
+	ph := p.PlacedHeight() + g.Settings.ToolDiameter + 2*allowance
+
+	var tabs []Tab
+	for side := 0; side < 4; side++ {
+		var length float64
+		if side == 0 || side == 2 {
+			length = pw
+		} else {
+			length = ph
+		}
+		spacing := length / float64(g.Settings.PartTabsPerSide+1)
+		for t := 1; t <= g.Settings.PartTabsPerSide; t++ {
+			tabs = append(tabs, Tab{
+				side:     side,
+				startPos: spacing * float64(t),
+			})
+		}
+	}
+	return tabs
+}
+
+// placeRectTabs moves each hand-placed tab onto the nearest side of the
+// toolpath, keeping it clear of the corners.
+func (g *Generator) placeRectTabs(p model.Placement, allowance float64) []Tab {
+	x0, y0, x1, y1 := g.rectToolpath(p, allowance)
+	tw := g.Settings.PartTabWidth
+	if tw <= 0 {
+		return nil
+	}
+
+	var tabs []Tab
+	for _, pt := range p.TabPositions() {
+		// Distance to each side and position along it in cutting direction
+		dists := []float64{math.Abs(pt.Y - y0), math.Abs(pt.X - x1), math.Abs(pt.Y - y1), math.Abs(pt.X - x0)}
+		positions := []float64{pt.X - x0, pt.Y - y0, x1 - pt.X, y1 - pt.Y}
+		lengths := []float64{x1 - x0, y1 - y0, x1 - x0, y1 - y0}
+
+		side := 0
+		for s := range dists {
+			if dists[s] < dists[side] {
+				side = s
+			}
+		}
+		if lengths[side] < tw {
+			continue
+		}
+		pos := math.Max(tw/2, math.Min(lengths[side]-tw/2, positions[side]))
+		tabs = append(tabs, Tab{side: side, startPos: pos})
+	}
+	sort.Slice(tabs, func(i, j int) bool {
+		if tabs[i].side != tabs[j].side {
+			return tabs[i].side < tabs[j].side
+		}
+		return tabs[i].startPos < tabs[j].startPos
+	})
+
+	// Drop tabs that would overlap the previous one on the same side
+	kept := tabs[:0]
+	for _, t := range tabs {
+		if n := len(kept); n > 0 && kept[n-1].side == t.side && t.startPos-kept[n-1].startPos < tw {
+			continue
+		}
+		kept = append(kept, t)
+	}
+	return kept
+}
+
+// writePerimeterWithTabs cuts the rectangular toolpath from the start
+// corner, lifting over the tabs. Reversed, it runs the sides in the opposite
+// order and direction.
+func (g *Generator) writePerimeterWithTabs(m *machine, x0, y0, x1, y1 float64, start int, depth float64, tabs []Tab, shape model.TabShape, reverse bool) {
+	tabDepth := depth - g.Settings.PartTabHeight
+	if tabDepth < 0 {
+		tabDepth = 0
+	}
+	profile := tabProfile(shape, g.Settings.PartTabWidth)
+
+	// Side s runs from corner s to corner s+1: bottom, right, top, left
+	corners := rectCorners(x0, y0, x1, y1)
+	lengths := [4]float64{x1 - x0, y1 - y0, x1 - x0, y1 - y0}
+	for i := 0; i < 4; i++ {
+		if reverse {
+			side := (start + 3 - i) % 4
+			a, b := corners[(side+1)%4], corners[side]
+			g.writeSideWithTabs(m, a[0], a[1], b[0], b[1], depth, tabDepth, profile,
+				reverseTabs(g.tabsForSide(tabs, side), lengths[side]))
+			continue
+		}
+		side := (start + i) % 4
+		a, b := corners[side], corners[(side+1)%4]
+		g.writeSideWithTabs(m, a[0], a[1], b[0], b[1], depth, tabDepth, profile, g.tabsForSide(tabs, side))
+	}
+}
+
+// reverseTabs returns the tabs of a side of the given length as positions
+// from its other end, in the order a reversed cut meets them.
+func reverseTabs(tabs []Tab, length float64) []Tab {
+	out := make([]Tab, len(tabs))
+	for i, t := range tabs {
+		out[len(tabs)-1-i] = Tab{side: t.side, startPos: length - t.startPos}
+	}
+	return out
+}
+
+func (g *Generator) tabsForSide(tabs []Tab, side int) []Tab {
+	var result []Tab
+	for _, t := range tabs {
+		if t.side == side {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+func (g *Generator) writeSideWithTabs(m *machine, x0, y0, x1, y1 float64,
+	cutDepth, tabDepth float64, profile []tabPoint, tabs []Tab) {
+
+	if len(tabs) == 0 {
+		m.feedAt(x1, y1, g.Settings.FeedRate)
+		return
+	}
+
+	dx := x1 - x0
+	dy := y1 - y0
+	length := math.Sqrt(dx*dx + dy*dy)
+	if length < 0.001 {
+		return
+	}
+	nx := dx / length
+	ny := dy / length
+	tabWidth := profile[len(profile)-1].at
+
+	// Walk along the side, following the tab profile over each tab
+	cursor := 0.0
+	for _, tab := range tabs {
+		tabStart := tab.startPos - tabWidth/2
+
+		// Cut to tab start
+		if tabStart > cursor {
+			px := x0 + nx*tabStart
+			py := y0 + ny*tabStart
+			m.feedAt(px, py, g.Settings.FeedRate)
+		}
+
+		for _, tp := range profile[1:] {
+			g.writeTabMove(m, x0+nx*(tabStart+tp.at), y0+ny*(tabStart+tp.at), tabZ(cutDepth, tabDepth, tp.lift))
+		}
+
+		cursor = tabStart + tabWidth
+	}
+
+	// Finish to end of side
+	m.feedAt(x1, y1, g.Settings.FeedRate)
+}
+
+// tabZ returns the Z coordinate at the given lift over a tab.
+func tabZ(cutDepth, tabDepth, lift float64) float64 {
+	return -(cutDepth + (tabDepth-cutDepth)*lift)
+}
+
+// writeTabMove feeds to the next point of a tab profile, leaving out the
+// axes that do not change.
+func (g *Generator) writeTabMove(m *machine, x, y, z float64) {
+	sameXY := math.Abs(m.x-x) < 1e-9 && math.Abs(m.y-y) < 1e-9
+	sameZ := math.Abs(m.z-z) < 1e-9
+	switch {
+	case sameXY && sameZ:
+	case sameXY:
+		m.feedZ(z, 0)
+	case sameZ:
+		m.feed(x, y)
+	default:
+		m.feedXYZ(x, y, z, 0)
+	}
+}
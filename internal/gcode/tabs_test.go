@@ -0,0 +1,101 @@
+package gcode
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/piwi3910/SlabCut/internal/model"
+)
+
+func TestCalculateTabs_HandPlaced(t *testing.T) {
+	settings := newTestSettings()
+	settings.PartTabWidth = 8
+	gen := New(settings)
+	p := newTestPlacement()
+	// One tab near the middle of the first edge, one on the right edge and
+	// one too close to a corner.
+	p.Part.Tabs = []model.PartTab{{X: 50, Y: 0}, {X: 100, Y: 30}, {X: 0, Y: 50}}
+
+	tabs := gen.calculateTabs(p, 0)
+	if len(tabs) != 3 {
+		t.Fatalf("expected 3 tabs, got %v", tabs)
+	}
+	// The toolpath starts tool radius (3mm) outside the part corner
+	want := []Tab{{side: 0, startPos: 53}, {side: 1, startPos: 33}, {side: 2, startPos: 102}}
+	for i, w := range want {
+		if tabs[i].side != w.side || math.Abs(tabs[i].startPos-w.startPos) > 1e-9 {
+			t.Errorf("tab %d = %+v, want %+v", i, tabs[i], w)
+		}
+	}
+
+	// Hand-placed tabs are honoured even when automatic tabs are off
+	code := gen.GenerateSheet(model.SheetResult{Stock: newTestSheet().Stock, Placements: []model.Placement{p}}, 1)
+	if got := strings.Count(code, "G1 Z-4.000\n"); got != 3 {
+		t.Errorf("expected 3 lifts over tabs, got %d", got)
+	}
+}
+
+func TestWriteSideWithTabs_Shapes(t *testing.T) {
+	settings := newTestSettings()
+	settings.PartTabWidth = 8
+	settings.PartTabHeight = 2
+	gen := New(settings)
+	tabs := []Tab{{side: 0, startPos: 50}}
+
+	tests := []struct {
+		shape model.TabShape
+		want  string
+	}{
+		{model.TabShapeRectangular, "G1 X46.000 Y0.000 F1000.000\nG1 Z-4.000\nG1 X54.000 Y0.000\nG1 Z-6.000\n"},
+		{model.TabShapeTrapezoid, "G1 X46.000 Y0.000 F1000.000\nG1 X48.000 Y0.000 Z-4.000\nG1 X52.000 Y0.000\nG1 X54.000 Y0.000 Z-6.000\n"},
+		{model.TabShapeTriangular, "G1 X46.000 Y0.000 F1000.000\nG1 X50.000 Y0.000 Z-4.000\nG1 X54.000 Y0.000 Z-6.000\n"},
+	}
+	for _, tt := range tests {
+		m := gen.newMachine()
+		gen.writeSideWithTabs(m, 0, 0, 100, 0, 6, 4, tabProfile(tt.shape, 8), tabs)
+		if !strings.HasPrefix(m.String(), tt.want) {
+			t.Errorf("%s: got\n%s\nwant prefix\n%s", tt.shape, m.String(), tt.want)
+		}
+	}
+}
+
+func TestOutlinePart_TriangularTabs(t *testing.T) {
+	settings := newTestSettings()
+	settings.PartTabsPerSide = 1
+	settings.PartTabWidth = 8
+	settings.PartTabShape = model.TabShapeTriangular
+	code := generateOutline(settings, newLShapePlacement())
+
+	if got := strings.Count(code, " Z-4.000\n"); got != 4 {
+		t.Errorf("expected each of the 4 tabs to peak once, got %d:\n%s", got, code)
+	}
+	if strings.Contains(code, "G1 Z-4.000") {
+		t.Error("triangular tabs should ramp, not lift straight up")
+	}
+}
+
+func TestPartTabs_MatchGenerator(t *testing.T) {
+	settings := newTestSettings()
+	settings.PartTabsPerSide = 1
+	gen := New(settings)
+
+	marks := gen.PartTabs(newTestPlacement())
+	if len(marks) != 4 {
+		t.Fatalf("expected 4 tab marks, got %d", len(marks))
+	}
+	// First tab is halfway along the 106mm bottom toolpath edge
+	if m := marks[0]; math.Abs(m.X-60) > 1e-9 || math.Abs(m.Y-7) > 1e-9 || m.DX != 1 {
+		t.Errorf("first mark = %+v, want centre (60, 7) heading +X", m)
+	}
+
+	outline := gen.PartTabs(newLShapePlacement())
+	if len(outline) != 4 {
+		t.Errorf("expected 4 outline tab marks, got %d", len(outline))
+	}
+
+	settings.PartTabsPerSide = 0
+	if marks := New(settings).PartTabs(newTestPlacement()); len(marks) != 0 {
+		t.Errorf("expected no marks with tabs off, got %d", len(marks))
+	}
+}
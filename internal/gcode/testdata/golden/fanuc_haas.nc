@@ -0,0 +1,56 @@
+%
+O0001
+( CNCCalculator GCode — Sheet 1 [Sample Sheet])
+( Stock: 600.0 x 400.0 mm)
+( Parts: 1, Efficiency: 8.3%)
+( Tool: 6.0mm, Feed: 1500 mm/min, Plunge: 500 mm/min)
+( Depth: 12.0mm in 6.0mm passes [2 passes])
+( Profile: Fanuc / Haas)
+N10 G90 G94 G17 G21 G40 G49 G80
+N20 G54
+N30 T1 M6
+N40 S18000 M3
+N50 G43 H1 Z5.000
+N60 G0 Z5.000
+N70 G0 X0.000 Y0.000
+
+( Drilling: Sample [1 holes])
+N80 G0 X150.000 Y100.000
+N90 G98 G83 X150.000 Y100.000 Z-10.000 R0.000 Q6.000 F500.000
+N100 G80
+N110 G0 Z5.000
+( --- Part 1: Sample [200.0 x 100.0] ---)
+( Pass 1/2, depth=6.00mm)
+( Lead-in arc)
+N120 G0 X42.000 Y47.000
+N130 G1 Z-6.000 F500.000
+N140 G3 X47.000 Y47.000 I5.000 J-5.000 F1500.000
+N150 G1 X253.000 Y47.000 F1500.000
+N160 G1 X253.000 Y153.000
+N170 G1 X47.000 Y153.000
+N180 G1 X47.000 Y47.000
+( Lead-out arc)
+N190 G3 X42.000 Y47.000 I0.000 J-5.000 F1500.000
+N200 G0 Z5.000
+( Pass 2/2, depth=12.00mm)
+( Lead-in arc)
+N210 G0 X42.000 Y47.000
+N220 G1 Z-12.000 F500.000
+N230 G3 X47.000 Y47.000 I5.000 J-5.000 F1500.000
+N240 G1 X253.000 Y47.000 F1500.000
+N250 G1 X253.000 Y153.000
+N260 G1 X47.000 Y153.000
+N270 G1 X47.000 Y47.000
+( Lead-out arc)
+N280 G3 X42.000 Y47.000 I0.000 J-5.000 F1500.000
+N290 G0 Z5.000
+
+
+( === Job complete ===)
+N300 G0 Z5.000
+N310 M5
+N320 G91 G28 Z0
+N330 G90
+N340 G0 X0 Y0
+N350 M30
+%
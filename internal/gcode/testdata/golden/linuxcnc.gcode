@@ -0,0 +1,53 @@
+; CNCCalculator GCode — Sheet 1 (Sample Sheet)
+; Stock: 600.0 x 400.0 mm
+; Parts: 1, Efficiency: 8.3%
+; Tool: 6.0mm, Feed: 1500 mm/min, Plunge: 500 mm/min
+; Depth: 12.0mm in 6.0mm passes (2 passes)
+; Profile: LinuxCNC
+G90
+G21
+G17
+G94
+M3 S18000
+G0 Z5.0000
+G0 X0.0000 Y0.0000
+
+; Drilling: Sample (1 holes)
+G0 X150.0000 Y100.0000
+G1 Z-6.0000 F500.0000
+G0 Z0.0000
+G1 Z-10.0000 F500.0000
+G0 Z0.0000
+G0 Z5.0000
+; --- Part 1: Sample (200.0 x 100.0) ---
+; Pass 1/2, depth=6.00mm
+; Lead-in arc
+G0 X42.0000 Y47.0000
+G1 Z-6.0000 F500.0000
+G3 X47.0000 Y47.0000 I5.0000 J-5.0000 F1500.0000
+G1 X253.0000 Y47.0000 F1500.0000
+G1 X253.0000 Y153.0000
+G1 X47.0000 Y153.0000
+G1 X47.0000 Y47.0000
+; Lead-out arc
+G3 X42.0000 Y47.0000 I0.0000 J-5.0000 F1500.0000
+G0 Z5.0000
+; Pass 2/2, depth=12.00mm
+; Lead-in arc
+G0 X42.0000 Y47.0000
+G1 Z-12.0000 F500.0000
+G3 X47.0000 Y47.0000 I5.0000 J-5.0000 F1500.0000
+G1 X253.0000 Y47.0000 F1500.0000
+G1 X253.0000 Y153.0000
+G1 X47.0000 Y153.0000
+G1 X47.0000 Y47.0000
+; Lead-out arc
+G3 X42.0000 Y47.0000 I0.0000 J-5.0000 F1500.0000
+G0 Z5.0000
+
+
+; === Job complete ===
+G0 Z5.0000
+G0 X0 Y0
+M5
+M2
@@ -0,0 +1,52 @@
+( CNCCalculator GCode — Sheet 1 [Sample Sheet])
+( Stock: 600.0 x 400.0 mm)
+( Parts: 1, Efficiency: 8.3%)
+( Tool: 6.0mm, Feed: 1500 mm/min, Plunge: 500 mm/min)
+( Depth: 12.0mm in 6.0mm passes [2 passes])
+( Profile: Masso)
+G90 G94 G17 G21
+G54
+T1 M6
+M3 S18000
+G0 Z5.000
+G0 X0.000 Y0.000
+
+( Drilling: Sample [1 holes])
+G0 X150.000 Y100.000
+G1 Z-6.000 F500.000
+G0 Z0.000
+G1 Z-10.000 F500.000
+G0 Z0.000
+G0 Z5.000
+( --- Part 1: Sample [200.0 x 100.0] ---)
+( Pass 1/2, depth=6.00mm)
+( Lead-in arc)
+G0 X42.000 Y47.000
+G1 Z-6.000 F500.000
+G3 X47.000 Y47.000 I5.000 J-5.000 F1500.000
+G1 X253.000 Y47.000 F1500.000
+G1 X253.000 Y153.000
+G1 X47.000 Y153.000
+G1 X47.000 Y47.000
+( Lead-out arc)
+G3 X42.000 Y47.000 I0.000 J-5.000 F1500.000
+G0 Z5.000
+( Pass 2/2, depth=12.00mm)
+( Lead-in arc)
+G0 X42.000 Y47.000
+G1 Z-12.000 F500.000
+G3 X47.000 Y47.000 I5.000 J-5.000 F1500.000
+G1 X253.000 Y47.000 F1500.000
+G1 X253.000 Y153.000
+G1 X47.000 Y153.000
+G1 X47.000 Y47.000
+( Lead-out arc)
+G3 X42.000 Y47.000 I0.000 J-5.000 F1500.000
+G0 Z5.000
+
+
+( === Job complete ===)
+G0 Z5.000
+M5
+G0 X0 Y0
+M30
@@ -25,20 +25,24 @@ type ImportResult struct {
 
 // ColumnMapping maps semantic column roles to their indices in the data.
 type ColumnMapping struct {
-	Label    int
-	Width    int
-	Height   int
-	Quantity int
-	Grain    int
+	Label     int
+	Width     int
+	Height    int
+	Quantity  int
+	Grain     int
+	Material  int
+	Thickness int
 }
 
 // headerAliases maps canonical column names to their accepted aliases (all lowercase).
 var headerAliases = map[string][]string{
-	"label":    {"label", "name", "part", "part name", "description", "desc", "piece", "item"},
-	"width":    {"width", "w", "length", "len", "x"},
-	"height":   {"height", "h", "depth", "d", "y"},
-	"quantity": {"quantity", "qty", "count", "num", "amount", "pcs", "pieces"},
-	"grain":    {"grain", "grain direction", "direction", "grain dir", "orientation"},
+	"label":     {"label", "name", "part", "part name", "description", "desc", "piece", "item"},
+	"width":     {"width", "w", "length", "len", "x"},
+	"height":    {"height", "h", "depth", "d", "y"},
+	"quantity":  {"quantity", "qty", "count", "num", "amount", "pcs", "pieces"},
+	"grain":     {"grain", "grain direction", "direction", "grain dir", "orientation"},
+	"material":  {"material", "mat", "board", "board type"},
+	"thickness": {"thickness", "thick", "thk", "t", "thickness (mm)"},
 }
 
 // DetectCSVDelimiter reads the file content and determines the most likely CSV delimiter.
@@ -91,11 +95,13 @@ func DetectCSVDelimiter(data []byte) rune {
 // mapping and false if no header was found.
 func DetectColumns(row []string) (ColumnMapping, bool) {
 	mapping := ColumnMapping{
-		Label:    -1,
-		Width:    -1,
-		Height:   -1,
-		Quantity: -1,
-		Grain:    -1,
+		Label:     -1,
+		Width:     -1,
+		Height:    -1,
+		Quantity:  -1,
+		Grain:     -1,
+		Material:  -1,
+		Thickness: -1,
 	}
 
 	isHeader := false
@@ -126,6 +132,14 @@ func DetectColumns(row []string) (ColumnMapping, bool) {
 						if mapping.Grain == -1 {
 							mapping.Grain = i
 						}
+					case "material":
+						if mapping.Material == -1 {
+							mapping.Material = i
+						}
+					case "thickness":
+						if mapping.Thickness == -1 {
+							mapping.Thickness = i
+						}
 					}
 				}
 			}
@@ -133,13 +147,17 @@ func DetectColumns(row []string) (ColumnMapping, bool) {
 	}
 
 	if !isHeader {
-		// Fall back to positional mapping: Label, Width, Height, Quantity, Grain
+		// Fall back to positional mapping: Label, Width, Height, Quantity, Grain.
+		// Material and Thickness are only read from named columns, so notes in
+		// trailing columns are never taken for them.
 		return ColumnMapping{
-			Label:    0,
-			Width:    1,
-			Height:   2,
-			Quantity: 3,
-			Grain:    4,
+			Label:     0,
+			Width:     1,
+			Height:    2,
+			Quantity:  3,
+			Grain:     4,
+			Material:  -1,
+			Thickness: -1,
 		}, false
 	}
 
@@ -223,6 +241,20 @@ func parseRow(row []string, mapping ColumnMapping, rowLabel string, partCount in
 		}
 	}
 
+	// Optional material and thickness
+	part.Material = getCell(row, mapping.Material)
+	thicknessStr := strings.TrimSuffix(getCell(row, mapping.Thickness), "mm")
+	if thicknessStr = strings.TrimSpace(thicknessStr); thicknessStr != "" {
+		thickness, err := strconv.ParseFloat(thicknessStr, 64)
+		if err != nil || thickness < 0 {
+			if warning == "" {
+				warning = fmt.Sprintf("%s: Invalid thickness '%s', ignoring", rowLabel, thicknessStr)
+			}
+		} else {
+			part.Thickness = thickness
+		}
+	}
+
 	return part, "", warning
 }
 
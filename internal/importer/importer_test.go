@@ -374,6 +374,61 @@ func TestImportCSVFromReader_GrainParsing(t *testing.T) {
 	}
 }
 
+func TestImportCSVFromReader_MaterialAndThickness(t *testing.T) {
+	data := "Label,Width,Height,Qty,Material,Thickness\nSide,720,560,2,MDF,18\nBack,720,800,1,HDF,3 mm\nShelf,500,300,1,,\n"
+	result := ImportCSVFromReader(strings.NewReader(data), ',')
+
+	if len(result.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	if len(result.Parts) != 3 {
+		t.Fatalf("expected 3 parts, got %d", len(result.Parts))
+	}
+	if result.Parts[0].Material != "MDF" || result.Parts[0].Thickness != 18 {
+		t.Errorf("expected MDF 18, got %q %.1f", result.Parts[0].Material, result.Parts[0].Thickness)
+	}
+	if result.Parts[1].Material != "HDF" || result.Parts[1].Thickness != 3 {
+		t.Errorf("expected HDF 3, got %q %.1f", result.Parts[1].Material, result.Parts[1].Thickness)
+	}
+	if result.Parts[2].Material != "" || result.Parts[2].Thickness != 0 {
+		t.Errorf("expected no material or thickness, got %q %.1f", result.Parts[2].Material, result.Parts[2].Thickness)
+	}
+}
+
+func TestImportCSVFromReader_InvalidThickness(t *testing.T) {
+	data := "Label,Width,Height,Quantity,Thick\nSide,720,560,2,thick\n"
+	result := ImportCSVFromReader(strings.NewReader(data), ',')
+
+	if len(result.Parts) != 1 {
+		t.Fatalf("expected 1 part, got %d", len(result.Parts))
+	}
+	if result.Parts[0].Thickness != 0 {
+		t.Errorf("expected thickness to be ignored, got %.1f", result.Parts[0].Thickness)
+	}
+	found := false
+	for _, w := range result.Warnings {
+		if strings.Contains(w, "Invalid thickness") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected an invalid thickness warning, got %v", result.Warnings)
+	}
+}
+
+func TestImportCSVFromReader_PositionalIgnoresTrailingColumns(t *testing.T) {
+	// Without a header, trailing notes are not read as material or thickness
+	data := "Side,720,560,2,none,left of sink,15\n"
+	result := ImportCSVFromReader(strings.NewReader(data), ',')
+
+	if len(result.Parts) != 1 {
+		t.Fatalf("expected 1 part, got %d (errors: %v)", len(result.Parts), result.Errors)
+	}
+	if result.Parts[0].Material != "" || result.Parts[0].Thickness != 0 {
+		t.Errorf("expected no material or thickness, got %q %.1f", result.Parts[0].Material, result.Parts[0].Thickness)
+	}
+}
+
 func TestImportCSVFromReader_MissingRequiredColumnInHeader(t *testing.T) {
 	data := "Label,Width,Grain\nShelf,600,H\n"
 	result := ImportCSVFromReader(strings.NewReader(data), ',')
@@ -515,6 +570,22 @@ func TestImportExcel_WithHeaders(t *testing.T) {
 	}
 }
 
+func TestImportExcel_MaterialAndThickness(t *testing.T) {
+	path := createTestExcel(t, [][]interface{}{
+		{"Name", "W", "H", "Qty", "Material", "Thickness"},
+		{"Side", 720, 560, 2, "MDF", 18},
+	})
+
+	result := ImportExcel(path)
+
+	if len(result.Parts) != 1 {
+		t.Fatalf("expected 1 part, got %d (errors: %v)", len(result.Parts), result.Errors)
+	}
+	if result.Parts[0].Material != "MDF" || result.Parts[0].Thickness != 18 {
+		t.Errorf("expected MDF 18, got %q %.1f", result.Parts[0].Material, result.Parts[0].Thickness)
+	}
+}
+
 func TestImportExcel_WithoutHeaders(t *testing.T) {
 	path := createTestExcel(t, [][]interface{}{
 		{"Shelf", 600, 300, 2},
@@ -0,0 +1,87 @@
+package model
+
+// CatalogSheet is a sheet size offered by a supplier.
+type CatalogSheet struct {
+	Name        string  `json:"name"`
+	Width       float64 `json:"width"`  // mm
+	Height      float64 `json:"height"` // mm
+	Thickness   float64 `json:"thickness,omitempty"`
+	Material    string  `json:"material,omitempty"`
+	Grain       Grain   `json:"grain"`
+	Price       float64 `json:"price"`                   // Price per sheet
+	MinOrderQty int     `json:"min_order_qty,omitempty"` // Minimum sheets per order (0 or 1 = no minimum)
+}
+
+// CatalogSheetFromPreset creates a catalog entry from an inventory stock preset.
+func CatalogSheetFromPreset(sp StockPreset) CatalogSheet {
+	return CatalogSheet{
+		Name:        sp.Name,
+		Width:       sp.Width,
+		Height:      sp.Height,
+		Material:    sp.Material,
+		Price:       sp.PricePerSheet,
+		MinOrderQty: sp.MinOrderQty,
+	}
+}
+
+// ToStockSheet converts the catalog entry into stock for optimization.
+func (c CatalogSheet) ToStockSheet(qty int) StockSheet {
+	s := NewStockSheet(c.Name, c.Width, c.Height, qty)
+	if c.Thickness > 0 {
+		s.Thickness = c.Thickness
+	}
+	s.Material = c.Material
+	s.Grain = c.Grain
+	s.PricePerSheet = c.Price
+	return s
+}
+
+// OrderQuantity returns how many sheets must be bought to use the given
+// number, respecting the minimum order quantity.
+func (c CatalogSheet) OrderQuantity(used int) int {
+	if used > 0 && used < c.MinOrderQty {
+		return c.MinOrderQty
+	}
+	return used
+}
+
+// BuyListLine is one catalog size in a buy list.
+type BuyListLine struct {
+	Sheet CatalogSheet `json:"sheet"`
+	Used  int          `json:"used"` // Sheets the layout cuts from
+	Buy   int          `json:"buy"`  // Sheets to order (at least the minimum order quantity)
+	Cost  float64      `json:"cost"` // Buy x price
+}
+
+// BuyList is the cheapest combination of catalog sheets found for a cut
+// list, together with the optimization that proves it packs every part.
+type BuyList struct {
+	Lines        []BuyListLine  `json:"lines"`
+	Result       OptimizeResult `json:"result"`
+	SheetCost    float64        `json:"sheet_cost"`           // Total price of sheets to order
+	OffcutCredit float64        `json:"offcut_credit"`        // Value credited for reusable offcuts and surplus sheets
+	Incomplete   bool           `json:"incomplete,omitempty"` // The search ran out of time before trying every combination
+}
+
+// NetCost returns the sheet cost less the offcut credit.
+func (b BuyList) NetCost() float64 {
+	return b.SheetCost - b.OffcutCredit
+}
+
+// TotalSheets returns the number of sheets to order.
+func (b BuyList) TotalSheets() int {
+	var total int
+	for _, l := range b.Lines {
+		total += l.Buy
+	}
+	return total
+}
+
+// Stocks returns the buy list as stock sheets for the project.
+func (b BuyList) Stocks() []StockSheet {
+	stocks := make([]StockSheet, 0, len(b.Lines))
+	for _, l := range b.Lines {
+		stocks = append(stocks, l.Sheet.ToStockSheet(l.Buy))
+	}
+	return stocks
+}
@@ -0,0 +1,29 @@
+package model
+
+import "testing"
+
+func TestCatalogSheet_OrderQuantity(t *testing.T) {
+	c := CatalogSheet{Name: "Bulk", Width: 2440, Height: 1220, Price: 40, MinOrderQty: 5}
+	cases := map[int]int{0: 0, 1: 5, 5: 5, 7: 7}
+	for used, want := range cases {
+		if got := c.OrderQuantity(used); got != want {
+			t.Errorf("OrderQuantity(%d) = %d, want %d", used, got, want)
+		}
+	}
+}
+
+func TestBuyList_CostsAndStocks(t *testing.T) {
+	full := CatalogSheet{Name: "Full", Width: 2440, Height: 1220, Price: 50, Material: "MDF"}
+	b := BuyList{
+		Lines:        []BuyListLine{{Sheet: full, Used: 2, Buy: 3, Cost: 150}},
+		SheetCost:    150,
+		OffcutCredit: 20,
+	}
+	if b.NetCost() != 130 || b.TotalSheets() != 3 {
+		t.Errorf("NetCost = %v, TotalSheets = %d", b.NetCost(), b.TotalSheets())
+	}
+	stocks := b.Stocks()
+	if len(stocks) != 1 || stocks[0].Quantity != 3 || stocks[0].PricePerSheet != 50 || stocks[0].Material != "MDF" {
+		t.Errorf("unexpected stocks %+v", stocks)
+	}
+}
@@ -0,0 +1,368 @@
+package model
+
+import (
+	"fmt"
+	"math"
+)
+
+// CabinetType selects the overall construction of a generated cabinet.
+type CabinetType string
+
+const (
+	CabinetBase CabinetType = "base" // Floor-standing, usually with a toe-kick
+	CabinetWall CabinetType = "wall" // Wall-hung, no toe-kick
+	CabinetTall CabinetType = "tall" // Floor-standing pantry/larder unit
+)
+
+// CabinetTypeOptions returns the available cabinet types for UI display.
+func CabinetTypeOptions() []string {
+	return []string{"Base", "Wall", "Tall"}
+}
+
+// CabinetTypeFromString converts a display string to a CabinetType.
+func CabinetTypeFromString(s string) CabinetType {
+	switch s {
+	case "Wall":
+		return CabinetWall
+	case "Tall":
+		return CabinetTall
+	default:
+		return CabinetBase
+	}
+}
+
+// String returns the display name for a CabinetType.
+func (c CabinetType) String() string {
+	switch c {
+	case CabinetWall:
+		return "Wall"
+	case CabinetTall:
+		return "Tall"
+	default:
+		return "Base"
+	}
+}
+
+// BackPanelStyle selects how the back panel is fitted to the carcass.
+type BackPanelStyle string
+
+const (
+	BackInset   BackPanelStyle = "inset"   // Fitted between sides, top and bottom
+	BackOverlay BackPanelStyle = "overlay" // Nailed or screwed over the rear of the carcass
+	BackNone    BackPanelStyle = "none"    // Open back
+)
+
+// BackPanelStyleOptions returns the available back panel styles for UI display.
+func BackPanelStyleOptions() []string {
+	return []string{"Inset", "Overlay", "None"}
+}
+
+// BackPanelStyleFromString converts a display string to a BackPanelStyle.
+func BackPanelStyleFromString(s string) BackPanelStyle {
+	switch s {
+	case "Overlay":
+		return BackOverlay
+	case "None":
+		return BackNone
+	default:
+		return BackInset
+	}
+}
+
+// String returns the display name for a BackPanelStyle.
+func (b BackPanelStyle) String() string {
+	switch b {
+	case BackOverlay:
+		return "Overlay"
+	case BackNone:
+		return "None"
+	default:
+		return "Inset"
+	}
+}
+
+// Cabinet hardware constants (mm). Shelf pins follow the 32 mm system and
+// hinge cups are standard 35 mm concealed hinges.
+const (
+	shelfPinDiameter  = 5.0
+	shelfPinDepth     = 10.0
+	shelfPinSetback   = 37.0 // Distance of each pin row from the panel edge
+	shelfPinPitch     = 32.0
+	shelfPinMargin    = 64.0 // Keep pins this far from the bottom and top panels
+	hingeCupDiameter  = 35.0
+	hingeCupDepth     = 13.0
+	hingeCupEdge      = 22.5  // Cup centre from the hinge edge of the door
+	hingeCupEnd       = 100.0 // Cup centre from the top and bottom of the door
+	shelfClearance    = 2.0   // Total side play for loose shelves
+	shelfFrontSetback = 20.0  // Shelves sit back from the carcass front
+)
+
+// CabinetParams describes a frameless (32 mm system) cabinet. All dimensions
+// are overall outside sizes in mm.
+type CabinetParams struct {
+	Name          string         `json:"name"`
+	Type          CabinetType    `json:"type"`
+	Width         float64        `json:"width"`
+	Height        float64        `json:"height"` // Including the toe-kick
+	Depth         float64        `json:"depth"`  // Including the back panel
+	Thickness     float64        `json:"thickness"`
+	BackStyle     BackPanelStyle `json:"back_style"`
+	BackThickness float64        `json:"back_thickness"`
+	ShelfCount    int            `json:"shelf_count"`
+	ToeKick       float64        `json:"toe_kick"`   // Toe-kick height; ignored for wall cabinets
+	DoorCount     int            `json:"door_count"` // 0, 1 or 2 full-overlay doors
+	DoorGap       float64        `json:"door_gap"`   // Reveal around and between doors
+	Material      string         `json:"material,omitempty"`
+}
+
+// DefaultCabinetParams returns a typical 600 mm base cabinet.
+func DefaultCabinetParams() CabinetParams {
+	return CabinetParams{
+		Name:          "Base Cabinet",
+		Type:          CabinetBase,
+		Width:         600,
+		Height:        870,
+		Depth:         560,
+		Thickness:     18,
+		BackStyle:     BackInset,
+		BackThickness: 8,
+		ShelfCount:    1,
+		ToeKick:       100,
+		DoorCount:     1,
+		DoorGap:       2,
+	}
+}
+
+// toeKick returns the effective toe-kick height for the cabinet type.
+func (c CabinetParams) toeKick() float64 {
+	if c.Type == CabinetWall {
+		return 0
+	}
+	return c.ToeKick
+}
+
+// sideDepth returns the depth of the sides: the full depth, except behind
+// an overlay back, which covers their back edges.
+func (c CabinetParams) sideDepth() float64 {
+	if c.BackStyle == BackOverlay {
+		return c.Depth - c.BackThickness
+	}
+	return c.Depth
+}
+
+// panelDepth returns the depth of the top and bottom panels,
+// which stop in front of the back panel.
+func (c CabinetParams) panelDepth() float64 {
+	if c.BackStyle == BackNone {
+		return c.Depth
+	}
+	return c.Depth - c.BackThickness
+}
+
+// InteriorHeight returns the clear height between the bottom and top panels.
+func (c CabinetParams) InteriorHeight() float64 {
+	return c.Height - c.toeKick() - 2*c.Thickness
+}
+
+// InteriorWidth returns the clear width between the sides.
+func (c CabinetParams) InteriorWidth() float64 {
+	return c.Width - 2*c.Thickness
+}
+
+// Validate checks that the parameters describe a buildable cabinet.
+func (c CabinetParams) Validate() error {
+	if c.Width <= 0 || c.Height <= 0 || c.Depth <= 0 {
+		return fmt.Errorf("width, height and depth must be > 0")
+	}
+	if c.Thickness <= 0 {
+		return fmt.Errorf("material thickness must be > 0")
+	}
+	if c.ShelfCount < 0 {
+		return fmt.Errorf("shelf count cannot be negative")
+	}
+	if c.DoorCount < 0 || c.DoorCount > 2 {
+		return fmt.Errorf("door count must be 0, 1 or 2")
+	}
+	if c.toeKick() < 0 || c.DoorGap < 0 {
+		return fmt.Errorf("toe-kick and door gap cannot be negative")
+	}
+	if c.BackStyle != BackNone && (c.BackThickness <= 0 || c.BackThickness >= c.Depth) {
+		return fmt.Errorf("back thickness must be > 0 and less than the depth")
+	}
+	if c.InteriorWidth() <= 0 {
+		return fmt.Errorf("width %.0f is too small for %.0f mm sides", c.Width, c.Thickness)
+	}
+	if c.InteriorHeight() <= 0 {
+		return fmt.Errorf("height %.0f is too small for the toe-kick and top/bottom panels", c.Height)
+	}
+	return nil
+}
+
+// GenerateCabinet produces the cut parts for a cabinet: two sides, top,
+// bottom, shelves, back, toe-kick and doors. Part quantities are for one
+// cabinet. Parts carry the carcass thickness, except the back which carries
+// BackThickness so it is cut from thinner stock. Visible front edges are edge banded, carcass panels carry
+// shelf-pin holes and doors carry hinge cup bores.
+//
+// Panels are laid out with their width along X and height along Y, drilled
+// face up. The left side has its front edge on the right and the right side
+// is its mirror image, so each is drilled on the face towards the interior.
+func GenerateCabinet(c CabinetParams) ([]Part, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	name := c.Name
+	if name == "" {
+		name = c.Type.String() + " Cabinet"
+	}
+	kick := c.toeKick()
+	depth := c.panelDepth()
+	sideDepth := c.sideDepth()
+	innerW := c.InteriorWidth()
+	carcassH := c.Height - kick
+
+	var parts []Part
+	add := func(label string, w, h, thickness float64, qty int, grain Grain, banding EdgeBanding, drills []DrillHole) {
+		p := NewPart(name+" - "+label, w, h, qty)
+		p.Grain = grain
+		p.Material = c.Material
+		p.Thickness = thickness
+		p.EdgeBanding = banding
+		p.Drills = drills
+		parts = append(parts, p)
+	}
+
+	// Sides run the full height. An inset back sits between them, behind
+	// the top and bottom; an overlay back covers their back edges.
+	pins := c.shelfPinHoles(kick)
+	mirrored := make([]DrillHole, len(pins))
+	for i, h := range pins {
+		h.X = sideDepth - h.X
+		mirrored[i] = h
+	}
+	add("Side Left", sideDepth, c.Height, c.Thickness, 1, GrainVertical, EdgeBanding{Right: true}, pins)
+	add("Side Right", sideDepth, c.Height, c.Thickness, 1, GrainVertical, EdgeBanding{Left: true}, mirrored)
+
+	// Top and bottom sit between the sides.
+	add("Bottom", innerW, depth, c.Thickness, 1, GrainHorizontal, EdgeBanding{Bottom: true}, nil)
+	add("Top", innerW, depth, c.Thickness, 1, GrainHorizontal, EdgeBanding{Bottom: true}, nil)
+
+	if c.ShelfCount > 0 {
+		add("Shelf", innerW-shelfClearance, depth-shelfFrontSetback, c.Thickness, c.ShelfCount,
+			GrainHorizontal, EdgeBanding{Bottom: true}, nil)
+	}
+
+	switch c.BackStyle {
+	case BackInset:
+		add("Back", innerW, carcassH, c.BackThickness, 1, GrainVertical, EdgeBanding{}, nil)
+	case BackOverlay:
+		add("Back", c.Width, carcassH, c.BackThickness, 1, GrainVertical, EdgeBanding{}, nil)
+	}
+
+	if kick > 0 {
+		add("Toe Kick", innerW, kick, c.Thickness, 1, GrainHorizontal, EdgeBanding{}, nil)
+	}
+
+	if c.DoorCount > 0 {
+		n := float64(c.DoorCount)
+		doorW := (c.Width - (n+1)*c.DoorGap) / n
+		doorH := c.Height - kick - 2*c.DoorGap
+		if doorW <= 2*hingeCupEdge || doorH <= 2*hingeCupEnd {
+			return nil, fmt.Errorf("doors of %.0f x %.0f mm are too small for hinges", doorW, doorH)
+		}
+		allEdges := EdgeBanding{Top: true, Bottom: true, Left: true, Right: true}
+		if c.DoorCount == 1 {
+			add("Door", doorW, doorH, c.Thickness, 1, GrainVertical, allEdges, hingeCupHoles(doorW, doorH, false))
+		} else {
+			add("Door Left", doorW, doorH, c.Thickness, 1, GrainVertical, allEdges, hingeCupHoles(doorW, doorH, false))
+			add("Door Right", doorW, doorH, c.Thickness, 1, GrainVertical, allEdges, hingeCupHoles(doorW, doorH, true))
+		}
+	}
+
+	return parts, nil
+}
+
+// shelfPinHoles returns two rows of shelf-pin holes on the 32 mm pitch for
+// the left side, one near the front (right edge) and one near the back.
+func (c CabinetParams) shelfPinHoles(kick float64) []DrillHole {
+	if c.ShelfCount == 0 {
+		return nil
+	}
+	// Side coordinates: Y grows downward from the top of the cabinet.
+	first := c.Thickness + shelfPinMargin
+	last := c.Height - kick - c.Thickness - shelfPinMargin
+	if last < first {
+		return nil
+	}
+	back := shelfPinSetback
+	if c.BackStyle == BackInset {
+		back += c.BackThickness
+	}
+	front := c.sideDepth() - shelfPinSetback
+	if front <= back {
+		return nil
+	}
+
+	var holes []DrillHole
+	for y := first; y <= last+1e-9; y += shelfPinPitch {
+		for _, x := range []float64{back, front} {
+			holes = append(holes, DrillHole{X: x, Y: y, Diameter: shelfPinDiameter, Depth: shelfPinDepth})
+		}
+	}
+	return holes
+}
+
+// hingeCupHoles returns the concealed hinge bores for a door. Doors taller
+// than 900 mm get a third hinge and doors taller than 1600 mm a fourth.
+// Hinges are on the left edge unless right is set.
+func hingeCupHoles(doorW, doorH float64, right bool) []DrillHole {
+	count := 2
+	if doorH > 900 {
+		count++
+	}
+	if doorH > 1600 {
+		count++
+	}
+	x := hingeCupEdge
+	if right {
+		x = doorW - hingeCupEdge
+	}
+	span := doorH - 2*hingeCupEnd
+	holes := make([]DrillHole, count)
+	for i := range holes {
+		y := hingeCupEnd + span*float64(i)/float64(count-1)
+		holes[i] = DrillHole{X: x, Y: math.Round(y*10) / 10, Diameter: hingeCupDiameter, Depth: hingeCupDepth}
+	}
+	return holes
+}
+
+// NewCabinetTemplate creates a parametric template that regenerates its
+// parts from params whenever it is instantiated.
+func NewCabinetTemplate(name, description string, params CabinetParams, stocks []StockSheet, settings CutSettings) (ProjectTemplate, error) {
+	parts, err := GenerateCabinet(params)
+	if err != nil {
+		return ProjectTemplate{}, err
+	}
+	t := NewProjectTemplate(name, description, parts, stocks, settings)
+	t.Cabinet = &params
+	return t, nil
+}
+
+// NewCabinetProduct generates a cabinet and wraps its parts in a product so
+// that quantity, labels and quoting treat the cabinet as one unit.
+func NewCabinetProduct(params CabinetParams, qty int) (Product, error) {
+	parts, err := GenerateCabinet(params)
+	if err != nil {
+		return Product{}, err
+	}
+	name := params.Name
+	if name == "" {
+		name = params.Type.String() + " Cabinet"
+	}
+	prod := NewProduct(name, qty)
+	prod.Parts = parts
+	prod.Notes = fmt.Sprintf("%s cabinet %.0f x %.0f x %.0f mm, %.0f mm %s",
+		params.Type, params.Width, params.Height, params.Depth, params.Thickness, params.Material)
+	return prod, nil
+}
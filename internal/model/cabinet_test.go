@@ -0,0 +1,270 @@
+package model
+
+import (
+	"strings"
+	"testing"
+)
+
+func findCabinetPart(t *testing.T, parts []Part, suffix string) Part {
+	t.Helper()
+	for _, p := range parts {
+		if strings.HasSuffix(p.Label, " - "+suffix) {
+			return p
+		}
+	}
+	t.Fatalf("no part labelled %q in %d parts", suffix, len(parts))
+	return Part{}
+}
+
+func TestGenerateCabinet_BaseDimensions(t *testing.T) {
+	params := DefaultCabinetParams()
+	params.ShelfCount = 2
+	params.DoorCount = 2
+
+	parts, err := GenerateCabinet(params)
+	if err != nil {
+		t.Fatalf("GenerateCabinet error: %v", err)
+	}
+
+	side := findCabinetPart(t, parts, "Side Left")
+	if side.Width != 560 || side.Height != 870 || side.Grain != GrainVertical {
+		t.Errorf("unexpected side %.0f x %.0f grain %v", side.Width, side.Height, side.Grain)
+	}
+	if !side.EdgeBanding.Right || side.EdgeBanding.EdgeCount() != 1 {
+		t.Errorf("expected side banded on the front edge only, got %+v", side.EdgeBanding)
+	}
+
+	bottom := findCabinetPart(t, parts, "Bottom")
+	if bottom.Width != 564 || bottom.Height != 552 {
+		t.Errorf("expected bottom 564 x 552, got %.0f x %.0f", bottom.Width, bottom.Height)
+	}
+
+	shelf := findCabinetPart(t, parts, "Shelf")
+	if shelf.Quantity != 2 || shelf.Width != 562 || shelf.Height != 532 {
+		t.Errorf("unexpected shelf %.0f x %.0f x%d", shelf.Width, shelf.Height, shelf.Quantity)
+	}
+
+	back := findCabinetPart(t, parts, "Back")
+	if back.Width != 564 || back.Height != 770 {
+		t.Errorf("expected inset back 564 x 770, got %.0f x %.0f", back.Width, back.Height)
+	}
+	if side.Thickness != 18 || back.Thickness != 8 {
+		t.Errorf("expected side 18 mm and back 8 mm thick, got %.0f and %.0f", side.Thickness, back.Thickness)
+	}
+
+	kick := findCabinetPart(t, parts, "Toe Kick")
+	if kick.Height != 100 {
+		t.Errorf("expected toe kick height 100, got %.0f", kick.Height)
+	}
+
+	door := findCabinetPart(t, parts, "Door Left")
+	if door.Width != 297 || door.Height != 766 {
+		t.Errorf("expected door 297 x 766, got %.0f x %.0f", door.Width, door.Height)
+	}
+	if door.EdgeBanding.EdgeCount() != 4 {
+		t.Errorf("expected door banded on all edges, got %+v", door.EdgeBanding)
+	}
+}
+
+func TestGenerateCabinet_Drilling(t *testing.T) {
+	parts, err := GenerateCabinet(DefaultCabinetParams())
+	if err != nil {
+		t.Fatalf("GenerateCabinet error: %v", err)
+	}
+
+	left := findCabinetPart(t, parts, "Side Left")
+	right := findCabinetPart(t, parts, "Side Right")
+	if len(left.Drills) == 0 || len(left.Drills)%2 != 0 {
+		t.Fatalf("expected two rows of shelf pin holes, got %d", len(left.Drills))
+	}
+	for i, h := range left.Drills {
+		if h.Diameter != 5 {
+			t.Errorf("expected 5mm shelf pins, got %.1f", h.Diameter)
+		}
+		if h.Y < 18+64 || h.Y > 870-100-18-64 {
+			t.Errorf("shelf pin at y=%.1f is outside the shelf zone", h.Y)
+		}
+		if mirrored := right.Drills[i]; mirrored.X != left.Width-h.X || mirrored.Y != h.Y {
+			t.Errorf("right side hole %d is not mirrored: %+v vs %+v", i, mirrored, h)
+		}
+	}
+
+	door := findCabinetPart(t, parts, "Door")
+	if len(door.Drills) != 2 {
+		t.Fatalf("expected 2 hinge cups, got %d", len(door.Drills))
+	}
+	if door.Drills[0].Diameter != 35 || door.Drills[0].X != 22.5 || door.Drills[0].Y != 100 {
+		t.Errorf("unexpected hinge cup %+v", door.Drills[0])
+	}
+}
+
+func TestGenerateCabinet_WallAndTall(t *testing.T) {
+	params := DefaultCabinetParams()
+	params.Type = CabinetWall
+	params.Height = 720
+	params.Depth = 320
+	params.BackStyle = BackOverlay
+
+	parts, err := GenerateCabinet(params)
+	if err != nil {
+		t.Fatalf("GenerateCabinet error: %v", err)
+	}
+	for _, p := range parts {
+		if strings.HasSuffix(p.Label, "Toe Kick") {
+			t.Error("wall cabinets should not have a toe kick")
+		}
+	}
+	back := findCabinetPart(t, parts, "Back")
+	if back.Width != 600 || back.Height != 720 {
+		t.Errorf("expected overlay back 600 x 720, got %.0f x %.0f", back.Width, back.Height)
+	}
+
+	params = DefaultCabinetParams()
+	params.Type = CabinetTall
+	params.Height = 2100
+	params.DoorCount = 1
+	parts, err = GenerateCabinet(params)
+	if err != nil {
+		t.Fatalf("GenerateCabinet error: %v", err)
+	}
+	if door := findCabinetPart(t, parts, "Door"); len(door.Drills) != 4 {
+		t.Errorf("expected 4 hinges on a tall door, got %d", len(door.Drills))
+	}
+}
+
+func TestGenerateCabinet_BackPanelsFit(t *testing.T) {
+	for _, style := range []BackPanelStyle{BackInset, BackOverlay, BackNone} {
+		params := DefaultCabinetParams()
+		params.BackStyle = style
+		parts, err := GenerateCabinet(params)
+		if err != nil {
+			t.Fatalf("back style %v: GenerateCabinet error: %v", style, err)
+		}
+		side := findCabinetPart(t, parts, "Side Left")
+		top := findCabinetPart(t, parts, "Top")
+
+		switch style {
+		case BackInset:
+			// Between the sides, from under the bottom to over the top,
+			// behind the top and bottom
+			back := findCabinetPart(t, parts, "Back")
+			if back.Width != top.Width || back.Height != 870-100 {
+				t.Errorf("inset back: expected %.0f x 770, got %.0f x %.0f", top.Width, back.Width, back.Height)
+			}
+			if side.Width != 560 || top.Height+back.Thickness != side.Width {
+				t.Errorf("inset back: expected side 560 deep and top 8 shorter, got %.0f and %.0f", side.Width, top.Height)
+			}
+		case BackOverlay:
+			// Across the whole back of the carcass, on the sides' back edges
+			back := findCabinetPart(t, parts, "Back")
+			if back.Width != 600 || back.Height != 870-100 {
+				t.Errorf("overlay back: expected 600 x 770, got %.0f x %.0f", back.Width, back.Height)
+			}
+			if side.Width+back.Thickness != 560 || top.Height != side.Width {
+				t.Errorf("overlay back: expected sides and top 552 deep, got %.0f and %.0f", side.Width, top.Height)
+			}
+			for _, h := range side.Drills {
+				if h.X > side.Width {
+					t.Errorf("overlay back: shelf pin at x=%.0f is off the %.0f mm side", h.X, side.Width)
+				}
+			}
+		case BackNone:
+			for _, p := range parts {
+				if strings.HasSuffix(p.Label, " - Back") {
+					t.Error("no back panel expected")
+				}
+			}
+			if side.Width != 560 || top.Height != 560 {
+				t.Errorf("no back: expected sides and top 560 deep, got %.0f and %.0f", side.Width, top.Height)
+			}
+		}
+	}
+}
+
+func TestCabinetParams_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*CabinetParams)
+	}{
+		{"zero width", func(c *CabinetParams) { c.Width = 0 }},
+		{"too narrow", func(c *CabinetParams) { c.Width = 30 }},
+		{"too many doors", func(c *CabinetParams) { c.DoorCount = 3 }},
+		{"negative shelves", func(c *CabinetParams) { c.ShelfCount = -1 }},
+		{"toe kick too tall", func(c *CabinetParams) { c.ToeKick = 900 }},
+		{"back too thick", func(c *CabinetParams) { c.BackThickness = 600 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := DefaultCabinetParams()
+			tt.modify(&params)
+			if _, err := GenerateCabinet(params); err == nil {
+				t.Error("expected validation error")
+			}
+		})
+	}
+}
+
+func TestCabinetTemplate_ToProject(t *testing.T) {
+	params := DefaultCabinetParams()
+	tmpl, err := NewCabinetTemplate("Base 600", "", params, nil, DefaultSettings())
+	if err != nil {
+		t.Fatalf("NewCabinetTemplate error: %v", err)
+	}
+	if !tmpl.IsParametric() {
+		t.Fatal("expected cabinet template to be parametric")
+	}
+
+	proj, err := tmpl.ToProject("Kitchen", nil)
+	if err != nil {
+		t.Fatalf("ToProject error: %v", err)
+	}
+	if len(proj.Parts) != 0 {
+		t.Errorf("expected no loose parts, got %d", len(proj.Parts))
+	}
+	if len(proj.Products) != 1 || proj.Products[0].Name != "Base Cabinet" {
+		t.Fatalf("expected one cabinet product, got %+v", proj.Products)
+	}
+	if len(proj.Products[0].Parts) != len(tmpl.Parts) {
+		t.Errorf("expected %d product parts, got %d", len(tmpl.Parts), len(proj.Products[0].Parts))
+	}
+	for _, p := range proj.AllParts() {
+		if p.ProductName != "Base Cabinet" {
+			t.Errorf("part %q not tagged with the cabinet product", p.Label)
+		}
+	}
+}
+
+func TestPlacement_DrillPositions(t *testing.T) {
+	part := NewPart("Door", 300, 700, 1)
+	part.Drills = []DrillHole{{X: 22.5, Y: 100, Diameter: 35, Depth: 13}}
+
+	pl := Placement{Part: part, X: 10, Y: 20}
+	if got := pl.DrillPositions()[0]; got.X != 32.5 || got.Y != 120 {
+		t.Errorf("unexpected unrotated position %+v", got)
+	}
+
+	pl.Rotated = true
+	if got := pl.DrillPositions()[0]; got.X != 610 || got.Y != 42.5 {
+		t.Errorf("unexpected rotated position %+v", got)
+	}
+}
+
+func TestPlacement_CutoutRectsTurnWithDrills(t *testing.T) {
+	part := NewPart("Panel", 300, 700, 1)
+	part.Cutouts = []Outline{{{X: 20, Y: 50}, {X: 120, Y: 50}, {X: 120, Y: 250}, {X: 20, Y: 250}}}
+	part.Drills = []DrillHole{{X: 40, Y: 100, Diameter: 5, Depth: 10}}
+
+	for _, rotated := range []bool{false, true} {
+		pl := Placement{Part: part, X: 10, Y: 20, Rotated: rotated}
+		c := pl.CutoutRects()[0]
+		h := pl.DrillPositions()[0]
+		if h.X < c.X || h.X > c.X+c.Width || h.Y < c.Y || h.Y > c.Y+c.Height {
+			t.Errorf("rotated=%v: hole at (%.0f, %.0f) outside its cutout %+v", rotated, h.X, h.Y, c)
+		}
+	}
+
+	pl := Placement{Part: part, X: 10, Y: 20, Rotated: true}
+	if c := pl.CutoutRects()[0]; c.X != 460 || c.Y != 40 || c.Width != 200 || c.Height != 100 {
+		t.Errorf("unexpected rotated cutout %+v", c)
+	}
+}
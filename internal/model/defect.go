@@ -0,0 +1,124 @@
+package model
+
+import (
+	"math"
+	"sort"
+)
+
+// Defect is a damaged or unusable area of a stock sheet, such as a knot, a
+// dent or a broken corner. Parts are never placed over a defect.
+type Defect struct {
+	Label   string  `json:"label,omitempty"`
+	Outline Outline `json:"outline"` // Polygon in sheet coordinates (mm from top-left)
+}
+
+// NewRectDefect creates a rectangular defect.
+func NewRectDefect(label string, x, y, w, h float64) Defect {
+	return Defect{
+		Label: label,
+		Outline: Outline{
+			{X: x, Y: y}, {X: x + w, Y: y}, {X: x + w, Y: y + h}, {X: x, Y: y + h},
+		},
+	}
+}
+
+// defectBands is the maximum number of horizontal bands a defect polygon is
+// split into when approximating it with rectangles.
+const defectBands = 8
+
+// ExclusionZones approximates the defect with rectangles that fully cover it.
+// The polygon is cut into horizontal bands and each band is covered by the
+// polygon's horizontal extent within it, so a diagonal broken corner loses
+// far less material than its bounding box would.
+func (d Defect) ExclusionZones() []TabZone {
+	if len(d.Outline) < 3 {
+		return nil
+	}
+	min, max := d.Outline.BoundingBox()
+	height := max.Y - min.Y
+	if height <= 0 || max.X <= min.X {
+		return nil
+	}
+
+	// Band edges: the bounding box split evenly, plus every vertex so that
+	// each band is bounded by straight edges.
+	edges := []float64{min.Y, max.Y}
+	for i := 1; i < defectBands; i++ {
+		edges = append(edges, min.Y+height*float64(i)/defectBands)
+	}
+	for _, p := range d.Outline {
+		edges = append(edges, p.Y)
+	}
+	sort.Float64s(edges)
+
+	var zones []TabZone
+	for i := 0; i+1 < len(edges); i++ {
+		y0, y1 := edges[i], edges[i+1]
+		if y1-y0 < 1e-6 {
+			continue
+		}
+		x0, x1, ok := d.Outline.xExtent(y0, y1)
+		if !ok {
+			continue
+		}
+		// Merge with the previous band when it has the same extent
+		if n := len(zones); n > 0 && zones[n-1].X == x0 && zones[n-1].X+zones[n-1].Width == x1 &&
+			math.Abs(zones[n-1].Y+zones[n-1].Height-y0) < 1e-6 {
+			zones[n-1].Height = y1 - zones[n-1].Y
+			continue
+		}
+		zones = append(zones, TabZone{X: x0, Y: y0, Width: x1 - x0, Height: y1 - y0})
+	}
+	return zones
+}
+
+// xExtent returns the horizontal extent of the polygon between y0 and y1.
+// Edges are linear, so the extent is reached at a vertex inside the band or
+// where an edge crosses the band's top or bottom.
+func (o Outline) xExtent(y0, y1 float64) (x0, x1 float64, ok bool) {
+	x0, x1 = math.Inf(1), math.Inf(-1)
+	add := func(x float64) {
+		x0 = math.Min(x0, x)
+		x1 = math.Max(x1, x)
+	}
+	n := len(o)
+	for i := 0; i < n; i++ {
+		a, b := o[i], o[(i+1)%n]
+		if a.Y >= y0 && a.Y <= y1 {
+			add(a.X)
+		}
+		if a.Y == b.Y {
+			continue
+		}
+		for _, y := range []float64{y0, y1} {
+			if (y-a.Y)*(y-b.Y) <= 0 {
+				add(a.X + (b.X-a.X)*(y-a.Y)/(b.Y-a.Y))
+			}
+		}
+	}
+	return x0, x1, x1 > x0
+}
+
+// Overlaps reports whether the defect's bounding box overlaps the rectangle.
+func (d Defect) Overlaps(x, y, w, h float64) bool {
+	min, max := d.Outline.BoundingBox()
+	return min.X < x+w && max.X > x && min.Y < y+h && max.Y > y
+}
+
+// Translate returns the defect shifted by dx, dy.
+func (d Defect) Translate(dx, dy float64) Defect {
+	return Defect{Label: d.Label, Outline: d.Outline.Translate(dx, dy)}
+}
+
+// DefectsInRect returns the defects that overlap a rectangle of the sheet,
+// moved into the rectangle's coordinates. Used to carry defects over to
+// offcuts cut from a damaged sheet.
+func DefectsInRect(defects []Defect, x, y, w, h float64) []Defect {
+	var out []Defect
+	for _, d := range defects {
+		if d.Overlaps(x, y, w, h) {
+			out = append(out, d.Translate(-x, -y))
+		}
+	}
+	return out
+}
@@ -0,0 +1,72 @@
+package model
+
+import "testing"
+
+func TestDefect_RectExclusionIsSingleZone(t *testing.T) {
+	zones := NewRectDefect("Dent", 100, 200, 50, 80).ExclusionZones()
+	if len(zones) != 1 {
+		t.Fatalf("expected 1 zone, got %d: %+v", len(zones), zones)
+	}
+	z := zones[0]
+	if z.X != 100 || z.Y != 200 || z.Width != 50 || z.Height != 80 {
+		t.Errorf("unexpected zone %+v", z)
+	}
+}
+
+func TestDefect_TriangleCoveredTighterThanBoundingBox(t *testing.T) {
+	// Broken top-left corner
+	d := Defect{Outline: Outline{{X: 0, Y: 0}, {X: 200, Y: 0}, {X: 0, Y: 200}}}
+	zones := d.ExclusionZones()
+	if len(zones) < 2 {
+		t.Fatalf("expected the triangle to be split into bands, got %+v", zones)
+	}
+
+	var area float64
+	for _, z := range zones {
+		area += z.Width * z.Height
+	}
+	if area >= 200*200 || area < d.Outline.Area() {
+		t.Errorf("zone area %.0f should be between the triangle %.0f and its box %d", area, d.Outline.Area(), 200*200)
+	}
+
+	// Every point of the triangle must be covered
+	for _, p := range []Point2D{{X: 1, Y: 1}, {X: 190, Y: 5}, {X: 5, Y: 190}, {X: 99, Y: 99}} {
+		covered := false
+		for _, z := range zones {
+			if p.X >= z.X && p.X <= z.X+z.Width && p.Y >= z.Y && p.Y <= z.Y+z.Height {
+				covered = true
+			}
+		}
+		if !covered {
+			t.Errorf("point %+v is not covered", p)
+		}
+	}
+}
+
+func TestDetectOffcuts_CarriesDefects(t *testing.T) {
+	stock := NewStockSheet("Damaged", 1000, 1000, 1)
+	stock.Defects = []Defect{
+		NewRectDefect("Knot", 800, 100, 50, 50), // In the right-hand offcut
+		NewRectDefect("Dent", 100, 100, 50, 50), // Under the placed part
+	}
+	sr := SheetResult{
+		Stock:      stock,
+		Placements: []Placement{{Part: NewPart("A", 500, 1000, 1)}},
+	}
+
+	offcuts := DetectOffcuts(sr, 0, 0)
+	if len(offcuts) != 1 {
+		t.Fatalf("expected 1 offcut, got %+v", offcuts)
+	}
+	o := offcuts[0]
+	if len(o.Defects) != 1 || o.Defects[0].Label != "Knot" {
+		t.Fatalf("expected the knot to carry over, got %+v", o.Defects)
+	}
+	min, _ := o.Defects[0].Outline.BoundingBox()
+	if min.X != 300 || min.Y != 100 {
+		t.Errorf("defect should be in offcut coordinates, got %+v", min)
+	}
+	if sheet := o.ToStockSheet(); len(sheet.Defects) != 1 {
+		t.Error("offcut stock should keep its defects")
+	}
+}
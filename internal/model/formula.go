@@ -0,0 +1,272 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// EvalExpression evaluates an arithmetic expression such as "H - 2*T" or
+// "ceil(W/600)" using the given variable values.
+//
+// Supported syntax: numbers, variable names, parentheses, the binary
+// operators + - * / % and ^ (power, right-associative), unary minus, and the
+// functions ceil, floor, round, abs, sqrt, min and max. Variable names are
+// case-sensitive.
+func EvalExpression(expr string, vars map[string]float64) (float64, error) {
+	p := &exprParser{src: expr, vars: vars}
+	p.next()
+	v, err := p.parseExpr()
+	if err != nil {
+		return 0, fmt.Errorf("invalid expression %q: %w", expr, err)
+	}
+	if p.tok.kind != tokEOF {
+		return 0, fmt.Errorf("invalid expression %q: unexpected %q at position %d", expr, p.tok.text, p.tok.pos+1)
+	}
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, fmt.Errorf("invalid expression %q: result is not a finite number", expr)
+	}
+	return v, nil
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNumber
+	tokIdent
+	tokOp // Single-character operator or punctuation
+)
+
+type exprToken struct {
+	kind tokenKind
+	text string
+	num  float64
+	pos  int
+}
+
+// exprParser is a recursive descent parser that evaluates as it parses.
+type exprParser struct {
+	src  string
+	pos  int
+	tok  exprToken
+	vars map[string]float64
+	err  error
+}
+
+// next advances to the following token.
+func (p *exprParser) next() {
+	for p.pos < len(p.src) && unicode.IsSpace(rune(p.src[p.pos])) {
+		p.pos++
+	}
+	start := p.pos
+	if p.pos >= len(p.src) {
+		p.tok = exprToken{kind: tokEOF, pos: start}
+		return
+	}
+
+	c := p.src[p.pos]
+	switch {
+	case c >= '0' && c <= '9' || c == '.':
+		for p.pos < len(p.src) && (p.src[p.pos] >= '0' && p.src[p.pos] <= '9' || p.src[p.pos] == '.') {
+			p.pos++
+		}
+		text := p.src[start:p.pos]
+		v, err := strconv.ParseFloat(text, 64)
+		if err != nil && p.err == nil {
+			p.err = fmt.Errorf("bad number %q", text)
+		}
+		p.tok = exprToken{kind: tokNumber, text: text, num: v, pos: start}
+	case c == '_' || unicode.IsLetter(rune(c)):
+		for p.pos < len(p.src) && (p.src[p.pos] == '_' || unicode.IsLetter(rune(p.src[p.pos])) || unicode.IsDigit(rune(p.src[p.pos]))) {
+			p.pos++
+		}
+		p.tok = exprToken{kind: tokIdent, text: p.src[start:p.pos], pos: start}
+	default:
+		p.pos++
+		p.tok = exprToken{kind: tokOp, text: string(c), pos: start}
+	}
+}
+
+func (p *exprParser) isOp(op string) bool {
+	return p.tok.kind == tokOp && p.tok.text == op
+}
+
+// parseExpr handles addition and subtraction.
+func (p *exprParser) parseExpr() (float64, error) {
+	v, err := p.parseTerm()
+	if err != nil {
+		return 0, err
+	}
+	for p.isOp("+") || p.isOp("-") {
+		op := p.tok.text
+		p.next()
+		rhs, err := p.parseTerm()
+		if err != nil {
+			return 0, err
+		}
+		if op == "+" {
+			v += rhs
+		} else {
+			v -= rhs
+		}
+	}
+	return v, nil
+}
+
+// parseTerm handles multiplication, division and modulo.
+func (p *exprParser) parseTerm() (float64, error) {
+	v, err := p.parseUnary()
+	if err != nil {
+		return 0, err
+	}
+	for p.isOp("*") || p.isOp("/") || p.isOp("%") {
+		op := p.tok.text
+		p.next()
+		rhs, err := p.parseUnary()
+		if err != nil {
+			return 0, err
+		}
+		switch op {
+		case "*":
+			v *= rhs
+		case "/":
+			if rhs == 0 {
+				return 0, fmt.Errorf("division by zero")
+			}
+			v /= rhs
+		case "%":
+			if rhs == 0 {
+				return 0, fmt.Errorf("modulo by zero")
+			}
+			v = math.Mod(v, rhs)
+		}
+	}
+	return v, nil
+}
+
+// parseUnary handles leading signs.
+func (p *exprParser) parseUnary() (float64, error) {
+	if p.isOp("-") || p.isOp("+") {
+		neg := p.tok.text == "-"
+		p.next()
+		v, err := p.parseUnary()
+		if neg {
+			v = -v
+		}
+		return v, err
+	}
+	return p.parsePower()
+}
+
+// parsePower handles the right-associative ^ operator.
+func (p *exprParser) parsePower() (float64, error) {
+	base, err := p.parsePrimary()
+	if err != nil {
+		return 0, err
+	}
+	if p.isOp("^") {
+		p.next()
+		exp, err := p.parseUnary()
+		if err != nil {
+			return 0, err
+		}
+		return math.Pow(base, exp), nil
+	}
+	return base, nil
+}
+
+// parsePrimary handles numbers, variables, function calls and parentheses.
+func (p *exprParser) parsePrimary() (float64, error) {
+	if p.err != nil {
+		return 0, p.err
+	}
+	tok := p.tok
+	switch tok.kind {
+	case tokNumber:
+		p.next()
+		return tok.num, nil
+
+	case tokIdent:
+		p.next()
+		if p.isOp("(") {
+			p.next()
+			var args []float64
+			if !p.isOp(")") {
+				for {
+					v, err := p.parseExpr()
+					if err != nil {
+						return 0, err
+					}
+					args = append(args, v)
+					if !p.isOp(",") {
+						break
+					}
+					p.next()
+				}
+			}
+			if !p.isOp(")") {
+				return 0, fmt.Errorf("missing ) after arguments to %s", tok.text)
+			}
+			p.next()
+			return callFunction(tok.text, args)
+		}
+		v, ok := p.vars[tok.text]
+		if !ok {
+			return 0, fmt.Errorf("unknown variable %q", tok.text)
+		}
+		return v, nil
+
+	case tokOp:
+		if tok.text == "(" {
+			p.next()
+			v, err := p.parseExpr()
+			if err != nil {
+				return 0, err
+			}
+			if !p.isOp(")") {
+				return 0, fmt.Errorf("missing )")
+			}
+			p.next()
+			return v, nil
+		}
+		return 0, fmt.Errorf("unexpected %q at position %d", tok.text, tok.pos+1)
+	}
+	return 0, fmt.Errorf("unexpected end of expression")
+}
+
+// callFunction evaluates a built-in function.
+func callFunction(name string, args []float64) (float64, error) {
+	unary := map[string]func(float64) float64{
+		"ceil":  math.Ceil,
+		"floor": math.Floor,
+		"round": math.Round,
+		"abs":   math.Abs,
+		"sqrt":  math.Sqrt,
+	}
+	if fn, ok := unary[strings.ToLower(name)]; ok {
+		if len(args) != 1 {
+			return 0, fmt.Errorf("%s takes 1 argument, got %d", name, len(args))
+		}
+		return fn(args[0]), nil
+	}
+
+	switch strings.ToLower(name) {
+	case "min", "max":
+		if len(args) == 0 {
+			return 0, fmt.Errorf("%s needs at least 1 argument", name)
+		}
+		v := args[0]
+		for _, a := range args[1:] {
+			if strings.ToLower(name) == "min" {
+				v = math.Min(v, a)
+			} else {
+				v = math.Max(v, a)
+			}
+		}
+		return v, nil
+	}
+	return 0, fmt.Errorf("unknown function %q", name)
+}
@@ -0,0 +1,62 @@
+package model
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEvalExpression(t *testing.T) {
+	vars := map[string]float64{"W": 1200, "H": 1800, "T": 18, "n_2": 3}
+	tests := []struct {
+		expr string
+		want float64
+	}{
+		{"42", 42},
+		{"1.5 * 2", 3},
+		{"H - 2*T", 1764},
+		{"(W - 2*T) / 2", 582},
+		{"ceil(W/600)", 2},
+		{"ceil(W/500)", 3},
+		{"floor(H / 400) - 1", 3},
+		{"round(2.5)", 3},
+		{"-T + 20", 2},
+		{"2^3^2", 512},
+		{"-2^2", -4},
+		{"10 % 4", 2},
+		{"min(W, H, 500)", 500},
+		{"max(0, n_2 - 5)", 0},
+		{"abs(-3) + sqrt(16)", 7},
+	}
+	for _, tt := range tests {
+		got, err := EvalExpression(tt.expr, vars)
+		if err != nil {
+			t.Errorf("EvalExpression(%q) error: %v", tt.expr, err)
+			continue
+		}
+		if math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("EvalExpression(%q) = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestEvalExpression_Errors(t *testing.T) {
+	vars := map[string]float64{"W": 600}
+	for _, expr := range []string{
+		"",
+		"W +",
+		"X * 2",
+		"(W",
+		"W)",
+		"W / 0",
+		"foo(1)",
+		"ceil(1, 2)",
+		"min()",
+		"1..2",
+		"W $ 2",
+		"sqrt(-1)",
+	} {
+		if _, err := EvalExpression(expr, vars); err == nil {
+			t.Errorf("EvalExpression(%q) expected error", expr)
+		}
+	}
+}
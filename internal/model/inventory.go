@@ -50,12 +50,16 @@ func (tp ToolProfile) ApplyToSettings(s *CutSettings) {
 
 // StockPreset represents a reusable stock sheet definition.
 type StockPreset struct {
-	ID            string  `json:"id"`
-	Name          string  `json:"name"`
-	Width         float64 `json:"width"`
-	Height        float64 `json:"height"`
-	Material      string  `json:"material"`
-	PricePerSheet float64 `json:"price_per_sheet"` // Cost per sheet in user's currency
+	ID            string   `json:"id"`
+	Name          string   `json:"name"`
+	Width         float64  `json:"width"`
+	Height        float64  `json:"height"`
+	Material      string   `json:"material"`
+	PricePerSheet float64  `json:"price_per_sheet"`           // Cost per sheet in user's currency
+	MinOrderQty   int      `json:"min_order_qty,omitempty"`   // Supplier minimum order (0 = none)
+	Defects       []Defect `json:"defects,omitempty"`         // Damaged areas, e.g. carried over from an offcut
+	Roll          bool     `json:"roll,omitempty"`            // Roll stock; Height is the length left on the roll
+	PricePerMeter float64  `json:"price_per_meter,omitempty"` // Cost per metre of roll length (rolls only)
 }
 
 // NewStockPreset creates a new StockPreset with a generated ID.
@@ -80,9 +84,21 @@ func NewStockPresetWithPrice(name string, width, height float64, material string
 func (sp StockPreset) ToStockSheet(qty int) StockSheet {
 	sheet := NewStockSheet(sp.Name, sp.Width, sp.Height, qty)
 	sheet.PricePerSheet = sp.PricePerSheet
+	sheet.Defects = append([]Defect(nil), sp.Defects...)
+	sheet.Roll = sp.Roll
+	sheet.PricePerMeter = sp.PricePerMeter
 	return sheet
 }
 
+// NewRollPreset creates a stock preset for the rest of a roll, as returned by
+// SheetResult.RemainingRoll, so it is packed and priced as a roll again.
+func NewRollPreset(roll StockSheet) StockPreset {
+	sp := NewStockPreset(roll.Label, roll.Width, roll.Height, "Roll")
+	sp.Roll = true
+	sp.PricePerMeter = roll.PricePerMeter
+	return sp
+}
+
 // Inventory holds the user's saved tool profiles and stock presets.
 type Inventory struct {
 	Tools  []ToolProfile `json:"tools"`
@@ -94,3 +94,12 @@ func TestOptimizeResultTotalCostZeroWhenNoPricing(t *testing.T) {
 		t.Errorf("expected 0 cost when no pricing, got %.2f", result.TotalCost())
 	}
 }
+
+func TestNewRollPreset_StaysARoll(t *testing.T) {
+	rest := NewRollStock("Veneer", 1000, 4400, 1)
+	rest.PricePerMeter = 12
+	sheet := NewRollPreset(rest).ToStockSheet(1)
+	if !sheet.Roll || sheet.Width != 1000 || sheet.Height != 4400 || sheet.PricePerMeter != 12 {
+		t.Errorf("expected the rest of the roll back as roll stock, got %+v", sheet)
+	}
+}
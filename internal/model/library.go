@@ -32,12 +32,14 @@ func NewLibraryPart(label string, w, h float64, grain Grain) LibraryPart {
 // ToPart converts a LibraryPart to a project Part with the given quantity.
 func (lp LibraryPart) ToPart(quantity int) Part {
 	return Part{
-		ID:       uuid.New().String()[:8],
-		Label:    lp.Label,
-		Width:    lp.Width,
-		Height:   lp.Height,
-		Quantity: quantity,
-		Grain:    lp.Grain,
+		ID:        uuid.New().String()[:8],
+		Label:     lp.Label,
+		Width:     lp.Width,
+		Height:    lp.Height,
+		Quantity:  quantity,
+		Grain:     lp.Grain,
+		Material:  lp.Material,
+		Thickness: lp.Thickness,
 	}
 }
 
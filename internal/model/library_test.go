@@ -25,6 +25,8 @@ func TestNewLibraryPart(t *testing.T) {
 
 func TestLibraryPartToPart(t *testing.T) {
 	lp := NewLibraryPart("Shelf", 600, 300, GrainVertical)
+	lp.Material = "MDF"
+	lp.Thickness = 18
 	part := lp.ToPart(5)
 
 	if part.Label != "Shelf" {
@@ -39,6 +41,9 @@ func TestLibraryPartToPart(t *testing.T) {
 	if part.Grain != GrainVertical {
 		t.Errorf("expected GrainVertical, got %v", part.Grain)
 	}
+	if part.Material != "MDF" || part.Thickness != 18 {
+		t.Errorf("expected MDF 18, got %q %.1f", part.Material, part.Thickness)
+	}
 	// Part should have its own ID, different from library part
 	if part.ID == lp.ID {
 		t.Error("expected different ID for converted part")
@@ -0,0 +1,205 @@
+package model
+
+import (
+	"math"
+
+	"github.com/google/uuid"
+)
+
+// Linear parts are cut to length from bars, boards, extrusions or rolls
+// rather than nested on sheets. A linear part's Width is its length; its
+// Height is the profile width and is informational only.
+
+// LinearSettings holds saw settings for 1D cutting.
+type LinearSettings struct {
+	Kerf     float64 `json:"kerf"`      // Saw blade width in mm
+	Trim     float64 `json:"trim"`      // Squaring cut removed from the start of each bar (mm)
+	EndWaste float64 `json:"end_waste"` // Unusable length at the end of each bar, e.g. clamp grip (mm)
+}
+
+// DefaultLinearSettings returns typical settings for a mitre or panel saw.
+func DefaultLinearSettings() LinearSettings {
+	return LinearSettings{
+		Kerf:     3.0,
+		Trim:     10.0,
+		EndWaste: 0,
+	}
+}
+
+// LinearStock is an available bar or board to cut linear parts from.
+type LinearStock struct {
+	ID            string  `json:"id"`
+	Label         string  `json:"label"`
+	Length        float64 `json:"length"` // mm
+	Quantity      int     `json:"quantity"`
+	Material      string  `json:"material,omitempty"` // Profile or species; empty means any
+	PricePerPiece float64 `json:"price_per_piece"`    // Cost per bar (0 = not set)
+}
+
+// NewLinearStock creates a linear stock entry with a generated ID.
+func NewLinearStock(label string, length float64, qty int) LinearStock {
+	return LinearStock{
+		ID:       uuid.New().String()[:8],
+		Label:    label,
+		Length:   length,
+		Quantity: qty,
+	}
+}
+
+// UsableLength returns the length available for parts after trim and end
+// waste. The trim cut takes a kerf of its own, so that is left out too.
+func (s LinearStock) UsableLength(ls LinearSettings) float64 {
+	usable := s.Length - ls.Trim - ls.EndWaste
+	if ls.Trim > 0 {
+		usable -= ls.Kerf
+	}
+	return usable
+}
+
+// LinearCut is one part cut from a bar. Position is the distance of the
+// part's start from the start of the bar.
+type LinearCut struct {
+	Part     Part    `json:"part"`
+	Position float64 `json:"position"`
+}
+
+// LinearBar is one stock bar with the parts cut from it.
+type LinearBar struct {
+	Stock LinearStock `json:"stock"`
+	Cuts  []LinearCut `json:"cuts"`
+}
+
+// UsedLength returns the total length of parts cut from the bar.
+func (b LinearBar) UsedLength() float64 {
+	var total float64
+	for _, c := range b.Cuts {
+		total += c.Part.Width
+	}
+	return total
+}
+
+// Remnant returns the length left over after the last cut and its kerf,
+// including the end waste.
+func (b LinearBar) Remnant(ls LinearSettings) float64 {
+	if len(b.Cuts) == 0 {
+		return b.Stock.Length
+	}
+	last := b.Cuts[len(b.Cuts)-1]
+	return math.Max(0, b.Stock.Length-(last.Position+last.Part.Width+ls.Kerf))
+}
+
+// Efficiency returns the percentage of the bar length used by parts.
+func (b LinearBar) Efficiency() float64 {
+	if b.Stock.Length <= 0 {
+		return 0
+	}
+	return b.UsedLength() / b.Stock.Length * 100.0
+}
+
+// LinearResult is the output of the 1D optimizer.
+type LinearResult struct {
+	Settings      LinearSettings `json:"settings"`
+	Bars          []LinearBar    `json:"bars"`
+	UnplacedParts []Part         `json:"unplaced_parts"`
+}
+
+// TotalEfficiency returns overall material usage across all bars.
+func (r LinearResult) TotalEfficiency() float64 {
+	var used, total float64
+	for _, b := range r.Bars {
+		used += b.UsedLength()
+		total += b.Stock.Length
+	}
+	if total == 0 {
+		return 0
+	}
+	return used / total * 100.0
+}
+
+// TotalCost returns the price of all bars used.
+func (r LinearResult) TotalCost() float64 {
+	var total float64
+	for _, b := range r.Bars {
+		total += b.Stock.PricePerPiece
+	}
+	return total
+}
+
+// LinearBarCount is the number of bars of one stock type used by a result.
+type LinearBarCount struct {
+	Stock LinearStock `json:"stock"`
+	Count int         `json:"count"`
+	Cost  float64     `json:"cost"`
+}
+
+// BarsByStock summarizes how many bars of each stock type the result uses,
+// in order of first use.
+func (r LinearResult) BarsByStock() []LinearBarCount {
+	var counts []LinearBarCount
+	index := make(map[string]int)
+	for _, b := range r.Bars {
+		i, ok := index[b.Stock.ID]
+		if !ok {
+			i = len(counts)
+			index[b.Stock.ID] = i
+			counts = append(counts, LinearBarCount{Stock: b.Stock})
+		}
+		counts[i].Count++
+		counts[i].Cost += b.Stock.PricePerPiece
+	}
+	return counts
+}
+
+// SheetParts returns the parts that are nested on sheets (non-linear).
+func SheetParts(parts []Part) []Part {
+	var out []Part
+	for _, p := range parts {
+		if !p.Linear {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// LinearParts returns the parts that are cut to length.
+func LinearParts(parts []Part) []Part {
+	var out []Part
+	for _, p := range parts {
+		if p.Linear {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// LinearPurchaseEstimate holds the result of a bar purchasing calculation.
+type LinearPurchaseEstimate struct {
+	TotalPartLength float64 `json:"total_part_length"` // Sum of part lengths plus kerf (mm)
+	UsableLength    float64 `json:"usable_length"`     // Usable length per bar (mm)
+	BarsNeededMin   int     `json:"bars_needed_min"`   // Lower bound on bars
+	BarsWithWaste   int     `json:"bars_with_waste"`   // Recommended bars including waste factor
+	WastePercent    float64 `json:"waste_percent"`
+	EstimatedCost   float64 `json:"estimated_cost"`
+}
+
+// CalculateLinearPurchaseEstimate estimates how many bars of the given length
+// to buy for the linear parts, allowing kerf, trim and end waste per bar and
+// an additional waste percentage.
+func CalculateLinearPurchaseEstimate(parts []Part, barLength float64, ls LinearSettings, wastePercent, pricePerBar float64) LinearPurchaseEstimate {
+	est := LinearPurchaseEstimate{WastePercent: wastePercent}
+	for _, p := range LinearParts(parts) {
+		est.TotalPartLength += (p.Width + ls.Kerf) * float64(p.Quantity)
+	}
+	est.UsableLength = LinearStock{Length: barLength}.UsableLength(ls)
+	if est.UsableLength <= 0 {
+		return est
+	}
+	exact := est.TotalPartLength / est.UsableLength
+	est.BarsNeededMin = int(math.Ceil(exact))
+	est.BarsWithWaste = int(math.Ceil(exact * (1 + wastePercent/100)))
+	if est.BarsWithWaste < est.BarsNeededMin {
+		est.BarsWithWaste = est.BarsNeededMin
+	}
+	est.EstimatedCost = float64(est.BarsWithWaste) * pricePerBar
+	return est
+}
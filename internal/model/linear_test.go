@@ -0,0 +1,80 @@
+package model
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLinearResult_BarsByStockAndCost(t *testing.T) {
+	a := NewLinearStock("Pine 2.4m", 2400, 5)
+	a.PricePerPiece = 8
+	b := NewLinearStock("Pine 3.6m", 3600, 5)
+	b.PricePerPiece = 12
+	r := LinearResult{Bars: []LinearBar{{Stock: a}, {Stock: b}, {Stock: a}}}
+
+	counts := r.BarsByStock()
+	if len(counts) != 2 || counts[0].Count != 2 || counts[1].Count != 1 {
+		t.Fatalf("unexpected bar counts %+v", counts)
+	}
+	if counts[0].Cost != 16 || r.TotalCost() != 28 {
+		t.Errorf("unexpected costs: %v, %v", counts[0].Cost, r.TotalCost())
+	}
+}
+
+func TestLinearBar_Efficiency(t *testing.T) {
+	bar := LinearBar{
+		Stock: NewLinearStock("Bar", 2000, 1),
+		Cuts: []LinearCut{
+			{Part: NewPart("A", 600, 40, 1), Position: 0},
+			{Part: NewPart("B", 400, 40, 1), Position: 603},
+		},
+	}
+	if got := bar.Efficiency(); math.Abs(got-50) > 1e-9 {
+		t.Errorf("expected 50%% efficiency, got %.2f", got)
+	}
+	if got := bar.Remnant(LinearSettings{Kerf: 3}); got != 994 {
+		t.Errorf("expected remnant 994, got %.1f", got)
+	}
+}
+
+func TestLinearStock_UsableLength(t *testing.T) {
+	bar := NewLinearStock("Bar", 2400, 1)
+	if got := bar.UsableLength(LinearSettings{Kerf: 3, Trim: 10, EndWaste: 50}); got != 2337 {
+		t.Errorf("expected the trim cut's kerf left out, got %.1f", got)
+	}
+	if got := bar.UsableLength(LinearSettings{Kerf: 3, EndWaste: 50}); got != 2350 {
+		t.Errorf("expected no kerf without a trim cut, got %.1f", got)
+	}
+}
+
+func TestSheetAndLinearParts(t *testing.T) {
+	rail := NewPart("Rail", 900, 45, 2)
+	rail.Linear = true
+	parts := []Part{NewPart("Panel", 500, 400, 1), rail}
+
+	if got := SheetParts(parts); len(got) != 1 || got[0].Label != "Panel" {
+		t.Errorf("unexpected sheet parts %+v", got)
+	}
+	if got := LinearParts(parts); len(got) != 1 || got[0].Label != "Rail" {
+		t.Errorf("unexpected linear parts %+v", got)
+	}
+}
+
+func TestCalculateLinearPurchaseEstimate(t *testing.T) {
+	rail := NewPart("Rail", 997, 45, 5)
+	rail.Linear = true
+	parts := []Part{rail, NewPart("Panel", 500, 400, 10)}
+	ls := LinearSettings{Kerf: 3, Trim: 0, EndWaste: 0}
+
+	est := CalculateLinearPurchaseEstimate(parts, 2500, ls, 10, 7.5)
+
+	if est.TotalPartLength != 5000 {
+		t.Errorf("expected 5000mm including kerf, got %.0f", est.TotalPartLength)
+	}
+	if est.BarsNeededMin != 2 || est.BarsWithWaste != 3 {
+		t.Errorf("expected 2 bars minimum and 3 with waste, got %d and %d", est.BarsNeededMin, est.BarsWithWaste)
+	}
+	if est.EstimatedCost != 22.5 {
+		t.Errorf("expected cost 22.5, got %.2f", est.EstimatedCost)
+	}
+}
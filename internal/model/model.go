@@ -323,6 +323,15 @@ type EdgeBanding struct {
 	Right  bool `json:"right"`  // Banding on the right edge (height side)
 }
 
+// DrillHole is a vertical bore into the face of a part, such as a shelf pin
+// hole or a hinge cup. X and Y are relative to the part origin (0,0).
+type DrillHole struct {
+	X        float64 `json:"x"`
+	Y        float64 `json:"y"`
+	Diameter float64 `json:"diameter"` // mm
+	Depth    float64 `json:"depth"`    // mm below the top surface
+}
+
 // HasAny returns true if any edge needs banding.
 func (eb EdgeBanding) HasAny() bool {
 	return eb.Top || eb.Bottom || eb.Left || eb.Right
@@ -402,9 +411,16 @@ type Part struct {
 	Quantity    int         `json:"quantity"`
 	Grain       Grain       `json:"grain"`
 	Material    string      `json:"material,omitempty"`     // Material type (e.g., "Plywood", "MDF"); empty means unspecified
+	Thickness   float64     `json:"thickness,omitempty"`    // Material thickness in mm; 0 means any thickness
 	Outline     Outline     `json:"outline,omitempty"`      // Non-rectangular part outline; nil for rectangular parts
 	Cutouts     []Outline   `json:"cutouts,omitempty"`      // Interior cutout holes where smaller parts can be nested
 	EdgeBanding EdgeBanding `json:"edge_banding,omitempty"` // Which edges need banding
+	Drills      []DrillHole `json:"drills,omitempty"`       // Holes bored into the face of the part
+	Tabs        []PartTab   `json:"tabs,omitempty"`         // Hand-placed holding tabs; nil for automatic tabs
+	TabShape    TabShape    `json:"tab_shape,omitempty"`    // Holding tab profile; empty uses the settings
+	Linear      bool        `json:"linear,omitempty"`       // Cut to length from linear stock; Width is the length
+	ProductID   string      `json:"product_id,omitempty"`   // Owning product/assembly; empty for loose parts
+	ProductName string      `json:"product_name,omitempty"` // Display name of the owning product
 }
 
 // CutoutBounds returns the bounding rectangles of all cutouts in the part.
@@ -462,6 +478,14 @@ type StockSheet struct {
 	Material      string         `json:"material,omitempty"` // Material type (e.g., "Plywood", "MDF"); empty means unspecified
 	Tabs          StockTabConfig `json:"tabs"`               // Override default tab config for this sheet
 	PricePerSheet float64        `json:"price_per_sheet"`    // Cost per sheet in user's currency (0 = not set)
+
+	// Roll marks stock cut to length from a roll; Height is then the maximum
+	// length (0 = unlimited). See NewRollStock.
+	Roll          bool    `json:"roll,omitempty"`
+	PricePerMeter float64 `json:"price_per_meter,omitempty"` // Cost per metre of roll length (rolls only)
+
+	// Defects are damaged areas of this particular sheet that parts must avoid.
+	Defects []Defect `json:"defects,omitempty"`
 }
 
 func NewStockSheet(label string, w, h float64, qty int) StockSheet {
@@ -516,6 +540,15 @@ type CutSettings struct {
 	EdgeTrim       float64   `json:"edge_trim"`       // Trim around sheet edges in mm
 	GuillotineOnly bool      `json:"guillotine_only"` // Restrict to guillotine cuts
 
+	// ThicknessTolerance is the largest difference in mm between a part's
+	// thickness and a stock sheet's thickness for the sheet to be usable.
+	ThicknessTolerance float64 `json:"thickness_tolerance"`
+
+	// Stacked cutting for panel saws: identical sheets are stacked and cut in
+	// one run, up to MaxStackHeight mm high (0 means no limit).
+	StackCutting   bool    `json:"stack_cutting"`
+	MaxStackHeight float64 `json:"max_stack_height"`
+
 	// CNC / GCode settings
 	ToolDiameter float64 `json:"tool_diameter"` // End mill diameter in mm
 	FeedRate     float64 `json:"feed_rate"`     // Cutting feed rate mm/min
@@ -526,10 +559,12 @@ type CutSettings struct {
 	PassDepth    float64 `json:"pass_depth"`    // Depth per pass mm
 
 	// Part holding tabs (for keeping parts connected during cut)
-	PartTabWidth    float64 `json:"part_tab_width"`     // Part tab width mm
-	PartTabHeight   float64 `json:"part_tab_height"`    // Part tab height mm
-	PartTabsPerSide int     `json:"part_tabs_per_side"` // Number of tabs per part side
-	UseClimb        bool    `json:"use_climb"`          // Climb vs conventional milling
+	PartTabWidth    float64  `json:"part_tab_width"`     // Part tab width mm
+	PartTabHeight   float64  `json:"part_tab_height"`    // Part tab height mm
+	PartTabsPerSide int      `json:"part_tabs_per_side"` // Number of tabs per part side
+	PartTabSpacing  float64  `json:"part_tab_spacing"`   // Tab spacing along outline parts in mm (0 = PartTabsPerSide*4 tabs)
+	PartTabShape    TabShape `json:"part_tab_shape"`     // Holding tab profile
+	UseClimb        bool     `json:"use_climb"`          // Climb vs conventional milling
 
 	// Lead-in/lead-out arcs (for smoother CNC entry and exit)
 	LeadInRadius  float64 `json:"lead_in_radius"`  // Arc radius for approach to cut (0 = disabled)
@@ -543,7 +578,8 @@ type CutSettings struct {
 	GCodeProfile string `json:"gcode_profile"` // Name of the GCode profile to use
 
 	// Toolpath ordering (minimize rapid travel distance)
-	OptimizeToolpath bool `json:"optimize_toolpath"` // Enable nearest-neighbor toolpath ordering
+	OptimizeToolpath  bool `json:"optimize_toolpath"`   // Optimize the cutting order and start points of parts
+	ToolpathOrderTime int  `json:"toolpath_order_time"` // Time budget for improving the order (ms, 0 = 200)
 
 	// Plunge entry strategy
 	PlungeType      PlungeType `json:"plunge_type"`       // Plunge strategy: direct, ramp, or helix
@@ -559,6 +595,22 @@ type CutSettings struct {
 	OnionSkinDepth   float64 `json:"onion_skin_depth"`   // Thickness of skin to leave (mm)
 	OnionSkinCleanup bool    `json:"onion_skin_cleanup"` // Generate a separate cleanup pass to remove the skin
 
+	// Finishing pass (roughing passes leave stock on the part for a clean final cut)
+	FinishingPass   bool    `json:"finishing_pass"`   // Cut a finishing pass after the roughing passes
+	FinishAllowance float64 `json:"finish_allowance"` // Radial stock the roughing passes leave on the part (mm)
+	FinishFeedRate  float64 `json:"finish_feed_rate"` // Finishing feed rate mm/min (0 = FeedRate)
+	FinishStepDown  float64 `json:"finish_step_down"` // Depth per finishing pass mm (0 = full depth in one pass)
+	FinishReverse   bool    `json:"finish_reverse"`   // Cut the finishing pass in the opposite direction to roughing
+
+	// Small parts on vacuum tables (cut them first and hold them better, since
+	// they shift once freed)
+	SmallPartStrategy  bool    `json:"small_part_strategy"`   // Detect small parts and cut them first
+	SmallPartArea      float64 `json:"small_part_area"`       // Parts smaller than this count as small (mm²)
+	SmallPartOnionSkin float64 `json:"small_part_onion_skin"` // Onion skin left on small parts (mm, 0 = as other parts)
+	SmallPartTabs      int     `json:"small_part_tabs"`       // Holding tabs per side on small parts (0 = as other parts)
+	TwoStageCut        bool    `json:"two_stage_cut"`         // Cut every part down to the skin before freeing any
+	TwoStageSkin       float64 `json:"two_stage_skin"`        // Material the first stage leaves under every part (mm)
+
 	// Structural integrity cut ordering (interior cuts first, perimeter last)
 	StructuralOrdering bool `json:"structural_ordering"` // Order cuts from center outward for structural integrity
 
@@ -567,15 +619,21 @@ type CutSettings struct {
 	NestingRotations int `json:"nesting_rotations"` // Number of rotation angles for outline parts (default 2)
 
 	// Fixture/clamp exclusion zones
-	ClampZones []ClampZone `json:"clamp_zones,omitempty"` // Clamp/fixture zones to exclude from optimization
+	ClampZones        []ClampZone `json:"clamp_zones,omitempty"` // Clamp/fixture zones to exclude from optimization
+	ClampAwareRetract bool        `json:"clamp_aware_retract"`   // Rapid over clamps at SafeZ above their ZHeight
+	RouteAroundClamps bool        `json:"route_around_clamps"`   // Route rapids around clamps, lifting over them only when there is no way round
 
 	// Dust shoe collision detection
-	DustShoeEnabled   bool    `json:"dust_shoe_enabled"`   // Enable dust shoe collision checking
-	DustShoeWidth     float64 `json:"dust_shoe_width"`     // Dust shoe diameter/width in mm
-	DustShoeClearance float64 `json:"dust_shoe_clearance"` // Minimum clearance between dust shoe edge and clamp (mm)
+	DustShoeEnabled      bool    `json:"dust_shoe_enabled"`      // Enable dust shoe collision checking
+	DustShoeWidth        float64 `json:"dust_shoe_width"`        // Dust shoe diameter/width in mm
+	DustShoeClearance    float64 `json:"dust_shoe_clearance"`    // Minimum clearance between dust shoe edge and clamp (mm)
+	AvoidClampCollisions bool    `json:"avoid_clamp_collisions"` // Re-optimize with parts moved away from clamps the dust shoe would hit
 
 	// Multi-objective optimization weights (all values 0-1, normalized internally)
 	OptimizeWeights OptimizeWeights `json:"optimize_weights"` // Weights for multi-objective fitness
+
+	// 1D cutting of linear parts (lumber, extrusions, banding rolls)
+	Linear LinearSettings `json:"linear"` // Saw kerf, trim and end waste for linear stock
 }
 
 // OptimizeWeights controls the priority of different optimization objectives.
@@ -680,27 +738,32 @@ type DustShoeCollision struct {
 }
 
 // GCodeProfile defines a post-processor configuration for different CNC controllers.
+// The program itself is written by Post; the fixed command strings below it
+// are only read from profiles saved before templates existed.
 type GCodeProfile struct {
 	Name        string `json:"name"`        // Profile name
 	Description string `json:"description"` // Profile description
 	IsBuiltIn   bool   `json:"is_built_in"` // Whether this is a built-in profile (cannot be deleted)
 	Units       string `json:"units"`       // "mm" or "inches"
 
+	Post          PostTemplate `json:"post"`                     // Post-processor template blocks
+	FileExtension string       `json:"file_extension,omitempty"` // Program file extension (default ".gcode")
+
 	// Startup codes
-	StartCode    []string `json:"start_code"`    // Commands at start of file
-	SpindleStart string   `json:"spindle_start"` // Spindle on command (e.g., "M3 S%d")
-	SpindleStop  string   `json:"spindle_stop"`  // Spindle off command
-	HomeAll      string   `json:"home_all"`      // Home all axes command
-	HomeXY       string   `json:"home_xy"`       // Home XY only command
+	StartCode    []string `json:"start_code,omitempty"`    // Commands at start of file
+	SpindleStart string   `json:"spindle_start,omitempty"` // Spindle on command (e.g., "M3 S%d")
+	SpindleStop  string   `json:"spindle_stop,omitempty"`  // Spindle off command
+	HomeAll      string   `json:"home_all,omitempty"`      // Home all axes command
+	HomeXY       string   `json:"home_xy,omitempty"`       // Home XY only command
 
 	// Motion settings
-	AbsoluteMode string `json:"absolute_mode"` // G90 or equivalent
-	FeedMode     string `json:"feed_mode"`     // Feed rate mode
-	RapidMove    string `json:"rapid_move"`    // G0 or equivalent
-	FeedMove     string `json:"feed_move"`     // G1 or equivalent
+	AbsoluteMode string `json:"absolute_mode,omitempty"` // G90 or equivalent
+	FeedMode     string `json:"feed_mode,omitempty"`     // Feed rate mode
+	RapidMove    string `json:"rapid_move,omitempty"`    // G0 or equivalent
+	FeedMove     string `json:"feed_move,omitempty"`     // G1 or equivalent
 
 	// End codes
-	EndCode []string `json:"end_code"` // Commands at end of file
+	EndCode []string `json:"end_code,omitempty"` // Commands at end of file
 
 	// Comment style
 	CommentPrefix string `json:"comment_prefix"` // Comment start (e.g., ";")
@@ -714,80 +777,180 @@ type GCodeProfile struct {
 // Built-in GCode profiles
 var GCodeProfiles = []GCodeProfile{
 	{
-		Name:          "Grbl",
-		Description:   "Standard Grbl configuration (Arduino CNC shields)",
-		IsBuiltIn:     true,
-		Units:         "mm",
-		StartCode:     []string{"G90", "G21", "G17"},
-		SpindleStart:  "M3 S%d",
-		SpindleStop:   "M5",
-		HomeAll:       "$H",
-		HomeXY:        "$H",
-		AbsoluteMode:  "G90",
-		FeedMode:      "G94",
-		RapidMove:     "G0",
-		FeedMove:      "G1",
-		EndCode:       []string{"G0 Z[SafeZ]", "G0 X0 Y0", "M5", "M2"},
+		Name:        "Grbl",
+		Description: "Standard Grbl configuration (Arduino CNC shields)",
+		IsBuiltIn:   true,
+		Units:       "mm",
+		Post: PostTemplate{
+			Header: PostBanner + "G90\nG21\nG17\nM3 S{{.SpindleSpeed}}",
+			Rapid:  PostG0,
+			Linear: PostG1,
+			Arc:    PostG2G3,
+			Footer: "G0 Z{{.ReturnZ}}\nG0 X0 Y0\nM5\nM2",
+		},
 		CommentPrefix: ";",
 		CommentSuffix: "",
 		DecimalPlaces: 3,
 		LeadingZeros:  false,
 	},
 	{
-		Name:          "Mach3",
-		Description:   "Mach3 CNC control software",
-		IsBuiltIn:     true,
-		Units:         "mm",
-		StartCode:     []string{"G90", "G21", "G17", "G94"},
-		SpindleStart:  "M3 S%d",
-		SpindleStop:   "M5",
-		HomeAll:       "G28 X0 Y0 Z0",
-		HomeXY:        "G28 X0 Y0",
-		AbsoluteMode:  "G90",
-		FeedMode:      "G94",
-		RapidMove:     "G0",
-		FeedMove:      "G1",
-		EndCode:       []string{"G0 Z[SafeZ]", "G28 X0 Y0", "M5", "M30"},
+		Name:        "Mach3",
+		Description: "Mach3 CNC control software",
+		IsBuiltIn:   true,
+		Units:       "mm",
+		Post: PostTemplate{
+			Header: PostBanner + "G90\nG21\nG17\nG94\nM3 S{{.SpindleSpeed}}",
+			Rapid:  PostG0,
+			Linear: PostG1,
+			Arc:    PostG2G3,
+			Footer: "G0 Z{{.ReturnZ}}\nG28 X0 Y0\nM5\nM30",
+		},
 		CommentPrefix: ";",
 		CommentSuffix: "",
 		DecimalPlaces: 4,
 		LeadingZeros:  false,
 	},
 	{
-		Name:          "LinuxCNC",
-		Description:   "LinuxCNC (formerly EMC2)",
-		IsBuiltIn:     true,
-		Units:         "mm",
-		StartCode:     []string{"G90", "G21", "G17", "G94"},
-		SpindleStart:  "M3 S%d",
-		SpindleStop:   "M5",
-		HomeAll:       "G28 X0 Y0 Z0",
-		HomeXY:        "G28 X0 Y0",
-		AbsoluteMode:  "G90",
-		FeedMode:      "G94",
-		RapidMove:     "G0",
-		FeedMove:      "G1",
-		EndCode:       []string{"G0 Z[SafeZ]", "G0 X0 Y0", "M5", "M2"},
+		Name:        "LinuxCNC",
+		Description: "LinuxCNC (formerly EMC2)",
+		IsBuiltIn:   true,
+		Units:       "mm",
+		Post: PostTemplate{
+			Header: PostBanner + "G90\nG21\nG17\nG94\nM3 S{{.SpindleSpeed}}",
+			Rapid:  PostG0,
+			Linear: PostG1,
+			Arc:    PostG2G3,
+			Footer: "G0 Z{{.ReturnZ}}\nG0 X0 Y0\nM5\nM2",
+		},
 		CommentPrefix: ";",
 		CommentSuffix: "",
 		DecimalPlaces: 4,
 		LeadingZeros:  false,
 	},
 	{
-		Name:          "Generic",
-		Description:   "Generic standard GCode",
-		IsBuiltIn:     true,
-		Units:         "mm",
-		StartCode:     []string{"G90", "G21"},
-		SpindleStart:  "M3 S%d",
-		SpindleStop:   "M5",
-		HomeAll:       "G28 X0 Y0 Z0",
-		HomeXY:        "G28 X0 Y0",
-		AbsoluteMode:  "G90",
-		FeedMode:      "G94",
-		RapidMove:     "G0",
-		FeedMove:      "G1",
-		EndCode:       []string{"G0 Z[SafeZ]", "G0 X0 Y0", "M5", "M2"},
+		Name:        "Fanuc / Haas",
+		Description: "Fanuc-style controls: % delimiters, O program numbers, N-words and parenthetical comments",
+		IsBuiltIn:   true,
+		Units:       "mm",
+		Post: PostTemplate{
+			Header:         "%\nO{{printf \"%04d\" .Program}}\n" + PostBanner + "G90 G94 G17 G21 G40 G49 G80\nG54",
+			ToolChange:     "T{{.Tool}} M6\nS{{.SpindleSpeed}} M3\nG43 H{{.Tool}} Z{{num .SafeZ}}",
+			Rapid:          PostG0,
+			Linear:         PostG1,
+			Arc:            PostG2G3,
+			Drill:          "G98 " + PostG83Cycle,
+			Footer:         "G0 Z{{.ReturnZ}}\nM5\nG91 G28 Z0\nG90\nG0 X0 Y0\nM30\n%",
+			LineNumberStep: 10,
+		},
+		FileExtension: ".nc",
+		CommentPrefix: "(",
+		CommentSuffix: ")",
+		DecimalPlaces: 3,
+	},
+	{
+		Name:        "Masso",
+		Description: "Masso G3 controller",
+		IsBuiltIn:   true,
+		Units:       "mm",
+		Post: PostTemplate{
+			Header:     PostBanner + "G90 G94 G17 G21\nG54",
+			ToolChange: "T{{.Tool}} M6\nM3 S{{.SpindleSpeed}}",
+			Rapid:      PostG0,
+			Linear:     PostG1,
+			Arc:        PostG2G3,
+			Footer:     "G0 Z{{.ReturnZ}}\nM5\nG0 X0 Y0\nM30",
+		},
+		FileExtension: ".nc",
+		CommentPrefix: "(",
+		CommentSuffix: ")",
+		DecimalPlaces: 3,
+	},
+	{
+		Name:        "UCCNC",
+		Description: "UCCNC control software",
+		IsBuiltIn:   true,
+		Units:       "mm",
+		Post: PostTemplate{
+			Header:     PostBanner + "G17 G21 G90 G94",
+			ToolChange: "T{{.Tool}} M6\nM3 S{{.SpindleSpeed}}",
+			Rapid:      PostG0,
+			Linear:     PostG1,
+			Arc:        PostG2G3,
+			Drill:      "G98 " + PostG83Cycle,
+			Footer:     "G0 Z{{.ReturnZ}}\nM5\nG0 X0 Y0\nM30",
+		},
+		FileExtension: ".tap",
+		CommentPrefix: "(",
+		CommentSuffix: ")",
+		DecimalPlaces: 4,
+	},
+	{
+		Name:        "Centroid",
+		Description: "Centroid Acorn / CNC12 router controls",
+		IsBuiltIn:   true,
+		Units:       "mm",
+		Post: PostTemplate{
+			Header:     PostBanner + "G90 G17 G21 G40 G49 G80",
+			ToolChange: "T{{.Tool}} M6\nG43 H{{.Tool}}\nM3 S{{.SpindleSpeed}}",
+			Rapid:      PostG0,
+			Linear:     PostG1,
+			Arc:        PostG2G3,
+			Drill:      "G98 " + PostG83Cycle,
+			Footer:     "G0 Z{{.ReturnZ}}\nM5\nG0 X0 Y0\nM30",
+		},
+		FileExtension: ".nc",
+		CommentPrefix: ";",
+		DecimalPlaces: 4,
+	},
+	{
+		Name:        "ShopBot",
+		Description: "ShopBot native OpenSBP (.sbp) syntax, metric",
+		IsBuiltIn:   true,
+		Units:       "mm",
+		Post: PostTemplate{
+			Header: PostBanner + "IF %(25)=0 THEN GOTO UNIT_ERROR\nSA\n" +
+				"JS,{{perSecond .FeedRate}},{{perSecond .PlungeRate}}\nTR,{{.SpindleSpeed}}\nC6\nPAUSE 2",
+			Rapid: "{{if .X}}J2,{{.X}},{{.Y}}{{else}}JZ,{{.Z}}{{end}}",
+			Linear: "{{if .F}}MS,{{perSecond .F}},{{perSecond .F}}\n{{end}}" +
+				"{{if and .X .Z}}M3,{{.X}},{{.Y}},{{.Z}}{{else if .X}}M2,{{.X}},{{.Y}}{{else}}MZ,{{.Z}}{{end}}",
+			Arc: "{{if .F}}MS,{{perSecond .F}},{{perSecond .F}}\n{{end}}" +
+				"CG,,{{.X}},{{.Y}},{{.I}},{{.J}},T,{{if .Clockwise}}1{{else}}-1{{end}}{{if .Z}}\nMZ,{{.Z}}{{end}}",
+			Footer: "JZ,{{.ReturnZ}}\nJ2,0,0\nC7\nEND\nUNIT_ERROR:\nCN,91\nEND",
+		},
+		FileExtension: ".sbp",
+		CommentPrefix: "'",
+		DecimalPlaces: 3,
+	},
+	{
+		Name:        "Carbide Motion",
+		Description: "Carbide 3D Shapeoko / Nomad with Carbide Motion",
+		IsBuiltIn:   true,
+		Units:       "mm",
+		Post: PostTemplate{
+			Header:     PostBanner + "G90\nG21",
+			ToolChange: "M6 T{{.Tool}}\nM3 S{{.SpindleSpeed}}",
+			Rapid:      PostG0,
+			Linear:     PostG1,
+			Arc:        PostG2G3,
+			Footer:     "G0 Z{{.ReturnZ}}\nM5\nG0 X0 Y0\nM30",
+		},
+		FileExtension: ".nc",
+		CommentPrefix: "(",
+		CommentSuffix: ")",
+		DecimalPlaces: 3,
+	},
+	{
+		Name:        "Generic",
+		Description: "Generic standard GCode",
+		IsBuiltIn:   true,
+		Units:       "mm",
+		Post: PostTemplate{
+			Header: PostBanner + "G90\nG21\nM3 S{{.SpindleSpeed}}",
+			Rapid:  PostG0,
+			Linear: PostG1,
+			Arc:    PostG2G3,
+			Footer: "G0 Z{{.ReturnZ}}\nG0 X0 Y0\nM5\nM2",
+		},
 		CommentPrefix: ";",
 		CommentSuffix: "",
 		DecimalPlaces: 3,
@@ -806,6 +969,14 @@ func AllProfiles() []GCodeProfile {
 	return all
 }
 
+// Extension returns the file extension for programs written with the profile.
+func (p GCodeProfile) Extension() string {
+	if p.FileExtension == "" {
+		return ".gcode"
+	}
+	return p.FileExtension
+}
+
 // GetProfile returns a GCode profile by name, or the Generic profile if not found.
 // It searches both built-in and custom profiles.
 func GetProfile(name string) GCodeProfile {
@@ -873,24 +1044,27 @@ func NewCustomProfile(name string) GCodeProfile {
 
 func DefaultSettings() CutSettings {
 	return CutSettings{
-		Algorithm:       AlgorithmGuillotine,
-		KerfWidth:       3.2,
-		EdgeTrim:        10.0,
-		GuillotineOnly:  false,
-		ToolDiameter:    6.0,
-		FeedRate:        1500.0,
-		PlungeRate:      500.0,
-		SpindleSpeed:    18000,
-		SafeZ:           5.0,
-		CutDepth:        18.0,
-		PassDepth:       6.0,
-		PartTabWidth:    8.0,
-		PartTabHeight:   2.0,
-		PartTabsPerSide: 0, // Disabled by default
-		UseClimb:        true,
-		LeadInRadius:    0.0,  // Disabled by default
-		LeadOutRadius:   0.0,  // Disabled by default
-		LeadInAngle:     90.0, // 90 degree approach angle
+		Algorithm:          AlgorithmGuillotine,
+		KerfWidth:          3.2,
+		EdgeTrim:           10.0,
+		GuillotineOnly:     false,
+		ThicknessTolerance: 0.5,
+		MaxStackHeight:     80.0,
+		ToolDiameter:       6.0,
+		FeedRate:           1500.0,
+		PlungeRate:         500.0,
+		SpindleSpeed:       18000,
+		SafeZ:              5.0,
+		CutDepth:           18.0,
+		PassDepth:          6.0,
+		PartTabWidth:       8.0,
+		PartTabHeight:      2.0,
+		PartTabsPerSide:    0, // Disabled by default
+		PartTabShape:       TabShapeRectangular,
+		UseClimb:           true,
+		LeadInRadius:       0.0,  // Disabled by default
+		LeadOutRadius:      0.0,  // Disabled by default
+		LeadInAngle:        90.0, // 90 degree approach angle
 		StockTabs: StockTabConfig{
 			Enabled:       true, // Enabled by default
 			AdvancedMode:  false,
@@ -900,31 +1074,42 @@ func DefaultSettings() CutSettings {
 			RightPadding:  25.0,
 			CustomZones:   nil,
 		},
-		GCodeProfile:     "Generic", // Default GCode profile
-		OptimizeToolpath: false,     // Disabled by default
-
-		PlungeType:        PlungeDirect,      // Direct plunge by default
-		RampAngle:         3.0,               // 3 degree ramp angle
-		HelixDiameter:     5.0,               // 5mm helix diameter
-		HelixRevPercent:   50.0,              // 50% of pass depth per revolution
-		CornerOvercut:     CornerOvercutNone, // No corner overcuts by default
-		OnionSkinEnabled:  false,             // Onion skinning disabled by default
-		OnionSkinDepth:    0.2,               // 0.2mm thin skin
-		OnionSkinCleanup:  false,             // No cleanup pass by default
-		DustShoeEnabled:   false,             // Dust shoe collision detection disabled by default
-		DustShoeWidth:     80.0,              // 80mm default dust shoe diameter
-		DustShoeClearance: 5.0,               // 5mm minimum clearance
-		OptimizeWeights:   DefaultOptimizeWeights(),
-		NestingRotations:  2, // Default: 0° and 90° (standard rectangular behavior)
+		GCodeProfile:      "Generic", // Default GCode profile
+		OptimizeToolpath:  false,     // Disabled by default
+		ToolpathOrderTime: 200,       // 200ms to improve the order
+
+		PlungeType:         PlungeDirect,      // Direct plunge by default
+		RampAngle:          3.0,               // 3 degree ramp angle
+		HelixDiameter:      5.0,               // 5mm helix diameter
+		HelixRevPercent:    50.0,              // 50% of pass depth per revolution
+		CornerOvercut:      CornerOvercutNone, // No corner overcuts by default
+		OnionSkinEnabled:   false,             // Onion skinning disabled by default
+		OnionSkinDepth:     0.2,               // 0.2mm thin skin
+		OnionSkinCleanup:   false,             // No cleanup pass by default
+		FinishingPass:      false,             // Roughing passes only by default
+		FinishAllowance:    0.5,               // 0.5mm left for the finishing pass
+		FinishFeedRate:     0,                 // Finish at the cutting feed rate
+		FinishStepDown:     0,                 // Finish at full depth in one pass
+		SmallPartArea:      10000,             // Parts under 100 x 100 mm count as small
+		SmallPartOnionSkin: 0.3,               // 0.3mm skin on small parts
+		SmallPartTabs:      2,                 // 2 tabs per side on small parts
+		TwoStageSkin:       0.5,               // 0.5mm left by the first stage
+		DustShoeEnabled:    false,             // Dust shoe collision detection disabled by default
+		DustShoeWidth:      80.0,              // 80mm default dust shoe diameter
+		DustShoeClearance:  5.0,               // 5mm minimum clearance
+		OptimizeWeights:    DefaultOptimizeWeights(),
+		NestingRotations:   2, // Default: 0° and 90° (standard rectangular behavior)
+		Linear:             DefaultLinearSettings(),
 	}
 }
 
 // Placement represents a single part placed on a stock sheet.
 type Placement struct {
 	Part    Part    `json:"part"`
-	X       float64 `json:"x"`       // Position from left edge (mm)
-	Y       float64 `json:"y"`       // Position from top edge (mm)
-	Rotated bool    `json:"rotated"` // Whether part was rotated 90°
+	X       float64 `json:"x"`                // Position from left edge (mm)
+	Y       float64 `json:"y"`                // Position from top edge (mm)
+	Rotated bool    `json:"rotated"`          // Whether part was rotated 90°
+	Pinned  bool    `json:"pinned,omitempty"` // Positioned by hand; kept when re-optimizing around it
 }
 
 // PlacedWidth returns the effective width considering rotation.
@@ -943,10 +1128,48 @@ func (p Placement) PlacedHeight() float64 {
 	return p.Part.Height
 }
 
+// DrillPositions returns the part's drill holes in stock coordinates.
+// A rotated placement turns the part 90° so that the part's height runs
+// along the sheet's X axis.
+func (p Placement) DrillPositions() []DrillHole {
+	holes := make([]DrillHole, len(p.Part.Drills))
+	for i, h := range p.Part.Drills {
+		h.X, h.Y = p.ToStock(h.X, h.Y)
+		holes[i] = h
+	}
+	return holes
+}
+
+// CutoutRects returns the bounding rectangles of the part's cutouts in stock
+// coordinates, turned with the part by ToStock like its drill holes and tabs.
+func (p Placement) CutoutRects() []CutoutRect {
+	bounds := p.Part.CutoutBounds()
+	rects := make([]CutoutRect, len(bounds))
+	for i, c := range bounds {
+		x0, y0 := p.ToStock(c.X, c.Y)
+		x1, y1 := p.ToStock(c.X+c.Width, c.Y+c.Height)
+		rects[i] = CutoutRect{
+			X:      math.Min(x0, x1),
+			Y:      math.Min(y0, y1),
+			Width:  math.Abs(x1 - x0),
+			Height: math.Abs(y1 - y0),
+		}
+	}
+	return rects
+}
+
 // SheetResult represents one stock sheet with its placed parts.
 type SheetResult struct {
 	Stock      StockSheet  `json:"stock"`
 	Placements []Placement `json:"placements"`
+
+	// Roll is set when the sheet was cut from roll stock. Stock.Height is
+	// then the length cut off the roll.
+	Roll *RollUsage `json:"roll,omitempty"`
+
+	// Locked sheets are kept exactly as they are when re-optimizing: their
+	// placements do not move and no other parts are added to them.
+	Locked bool `json:"locked,omitempty"`
 }
 
 // UsedArea returns the total area used by placed parts.
@@ -976,6 +1199,23 @@ func (sr SheetResult) Efficiency() float64 {
 type OptimizeResult struct {
 	Sheets        []SheetResult `json:"sheets"`
 	UnplacedParts []Part        `json:"unplaced_parts"`
+	Warnings      []string      `json:"warnings,omitempty"` // e.g. parts without compatible stock
+}
+
+// HasLocks reports whether any sheet is locked or any placement pinned, so
+// that re-optimizing should keep them in place.
+func (or OptimizeResult) HasLocks() bool {
+	for _, s := range or.Sheets {
+		if s.Locked {
+			return true
+		}
+		for _, p := range s.Placements {
+			if p.Pinned {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // TotalEfficiency returns overall material usage percentage.
@@ -1066,6 +1306,40 @@ type Project struct {
 	Stocks   []StockSheet    `json:"stocks"`
 	Settings CutSettings     `json:"settings"`
 	Result   *OptimizeResult `json:"result,omitempty"`
+
+	// Products group parts into assemblies (e.g. cabinets). Their parts are
+	// expanded alongside the loose Parts by AllParts.
+	Products []Product `json:"products,omitempty"`
+
+	// Quote holds the pricing inputs for customer quotes.
+	Quote QuoteSettings `json:"quote"`
+
+	// ImportSources records the files parts were imported from (CSV, Excel, DXF)
+	// so they can be shipped alongside the project in a bundle.
+	ImportSources []string `json:"import_sources,omitempty"`
+
+	// ImportData holds the contents of import sources opened from a bundle,
+	// keyed by their path in ImportSources, so exporting the project as a
+	// bundle again keeps them. It is not saved with the project.
+	ImportData map[string][]byte `json:"-"`
+
+	// LinearStocks are the bars available for linear parts, and LinearResult
+	// is the latest 1D cutting plan.
+	LinearStocks []LinearStock `json:"linear_stocks,omitempty"`
+	LinearResult *LinearResult `json:"linear_result,omitempty"`
+}
+
+// AddImportSource records path as an import source, ignoring duplicates.
+func (p *Project) AddImportSource(path string) {
+	if path == "" {
+		return
+	}
+	for _, existing := range p.ImportSources {
+		if existing == path {
+			return
+		}
+	}
+	p.ImportSources = append(p.ImportSources, path)
 }
 
 func NewProject() Project {
@@ -1074,5 +1348,6 @@ func NewProject() Project {
 		Parts:    []Part{},
 		Stocks:   []StockSheet{},
 		Settings: DefaultSettings(),
+		Quote:    DefaultQuoteSettings(),
 	}
 }
@@ -150,8 +150,8 @@ func TestNewCustomProfile(t *testing.T) {
 		t.Error("custom profile should not be built-in")
 	}
 	// Should inherit Generic defaults
-	if p.RapidMove != "G0" {
-		t.Errorf("expected G0 rapid move from Generic, got %s", p.RapidMove)
+	if p.Post != GetProfile("Generic").Post {
+		t.Errorf("expected the Generic post-processor template, got %+v", p.Post)
 	}
 }
 
@@ -209,3 +209,22 @@ func TestDefaultSettingsHasNoClampZones(t *testing.T) {
 		t.Errorf("expected no clamp zones by default, got %d", len(s.ClampZones))
 	}
 }
+
+func TestOptimizeResultHasLocks(t *testing.T) {
+	part := NewPart("A", 100, 100, 1)
+	result := OptimizeResult{Sheets: []SheetResult{{Placements: []Placement{{Part: part}}}}}
+	if result.HasLocks() {
+		t.Error("expected no locks on a fresh result")
+	}
+
+	result.Sheets[0].Placements[0].Pinned = true
+	if !result.HasLocks() {
+		t.Error("expected a pinned placement to count as a lock")
+	}
+
+	result.Sheets[0].Placements[0].Pinned = false
+	result.Sheets[0].Locked = true
+	if !result.HasLocks() {
+		t.Error("expected a locked sheet to count as a lock")
+	}
+}
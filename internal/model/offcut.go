@@ -9,14 +9,15 @@ import (
 
 // Offcut represents a usable rectangular remnant area left over after cutting.
 type Offcut struct {
-	ID            string  `json:"id"`
-	SheetLabel    string  `json:"sheet_label"`     // Which sheet it came from
-	SheetIndex    int     `json:"sheet_index"`     // Index of the source sheet in the result
-	X             float64 `json:"x"`               // Position on the sheet (mm from left)
-	Y             float64 `json:"y"`               // Position on the sheet (mm from top)
-	Width         float64 `json:"width"`           // Usable width (mm)
-	Height        float64 `json:"height"`          // Usable height (mm)
-	PricePerSheet float64 `json:"price_per_sheet"` // Inherited price proportional to area (0 if not set)
+	ID            string   `json:"id"`
+	SheetLabel    string   `json:"sheet_label"`       // Which sheet it came from
+	SheetIndex    int      `json:"sheet_index"`       // Index of the source sheet in the result
+	X             float64  `json:"x"`                 // Position on the sheet (mm from left)
+	Y             float64  `json:"y"`                 // Position on the sheet (mm from top)
+	Width         float64  `json:"width"`             // Usable width (mm)
+	Height        float64  `json:"height"`            // Usable height (mm)
+	PricePerSheet float64  `json:"price_per_sheet"`   // Inherited price proportional to area (0 if not set)
+	Defects       []Defect `json:"defects,omitempty"` // Source sheet defects within the offcut, in offcut coordinates
 }
 
 // Area returns the area of the offcut in square mm.
@@ -29,6 +30,7 @@ func (o Offcut) ToStockSheet() StockSheet {
 	label := "Offcut " + o.SheetLabel
 	sheet := NewStockSheet(label, o.Width, o.Height, 1)
 	sheet.PricePerSheet = o.PricePerSheet
+	sheet.Defects = o.Defects
 	return sheet
 }
 
@@ -57,6 +59,7 @@ func DetectOffcuts(sr SheetResult, sheetIndex int, kerf float64) []Offcut {
 			Width:         sheetW,
 			Height:        sheetH,
 			PricePerSheet: sr.Stock.PricePerSheet,
+			Defects:       sr.Stock.Defects,
 		}}
 	}
 
@@ -104,6 +107,11 @@ func DetectOffcuts(sr SheetResult, sheetIndex int, kerf float64) []Offcut {
 		})
 	}
 
+	for i := range offcuts {
+		o := &offcuts[i]
+		o.Defects = DefectsInRect(sr.Stock.Defects, o.X, o.Y, o.Width, o.Height)
+	}
+
 	// Assign proportional pricing to offcuts
 	if sr.Stock.PricePerSheet > 0 {
 		totalSheetArea := sheetW * sheetH
@@ -0,0 +1,155 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// TabShape is the side profile of a part holding tab.
+type TabShape string
+
+const (
+	TabShapeRectangular TabShape = "rectangular" // Straight up, across and back down
+	TabShapeTrapezoid   TabShape = "trapezoid"   // Ramps up over the tab and back down
+	TabShapeTriangular  TabShape = "triangular"  // Peaks in the middle so it snaps off cleanly
+)
+
+// TabShapeOptions returns available tab shape choices for UI display.
+func TabShapeOptions() []string {
+	return []string{"Rectangular", "Trapezoid", "Triangular"}
+}
+
+// TabShapeFromString converts a display string to a TabShape.
+func TabShapeFromString(s string) TabShape {
+	switch s {
+	case "Trapezoid":
+		return TabShapeTrapezoid
+	case "Triangular":
+		return TabShapeTriangular
+	default:
+		return TabShapeRectangular
+	}
+}
+
+// String returns the display name for a TabShape.
+func (t TabShape) String() string {
+	switch t {
+	case TabShapeTrapezoid:
+		return "Trapezoid"
+	case TabShapeTriangular:
+		return "Triangular"
+	default:
+		return "Rectangular"
+	}
+}
+
+// PartTab is a hand-placed holding tab: a point on the part's edge in part
+// coordinates, before any rotation on the sheet.
+type PartTab struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// EdgePoint returns the point on the part's edge nearest to (x, y), in part
+// coordinates. Outline parts use their outline, other parts their rectangle.
+func (p Part) EdgePoint(x, y float64) Point2D {
+	edge := p.Outline
+	if len(edge) < 3 {
+		edge = Outline{{X: 0, Y: 0}, {X: p.Width, Y: 0}, {X: p.Width, Y: p.Height}, {X: 0, Y: p.Height}}
+	}
+	best, bestDist := edge[0], math.Inf(1)
+	for i := range edge {
+		a, b := edge[i], edge[(i+1)%len(edge)]
+		dx, dy := b.X-a.X, b.Y-a.Y
+		t := 0.0
+		if l := dx*dx + dy*dy; l > 0 {
+			t = math.Max(0, math.Min(1, ((x-a.X)*dx+(y-a.Y)*dy)/l))
+		}
+		q := Point2D{X: a.X + dx*t, Y: a.Y + dy*t}
+		if d := math.Hypot(x-q.X, y-q.Y); d < bestDist {
+			best, bestDist = q, d
+		}
+	}
+	return best
+}
+
+// TabShapeOrDefault returns the part's tab shape, falling back to the
+// settings and then to rectangular tabs.
+func (p Part) TabShapeOrDefault(settings CutSettings) TabShape {
+	switch {
+	case p.TabShape != "":
+		return p.TabShape
+	case settings.PartTabShape != "":
+		return settings.PartTabShape
+	default:
+		return TabShapeRectangular
+	}
+}
+
+// ToStock converts a point in part coordinates to stock coordinates. A
+// rotated placement turns the part 90° so that the part's height runs along
+// the sheet's X axis.
+func (p Placement) ToStock(x, y float64) (float64, float64) {
+	if p.Rotated {
+		x, y = p.Part.Height-y, x
+	}
+	return x + p.X, y + p.Y
+}
+
+// ToPart converts a point in stock coordinates to part coordinates; it is
+// the inverse of ToStock.
+func (p Placement) ToPart(x, y float64) (float64, float64) {
+	x -= p.X
+	y -= p.Y
+	if p.Rotated {
+		x, y = y, p.Part.Height-x
+	}
+	return x, y
+}
+
+// TabPositions returns the part's hand-placed tabs in stock coordinates.
+func (p Placement) TabPositions() []Point2D {
+	pts := make([]Point2D, len(p.Part.Tabs))
+	for i, t := range p.Part.Tabs {
+		pts[i].X, pts[i].Y = p.ToStock(t.X, t.Y)
+	}
+	return pts
+}
+
+// FormatPartTabs writes tab positions as "x,y; x,y" for editing as text.
+func FormatPartTabs(tabs []PartTab) string {
+	parts := make([]string, len(tabs))
+	for i, t := range tabs {
+		parts[i] = fmt.Sprintf("%g,%g", t.X, t.Y)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// ParsePartTabs reads tab positions written by FormatPartTabs. Each position
+// is moved onto the nearest edge of the part.
+func ParsePartTabs(s string, part Part) ([]PartTab, error) {
+	var tabs []PartTab
+	for _, field := range strings.Split(s, ";") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		xy := strings.Split(field, ",")
+		if len(xy) != 2 {
+			return nil, fmt.Errorf("invalid tab position %q: expected x,y", field)
+		}
+		x, err := strconv.ParseFloat(strings.TrimSpace(xy[0]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tab position %q: %w", field, err)
+		}
+		y, err := strconv.ParseFloat(strings.TrimSpace(xy[1]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tab position %q: %w", field, err)
+		}
+		pt := part.EdgePoint(x, y)
+		tabs = append(tabs, PartTab{X: pt.X, Y: pt.Y})
+	}
+	return tabs, nil
+}
@@ -0,0 +1,87 @@
+package model
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPart_EdgePoint(t *testing.T) {
+	rect := NewPart("Side", 200, 100, 1)
+	tests := []struct {
+		x, y, wantX, wantY float64
+	}{
+		{50, 10, 50, 0},     // Near the first edge
+		{190, 60, 200, 60},  // Near the right edge
+		{-20, 130, 0, 100},  // Outside a corner
+		{120, 95, 120, 100}, // Near the last edge
+	}
+	for _, tt := range tests {
+		got := rect.EdgePoint(tt.x, tt.y)
+		if math.Abs(got.X-tt.wantX) > 1e-9 || math.Abs(got.Y-tt.wantY) > 1e-9 {
+			t.Errorf("EdgePoint(%g, %g) = %v, want (%g, %g)", tt.x, tt.y, got, tt.wantX, tt.wantY)
+		}
+	}
+
+	tri := Part{Width: 100, Height: 100, Outline: Outline{{X: 0, Y: 0}, {X: 100, Y: 0}, {X: 0, Y: 100}}}
+	got := tri.EdgePoint(60, 60)
+	if math.Abs(got.X-50) > 1e-9 || math.Abs(got.Y-50) > 1e-9 {
+		t.Errorf("expected the point on the hypotenuse, got %v", got)
+	}
+}
+
+func TestPlacement_ToStockToPart(t *testing.T) {
+	for _, rotated := range []bool{false, true} {
+		p := Placement{Part: NewPart("Side", 200, 100, 1), X: 10, Y: 20, Rotated: rotated}
+		sx, sy := p.ToStock(150, 0)
+		x, y := p.ToPart(sx, sy)
+		if math.Abs(x-150) > 1e-9 || math.Abs(y) > 1e-9 {
+			t.Errorf("rotated=%v: round trip gave (%g, %g)", rotated, x, y)
+		}
+	}
+
+	p := Placement{Part: NewPart("Side", 200, 100, 1), X: 10, Y: 20, Rotated: true}
+	p.Part.Tabs = []PartTab{{X: 150, Y: 0}}
+	got := p.TabPositions()
+	// The part's first edge runs down the right side of the rotated placement
+	if got[0].X != 110 || got[0].Y != 170 {
+		t.Errorf("TabPositions = %v, want [(110, 170)]", got)
+	}
+}
+
+func TestParsePartTabs(t *testing.T) {
+	part := NewPart("Side", 200, 100, 1)
+	tabs, err := ParsePartTabs("50, 2; 198,60", part)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []PartTab{{X: 50, Y: 0}, {X: 200, Y: 60}}
+	if len(tabs) != 2 || tabs[0] != want[0] || tabs[1] != want[1] {
+		t.Errorf("ParsePartTabs = %v, want %v", tabs, want)
+	}
+	if got := FormatPartTabs(tabs); got != "50,0; 200,60" {
+		t.Errorf("FormatPartTabs = %q", got)
+	}
+
+	if tabs, err := ParsePartTabs("  ", part); err != nil || tabs != nil {
+		t.Errorf("expected no tabs for blank input, got %v, %v", tabs, err)
+	}
+	if _, err := ParsePartTabs("50", part); err == nil {
+		t.Error("expected an error for a position without y")
+	}
+}
+
+func TestTabShapeOrDefault(t *testing.T) {
+	settings := DefaultSettings()
+	settings.PartTabShape = TabShapeTrapezoid
+	part := NewPart("Side", 200, 100, 1)
+	if got := part.TabShapeOrDefault(settings); got != TabShapeTrapezoid {
+		t.Errorf("expected settings shape, got %q", got)
+	}
+	part.TabShape = TabShapeTriangular
+	if got := part.TabShapeOrDefault(settings); got != TabShapeTriangular {
+		t.Errorf("expected part shape, got %q", got)
+	}
+	if got := TabShapeFromString(TabShapeTriangular.String()); got != TabShapeTriangular {
+		t.Errorf("display round trip gave %q", got)
+	}
+}
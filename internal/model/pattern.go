@@ -0,0 +1,122 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// patternEpsilon absorbs floating point noise when comparing positions.
+const patternEpsilon = 0.01
+
+// SheetPattern groups identical sheets of a result: the same stock size and
+// material cut with the same layout, so they can be documented and
+// programmed once and run several times.
+type SheetPattern struct {
+	Sheet  SheetResult `json:"sheet"`  // The first sheet with this layout
+	Sheets []int       `json:"sheets"` // 0-based indexes of all sheets with this layout
+}
+
+// Count returns how many sheets are cut with the pattern.
+func (p SheetPattern) Count() int {
+	return len(p.Sheets)
+}
+
+// SheetNumbers returns the 1-based sheet numbers, e.g. "1, 2, 5".
+func (p SheetPattern) SheetNumbers() string {
+	nums := make([]string, len(p.Sheets))
+	for i, idx := range p.Sheets {
+		nums[i] = fmt.Sprintf("%d", idx+1)
+	}
+	return strings.Join(nums, ", ")
+}
+
+// StackSize returns how many sheets of the pattern are cut together in one
+// run. It is 1 unless stacked cutting is enabled; then sheets are stacked up
+// to MaxStackHeight (0 means no limit), at least one per run.
+func (p SheetPattern) StackSize(settings CutSettings) int {
+	if !settings.StackCutting || p.Count() == 0 {
+		return 1
+	}
+	thickness := p.Sheet.Stock.Thickness
+	if thickness <= 0 {
+		thickness = settings.CutDepth
+	}
+	if settings.MaxStackHeight <= 0 || thickness <= 0 {
+		return p.Count()
+	}
+	n := int(math.Floor(settings.MaxStackHeight/thickness + patternEpsilon))
+	if n < 1 {
+		return 1
+	}
+	if n > p.Count() {
+		return p.Count()
+	}
+	return n
+}
+
+// Runs returns how many times the pattern has to be cut.
+func (p SheetPattern) Runs(settings CutSettings) int {
+	size := p.StackSize(settings)
+	return (p.Count() + size - 1) / size
+}
+
+// Patterns groups the sheets of a result into patterns of identical sheets,
+// in order of their first sheet. A result without repeated layouts has one
+// pattern per sheet.
+func (or OptimizeResult) Patterns() []SheetPattern {
+	var patterns []SheetPattern
+	for i, sheet := range or.Sheets {
+		found := false
+		for j := range patterns {
+			if SameLayout(patterns[j].Sheet, sheet) {
+				patterns[j].Sheets = append(patterns[j].Sheets, i)
+				found = true
+				break
+			}
+		}
+		if !found {
+			patterns = append(patterns, SheetPattern{Sheet: sheet, Sheets: []int{i}})
+		}
+	}
+	return patterns
+}
+
+// SameLayout reports whether two sheets are cut identically: stock of the
+// same size, thickness and material carrying the same parts at the same
+// positions and orientations, in any order.
+func SameLayout(a, b SheetResult) bool {
+	if a.Stock.Width != b.Stock.Width || a.Stock.Height != b.Stock.Height ||
+		a.Stock.Thickness != b.Stock.Thickness || a.Stock.Material != b.Stock.Material ||
+		len(a.Placements) != len(b.Placements) {
+		return false
+	}
+	pa, pb := sortedPlacements(a.Placements), sortedPlacements(b.Placements)
+	for i := range pa {
+		if !samePlacement(pa[i], pb[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// sortedPlacements returns a copy of placements ordered top to bottom, then
+// left to right.
+func sortedPlacements(placements []Placement) []Placement {
+	sorted := append([]Placement(nil), placements...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if math.Abs(sorted[i].Y-sorted[j].Y) > patternEpsilon {
+			return sorted[i].Y < sorted[j].Y
+		}
+		return sorted[i].X < sorted[j].X
+	})
+	return sorted
+}
+
+func samePlacement(a, b Placement) bool {
+	return a.Part.ID == b.Part.ID && a.Part.Label == b.Part.Label &&
+		a.Part.Width == b.Part.Width && a.Part.Height == b.Part.Height &&
+		a.Rotated == b.Rotated &&
+		math.Abs(a.X-b.X) < patternEpsilon && math.Abs(a.Y-b.Y) < patternEpsilon
+}
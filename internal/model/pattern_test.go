@@ -0,0 +1,69 @@
+package model
+
+import "testing"
+
+func patternSheet(stock StockSheet, a, b Part) SheetResult {
+	return SheetResult{Stock: stock, Placements: []Placement{
+		{Part: a, X: 10, Y: 10},
+		{Part: b, X: 620, Y: 10, Rotated: true},
+	}}
+}
+
+func TestOptimizeResult_Patterns(t *testing.T) {
+	stock := NewStockSheet("Sheet", 2440, 1220, 6)
+	side := NewPart("Side", 600, 400, 8)
+	shelf := NewPart("Shelf", 500, 300, 8)
+
+	same := patternSheet(stock, side, shelf)
+	// Same layout with placements listed in another order
+	reordered := SheetResult{Stock: stock, Placements: []Placement{same.Placements[1], same.Placements[0]}}
+	moved := patternSheet(stock, side, shelf)
+	moved.Placements[1].X = 700
+
+	result := OptimizeResult{Sheets: []SheetResult{same, moved, reordered, same}}
+	patterns := result.Patterns()
+	if len(patterns) != 2 {
+		t.Fatalf("expected 2 patterns, got %d", len(patterns))
+	}
+	if patterns[0].Count() != 3 || patterns[0].SheetNumbers() != "1, 3, 4" {
+		t.Errorf("first pattern covers sheets %q", patterns[0].SheetNumbers())
+	}
+	if patterns[1].Count() != 1 || patterns[1].Sheets[0] != 1 {
+		t.Errorf("second pattern covers sheets %v", patterns[1].Sheets)
+	}
+
+	thinner := stock
+	thinner.Thickness = 12
+	if SameLayout(same, patternSheet(thinner, side, shelf)) {
+		t.Error("sheets of different thickness should not share a pattern")
+	}
+}
+
+func TestSheetPattern_StackSizeAndRuns(t *testing.T) {
+	stock := NewStockSheet("Sheet", 2440, 1220, 6) // 18 mm
+	p := SheetPattern{Sheet: SheetResult{Stock: stock}, Sheets: []int{0, 1, 2, 3, 4, 5}}
+	settings := DefaultSettings()
+
+	if got := p.Runs(settings); got != 6 {
+		t.Errorf("without stacking Runs = %d, want 6", got)
+	}
+
+	settings.StackCutting = true
+	settings.MaxStackHeight = 75
+	if got := p.StackSize(settings); got != 4 {
+		t.Errorf("StackSize = %d, want 4", got)
+	}
+	if got := p.Runs(settings); got != 2 {
+		t.Errorf("Runs = %d, want 2", got)
+	}
+
+	settings.MaxStackHeight = 0
+	if got := p.Runs(settings); got != 1 {
+		t.Errorf("unlimited stack Runs = %d, want 1", got)
+	}
+
+	settings.MaxStackHeight = 10
+	if got := p.StackSize(settings); got != 1 {
+		t.Errorf("stack lower than one sheet should still cut one sheet, got %d", got)
+	}
+}
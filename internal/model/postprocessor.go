@@ -0,0 +1,98 @@
+package model
+
+import "strings"
+
+// PostTemplate defines a post-processor as a set of named blocks, each a Go
+// text/template rendered with the job and move variables (see
+// gcode.PostVars). Blocks can also call comment, num and perSecond. Blank
+// lines in the rendered output are dropped, so blocks can use {{if}} freely.
+type PostTemplate struct {
+	Header     string `json:"header"`                // Start of the program, before the first move
+	ToolChange string `json:"tool_change,omitempty"` // Tool change, after the header
+	Rapid      string `json:"rapid"`                 // Rapid move; X, Y and Z are empty when not moved
+	Linear     string `json:"linear"`                // Feed move; F is empty when the feed rate is modal
+	Arc        string `json:"arc"`                   // Arc or helix; Clockwise selects G2 over G3
+	Drill      string `json:"drill,omitempty"`       // Canned drill cycle (empty = peck with linear moves)
+	Footer     string `json:"footer"`                // End of the program
+
+	LineNumberStep int `json:"line_number_step,omitempty"` // N-word increment (0 = no line numbers)
+}
+
+// PostBlock is one named block of a post-processor template.
+type PostBlock struct {
+	Name string
+	Text string
+}
+
+// Post-processor block names.
+const (
+	BlockHeader     = "header"
+	BlockToolChange = "tool_change"
+	BlockRapid      = "rapid"
+	BlockLinear     = "linear"
+	BlockArc        = "arc"
+	BlockDrill      = "drill"
+	BlockFooter     = "footer"
+)
+
+// IsZero reports whether no block is defined.
+func (t PostTemplate) IsZero() bool {
+	for _, b := range t.Blocks() {
+		if b.Text != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// Blocks returns the blocks in program order.
+func (t PostTemplate) Blocks() []PostBlock {
+	return []PostBlock{
+		{BlockHeader, t.Header},
+		{BlockToolChange, t.ToolChange},
+		{BlockRapid, t.Rapid},
+		{BlockLinear, t.Linear},
+		{BlockArc, t.Arc},
+		{BlockDrill, t.Drill},
+		{BlockFooter, t.Footer},
+	}
+}
+
+// Common post-processor blocks for controllers using standard G-code words.
+const (
+	PostBanner   = "{{range .Banner}}{{comment .}}\n{{end}}"
+	PostG0       = "G0{{if .X}} X{{.X}}{{end}}{{if .Y}} Y{{.Y}}{{end}}{{if .Z}} Z{{.Z}}{{end}}"
+	PostG1       = "G1{{if .X}} X{{.X}}{{end}}{{if .Y}} Y{{.Y}}{{end}}{{if .Z}} Z{{.Z}}{{end}}{{if .F}} F{{.F}}{{end}}"
+	PostG2G3     = "G{{if .Clockwise}}2{{else}}3{{end}} X{{.X}} Y{{.Y}}{{if .Z}} Z{{.Z}}{{end}} I{{.I}} J{{.J}}{{if .F}} F{{.F}}{{end}}"
+	PostG83Cycle = "G83 X{{.X}} Y{{.Y}} Z{{.Z}} R{{.R}} Q{{.Q}} F{{.F}}\nG80"
+)
+
+// Template returns the profile's post-processor. Profiles saved before
+// templates existed only have the fixed command strings; they are turned
+// into an equivalent template.
+func (p GCodeProfile) Template() PostTemplate {
+	if !p.Post.IsZero() {
+		return p.Post
+	}
+	axes := "{{if .X}} X{{.X}}{{end}}{{if .Y}} Y{{.Y}}{{end}}{{if .Z}} Z{{.Z}}{{end}}"
+
+	header := append([]string(nil), p.StartCode...)
+	if p.SpindleStart != "" {
+		header = append(header, strings.ReplaceAll(p.SpindleStart, "%d", "{{.SpindleSpeed}}"))
+	}
+	var footer []string
+	for _, code := range p.EndCode {
+		footer = append(footer, strings.ReplaceAll(code, "[SafeZ]", "{{.ReturnZ}}"))
+	}
+	if p.SpindleStop != "" {
+		footer = append(footer, p.SpindleStop)
+	}
+
+	return PostTemplate{
+		Header: PostBanner + strings.Join(header, "\n"),
+		Rapid:  p.RapidMove + axes,
+		Linear: p.FeedMove + axes + "{{if .F}} F{{.F}}{{end}}",
+		Arc:    PostG2G3,
+		Footer: strings.Join(footer, "\n"),
+	}
+}
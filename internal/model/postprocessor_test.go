@@ -0,0 +1,46 @@
+package model
+
+import "testing"
+
+func TestTemplate_UsesPost(t *testing.T) {
+	p := GetProfile("Grbl")
+	if p.Template() != p.Post {
+		t.Error("a profile with a template should use it as is")
+	}
+}
+
+func TestTemplate_ConvertsLegacyProfile(t *testing.T) {
+	p := GCodeProfile{
+		StartCode:    []string{"G90"},
+		SpindleStart: "M3 S%d",
+		SpindleStop:  "M5",
+		RapidMove:    "G00",
+		FeedMove:     "G01",
+		EndCode:      []string{"G00 Z[SafeZ]", "M30"},
+	}
+	tmpl := p.Template()
+	if tmpl.IsZero() {
+		t.Fatal("expected a template from the legacy fields")
+	}
+	if want := PostBanner + "G90\nM3 S{{.SpindleSpeed}}"; tmpl.Header != want {
+		t.Errorf("header = %q, want %q", tmpl.Header, want)
+	}
+	if want := "G00 Z{{.ReturnZ}}\nM30\nM5"; tmpl.Footer != want {
+		t.Errorf("footer = %q, want %q", tmpl.Footer, want)
+	}
+	if tmpl.Rapid[:3] != "G00" || tmpl.Linear[:3] != "G01" {
+		t.Errorf("expected legacy motion words, got %q and %q", tmpl.Rapid, tmpl.Linear)
+	}
+}
+
+func TestPostTemplate_IsZero(t *testing.T) {
+	if !(PostTemplate{}).IsZero() {
+		t.Error("empty template should be zero")
+	}
+	if !(PostTemplate{LineNumberStep: 10}).IsZero() {
+		t.Error("a template without blocks should be zero")
+	}
+	if (PostTemplate{Footer: "M2"}).IsZero() {
+		t.Error("a template with a block should not be zero")
+	}
+}
@@ -0,0 +1,110 @@
+package model
+
+import (
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// Product is an assembly (e.g. a cabinet) made of several parts. Building
+// Quantity units of the product requires Quantity times each part's quantity.
+type Product struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Quantity int    `json:"quantity"` // Number of units to build
+	Parts    []Part `json:"parts"`    // Part quantities are per single unit
+	Notes    string `json:"notes,omitempty"`
+}
+
+// NewProduct creates an empty product with a generated ID.
+func NewProduct(name string, qty int) Product {
+	return Product{
+		ID:       uuid.New().String()[:8],
+		Name:     name,
+		Quantity: qty,
+		Parts:    []Part{},
+	}
+}
+
+// ExpandedParts returns the product's parts with quantities multiplied by
+// the product quantity and tagged with the product ID and name.
+func (p Product) ExpandedParts() []Part {
+	qty := p.Quantity
+	if qty < 1 {
+		qty = 1
+	}
+	parts := make([]Part, 0, len(p.Parts))
+	for _, part := range p.Parts {
+		part.Quantity *= qty
+		part.ProductID = p.ID
+		part.ProductName = p.Name
+		parts = append(parts, part)
+	}
+	return parts
+}
+
+// PieceCount returns the total number of pieces needed for all units.
+func (p Product) PieceCount() int {
+	total := 0
+	for _, part := range p.ExpandedParts() {
+		total += part.Quantity
+	}
+	return total
+}
+
+// AllParts returns the loose parts followed by the expanded parts of every
+// product. This is the list that should be handed to the optimizer.
+func (p Project) AllParts() []Part {
+	all := make([]Part, 0, len(p.Parts))
+	all = append(all, p.Parts...)
+	for _, prod := range p.Products {
+		all = append(all, prod.ExpandedParts()...)
+	}
+	return all
+}
+
+// FindProduct returns a pointer to the product with the given ID, or nil.
+func (p *Project) FindProduct(id string) *Product {
+	for i := range p.Products {
+		if p.Products[i].ID == id {
+			return &p.Products[i]
+		}
+	}
+	return nil
+}
+
+// Product returns the name of the product the placed part belongs to,
+// or an empty string for loose parts.
+func (pl Placement) Product() string {
+	return pl.Part.ProductName
+}
+
+// ProductPlacements groups the placements of a result by product ID, so
+// products that share a name stay apart. Loose parts are grouped under the
+// empty string. IDs are returned sorted by product name with loose parts
+// last, and each group keeps placement order.
+func ProductPlacements(result OptimizeResult) ([]string, map[string][]Placement) {
+	groups := make(map[string][]Placement)
+	for _, sheet := range result.Sheets {
+		for _, pl := range sheet.Placements {
+			groups[pl.Part.ProductID] = append(groups[pl.Part.ProductID], pl)
+		}
+	}
+	ids := make([]string, 0, len(groups))
+	for id := range groups {
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		a, b := groups[ids[i]][0].Product(), groups[ids[j]][0].Product()
+		if a != b {
+			return a < b
+		}
+		return ids[i] < ids[j]
+	})
+	if _, ok := groups[""]; ok {
+		ids = append(ids, "")
+	}
+	return ids, groups
+}
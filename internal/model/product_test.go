@@ -0,0 +1,80 @@
+package model
+
+import "testing"
+
+func TestProductExpandedParts(t *testing.T) {
+	prod := NewProduct("Wall Cabinet", 3)
+	prod.Parts = append(prod.Parts, NewPart("Side", 700, 300, 2), NewPart("Top", 560, 300, 1))
+
+	parts := prod.ExpandedParts()
+	if len(parts) != 2 {
+		t.Fatalf("expected 2 parts, got %d", len(parts))
+	}
+	if parts[0].Quantity != 6 || parts[1].Quantity != 3 {
+		t.Errorf("expected quantities 6 and 3, got %d and %d", parts[0].Quantity, parts[1].Quantity)
+	}
+	for _, p := range parts {
+		if p.ProductID != prod.ID || p.ProductName != "Wall Cabinet" {
+			t.Errorf("part %q not tagged with product: %q/%q", p.Label, p.ProductID, p.ProductName)
+		}
+	}
+	if prod.Parts[0].Quantity != 2 || prod.Parts[0].ProductID != "" {
+		t.Error("ExpandedParts must not modify the product's own parts")
+	}
+	if prod.PieceCount() != 9 {
+		t.Errorf("expected 9 pieces, got %d", prod.PieceCount())
+	}
+}
+
+func TestProjectAllParts(t *testing.T) {
+	proj := NewProject()
+	proj.Parts = append(proj.Parts, NewPart("Loose", 100, 100, 1))
+	prod := NewProduct("Drawer", 0) // zero quantity is treated as one unit
+	prod.Parts = append(prod.Parts, NewPart("Front", 400, 150, 1))
+	proj.Products = append(proj.Products, prod)
+
+	all := proj.AllParts()
+	if len(all) != 2 {
+		t.Fatalf("expected 2 parts, got %d", len(all))
+	}
+	if all[0].ProductName != "" {
+		t.Error("loose part should not have a product")
+	}
+	if all[1].ProductName != "Drawer" || all[1].Quantity != 1 {
+		t.Errorf("unexpected product part: %+v", all[1])
+	}
+	if proj.FindProduct(prod.ID) == nil {
+		t.Error("FindProduct should find the product by ID")
+	}
+}
+
+func TestProductPlacements(t *testing.T) {
+	result := OptimizeResult{Sheets: []SheetResult{
+		{Placements: []Placement{
+			{Part: Part{Label: "A", ProductID: "t1", ProductName: "Tall"}},
+			{Part: Part{Label: "B"}},
+		}},
+		{Placements: []Placement{
+			{Part: Part{Label: "C", ProductID: "b1", ProductName: "Base"}},
+			{Part: Part{Label: "D", ProductID: "t1", ProductName: "Tall"}},
+			{Part: Part{Label: "E", ProductID: "t2", ProductName: "Tall"}},
+		}},
+	}}
+
+	ids, groups := ProductPlacements(result)
+	want := []string{"b1", "t1", "t2", ""}
+	if len(ids) != len(want) {
+		t.Fatalf("expected ids %v, got %v", want, ids)
+	}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Errorf("ids[%d] = %q, want %q", i, ids[i], want[i])
+		}
+	}
+	if len(groups["t1"]) != 2 || groups["t1"][1].Part.Label != "D" {
+		t.Errorf("unexpected Tall group: %+v", groups["t1"])
+	}
+	if len(groups["t2"]) != 1 {
+		t.Errorf("expected the second product named Tall kept apart, got %+v", groups["t2"])
+	}
+}
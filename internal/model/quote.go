@@ -0,0 +1,214 @@
+package model
+
+import (
+	"math"
+
+	"github.com/google/uuid"
+)
+
+// HardwareItem is a non-sheet line item on a quote (hinges, handles, screws...).
+type HardwareItem struct {
+	ID        string  `json:"id"`
+	Name      string  `json:"name"`
+	UnitPrice float64 `json:"unit_price"`
+	Quantity  int     `json:"quantity"`
+}
+
+// NewHardwareItem creates a hardware line item with a generated ID.
+func NewHardwareItem(name string, unitPrice float64, qty int) HardwareItem {
+	return HardwareItem{
+		ID:        uuid.New().String()[:8],
+		Name:      name,
+		UnitPrice: unitPrice,
+		Quantity:  qty,
+	}
+}
+
+// Total returns the line total for this hardware item.
+func (h HardwareItem) Total() float64 {
+	return h.UnitPrice * float64(h.Quantity)
+}
+
+// QuoteSettings holds the pricing inputs used to build a customer quote.
+type QuoteSettings struct {
+	CustomerName         string         `json:"customer_name"`
+	Reference            string         `json:"reference"`               // Quote number or job reference
+	Currency             string         `json:"currency"`                // Display symbol, e.g. "EUR"
+	MachineHourlyRate    float64        `json:"machine_hourly_rate"`     // Cost per hour of CNC time
+	SetupMinutesPerSheet float64        `json:"setup_minutes_per_sheet"` // Loading/unloading overhead per sheet
+	EdgeBandingPerMeter  float64        `json:"edge_banding_per_meter"`  // Cost per metre of edge banding
+	EdgeBandingWaste     float64        `json:"edge_banding_waste"`      // Extra banding percentage for waste
+	MarginPercent        float64        `json:"margin_percent"`          // Markup applied to all costs
+	TaxPercent           float64        `json:"tax_percent"`             // Sales tax / VAT applied after margin
+	Hardware             []HardwareItem `json:"hardware"`
+	Notes                string         `json:"notes"`
+}
+
+// DefaultQuoteSettings returns quote settings with sensible defaults.
+func DefaultQuoteSettings() QuoteSettings {
+	return QuoteSettings{
+		Currency:             "EUR",
+		MachineHourlyRate:    60,
+		SetupMinutesPerSheet: 5,
+		EdgeBandingPerMeter:  1.5,
+		EdgeBandingWaste:     10,
+		MarginPercent:        25,
+		TaxPercent:           21,
+		Hardware:             []HardwareItem{},
+	}
+}
+
+// QuoteLine is the cost breakdown for one group of placed parts: either a
+// product or a loose part label.
+type QuoteLine struct {
+	Label           string  `json:"label"`
+	Product         bool    `json:"product"`  // Line represents a product/assembly
+	Quantity        int     `json:"quantity"` // Product units, or pieces for loose parts
+	Pieces          int     `json:"pieces"`   // Placed pieces in this line
+	MaterialCost    float64 `json:"material_cost"`
+	MachineCost     float64 `json:"machine_cost"`
+	EdgeBandingCost float64 `json:"edge_banding_cost"`
+	EdgeBandingM    float64 `json:"edge_banding_m"`
+}
+
+// Cost returns the total cost of the line before margin and tax.
+func (l QuoteLine) Cost() float64 {
+	return l.MaterialCost + l.MachineCost + l.EdgeBandingCost
+}
+
+// Quote is a fully priced job built from an optimization result.
+type Quote struct {
+	Settings QuoteSettings `json:"settings"`
+	Lines    []QuoteLine   `json:"lines"`
+
+	MaterialCost    float64 `json:"material_cost"`
+	MachineMinutes  float64 `json:"machine_minutes"`
+	MachineCost     float64 `json:"machine_cost"`
+	EdgeBandingM    float64 `json:"edge_banding_m"`
+	EdgeBandingCost float64 `json:"edge_banding_cost"`
+	HardwareCost    float64 `json:"hardware_cost"`
+
+	Cost   float64 `json:"cost"`   // Sum of all costs
+	Margin float64 `json:"margin"` // Markup amount
+	Net    float64 `json:"net"`    // Cost + margin
+	Tax    float64 `json:"tax"`
+	Total  float64 `json:"total"` // Net + tax
+}
+
+// MarginFactor returns the multiplier that turns a cost into a selling price.
+func (q Quote) MarginFactor() float64 {
+	return 1 + q.Settings.MarginPercent/100
+}
+
+// BuildQuote prices an optimization result. Sheet cost is shared among the
+// parts on each sheet in proportion to their area, so offcut waste is carried
+// by the parts that caused it. Machine time comes from EstimatedJobTime and is
+// shared in proportion to each part's cut length. Edge banding is priced per
+// metre using CalculateEdgeBanding. Parts belonging to a product are grouped
+// into one line per product; loose parts are grouped by label. Lines keep the
+// order of first appearance. Passing the project's products lets product
+// lines report the number of units rather than pieces.
+func BuildQuote(result OptimizeResult, settings CutSettings, qs QuoteSettings, products ...Product) Quote {
+	q := Quote{Settings: qs}
+
+	q.MachineMinutes = result.EstimatedJobTime(settings.FeedRate, settings.PassDepth, settings.CutDepth, qs.SetupMinutesPerSheet)
+	q.MachineCost = q.MachineMinutes / 60 * qs.MachineHourlyRate
+	totalCut := result.TotalCutLength()
+
+	index := make(map[quoteKey]int)
+	var keys []quoteKey
+	lineParts := make(map[quoteKey][]Part)
+	for _, sheet := range result.Sheets {
+		used := sheet.UsedArea()
+		for _, p := range sheet.Placements {
+			key := quoteLineKey(p.Part)
+			i, ok := index[key]
+			if !ok {
+				i = len(q.Lines)
+				index[key] = i
+				keys = append(keys, key)
+				label := key.label
+				if key.product != "" {
+					label = p.Part.ProductName
+				}
+				q.Lines = append(q.Lines, QuoteLine{Label: label, Product: key.product != ""})
+			}
+			line := &q.Lines[i]
+			line.Pieces++
+
+			if used > 0 {
+				line.MaterialCost += sheet.Stock.PricePerSheet * p.PlacedWidth() * p.PlacedHeight() / used
+			}
+			if totalCut > 0 {
+				line.MachineCost += q.MachineCost * placementCutLength(p) / totalCut
+			}
+
+			piece := p.Part
+			piece.Quantity = 1
+			lineParts[key] = append(lineParts[key], piece)
+		}
+	}
+
+	units := make(map[string]int)
+	for _, prod := range products {
+		units[prod.ID] = prod.Quantity
+	}
+
+	for i := range q.Lines {
+		line := &q.Lines[i]
+		line.Quantity = line.Pieces
+		if n, ok := units[keys[i].product]; line.Product && ok && n > 0 {
+			line.Quantity = n
+		}
+		banding := CalculateEdgeBanding(lineParts[keys[i]], qs.EdgeBandingWaste)
+		line.EdgeBandingM = banding.TotalWithWasteM
+		line.EdgeBandingCost = banding.TotalWithWasteM * qs.EdgeBandingPerMeter
+
+		q.MaterialCost += line.MaterialCost
+		q.EdgeBandingM += line.EdgeBandingM
+		q.EdgeBandingCost += line.EdgeBandingCost
+	}
+
+	// Sheets with no placements still have to be paid for.
+	for _, sheet := range result.Sheets {
+		if sheet.UsedArea() == 0 {
+			q.MaterialCost += sheet.Stock.PricePerSheet
+		}
+	}
+
+	for _, h := range qs.Hardware {
+		q.HardwareCost += h.Total()
+	}
+
+	q.Cost = q.MaterialCost + q.MachineCost + q.EdgeBandingCost + q.HardwareCost
+	q.Margin = roundCents(q.Cost * qs.MarginPercent / 100)
+	q.Net = roundCents(q.Cost) + q.Margin
+	q.Tax = roundCents(q.Net * qs.TaxPercent / 100)
+	q.Total = q.Net + q.Tax
+	return q
+}
+
+// quoteKey identifies a quote line: a product by its ID, or loose parts by
+// their label.
+type quoteKey struct {
+	product, label string
+}
+
+// quoteLineKey returns the grouping key for a part on a quote.
+func quoteLineKey(p Part) quoteKey {
+	if p.ProductID != "" {
+		return quoteKey{product: p.ProductID}
+	}
+	return quoteKey{label: p.Label}
+}
+
+func placementCutLength(p Placement) float64 {
+	if len(p.Part.Outline) > 0 {
+		return p.Part.Outline.Perimeter()
+	}
+	return 2 * (p.PlacedWidth() + p.PlacedHeight())
+}
+
+func roundCents(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,188 @@
+package model
+
+import (
+	"math"
+	"testing"
+)
+
+func buildQuoteTestResult() OptimizeResult {
+	side := Part{ID: "p1", Label: "Side", Width: 1000, Height: 500, Quantity: 2,
+		EdgeBanding: EdgeBanding{Top: true}}
+	shelf := Part{ID: "p2", Label: "Shelf", Width: 500, Height: 500, Quantity: 2}
+	return OptimizeResult{
+		Sheets: []SheetResult{
+			{
+				Stock: StockSheet{Label: "Ply", Width: 2000, Height: 1000, PricePerSheet: 60},
+				Placements: []Placement{
+					{Part: side, X: 0, Y: 0},
+					{Part: side, X: 0, Y: 500},
+					{Part: shelf, X: 1000, Y: 0},
+					{Part: shelf, X: 1500, Y: 0},
+				},
+			},
+		},
+	}
+}
+
+func TestBuildQuote_MaterialAllocatedByArea(t *testing.T) {
+	qs := QuoteSettings{}
+	q := BuildQuote(buildQuoteTestResult(), DefaultSettings(), qs)
+
+	if len(q.Lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d", len(q.Lines))
+	}
+	// Sides use 1,000,000 of 1,500,000 mm² used area → 2/3 of the sheet price.
+	if math.Abs(q.Lines[0].MaterialCost-40) > 0.001 {
+		t.Errorf("expected side material cost 40, got %.3f", q.Lines[0].MaterialCost)
+	}
+	if math.Abs(q.Lines[1].MaterialCost-20) > 0.001 {
+		t.Errorf("expected shelf material cost 20, got %.3f", q.Lines[1].MaterialCost)
+	}
+	if q.Lines[0].Quantity != 2 {
+		t.Errorf("expected side quantity 2, got %d", q.Lines[0].Quantity)
+	}
+	if math.Abs(q.MaterialCost-60) > 0.001 {
+		t.Errorf("expected total material cost 60, got %.3f", q.MaterialCost)
+	}
+}
+
+func TestBuildQuote_MachineAndBanding(t *testing.T) {
+	settings := DefaultSettings()
+	settings.FeedRate = 1000
+	settings.CutDepth = 18
+	settings.PassDepth = 6
+
+	qs := QuoteSettings{
+		MachineHourlyRate:    60,
+		SetupMinutesPerSheet: 5,
+		EdgeBandingPerMeter:  2,
+	}
+	result := buildQuoteTestResult()
+	q := BuildQuote(result, settings, qs)
+
+	wantMinutes := result.EstimatedJobTime(1000, 6, 18, 5)
+	if math.Abs(q.MachineMinutes-wantMinutes) > 0.001 {
+		t.Errorf("expected %.3f machine minutes, got %.3f", wantMinutes, q.MachineMinutes)
+	}
+	if math.Abs(q.MachineCost-wantMinutes) > 0.001 { // 60/h == 1 per minute
+		t.Errorf("expected machine cost %.3f, got %.3f", wantMinutes, q.MachineCost)
+	}
+	var machineSum float64
+	for _, l := range q.Lines {
+		machineSum += l.MachineCost
+	}
+	if math.Abs(machineSum-q.MachineCost) > 0.001 {
+		t.Errorf("line machine costs %.3f do not add up to %.3f", machineSum, q.MachineCost)
+	}
+
+	// Two sides banded on the top edge: 2 x 1000mm = 2m at 2/m.
+	if math.Abs(q.EdgeBandingM-2) > 0.001 || math.Abs(q.EdgeBandingCost-4) > 0.001 {
+		t.Errorf("expected 2m banding costing 4, got %.3fm costing %.3f", q.EdgeBandingM, q.EdgeBandingCost)
+	}
+	if q.Lines[1].EdgeBandingCost != 0 {
+		t.Errorf("shelf has no banding, got cost %.3f", q.Lines[1].EdgeBandingCost)
+	}
+}
+
+func TestBuildQuote_MarginTaxAndHardware(t *testing.T) {
+	qs := QuoteSettings{
+		MarginPercent: 50,
+		TaxPercent:    10,
+		Hardware:      []HardwareItem{NewHardwareItem("Hinge", 2.5, 4)},
+	}
+	settings := DefaultSettings()
+	settings.FeedRate = 0 // no machine time
+
+	q := BuildQuote(buildQuoteTestResult(), settings, qs)
+
+	if q.HardwareCost != 10 {
+		t.Errorf("expected hardware cost 10, got %.2f", q.HardwareCost)
+	}
+	if q.Cost != 70 {
+		t.Errorf("expected cost 70, got %.2f", q.Cost)
+	}
+	if q.Margin != 35 || q.Net != 105 {
+		t.Errorf("expected margin 35 and net 105, got %.2f and %.2f", q.Margin, q.Net)
+	}
+	if q.Tax != 10.5 || q.Total != 115.5 {
+		t.Errorf("expected tax 10.50 and total 115.50, got %.2f and %.2f", q.Tax, q.Total)
+	}
+}
+
+func TestBuildQuote_EmptySheetStillCharged(t *testing.T) {
+	result := buildQuoteTestResult()
+	result.Sheets = append(result.Sheets, SheetResult{
+		Stock: StockSheet{Label: "Spare", Width: 1000, Height: 1000, PricePerSheet: 25},
+	})
+	q := BuildQuote(result, DefaultSettings(), QuoteSettings{})
+	if math.Abs(q.MaterialCost-85) > 0.001 {
+		t.Errorf("expected material cost 85, got %.3f", q.MaterialCost)
+	}
+}
+
+func TestBuildQuote_GroupsByProduct(t *testing.T) {
+	base := NewProduct("Base Cabinet", 2)
+	base.Parts = append(base.Parts, NewPart("Side", 700, 500, 2), NewPart("Bottom", 500, 500, 1))
+	loose := NewPart("Shelf", 400, 300, 1)
+
+	proj := NewProject()
+	proj.Parts = []Part{loose}
+	proj.Products = []Product{base}
+
+	var placements []Placement
+	for _, p := range proj.AllParts() {
+		for i := 0; i < p.Quantity; i++ {
+			placements = append(placements, Placement{Part: p})
+		}
+	}
+	result := OptimizeResult{Sheets: []SheetResult{{
+		Stock:      StockSheet{Width: 2440, Height: 1220, PricePerSheet: 50},
+		Placements: placements,
+	}}}
+
+	q := BuildQuote(result, DefaultSettings(), QuoteSettings{}, proj.Products...)
+	if len(q.Lines) != 2 {
+		t.Fatalf("expected 2 lines (Shelf, Base Cabinet), got %d", len(q.Lines))
+	}
+	cab := q.Lines[1]
+	if cab.Label != "Base Cabinet" || !cab.Product {
+		t.Fatalf("expected product line for Base Cabinet, got %+v", cab)
+	}
+	if cab.Quantity != 2 || cab.Pieces != 6 {
+		t.Errorf("expected 2 units / 6 pieces, got %d / %d", cab.Quantity, cab.Pieces)
+	}
+	if q.Lines[0].Product || q.Lines[0].Quantity != 1 {
+		t.Errorf("unexpected loose line: %+v", q.Lines[0])
+	}
+}
+
+func TestBuildQuote_SameNameProductsStayApart(t *testing.T) {
+	first := NewProduct("Cabinet", 2)
+	first.Parts = append(first.Parts, NewPart("Side", 700, 500, 2))
+	second := NewProduct("Cabinet", 3)
+	second.Parts = append(second.Parts, NewPart("Side", 700, 500, 2))
+	loose := NewPart("Cabinet", 400, 300, 1)
+
+	proj := NewProject()
+	proj.Parts = []Part{loose}
+	proj.Products = []Product{first, second}
+
+	var placements []Placement
+	for _, p := range proj.AllParts() {
+		for i := 0; i < p.Quantity; i++ {
+			placements = append(placements, Placement{Part: p})
+		}
+	}
+	result := OptimizeResult{Sheets: []SheetResult{{
+		Stock:      StockSheet{Width: 2440, Height: 1220, PricePerSheet: 50},
+		Placements: placements,
+	}}}
+
+	q := BuildQuote(result, DefaultSettings(), QuoteSettings{}, proj.Products...)
+	if len(q.Lines) != 3 {
+		t.Fatalf("expected a line for the loose part and each product, got %+v", q.Lines)
+	}
+	if q.Lines[1].Quantity != 2 || q.Lines[1].Pieces != 4 || q.Lines[2].Quantity != 3 || q.Lines[2].Pieces != 6 {
+		t.Errorf("expected 2 units / 4 pieces and 3 units / 6 pieces, got %+v and %+v", q.Lines[1], q.Lines[2])
+	}
+}
@@ -0,0 +1,95 @@
+package model
+
+import (
+	"fmt"
+	"math"
+)
+
+// Roll stock such as laminate, veneer or fabric has a fixed width and is cut
+// to length as needed. A StockSheet with Roll set is strip-packed: its Height
+// is the maximum length available on the roll (0 means unlimited) and the
+// optimizer minimizes the length consumed.
+
+// NewRollStock creates a roll stock entry. maxLength is the length left on
+// the roll in mm; pass 0 for an unlimited roll.
+func NewRollStock(label string, width, maxLength float64, qty int) StockSheet {
+	s := NewStockSheet(label, width, maxLength, qty)
+	s.Roll = true
+	return s
+}
+
+// UnlimitedLength reports whether the stock is a roll without a maximum length.
+func (s StockSheet) UnlimitedLength() bool {
+	return s.Roll && s.Height <= 0
+}
+
+// RollCost returns the price of the given length (mm) cut from a roll.
+func (s StockSheet) RollCost(length float64) float64 {
+	return s.PricePerMeter * length / 1000.0
+}
+
+// RollUsage reports how much of a roll a strip-packed layout consumes.
+type RollUsage struct {
+	MaxLength  float64 `json:"max_length"`  // Length available on the roll (0 = unlimited)
+	UsedLength float64 `json:"used_length"` // Length cut off the roll, including trim and kerf
+}
+
+// Unlimited reports whether the roll had no maximum length.
+func (u RollUsage) Unlimited() bool {
+	return u.MaxLength <= 0
+}
+
+// RemainingLength returns the length left on the roll after the cut, or 0
+// for an unlimited roll.
+func (u RollUsage) RemainingLength() float64 {
+	if u.Unlimited() {
+		return 0
+	}
+	return math.Max(0, u.MaxLength-u.UsedLength)
+}
+
+// String summarizes the usage, e.g. "625 mm used, 4375 mm left on roll".
+func (u RollUsage) String() string {
+	if u.Unlimited() {
+		return fmt.Sprintf("%.0f mm cut from roll", u.UsedLength)
+	}
+	return fmt.Sprintf("%.0f mm used, %.0f mm left on roll", u.UsedLength, u.RemainingLength())
+}
+
+// TotalRollLength returns the total length cut from rolls across all sheets.
+func (or OptimizeResult) TotalRollLength() float64 {
+	var total float64
+	for _, s := range or.Sheets {
+		if s.Roll != nil {
+			total += s.Roll.UsedLength
+		}
+	}
+	return total
+}
+
+// RemainingRoll returns the rest of the roll as stock for a future project.
+// It reports false for sheets, unlimited rolls, or rolls that were used up.
+// Offcuts within the cut length are found by DetectOffcuts like a sheet's.
+func (sr SheetResult) RemainingRoll() (StockSheet, bool) {
+	if sr.Roll == nil || sr.Roll.Unlimited() || sr.Roll.RemainingLength() < MinOffcutDimension {
+		return StockSheet{}, false
+	}
+	rest := NewRollStock(sr.Stock.Label, sr.Stock.Width, sr.Roll.RemainingLength(), 1)
+	rest.Thickness = sr.Stock.Thickness
+	rest.Grain = sr.Stock.Grain
+	rest.Material = sr.Stock.Material
+	rest.PricePerMeter = sr.Stock.PricePerMeter
+	return rest, true
+}
+
+// RemainingRolls returns the rest of every limited roll the result cuts
+// from, to return to the inventory.
+func (or OptimizeResult) RemainingRolls() []StockSheet {
+	var rolls []StockSheet
+	for _, s := range or.Sheets {
+		if rest, ok := s.RemainingRoll(); ok {
+			rolls = append(rolls, rest)
+		}
+	}
+	return rolls
+}
@@ -0,0 +1,43 @@
+package model
+
+import "testing"
+
+func TestRollUsage_Remaining(t *testing.T) {
+	limited := RollUsage{MaxLength: 5000, UsedLength: 1200}
+	if got := limited.RemainingLength(); got != 3800 {
+		t.Errorf("RemainingLength = %v, want 3800", got)
+	}
+	if got := limited.String(); got != "1200 mm used, 3800 mm left on roll" {
+		t.Errorf("String = %q", got)
+	}
+
+	unlimited := RollUsage{UsedLength: 1200}
+	if !unlimited.Unlimited() || unlimited.RemainingLength() != 0 {
+		t.Errorf("unlimited roll should report no remaining length")
+	}
+}
+
+func TestSheetResult_RemainingRoll(t *testing.T) {
+	stock := NewRollStock("Laminate", 1300, 1200, 1)
+	stock.PricePerMeter = 15
+	sr := SheetResult{Stock: stock, Roll: &RollUsage{MaxLength: 5000, UsedLength: 1200}}
+
+	rest, ok := sr.RemainingRoll()
+	if !ok {
+		t.Fatal("expected a remaining roll")
+	}
+	if !rest.Roll || rest.Width != 1300 || rest.Height != 3800 || rest.PricePerMeter != 15 {
+		t.Errorf("unexpected remaining roll %+v", rest)
+	}
+
+	if _, ok := (SheetResult{Stock: NewStockSheet("Sheet", 2440, 1220, 1)}).RemainingRoll(); ok {
+		t.Error("a sheet has no remaining roll")
+	}
+	result := OptimizeResult{Sheets: []SheetResult{sr, {Stock: NewStockSheet("Sheet", 2440, 1220, 1)}}}
+	if rolls := result.RemainingRolls(); len(rolls) != 1 || rolls[0].Height != 3800 {
+		t.Errorf("expected the one remaining roll, got %+v", rolls)
+	}
+	if got := stock.RollCost(2500); got != 37.5 {
+		t.Errorf("RollCost = %v, want 37.5", got)
+	}
+}
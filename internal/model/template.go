@@ -1,6 +1,8 @@
 package model
 
 import (
+	"fmt"
+	"math"
 	"time"
 
 	"github.com/google/uuid"
@@ -8,15 +10,139 @@ import (
 
 // ProjectTemplate represents a reusable project configuration that captures
 // parts, stock sheets, and settings but not optimization results.
+// A parametric template also stores the parameters its parts were generated
+// from, so that it can be instantiated again at different sizes: either a
+// cabinet description or a set of variables and part formulas.
 type ProjectTemplate struct {
-	ID          string       `json:"id"`
-	Name        string       `json:"name"`
-	Description string       `json:"description"`
-	CreatedAt   string       `json:"created_at"`
-	UpdatedAt   string       `json:"updated_at"`
-	Parts       []Part       `json:"parts"`
-	Stocks      []StockSheet `json:"stocks"`
-	Settings    CutSettings  `json:"settings"`
+	ID          string             `json:"id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	CreatedAt   string             `json:"created_at"`
+	UpdatedAt   string             `json:"updated_at"`
+	Parts       []Part             `json:"parts"`
+	Stocks      []StockSheet       `json:"stocks"`
+	Settings    CutSettings        `json:"settings"`
+	Cabinet     *CabinetParams     `json:"cabinet,omitempty"`   // Set for parametric cabinet templates
+	Variables   []TemplateVariable `json:"variables,omitempty"` // Inputs for formula templates
+	Formulas    []PartFormula      `json:"formulas,omitempty"`  // Parts whose sizes are expressions
+}
+
+// TemplateVariable is a named numeric input of a formula template.
+// Bounds apply only when Max is greater than Min.
+type TemplateVariable struct {
+	Name    string  `json:"name"`  // Identifier used in expressions, e.g. "W"
+	Label   string  `json:"label"` // Prompt shown to the user, e.g. "Width (mm)"
+	Default float64 `json:"default"`
+	Min     float64 `json:"min"`
+	Max     float64 `json:"max"`
+}
+
+// HasBounds reports whether the variable restricts its value to [Min, Max].
+func (v TemplateVariable) HasBounds() bool {
+	return v.Max > v.Min
+}
+
+// PartFormula is a template part whose width, height and quantity are
+// expressions over the template variables, such as "H - 2*T" or
+// "ceil(W/600)". See EvalExpression for the supported syntax.
+type PartFormula struct {
+	Label       string      `json:"label"`
+	Width       string      `json:"width"`
+	Height      string      `json:"height"`
+	Quantity    string      `json:"quantity"`
+	Grain       Grain       `json:"grain"`
+	Material    string      `json:"material,omitempty"`
+	EdgeBanding EdgeBanding `json:"edge_banding,omitempty"`
+}
+
+// NewFormulaTemplate creates a template driven by variables and part
+// formulas. The formulas are checked by evaluating them with the default
+// values, whose results become the template's stored parts.
+func NewFormulaTemplate(name, description string, vars []TemplateVariable, formulas []PartFormula, stocks []StockSheet, settings CutSettings) (ProjectTemplate, error) {
+	t := NewProjectTemplate(name, description, nil, stocks, settings)
+	t.Variables = append([]TemplateVariable(nil), vars...)
+	t.Formulas = append([]PartFormula(nil), formulas...)
+	parts, err := t.EvaluateParts(nil)
+	if err != nil {
+		return ProjectTemplate{}, err
+	}
+	t.Parts = parts
+	return t, nil
+}
+
+// VariableValues returns the value of every template variable, taking values
+// from the given map and falling back to defaults. Values outside a
+// variable's bounds and values for unknown variables are rejected.
+func (t ProjectTemplate) VariableValues(values map[string]float64) (map[string]float64, error) {
+	known := make(map[string]bool, len(t.Variables))
+	out := make(map[string]float64, len(t.Variables))
+	for _, v := range t.Variables {
+		if v.Name == "" {
+			return nil, fmt.Errorf("template variable has no name")
+		}
+		if known[v.Name] {
+			return nil, fmt.Errorf("duplicate template variable %q", v.Name)
+		}
+		known[v.Name] = true
+
+		val := v.Default
+		if given, ok := values[v.Name]; ok {
+			val = given
+		}
+		if v.HasBounds() && (val < v.Min || val > v.Max) {
+			return nil, fmt.Errorf("%s must be between %g and %g, got %g", v.Name, v.Min, v.Max, val)
+		}
+		out[v.Name] = val
+	}
+	for name := range values {
+		if !known[name] {
+			return nil, fmt.Errorf("unknown template variable %q", name)
+		}
+	}
+	return out, nil
+}
+
+// EvaluateParts evaluates the template's part formulas with the given
+// variable values (missing values use defaults). Each part must have a
+// positive width and height and a whole, non-negative quantity; parts whose
+// quantity evaluates to zero are omitted.
+func (t ProjectTemplate) EvaluateParts(values map[string]float64) ([]Part, error) {
+	vars, err := t.VariableValues(values)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := []Part{}
+	for _, f := range t.Formulas {
+		w, err := EvalExpression(f.Width, vars)
+		if err != nil {
+			return nil, fmt.Errorf("part %q width: %w", f.Label, err)
+		}
+		h, err := EvalExpression(f.Height, vars)
+		if err != nil {
+			return nil, fmt.Errorf("part %q height: %w", f.Label, err)
+		}
+		q, err := EvalExpression(f.Quantity, vars)
+		if err != nil {
+			return nil, fmt.Errorf("part %q quantity: %w", f.Label, err)
+		}
+		if w <= 0 || h <= 0 {
+			return nil, fmt.Errorf("part %q evaluates to %.1f x %.1f mm; width and height must be > 0", f.Label, w, h)
+		}
+		if q < 0 || math.Abs(q-math.Round(q)) > 1e-9 {
+			return nil, fmt.Errorf("part %q quantity evaluates to %g; it must be a whole number >= 0", f.Label, q)
+		}
+		if math.Round(q) == 0 {
+			continue
+		}
+
+		p := NewPart(f.Label, w, h, int(math.Round(q)))
+		p.Grain = f.Grain
+		p.Material = f.Material
+		p.EdgeBanding = f.EdgeBanding
+		parts = append(parts, p)
+	}
+	return parts, nil
 }
 
 // NewProjectTemplate creates a new template from the given project data.
@@ -35,14 +161,44 @@ func NewProjectTemplate(name, description string, parts []Part, stocks []StockSh
 	}
 }
 
+// IsParametric reports whether the template regenerates its parts from
+// stored parameters.
+func (t ProjectTemplate) IsParametric() bool {
+	return t.Cabinet != nil || len(t.Formulas) > 0
+}
+
 // ToProject creates a new Project from this template.
 // Parts and stocks get fresh IDs so they are independent of the template.
-func (t ProjectTemplate) ToProject(projectName string) Project {
+// Cabinet templates regenerate their parts as a single product. Formula
+// templates evaluate their parts with values, where any variable missing
+// from values takes its default; values may be nil for other templates.
+func (t ProjectTemplate) ToProject(projectName string, values map[string]float64) (Project, error) {
+	if len(t.Formulas) > 0 {
+		evaluated, err := t.EvaluateParts(values)
+		if err != nil {
+			return Project{}, fmt.Errorf("failed to evaluate template %q: %w", t.Name, err)
+		}
+		t.Parts = evaluated
+	}
+
 	parts := make([]Part, len(t.Parts))
 	for i, p := range t.Parts {
 		parts[i] = NewPart(p.Label, p.Width, p.Height, p.Quantity)
 		parts[i].Grain = p.Grain
 		parts[i].Outline = p.Outline
+		parts[i].Material = p.Material
+		parts[i].EdgeBanding = p.EdgeBanding
+		parts[i].Drills = p.Drills
+	}
+
+	var products []Product
+	if t.Cabinet != nil {
+		prod, err := NewCabinetProduct(*t.Cabinet, 1)
+		if err != nil {
+			return Project{}, fmt.Errorf("failed to generate cabinet for template %q: %w", t.Name, err)
+		}
+		products = append(products, prod)
+		parts = []Part{}
 	}
 
 	stocks := make([]StockSheet, len(t.Stocks))
@@ -54,9 +210,11 @@ func (t ProjectTemplate) ToProject(projectName string) Project {
 	return Project{
 		Name:     projectName,
 		Parts:    parts,
+		Products: products,
 		Stocks:   stocks,
 		Settings: t.Settings,
-	}
+		Quote:    DefaultQuoteSettings(),
+	}, nil
 }
 
 // TemplateStore holds a collection of project templates.
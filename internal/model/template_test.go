@@ -47,7 +47,10 @@ func TestProjectTemplate_ToProject(t *testing.T) {
 	settings.KerfWidth = 5.0
 
 	tmpl := NewProjectTemplate("Test", "desc", parts, stocks, settings)
-	proj := tmpl.ToProject("My Project")
+	proj, err := tmpl.ToProject("My Project", nil)
+	if err != nil {
+		t.Fatalf("ToProject error: %v", err)
+	}
 
 	if proj.Name != "My Project" {
 		t.Errorf("expected project name 'My Project', got %q", proj.Name)
@@ -147,3 +150,81 @@ func TestNewProjectTemplate_NilSlices(t *testing.T) {
 		t.Error("Stocks should not be nil (should be empty slice)")
 	}
 }
+
+func newBookcaseTemplate(t *testing.T) ProjectTemplate {
+	t.Helper()
+	vars := []TemplateVariable{
+		{Name: "W", Label: "Width", Default: 800, Min: 300, Max: 2400},
+		{Name: "H", Label: "Height", Default: 1800},
+		{Name: "T", Label: "Thickness", Default: 18},
+	}
+	formulas := []PartFormula{
+		{Label: "Side", Width: "300", Height: "H", Quantity: "2", Grain: GrainVertical},
+		{Label: "Shelf", Width: "W - 2*T", Height: "280", Quantity: "floor(H/400) - 1", Grain: GrainHorizontal},
+		{Label: "Divider", Width: "280", Height: "H - 2*T", Quantity: "ceil(W/900) - 1"},
+	}
+	tmpl, err := NewFormulaTemplate("Bookcase", "", vars, formulas, nil, DefaultSettings())
+	if err != nil {
+		t.Fatalf("NewFormulaTemplate error: %v", err)
+	}
+	return tmpl
+}
+
+func TestFormulaTemplate_Defaults(t *testing.T) {
+	tmpl := newBookcaseTemplate(t)
+	if !tmpl.IsParametric() {
+		t.Error("expected formula template to be parametric")
+	}
+	// Divider quantity is zero at the default width, so it is omitted.
+	if len(tmpl.Parts) != 2 {
+		t.Fatalf("expected 2 default parts, got %d", len(tmpl.Parts))
+	}
+	shelf := tmpl.Parts[1]
+	if shelf.Width != 764 || shelf.Quantity != 3 || shelf.Grain != GrainHorizontal {
+		t.Errorf("unexpected default shelf %+v", shelf)
+	}
+}
+
+func TestFormulaTemplate_ToProjectWithValues(t *testing.T) {
+	tmpl := newBookcaseTemplate(t)
+
+	proj, err := tmpl.ToProject("Wide", map[string]float64{"W": 2000, "H": 2000})
+	if err != nil {
+		t.Fatalf("ToProject error: %v", err)
+	}
+	if len(proj.Parts) != 3 {
+		t.Fatalf("expected 3 parts, got %d", len(proj.Parts))
+	}
+	if proj.Parts[0].Height != 2000 {
+		t.Errorf("expected side height 2000, got %.0f", proj.Parts[0].Height)
+	}
+	if proj.Parts[1].Width != 1964 || proj.Parts[1].Quantity != 4 {
+		t.Errorf("unexpected shelf %.0f x%d", proj.Parts[1].Width, proj.Parts[1].Quantity)
+	}
+	if proj.Parts[2].Quantity != 2 {
+		t.Errorf("expected 2 dividers, got %d", proj.Parts[2].Quantity)
+	}
+}
+
+func TestFormulaTemplate_Validation(t *testing.T) {
+	tmpl := newBookcaseTemplate(t)
+
+	if _, err := tmpl.ToProject("x", map[string]float64{"W": 100}); err == nil {
+		t.Error("expected error for value below the minimum")
+	}
+	if _, err := tmpl.ToProject("x", map[string]float64{"Z": 1}); err == nil {
+		t.Error("expected error for unknown variable")
+	}
+	if _, err := tmpl.ToProject("x", map[string]float64{"T": 400}); err == nil {
+		t.Error("expected error for non-positive shelf width")
+	}
+
+	bad := []PartFormula{{Label: "Half", Width: "100", Height: "100", Quantity: "W/3"}}
+	if _, err := NewFormulaTemplate("Bad", "", []TemplateVariable{{Name: "W", Default: 100}}, bad, nil, DefaultSettings()); err == nil {
+		t.Error("expected error for fractional quantity")
+	}
+	unknown := []PartFormula{{Label: "P", Width: "Q", Height: "100", Quantity: "1"}}
+	if _, err := NewFormulaTemplate("Bad", "", nil, unknown, nil, DefaultSettings()); err == nil {
+		t.Error("expected error for formula referencing an undefined variable")
+	}
+}
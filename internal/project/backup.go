@@ -10,21 +10,111 @@ import (
 	"github.com/piwi3910/SlabCut/internal/model"
 )
 
+// BackupVersion is the version written to new backup files. Version 1.0.0
+// backups only contain the app config; 1.1.0 adds the remaining user data.
+const BackupVersion = "1.1.0"
+
 // BackupData is the top-level structure for import/export of all application data.
+// Sections other than Config are optional so that older backups still load.
 type BackupData struct {
-	Version   string          `json:"version"`
-	CreatedAt string          `json:"created_at"`
-	Config    model.AppConfig `json:"config"`
+	Version   string               `json:"version"`
+	CreatedAt string               `json:"created_at"`
+	Config    model.AppConfig      `json:"config"`
+	Inventory *model.Inventory     `json:"inventory,omitempty"`
+	Library   *model.PartsLibrary  `json:"library,omitempty"`
+	Templates *model.TemplateStore `json:"templates,omitempty"`
+	Profiles  []model.GCodeProfile `json:"profiles,omitempty"` // Custom GCode profiles only
+}
+
+// BackupCategory identifies one restorable section of a backup.
+type BackupCategory string
+
+const (
+	BackupConfig    BackupCategory = "config"
+	BackupInventory BackupCategory = "inventory"
+	BackupLibrary   BackupCategory = "library"
+	BackupTemplates BackupCategory = "templates"
+	BackupProfiles  BackupCategory = "profiles"
+)
+
+// AllBackupCategories lists every category in display order.
+var AllBackupCategories = []BackupCategory{
+	BackupConfig, BackupInventory, BackupLibrary, BackupTemplates, BackupProfiles,
+}
+
+// Label returns a human-readable name for the category.
+func (c BackupCategory) Label() string {
+	switch c {
+	case BackupConfig:
+		return "Application Settings"
+	case BackupInventory:
+		return "Tool & Stock Inventory"
+	case BackupLibrary:
+		return "Parts Library"
+	case BackupTemplates:
+		return "Project Templates"
+	case BackupProfiles:
+		return "Custom GCode Profiles"
+	}
+	return string(c)
+}
+
+// Has reports whether the backup contains data for the given category.
+func (b BackupData) Has(c BackupCategory) bool {
+	switch c {
+	case BackupConfig:
+		return true
+	case BackupInventory:
+		return b.Inventory != nil
+	case BackupLibrary:
+		return b.Library != nil
+	case BackupTemplates:
+		return b.Templates != nil
+	case BackupProfiles:
+		return len(b.Profiles) > 0
+	}
+	return false
 }
 
-// ExportAllData exports all application data (config and future inventory data)
-// to a single JSON file at the specified path.
-func ExportAllData(exportPath string, config model.AppConfig) error {
-	backup := BackupData{
-		Version:   "1.0.0",
-		CreatedAt: time.Now().UTC().Format(time.RFC3339),
-		Config:    config,
+// Count returns the number of items stored for a category (1 for config).
+func (b BackupData) Count(c BackupCategory) int {
+	switch c {
+	case BackupConfig:
+		return 1
+	case BackupInventory:
+		if b.Inventory != nil {
+			return len(b.Inventory.Tools) + len(b.Inventory.Stocks)
+		}
+	case BackupLibrary:
+		if b.Library != nil {
+			return len(b.Library.Parts)
+		}
+	case BackupTemplates:
+		if b.Templates != nil {
+			return len(b.Templates.Templates)
+		}
+	case BackupProfiles:
+		return len(b.Profiles)
 	}
+	return 0
+}
+
+// ExportAllData exports all application data (config, inventory, parts library,
+// templates and custom GCode profiles) to a single JSON file at the specified path.
+// Version and CreatedAt are filled in automatically.
+func ExportAllData(exportPath string, backup BackupData) error {
+	backup.Version = BackupVersion
+	backup.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+
+	// Built-in profiles ship with the application and are never backed up.
+	var custom []model.GCodeProfile
+	for _, p := range backup.Profiles {
+		if !p.IsBuiltIn {
+			custom = append(custom, p)
+		}
+	}
+	backup.Profiles = custom
+
 	data, err := json.MarshalIndent(backup, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal backup data: %w", err)
@@ -42,7 +132,7 @@ func ExportAllData(exportPath string, config model.AppConfig) error {
 }
 
 // ImportAllData reads a backup JSON file and returns the contained data.
-// The caller is responsible for applying the imported config.
+// The caller is responsible for applying the imported data, typically via RestoreBackup.
 func ImportAllData(importPath string) (BackupData, error) {
 	data, err := os.ReadFile(importPath)
 	if err != nil {
@@ -59,5 +149,157 @@ func ImportAllData(importPath string) (BackupData, error) {
 	if backup.Config.RecentProjects == nil {
 		backup.Config.RecentProjects = []string{}
 	}
+	for i := range backup.Profiles {
+		backup.Profiles[i].IsBuiltIn = false
+	}
 	return backup, nil
 }
+
+// RestoreMode controls how restored items are combined with existing data.
+type RestoreMode int
+
+const (
+	// RestoreReplace discards the existing data of each selected category.
+	RestoreReplace RestoreMode = iota
+	// RestoreMerge keeps existing items, overwriting those with a matching
+	// ID (or name, for GCode profiles) and adding the rest.
+	RestoreMerge
+)
+
+// RestoreOptions selects which categories to restore and how.
+type RestoreOptions struct {
+	Categories []BackupCategory
+	Mode       RestoreMode
+}
+
+// RestoreChange summarizes the effect of restoring one category.
+type RestoreChange struct {
+	Category BackupCategory
+	Added    int
+	Updated  int
+	Removed  int
+}
+
+// String formats the change for display in a preview.
+func (c RestoreChange) String() string {
+	if c.Category == BackupConfig {
+		return fmt.Sprintf("%s: replaced", c.Category.Label())
+	}
+	return fmt.Sprintf("%s: %d added, %d updated, %d removed",
+		c.Category.Label(), c.Added, c.Updated, c.Removed)
+}
+
+// RestoreBackup applies the selected categories of backup onto current and
+// returns the resulting data along with a per-category summary. Neither input
+// is modified, so calling it without using the result serves as a preview.
+// Categories that are not present in the backup are skipped.
+func RestoreBackup(current, backup BackupData, opts RestoreOptions) (BackupData, []RestoreChange) {
+	result := current
+	var changes []RestoreChange
+
+	for _, cat := range opts.Categories {
+		if !backup.Has(cat) {
+			continue
+		}
+		change := RestoreChange{Category: cat}
+
+		switch cat {
+		case BackupConfig:
+			result.Config = backup.Config
+
+		case BackupInventory:
+			var inv model.Inventory
+			if current.Inventory != nil {
+				inv = *current.Inventory
+			}
+			var a, u, r int
+			inv.Tools, a, u, r = mergeByKey(inv.Tools, backup.Inventory.Tools, opts.Mode,
+				func(t model.ToolProfile) string { return t.ID })
+			change.Added, change.Updated, change.Removed = a, u, r
+			inv.Stocks, a, u, r = mergeByKey(inv.Stocks, backup.Inventory.Stocks, opts.Mode,
+				func(s model.StockPreset) string { return s.ID })
+			change.Added += a
+			change.Updated += u
+			change.Removed += r
+			result.Inventory = &inv
+
+		case BackupLibrary:
+			lib := model.NewPartsLibrary()
+			if current.Library != nil {
+				lib = *current.Library
+			}
+			lib.Parts, change.Added, change.Updated, change.Removed = mergeByKey(lib.Parts, backup.Library.Parts, opts.Mode,
+				func(p model.LibraryPart) string { return p.ID })
+			if opts.Mode == RestoreReplace {
+				lib.Categories = append([]string(nil), backup.Library.Categories...)
+			} else {
+				lib.Categories, _, _, _ = mergeByKey(lib.Categories, backup.Library.Categories, RestoreMerge,
+					func(s string) string { return s })
+			}
+			result.Library = &lib
+
+		case BackupTemplates:
+			store := model.NewTemplateStore()
+			if current.Templates != nil {
+				store = *current.Templates
+			}
+			store.Templates, change.Added, change.Updated, change.Removed = mergeByKey(store.Templates, backup.Templates.Templates, opts.Mode,
+				func(t model.ProjectTemplate) string { return t.ID })
+			result.Templates = &store
+
+		case BackupProfiles:
+			result.Profiles, change.Added, change.Updated, change.Removed = mergeByKey(current.Profiles, backup.Profiles, opts.Mode,
+				func(p model.GCodeProfile) string { return p.Name })
+		}
+
+		changes = append(changes, change)
+	}
+	return result, changes
+}
+
+// mergeByKey combines existing and incoming items. In replace mode the result
+// is a copy of incoming; in merge mode existing items are kept in order, those
+// whose key matches an incoming item are overwritten, and the remaining
+// incoming items are appended. The returned slice never aliases either input.
+func mergeByKey[T any](existing, incoming []T, mode RestoreMode, key func(T) string) (merged []T, added, updated, removed int) {
+	incomingKeys := make(map[string]bool, len(incoming))
+	for _, item := range incoming {
+		incomingKeys[key(item)] = true
+	}
+
+	if mode == RestoreReplace {
+		existingKeys := make(map[string]bool, len(existing))
+		for _, item := range existing {
+			existingKeys[key(item)] = true
+			if !incomingKeys[key(item)] {
+				removed++
+			}
+		}
+		for _, item := range incoming {
+			if existingKeys[key(item)] {
+				updated++
+			} else {
+				added++
+			}
+		}
+		return append([]T{}, incoming...), added, updated, removed
+	}
+
+	merged = append([]T{}, existing...)
+	index := make(map[string]int, len(merged))
+	for i, item := range merged {
+		index[key(item)] = i
+	}
+	for _, item := range incoming {
+		k := key(item)
+		if i, ok := index[k]; ok {
+			merged[i] = item
+			updated++
+			continue
+		}
+		index[k] = len(merged)
+		merged = append(merged, item)
+		added++
+	}
+	return merged, added, updated, 0
+}
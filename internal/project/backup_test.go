@@ -16,7 +16,7 @@ func TestExportAndImportAllData(t *testing.T) {
 	cfg.DefaultFeedRate = 2000.0
 	cfg.Theme = "dark"
 
-	if err := ExportAllData(path, cfg); err != nil {
+	if err := ExportAllData(path, BackupData{Config: cfg}); err != nil {
 		t.Fatalf("ExportAllData failed: %v", err)
 	}
 
@@ -25,8 +25,8 @@ func TestExportAndImportAllData(t *testing.T) {
 		t.Fatalf("ImportAllData failed: %v", err)
 	}
 
-	if backup.Version != "1.0.0" {
-		t.Errorf("expected version 1.0.0, got %s", backup.Version)
+	if backup.Version != BackupVersion {
+		t.Errorf("expected version %s, got %s", BackupVersion, backup.Version)
 	}
 	if backup.CreatedAt == "" {
 		t.Error("expected non-empty CreatedAt")
@@ -78,7 +78,7 @@ func TestExportAllDataCreatesDirectories(t *testing.T) {
 	path := filepath.Join(dir, "deep", "nested", "backup.json")
 
 	cfg := model.DefaultAppConfig()
-	if err := ExportAllData(path, cfg); err != nil {
+	if err := ExportAllData(path, BackupData{Config: cfg}); err != nil {
 		t.Fatalf("ExportAllData should create parent dirs: %v", err)
 	}
 
@@ -103,3 +103,147 @@ func TestImportAllDataNilRecentProjects(t *testing.T) {
 		t.Error("RecentProjects should not be nil after import")
 	}
 }
+
+func TestExportAllDataIncludesUserData(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "backup.json")
+
+	inv := model.DefaultInventory()
+	lib := model.NewPartsLibrary()
+	lib.AddPart(model.NewLibraryPart("Shelf", 500, 300, model.GrainNone))
+	store := model.NewTemplateStore()
+	store.Add(model.NewProjectTemplate("Cabinet", "", nil, nil, model.DefaultSettings()))
+	custom := model.NewCustomProfile("My Router")
+	builtIn := model.GetProfile("Grbl")
+
+	err := ExportAllData(path, BackupData{
+		Config:    model.DefaultAppConfig(),
+		Inventory: &inv,
+		Library:   &lib,
+		Templates: &store,
+		Profiles:  []model.GCodeProfile{builtIn, custom},
+	})
+	if err != nil {
+		t.Fatalf("ExportAllData failed: %v", err)
+	}
+
+	backup, err := ImportAllData(path)
+	if err != nil {
+		t.Fatalf("ImportAllData failed: %v", err)
+	}
+	for _, cat := range AllBackupCategories {
+		if !backup.Has(cat) {
+			t.Errorf("expected backup to contain %s", cat)
+		}
+	}
+	if backup.Count(BackupInventory) != len(inv.Tools)+len(inv.Stocks) {
+		t.Errorf("inventory count mismatch: got %d", backup.Count(BackupInventory))
+	}
+	if len(backup.Profiles) != 1 || backup.Profiles[0].Name != "My Router" {
+		t.Errorf("expected only the custom profile to be backed up, got %+v", backup.Profiles)
+	}
+}
+
+func TestImportAllDataLegacyBackup(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "legacy.json")
+	data := []byte(`{"version":"1.0.0","created_at":"2025-01-01T00:00:00Z","config":{"theme":"dark"}}`)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	backup, err := ImportAllData(path)
+	if err != nil {
+		t.Fatalf("ImportAllData failed: %v", err)
+	}
+	if !backup.Has(BackupConfig) {
+		t.Error("legacy backup should contain config")
+	}
+	for _, cat := range []BackupCategory{BackupInventory, BackupLibrary, BackupTemplates, BackupProfiles} {
+		if backup.Has(cat) {
+			t.Errorf("legacy backup should not contain %s", cat)
+		}
+	}
+}
+
+func TestRestoreBackupMergeByID(t *testing.T) {
+	current := BackupData{
+		Config: model.DefaultAppConfig(),
+		Inventory: &model.Inventory{
+			Tools:  []model.ToolProfile{{ID: "t1", Name: "Old 6mm"}, {ID: "t2", Name: "3mm"}},
+			Stocks: []model.StockPreset{{ID: "s1", Name: "Ply"}},
+		},
+		Profiles: []model.GCodeProfile{{Name: "Shop"}},
+	}
+	backup := BackupData{
+		Config: model.AppConfig{Theme: "dark"},
+		Inventory: &model.Inventory{
+			Tools: []model.ToolProfile{{ID: "t1", Name: "New 6mm"}, {ID: "t3", Name: "12mm"}},
+		},
+		Profiles: []model.GCodeProfile{{Name: "Shop", Description: "updated"}, {Name: "Laser"}},
+	}
+
+	result, changes := RestoreBackup(current, backup, RestoreOptions{
+		Categories: []BackupCategory{BackupInventory, BackupProfiles},
+		Mode:       RestoreMerge,
+	})
+
+	if result.Config.Theme == "dark" {
+		t.Error("config should not be restored when not selected")
+	}
+	if len(result.Inventory.Tools) != 3 {
+		t.Fatalf("expected 3 tools after merge, got %d", len(result.Inventory.Tools))
+	}
+	if result.Inventory.Tools[0].Name != "New 6mm" {
+		t.Errorf("expected t1 to be overwritten, got %q", result.Inventory.Tools[0].Name)
+	}
+	if len(result.Inventory.Stocks) != 1 {
+		t.Errorf("merge should keep existing stocks, got %d", len(result.Inventory.Stocks))
+	}
+	if len(result.Profiles) != 2 || result.Profiles[0].Description != "updated" {
+		t.Errorf("expected profiles merged by name, got %+v", result.Profiles)
+	}
+	if current.Inventory.Tools[0].Name != "Old 6mm" {
+		t.Error("RestoreBackup must not modify the current data")
+	}
+
+	if len(changes) != 2 {
+		t.Fatalf("expected 2 changes, got %d", len(changes))
+	}
+	if changes[0].Added != 1 || changes[0].Updated != 1 || changes[0].Removed != 0 {
+		t.Errorf("unexpected inventory change: %+v", changes[0])
+	}
+}
+
+func TestRestoreBackupReplace(t *testing.T) {
+	lib := model.PartsLibrary{
+		Parts:      []model.LibraryPart{{ID: "a"}, {ID: "b"}},
+		Categories: []string{"General", "Doors"},
+	}
+	backupLib := model.PartsLibrary{
+		Parts:      []model.LibraryPart{{ID: "b"}, {ID: "c"}, {ID: "d"}},
+		Categories: []string{"General"},
+	}
+	current := BackupData{Library: &lib}
+	backup := BackupData{Library: &backupLib, Config: model.AppConfig{Theme: "light"}}
+
+	result, changes := RestoreBackup(current, backup, RestoreOptions{
+		Categories: AllBackupCategories,
+		Mode:       RestoreReplace,
+	})
+
+	if result.Config.Theme != "light" {
+		t.Errorf("expected config replaced, got theme %q", result.Config.Theme)
+	}
+	if len(result.Library.Parts) != 3 || len(result.Library.Categories) != 1 {
+		t.Errorf("expected library replaced, got %+v", result.Library)
+	}
+	// Config and library only: other categories are absent from the backup.
+	if len(changes) != 2 {
+		t.Fatalf("expected 2 changes, got %d", len(changes))
+	}
+	lc := changes[1]
+	if lc.Added != 2 || lc.Updated != 1 || lc.Removed != 1 {
+		t.Errorf("unexpected library change: %+v", lc)
+	}
+}
@@ -0,0 +1,354 @@
+package project
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/piwi3910/SlabCut/internal/export"
+	"github.com/piwi3910/SlabCut/internal/gcode"
+	"github.com/piwi3910/SlabCut/internal/model"
+)
+
+// BundleExtension is the file extension used for self-contained project bundles.
+const BundleExtension = ".slabcut"
+
+// BundleFormatVersion is the current bundle format version.
+const BundleFormatVersion = "1.0"
+
+// Well-known entry names inside a bundle archive.
+const (
+	bundleManifestName = "manifest.json"
+	bundleProjectName  = "project.json"
+	bundleImportsDir   = "imports"
+	bundleGCodeDir     = "gcode"
+	bundleLayoutName   = "cut-layout.pdf"
+	bundleLabelsName   = "part-labels.pdf"
+)
+
+// BundleFile describes a single file stored in a bundle.
+type BundleFile struct {
+	Path   string `json:"path"`             // Slash-separated path inside the archive
+	Size   int64  `json:"size"`             // Uncompressed size in bytes
+	SHA256 string `json:"sha256"`           // Hex-encoded SHA-256 of the contents
+	Source string `json:"source,omitempty"` // Original location for imported files
+}
+
+// BundleManifest lists every file in a bundle along with its hash so that
+// the recipient can verify nothing was lost or altered in transit.
+type BundleManifest struct {
+	FormatVersion string       `json:"format_version"`
+	CreatedAt     string       `json:"created_at"`
+	CreatedBy     string       `json:"created_by,omitempty"`
+	ProjectName   string       `json:"project_name"`
+	SheetCount    int          `json:"sheet_count"`
+	Files         []BundleFile `json:"files"`
+}
+
+// Bundle is the in-memory representation of an opened .slabcut archive.
+type Bundle struct {
+	Manifest BundleManifest
+	Project  model.Project
+	Files    map[string][]byte // All archive entries except the manifest, keyed by path
+}
+
+// GCodeFiles returns the names of the per-sheet G-code files in sheet order.
+func (b *Bundle) GCodeFiles() []string {
+	var names []string
+	for _, f := range b.Manifest.Files {
+		if path.Dir(f.Path) == bundleGCodeDir {
+			names = append(names, f.Path)
+		}
+	}
+	return names
+}
+
+// ExportBundle writes a self-contained .slabcut bundle to path. The bundle
+// contains the project JSON, copies of the original import files, and — when
+// the project has an optimization result — the G-code for every sheet, the
+// cut layout PDF and the part labels PDF. A manifest with SHA-256 hashes of
+// every entry is stored alongside. Import files carried over from an opened
+// bundle are bundled again; the returned warnings name any import source
+// that could not be found.
+func ExportBundle(path string, proj model.Project, author, notes string) ([]string, error) {
+	now := time.Now().UTC().Format(time.RFC3339)
+	proj.Metadata.UpdatedAt = now
+	if proj.Metadata.CreatedAt == "" {
+		proj.Metadata.CreatedAt = now
+	}
+	if author != "" {
+		proj.Metadata.Author = author
+	}
+	if notes != "" {
+		proj.Metadata.Notes = notes
+	}
+
+	manifest := BundleManifest{
+		FormatVersion: BundleFormatVersion,
+		CreatedAt:     now,
+		CreatedBy:     author,
+		ProjectName:   proj.Name,
+	}
+
+	type entry struct {
+		file BundleFile
+		data []byte
+	}
+	var entries []entry
+	add := func(name, source string, data []byte) {
+		sum := sha256.Sum256(data)
+		entries = append(entries, entry{
+			file: BundleFile{
+				Path:   name,
+				Size:   int64(len(data)),
+				SHA256: hex.EncodeToString(sum[:]),
+				Source: source,
+			},
+			data: data,
+		})
+	}
+
+	// Read import sources up front so the bundled project can refer to
+	// them by their path inside the archive. Sources from an opened bundle
+	// come from its contents; sources that no longer exist are left out
+	// with a warning.
+	type importFile struct {
+		name, source string
+		data         []byte
+	}
+	var imports []importFile
+	var warnings []string
+	usedNames := make(map[string]bool)
+	for _, src := range proj.ImportSources {
+		data, ok := proj.ImportData[src]
+		if !ok {
+			var err error
+			data, err = os.ReadFile(src)
+			if os.IsNotExist(err) {
+				warnings = append(warnings, fmt.Sprintf("Import file %s no longer exists and was left out of the bundle", src))
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to read import source %s: %w", src, err)
+			}
+		}
+		name := uniqueBundleName(bundleImportsDir, filepath.Base(src), usedNames)
+		imports = append(imports, importFile{name: name, source: src, data: data})
+	}
+	proj.ImportSources, proj.ImportData = nil, nil
+	for _, imp := range imports {
+		proj.ImportSources = append(proj.ImportSources, imp.name)
+	}
+
+	projData, err := json.MarshalIndent(proj, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal project: %w", err)
+	}
+	add(bundleProjectName, "", projData)
+	for _, imp := range imports {
+		add(imp.name, imp.source, imp.data)
+	}
+
+	if proj.Result != nil && len(proj.Result.Sheets) > 0 {
+		manifest.SheetCount = len(proj.Result.Sheets)
+
+		gen := gcode.New(proj.Settings)
+		for _, prog := range gen.GeneratePatterns(*proj.Result) {
+			add(bundleGCodeDir+"/"+prog.FileName(), "", []byte(prog.Code))
+		}
+
+		tmpDir, err := os.MkdirTemp("", "slabcut-bundle-*")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create temp directory: %w", err)
+		}
+		defer os.RemoveAll(tmpDir)
+
+		layoutPath := filepath.Join(tmpDir, bundleLayoutName)
+		if err := export.ExportPDF(layoutPath, *proj.Result, proj.Settings); err != nil {
+			return nil, fmt.Errorf("failed to render cut layout: %w", err)
+		}
+		data, err := os.ReadFile(layoutPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cut layout: %w", err)
+		}
+		add(bundleLayoutName, "", data)
+
+		if len(export.CollectLabelInfos(*proj.Result)) > 0 {
+			labelsPath := filepath.Join(tmpDir, bundleLabelsName)
+			if err := export.ExportLabels(labelsPath, *proj.Result); err != nil {
+				return nil, fmt.Errorf("failed to render part labels: %w", err)
+			}
+			data, err := os.ReadFile(labelsPath)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read part labels: %w", err)
+			}
+			add(bundleLabelsName, "", data)
+		}
+	}
+
+	for _, e := range entries {
+		manifest.Files = append(manifest.Files, e.file)
+	}
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	write := func(name string, data []byte) error {
+		w, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	}
+	if err := write(bundleManifestName, manifestData); err != nil {
+		return nil, fmt.Errorf("failed to write manifest: %w", err)
+	}
+	for _, e := range entries {
+		if err := write(e.file.Path, e.data); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", e.file.Path, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finalize bundle: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write bundle: %w", err)
+	}
+	return warnings, nil
+}
+
+// OpenBundle reads a .slabcut bundle and verifies every entry against the
+// hashes recorded in its manifest.
+func OpenBundle(path string) (*Bundle, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bundle: %w", err)
+	}
+	return readBundle(data)
+}
+
+// IsBundle reports whether the file at path is a zip-based bundle rather
+// than a plain JSON project.
+func IsBundle(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(f, magic); err != nil {
+		return false
+	}
+	return isZipData(magic)
+}
+
+func isZipData(data []byte) bool {
+	return len(data) >= 4 && bytes.Equal(data[:4], []byte("PK\x03\x04"))
+}
+
+func readBundle(data []byte) (*Bundle, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open bundle archive: %w", err)
+	}
+
+	files := make(map[string][]byte)
+	var manifestData []byte
+	for _, zf := range zr.File {
+		if zf.FileInfo().IsDir() {
+			continue
+		}
+		rc, err := zf.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", zf.Name, err)
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", zf.Name, err)
+		}
+		if zf.Name == bundleManifestName {
+			manifestData = content
+			continue
+		}
+		files[zf.Name] = content
+	}
+
+	if manifestData == nil {
+		return nil, fmt.Errorf("bundle is missing %s", bundleManifestName)
+	}
+	var manifest BundleManifest
+	if err := json.Unmarshal(manifestData, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	if manifest.FormatVersion == "" {
+		return nil, fmt.Errorf("invalid bundle: missing format version")
+	}
+
+	for _, f := range manifest.Files {
+		content, ok := files[f.Path]
+		if !ok {
+			return nil, fmt.Errorf("bundle is missing %s", f.Path)
+		}
+		sum := sha256.Sum256(content)
+		if hex.EncodeToString(sum[:]) != f.SHA256 {
+			return nil, fmt.Errorf("checksum mismatch for %s", f.Path)
+		}
+	}
+
+	projData, ok := files[bundleProjectName]
+	if !ok {
+		return nil, fmt.Errorf("bundle is missing %s", bundleProjectName)
+	}
+	var proj model.Project
+	if err := json.Unmarshal(projData, &proj); err != nil {
+		return nil, fmt.Errorf("failed to parse project: %w", err)
+	}
+
+	// Keep the bundled import files, which the manifest has verified, so
+	// the project can be bundled again
+	listed := make(map[string]bool, len(manifest.Files))
+	for _, f := range manifest.Files {
+		listed[f.Path] = true
+	}
+	for _, src := range proj.ImportSources {
+		if listed[src] {
+			if proj.ImportData == nil {
+				proj.ImportData = make(map[string][]byte)
+			}
+			proj.ImportData[src] = files[src]
+		}
+	}
+
+	return &Bundle{Manifest: manifest, Project: proj, Files: files}, nil
+}
+
+// uniqueBundleName returns dir/name, appending a numeric suffix if the
+// name has already been used.
+func uniqueBundleName(dir, name string, used map[string]bool) string {
+	candidate := path.Join(dir, name)
+	ext := path.Ext(name)
+	stem := name[:len(name)-len(ext)]
+	for i := 2; used[candidate]; i++ {
+		candidate = path.Join(dir, fmt.Sprintf("%s_%d%s", stem, i, ext))
+	}
+	used[candidate] = true
+	return candidate
+}
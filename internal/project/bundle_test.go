@@ -0,0 +1,263 @@
+package project
+
+import (
+	"archive/zip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/piwi3910/SlabCut/internal/model"
+)
+
+func newBundleTestProject(t *testing.T) model.Project {
+	t.Helper()
+	proj := model.NewProject()
+	proj.Name = "Kitchen"
+	proj.Parts = append(proj.Parts, model.NewPart("Side", 600, 400, 2))
+	proj.Stocks = append(proj.Stocks, model.NewStockSheet("Plywood", 2440, 1220, 1))
+	proj.Settings.StockTabs.Enabled = false
+	proj.Result = &model.OptimizeResult{
+		Sheets: []model.SheetResult{
+			{
+				Stock: proj.Stocks[0],
+				Placements: []model.Placement{
+					{Part: proj.Parts[0], X: 10, Y: 10},
+					{Part: proj.Parts[0], X: 620, Y: 10},
+				},
+			},
+		},
+	}
+	return proj
+}
+
+func TestExportBundle_Contents(t *testing.T) {
+	dir := t.TempDir()
+	csvPath := filepath.Join(dir, "parts.csv")
+	if err := os.WriteFile(csvPath, []byte("label,width,height,qty\nSide,600,400,2\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	proj := newBundleTestProject(t)
+	proj.AddImportSource(csvPath)
+	path := filepath.Join(dir, "kitchen"+BundleExtension)
+
+	if _, err := ExportBundle(path, proj, "Pascal", "For the shop floor"); err != nil {
+		t.Fatalf("ExportBundle error: %v", err)
+	}
+	if !IsBundle(path) {
+		t.Fatal("expected exported file to be detected as a bundle")
+	}
+
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatalf("failed to open zip: %v", err)
+	}
+	defer zr.Close()
+
+	names := make(map[string]bool)
+	for _, f := range zr.File {
+		names[f.Name] = true
+	}
+	for _, want := range []string{
+		"manifest.json", "project.json", "imports/parts.csv",
+		"gcode/sheet1.gcode", "cut-layout.pdf", "part-labels.pdf",
+	} {
+		if !names[want] {
+			t.Errorf("bundle is missing %s", want)
+		}
+	}
+
+	b, err := OpenBundle(path)
+	if err != nil {
+		t.Fatalf("OpenBundle error: %v", err)
+	}
+	if b.Manifest.SheetCount != 1 {
+		t.Errorf("expected sheet count 1, got %d", b.Manifest.SheetCount)
+	}
+	if len(b.Manifest.Files) != len(b.Files) {
+		t.Errorf("manifest lists %d files, archive has %d", len(b.Manifest.Files), len(b.Files))
+	}
+	if got := b.GCodeFiles(); len(got) != 1 || got[0] != "gcode/sheet1.gcode" {
+		t.Errorf("unexpected gcode files: %v", got)
+	}
+	if len(b.Project.ImportSources) != 1 || b.Project.ImportSources[0] != "imports/parts.csv" {
+		t.Errorf("expected import source rewritten to bundle path, got %v", b.Project.ImportSources)
+	}
+	if !strings.HasPrefix(string(b.Files["imports/parts.csv"]), "label,width") {
+		t.Error("imported CSV content not preserved")
+	}
+}
+
+func TestLoad_OpensBundleTransparently(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "kitchen"+BundleExtension)
+
+	if _, err := ExportBundle(path, newBundleTestProject(t), "Pascal", ""); err != nil {
+		t.Fatalf("ExportBundle error: %v", err)
+	}
+
+	proj, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+	if proj.Name != "Kitchen" {
+		t.Errorf("expected name 'Kitchen', got %q", proj.Name)
+	}
+	if proj.Result == nil || len(proj.Result.Sheets) != 1 {
+		t.Error("expected optimization result to survive the round trip")
+	}
+
+	shared, err := ImportShared(path)
+	if err != nil {
+		t.Fatalf("ImportShared error: %v", err)
+	}
+	if shared.Metadata.SharedFrom != "Pascal" {
+		t.Errorf("expected SharedFrom 'Pascal', got %q", shared.Metadata.SharedFrom)
+	}
+}
+
+func TestExportBundle_ReexportKeepsImports(t *testing.T) {
+	dir := t.TempDir()
+	csvPath := filepath.Join(dir, "parts.csv")
+	if err := os.WriteFile(csvPath, []byte("label,width,height,qty\nSide,600,400,2\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	proj := newBundleTestProject(t)
+	proj.AddImportSource(csvPath)
+	first := filepath.Join(dir, "first"+BundleExtension)
+	if _, err := ExportBundle(first, proj, "", ""); err != nil {
+		t.Fatalf("ExportBundle error: %v", err)
+	}
+
+	// The project opened from the bundle no longer has the file on disk
+	if err := os.Remove(csvPath); err != nil {
+		t.Fatal(err)
+	}
+	opened, err := Load(first)
+	if err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+	second := filepath.Join(dir, "second"+BundleExtension)
+	warnings, err := ExportBundle(second, opened, "", "")
+	if err != nil {
+		t.Fatalf("ExportBundle error: %v", err)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("expected no warnings, got %v", warnings)
+	}
+	b, err := OpenBundle(second)
+	if err != nil {
+		t.Fatalf("OpenBundle error: %v", err)
+	}
+	if !strings.HasPrefix(string(b.Files["imports/parts.csv"]), "label,width") {
+		t.Errorf("expected the import file carried into the new bundle, got %v", b.Project.ImportSources)
+	}
+}
+
+func TestExportBundle_WarnsAboutMissingImport(t *testing.T) {
+	dir := t.TempDir()
+	proj := newBundleTestProject(t)
+	proj.AddImportSource(filepath.Join(dir, "gone.csv"))
+
+	warnings, err := ExportBundle(filepath.Join(dir, "kitchen"+BundleExtension), proj, "", "")
+	if err != nil {
+		t.Fatalf("ExportBundle error: %v", err)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "gone.csv") {
+		t.Errorf("expected a warning about the missing import, got %v", warnings)
+	}
+}
+
+func TestExportBundle_WithoutResult(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "empty"+BundleExtension)
+
+	proj := model.NewProject()
+	proj.Parts = append(proj.Parts, model.NewPart("Shelf", 500, 300, 1))
+
+	if _, err := ExportBundle(path, proj, "", ""); err != nil {
+		t.Fatalf("ExportBundle error: %v", err)
+	}
+	b, err := OpenBundle(path)
+	if err != nil {
+		t.Fatalf("OpenBundle error: %v", err)
+	}
+	if len(b.GCodeFiles()) != 0 {
+		t.Errorf("expected no gcode without a result, got %v", b.GCodeFiles())
+	}
+	if len(b.Project.Parts) != 1 {
+		t.Errorf("expected 1 part, got %d", len(b.Project.Parts))
+	}
+}
+
+func TestOpenBundle_DetectsTampering(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "orig"+BundleExtension)
+	if _, err := ExportBundle(path, newBundleTestProject(t), "", ""); err != nil {
+		t.Fatalf("ExportBundle error: %v", err)
+	}
+
+	b, err := OpenBundle(path)
+	if err != nil {
+		t.Fatalf("OpenBundle error: %v", err)
+	}
+
+	// Rebuild the archive with a modified G-code file but the original manifest.
+	tampered := filepath.Join(dir, "tampered"+BundleExtension)
+	f, err := os.Create(tampered)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(f)
+	src, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer src.Close()
+	for _, zf := range src.File {
+		w, err := zw.Create(zf.Name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data := b.Files[zf.Name]
+		if zf.Name == "manifest.json" {
+			rc, err := zf.Open()
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, err = io.ReadAll(rc)
+			rc.Close()
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		if zf.Name == "gcode/sheet1.gcode" {
+			data = append([]byte("M30\n"), data...)
+		}
+		if _, err := w.Write(data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	zw.Close()
+	f.Close()
+
+	if _, err := OpenBundle(tampered); err == nil {
+		t.Fatal("expected checksum error for tampered bundle")
+	}
+}
+
+func TestIsBundle_PlainProject(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "plain.cnccalc")
+	if err := Save(path, model.NewProject()); err != nil {
+		t.Fatalf("Save error: %v", err)
+	}
+	if IsBundle(path) {
+		t.Error("plain JSON project should not be detected as a bundle")
+	}
+	if IsBundle(filepath.Join(dir, "missing.slabcut")) {
+		t.Error("missing file should not be detected as a bundle")
+	}
+}
@@ -16,12 +16,20 @@ func Save(path string, proj model.Project) error {
 	return os.WriteFile(path, data, 0644)
 }
 
-// Load loads a project from a JSON file.
+// Load loads a project from a JSON file. Zip-based .slabcut bundles are
+// detected automatically and the bundled project is returned.
 func Load(path string) (model.Project, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return model.Project{}, err
 	}
+	if isZipData(data) {
+		b, err := readBundle(data)
+		if err != nil {
+			return model.Project{}, err
+		}
+		return b.Project, nil
+	}
 	var proj model.Project
 	err = json.Unmarshal(data, &proj)
 	return proj, err
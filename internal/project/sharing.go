@@ -57,15 +57,27 @@ func ExportShared(path string, proj model.Project, author, notes string) error {
 	return nil
 }
 
-// ImportShared imports a shared project file. It handles both the shared
-// format (SharedProject wrapper) and plain project files for backward
-// compatibility. Returns the imported project with metadata populated.
+// ImportShared imports a shared project file. It handles .slabcut bundles,
+// the shared format (SharedProject wrapper) and plain project files for
+// backward compatibility. Returns the imported project with metadata populated.
 func ImportShared(path string) (model.Project, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return model.Project{}, fmt.Errorf("failed to read shared file: %w", err)
 	}
 
+	if isZipData(data) {
+		b, err := readBundle(data)
+		if err != nil {
+			return model.Project{}, err
+		}
+		proj := b.Project
+		if proj.Metadata.SharedFrom == "" {
+			proj.Metadata.SharedFrom = b.Manifest.CreatedBy
+		}
+		return proj, nil
+	}
+
 	// Try parsing as SharedProject first
 	var shared SharedProject
 	if err := json.Unmarshal(data, &shared); err == nil && shared.FormatVersion != "" {
@@ -3,6 +3,7 @@ package ui
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
@@ -100,7 +101,7 @@ func (a *App) showImportExportDialog() {
 			}
 			defer writer.Close()
 			path := writer.URI().Path()
-			if err := project.ExportAllData(path, a.config); err != nil {
+			if err := project.ExportAllData(path, a.currentBackupData()); err != nil {
 				dialog.ShowError(err, a.window)
 			} else {
 				dialog.ShowInformation("Export Complete",
@@ -112,39 +113,23 @@ func (a *App) showImportExportDialog() {
 	})
 
 	importBtn := widget.NewButton("Import All Data...", func() {
-		dialog.ShowConfirm("Import Data",
-			"Importing data will replace your current application settings.\n\nAre you sure you want to continue?",
-			func(ok bool) {
-				if !ok {
-					return
-				}
-				d := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
-					if err != nil || reader == nil {
-						return
-					}
-					defer reader.Close()
-					path := reader.URI().Path()
-					backup, err := project.ImportAllData(path)
-					if err != nil {
-						dialog.ShowError(err, a.window)
-						return
-					}
-					a.config = backup.Config
-					if err := a.saveConfig(); err != nil {
-						dialog.ShowError(fmt.Errorf("failed to save imported settings: %w", err), a.window)
-						return
-					}
-					dialog.ShowInformation("Import Complete",
-						fmt.Sprintf("Data imported successfully from backup created at %s.", backup.CreatedAt), a.window)
-				}, a.window)
-				d.Show()
-			},
-			a.window,
-		)
+		d := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+			if err != nil || reader == nil {
+				return
+			}
+			defer reader.Close()
+			backup, err := project.ImportAllData(reader.URI().Path())
+			if err != nil {
+				dialog.ShowError(err, a.window)
+				return
+			}
+			a.showRestorePreviewDialog(backup)
+		}, a.window)
+		d.Show()
 	})
 
 	content := container.NewVBox(
-		widget.NewLabel("Export all application data (settings, preferences) to a backup file,\nor import from a previously exported backup."),
+		widget.NewLabel("Export all application data (settings, inventory, parts library,\ntemplates and custom GCode profiles) to a backup file,\nor restore from a previously exported backup."),
 		widget.NewSeparator(),
 		exportBtn,
 		widget.NewSeparator(),
@@ -156,6 +141,147 @@ func (a *App) showImportExportDialog() {
 	d.Show()
 }
 
+// currentBackupData collects all user data into a backup structure.
+func (a *App) currentBackupData() project.BackupData {
+	inv := a.inventory
+	lib := a.library
+	store := a.templates
+	return project.BackupData{
+		Config:    a.config,
+		Inventory: &inv,
+		Library:   &lib,
+		Templates: &store,
+		Profiles:  append([]model.GCodeProfile(nil), model.CustomProfiles...),
+	}
+}
+
+// showRestorePreviewDialog lets the user choose which categories of a backup
+// to restore and whether to replace or merge, previewing the effect first.
+func (a *App) showRestorePreviewDialog(backup project.BackupData) {
+	const (
+		modeMerge   = "Merge (keep existing, update by ID/name)"
+		modeReplace = "Replace existing data"
+	)
+
+	selected := make(map[project.BackupCategory]bool)
+	previewLabel := widget.NewLabel("")
+	modeRadio := widget.NewRadioGroup([]string{modeMerge, modeReplace}, nil)
+
+	options := func() project.RestoreOptions {
+		opts := project.RestoreOptions{Mode: project.RestoreMerge}
+		if modeRadio.Selected == modeReplace {
+			opts.Mode = project.RestoreReplace
+		}
+		for _, cat := range project.AllBackupCategories {
+			if selected[cat] {
+				opts.Categories = append(opts.Categories, cat)
+			}
+		}
+		return opts
+	}
+
+	updatePreview := func() {
+		_, changes := project.RestoreBackup(a.currentBackupData(), backup, options())
+		if len(changes) == 0 {
+			previewLabel.SetText("Nothing selected to restore.")
+			return
+		}
+		var lines string
+		for _, c := range changes {
+			lines += "  " + c.String() + "\n"
+		}
+		previewLabel.SetText("Preview:\n" + lines)
+	}
+
+	checks := container.NewVBox()
+	for _, cat := range project.AllBackupCategories {
+		cat := cat
+		label := fmt.Sprintf("%s (%d)", cat.Label(), backup.Count(cat))
+		check := widget.NewCheck(label, func(on bool) {
+			selected[cat] = on
+			updatePreview()
+		})
+		if backup.Has(cat) {
+			check.SetChecked(true)
+		} else {
+			check.SetText(cat.Label() + " (not in backup)")
+			check.Disable()
+		}
+		checks.Add(check)
+	}
+	modeRadio.OnChanged = func(string) { updatePreview() }
+	modeRadio.SetSelected(modeMerge)
+
+	content := container.NewVBox(
+		widget.NewLabel(fmt.Sprintf("Backup version %s, created %s", backup.Version, backup.CreatedAt)),
+		widget.NewSeparator(),
+		widget.NewLabelWithStyle("Restore", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		checks,
+		widget.NewSeparator(),
+		modeRadio,
+		widget.NewSeparator(),
+		previewLabel,
+	)
+
+	d := dialog.NewCustomConfirm("Restore Backup", "Restore", "Cancel", content, func(ok bool) {
+		if !ok {
+			return
+		}
+		opts := options()
+		if len(opts.Categories) == 0 {
+			return
+		}
+		restored, _ := project.RestoreBackup(a.currentBackupData(), backup, opts)
+		if err := a.applyRestoredData(restored, opts.Categories); err != nil {
+			dialog.ShowError(err, a.window)
+			return
+		}
+		dialog.ShowInformation("Import Complete",
+			fmt.Sprintf("Data restored successfully from backup created at %s.", backup.CreatedAt), a.window)
+	}, a.window)
+	d.Resize(fyne.NewSize(500, 450))
+	d.Show()
+}
+
+// applyRestoredData installs restored data for the given categories and
+// persists each one to its usual location on disk.
+func (a *App) applyRestoredData(data project.BackupData, categories []project.BackupCategory) error {
+	for _, cat := range categories {
+		switch cat {
+		case project.BackupConfig:
+			a.config = data.Config
+			a.applyTheme()
+			if err := a.saveConfig(); err != nil {
+				return fmt.Errorf("failed to save imported settings: %w", err)
+			}
+		case project.BackupInventory:
+			if data.Inventory != nil {
+				a.inventory = *data.Inventory
+				a.saveInventory()
+			}
+		case project.BackupLibrary:
+			if data.Library != nil {
+				a.library = *data.Library
+				a.saveLibrary()
+			}
+		case project.BackupTemplates:
+			if data.Templates != nil {
+				a.templates = *data.Templates
+				if err := project.SaveDefaultTemplates(a.templates); err != nil {
+					return fmt.Errorf("failed to save imported templates: %w", err)
+				}
+			}
+		case project.BackupProfiles:
+			model.CustomProfiles = data.Profiles
+			if err := project.SaveCustomProfilesToDefault(model.CustomProfiles); err != nil {
+				return fmt.Errorf("failed to save imported profiles: %w", err)
+			}
+			a.refreshProfileSelector()
+		}
+	}
+	return nil
+}
+
 // saveConfig persists the current app config to disk.
 func (a *App) saveConfig() error {
 	return project.SaveAppConfig(project.DefaultConfigPath(), a.config)
@@ -188,25 +314,7 @@ func (a *App) showTemplateManager() {
 			tmpl := a.templates.Templates[idx]
 
 			loadBtn := widget.NewButton("Load", func() {
-				dialog.ShowConfirm("Load Template",
-					fmt.Sprintf("Load template %q? This will replace your current project.", tmpl.Name),
-					func(ok bool) {
-						if !ok {
-							return
-						}
-						a.saveState("Load Template")
-						proj := tmpl.ToProject(tmpl.Name)
-						a.project = proj
-						a.refreshPartsList()
-						a.refreshStockList()
-						a.refreshResults()
-						dialog.ShowInformation("Template Loaded",
-							fmt.Sprintf("Loaded template %q with %d parts and %d stock sheets.",
-								tmpl.Name, len(tmpl.Parts), len(tmpl.Stocks)),
-							a.window)
-					},
-					a.window,
-				)
+				a.loadTemplate(tmpl)
 			})
 
 			deleteBtn := widget.NewButton("Delete", func() {
@@ -228,7 +336,7 @@ func (a *App) showTemplateManager() {
 
 			row := container.NewGridWithColumns(4,
 				widget.NewLabel(tmpl.Name),
-				widget.NewLabel(fmt.Sprintf("%d parts, %d stocks", len(tmpl.Parts), len(tmpl.Stocks))),
+				widget.NewLabel(templateSummary(tmpl)),
 				loadBtn,
 				deleteBtn,
 			)
@@ -242,12 +350,15 @@ func (a *App) showTemplateManager() {
 	saveAsBtn := widget.NewButton("Save Current Project as Template...", func() {
 		a.showSaveAsTemplateDialog(refreshList)
 	})
+	formulaBtn := widget.NewButton("New Formula Template...", func() {
+		a.showFormulaTemplateEditor(refreshList)
+	})
 
 	content := container.NewBorder(
 		container.NewVBox(
 			widget.NewLabel("Manage project templates. Save your current project configuration\nas a reusable template, or load a template to start a new project."),
 			widget.NewSeparator(),
-			saveAsBtn,
+			container.NewHBox(saveAsBtn, formulaBtn),
 			widget.NewSeparator(),
 		),
 		nil, nil, nil,
@@ -259,6 +370,59 @@ func (a *App) showTemplateManager() {
 	d.Show()
 }
 
+// loadTemplate replaces the current project with a new project created from
+// the template. Cabinet templates open the cabinet generator instead, and
+// formula templates first prompt for their variable values.
+func (a *App) loadTemplate(tmpl model.ProjectTemplate) {
+	if tmpl.Cabinet != nil {
+		params := *tmpl.Cabinet
+		a.showCabinetGenerator(&params)
+		return
+	}
+
+	load := func(values map[string]float64) {
+		proj, err := tmpl.ToProject(tmpl.Name, values)
+		if err != nil {
+			dialog.ShowError(err, a.window)
+			return
+		}
+		a.saveState("Load Template")
+		a.project = proj
+		a.refreshPartsList()
+		a.refreshStockList()
+		a.refreshResults()
+		dialog.ShowInformation("Template Loaded",
+			fmt.Sprintf("Loaded template %q with %d parts and %d stock sheets.",
+				tmpl.Name, len(proj.Parts), len(proj.Stocks)),
+			a.window)
+	}
+
+	if len(tmpl.Formulas) > 0 {
+		a.showTemplateValuesDialog(tmpl, load)
+		return
+	}
+	dialog.ShowConfirm("Load Template",
+		fmt.Sprintf("Load template %q? This will replace your current project.", tmpl.Name),
+		func(ok bool) {
+			if ok {
+				load(nil)
+			}
+		},
+		a.window,
+	)
+}
+
+// templateSummary describes a template's contents for the template list.
+func templateSummary(t model.ProjectTemplate) string {
+	if t.Cabinet != nil {
+		return fmt.Sprintf("Parametric %s cabinet", strings.ToLower(t.Cabinet.Type.String()))
+	}
+	if len(t.Formulas) > 0 {
+		return fmt.Sprintf("%d formula parts, %d variables", len(t.Formulas), len(t.Variables))
+	}
+	return fmt.Sprintf("%d parts, %d stocks", len(t.Parts), len(t.Stocks))
+}
+
 // showSaveAsTemplateDialog shows a form to save the current project as a template.
 func (a *App) showSaveAsTemplateDialog(onSave func()) {
 	nameEntry := widget.NewEntry()
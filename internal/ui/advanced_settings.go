@@ -120,6 +120,39 @@ func (a *App) showAdvancedSettingsDialog() {
 			widget.NewLabel("Generate Cleanup Pass"), onionCleanupCheck,
 		))
 
+	// --- Finishing Pass ---
+	finishingCheck := widget.NewCheck("", func(b bool) { s.FinishingPass = b })
+	finishingCheck.Checked = s.FinishingPass
+	finishReverseCheck := widget.NewCheck("", func(b bool) { s.FinishReverse = b })
+	finishReverseCheck.Checked = s.FinishReverse
+
+	finishingSection := widget.NewCard("Finishing Pass",
+		"Rough with stock to leave, then finish the part edge in a light pass",
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Enable Finishing Pass"), finishingCheck,
+			widget.NewLabel("Stock to Leave (mm)"), floatEntry(&s.FinishAllowance),
+			widget.NewLabel("Finishing Feed Rate (mm/min, 0 = feed rate)"), floatEntry(&s.FinishFeedRate),
+			widget.NewLabel("Finishing Step Down (mm, 0 = full depth)"), floatEntry(&s.FinishStepDown),
+			widget.NewLabel("Reverse Direction"), finishReverseCheck,
+		))
+
+	// --- Small Parts ---
+	smallPartCheck := widget.NewCheck("", func(b bool) { s.SmallPartStrategy = b })
+	smallPartCheck.Checked = s.SmallPartStrategy
+	twoStageCheck := widget.NewCheck("", func(b bool) { s.TwoStageCut = b })
+	twoStageCheck.Checked = s.TwoStageCut
+
+	smallPartSection := widget.NewCard("Small Parts (Vacuum Table)",
+		"Cut small parts first and hold them down until the sheet is cut",
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Small Part Strategy"), smallPartCheck,
+			widget.NewLabel("Small Part Area (mm²)"), floatEntry(&s.SmallPartArea),
+			widget.NewLabel("Small Part Onion Skin (mm, 0 = as others)"), floatEntry(&s.SmallPartOnionSkin),
+			widget.NewLabel("Small Part Tabs per Side (0 = as others)"), intEntry(&s.SmallPartTabs),
+			widget.NewLabel("Two-Stage Cut"), twoStageCheck,
+			widget.NewLabel("First Stage Skin (mm)"), floatEntry(&s.TwoStageSkin),
+		))
+
 	// --- Toolpath Ordering ---
 	optimizeToolpathCheck := widget.NewCheck("", func(b bool) { s.OptimizeToolpath = b })
 	optimizeToolpathCheck.Checked = s.OptimizeToolpath
@@ -130,6 +163,7 @@ func (a *App) showAdvancedSettingsDialog() {
 	toolpathSection := widget.NewCard("Toolpath Ordering", "",
 		container.NewGridWithColumns(2,
 			widget.NewLabel("Optimize Toolpath Order"), optimizeToolpathCheck,
+			widget.NewLabel("Ordering Time Budget (ms)"), intEntry(&s.ToolpathOrderTime),
 			widget.NewLabel("Structural Cut Ordering"), structuralOrderCheck,
 			widget.NewLabel("Nesting Rotations (outline parts)"), intEntry(&s.NestingRotations),
 		))
@@ -159,16 +193,29 @@ func (a *App) showAdvancedSettingsDialog() {
 		a.showAddClampZoneDialog()
 	})
 
+	clampRetractCheck := widget.NewCheck("Rapid over clamps at Safe Z above their height", func(b bool) {
+		s.ClampAwareRetract = b
+	})
+	clampRetractCheck.Checked = s.ClampAwareRetract
+
+	clampRouteCheck := widget.NewCheck("Route rapids around clamps", func(b bool) {
+		s.RouteAroundClamps = b
+	})
+	clampRouteCheck.Checked = s.RouteAroundClamps
+
 	clampZoneSection := widget.NewCard("Fixture / Clamp Zones",
 		"Define exclusion zones where clamps or fixtures are placed on the stock sheet",
 		container.NewVBox(
-			container.NewHBox(layout.NewSpacer(), addClampBtn),
+			container.NewHBox(clampRetractCheck, layout.NewSpacer(), addClampBtn),
+			clampRouteCheck,
 			clampZoneListContainer,
 		))
 
 	// --- Dust Shoe Collision Detection ---
 	dustShoeCheck := widget.NewCheck("", func(b bool) { s.DustShoeEnabled = b })
 	dustShoeCheck.Checked = s.DustShoeEnabled
+	avoidClampsCheck := widget.NewCheck("", func(b bool) { s.AvoidClampCollisions = b })
+	avoidClampsCheck.Checked = s.AvoidClampCollisions
 
 	dustShoeSection := widget.NewCard("Dust Shoe Collision Detection",
 		"Detect potential collisions between dust shoe and clamp/fixture zones",
@@ -176,15 +223,23 @@ func (a *App) showAdvancedSettingsDialog() {
 			widget.NewLabel("Enable Collision Detection"), dustShoeCheck,
 			widget.NewLabel("Dust Shoe Width (mm)"), floatEntry(&s.DustShoeWidth),
 			widget.NewLabel("Minimum Clearance (mm)"), floatEntry(&s.DustShoeClearance),
+			widget.NewLabel("Move Parts Away from Clamps"), avoidClampsCheck,
 		))
 
 	// --- Part Holding Tabs ---
+	tabShapeSelect := widget.NewSelect(model.TabShapeOptions(), func(selected string) {
+		s.PartTabShape = model.TabShapeFromString(selected)
+	})
+	tabShapeSelect.SetSelected(s.PartTabShape.String())
+
 	partTabSection := widget.NewCard("Part Holding Tabs",
 		"Tabs to keep parts connected during cut",
 		container.NewGridWithColumns(2,
 			widget.NewLabel("Tab Width (mm)"), floatEntry(&s.PartTabWidth),
 			widget.NewLabel("Tab Height (mm)"), floatEntry(&s.PartTabHeight),
 			widget.NewLabel("Tabs per Side"), intEntry(&s.PartTabsPerSide),
+			widget.NewLabel("Outline Tab Spacing (mm)"), floatEntry(&s.PartTabSpacing),
+			widget.NewLabel("Tab Shape"), tabShapeSelect,
 		))
 
 	// Assemble all sections into a scrollable layout
@@ -196,6 +251,8 @@ func (a *App) showAdvancedSettingsDialog() {
 		leadInOutSection,
 		cornerSection,
 		onionSkinSection,
+		finishingSection,
+		smallPartSection,
 		partTabSection,
 		stockTabSection,
 		clampZoneSection,
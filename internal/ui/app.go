@@ -58,6 +58,14 @@ type App struct {
 	gcodePreviewBox   *fyne.Container
 	selectedSheetIdx  int
 	settingsContainer *fyne.Container
+	layoutIssuesLabel *widget.Label
+
+	// manualLayout is set while the current result holds hand edits, so that
+	// undo history keeps the layout rather than re-optimizing it.
+	manualLayout bool
+	// lastLayoutDiff describes what moved in the last re-optimization around
+	// locked sheets and pinned parts; nil after a full optimization.
+	lastLayoutDiff *engine.LayoutDiff
 
 	// Dust shoe collision results from last optimization
 	lastCollisions []model.DustShoeCollision
@@ -228,9 +236,22 @@ func (a *App) SetupMenus() {
 			a.showCompareDialog()
 		}),
 		fyne.NewMenuItemSeparator(),
+		fyne.NewMenuItem("Cabinet Generator...", func() {
+			a.showCabinetGenerator(nil)
+		}),
+		fyne.NewMenuItem("Linear Cut Plan...", func() {
+			a.showLinearCutDialog()
+		}),
+		fyne.NewMenuItemSeparator(),
 		fyne.NewMenuItem("Purchasing Calculator...", func() {
 			a.showPurchasingCalculator()
 		}),
+		fyne.NewMenuItem("Buy List Optimizer...", func() {
+			a.showBuyListOptimizer()
+		}),
+		fyne.NewMenuItem("Quote...", func() {
+			a.showQuoteDialog()
+		}),
 	)
 
 	// Admin Menu
@@ -433,6 +454,7 @@ func (a *App) buildQuickSettingsPanel() fyne.CanvasObject {
 
 	kerfEntry := floatEntry(&s.KerfWidth)
 	edgeTrimEntry := floatEntry(&s.EdgeTrim)
+	thicknessTolEntry := floatEntry(&s.ThicknessTolerance)
 
 	materialContent := container.NewVBox()
 	if stockPresetSelect != nil {
@@ -443,6 +465,7 @@ func (a *App) buildQuickSettingsPanel() fyne.CanvasObject {
 	materialContent.Add(container.NewGridWithColumns(2,
 		widget.NewLabel("Kerf Width (mm)"), kerfEntry,
 		widget.NewLabel("Edge Trim (mm)"), edgeTrimEntry,
+		widget.NewLabel("Thickness Tolerance (mm)"), thicknessTolEntry,
 	))
 
 	// --- Cutting Section ---
@@ -454,6 +477,17 @@ func (a *App) buildQuickSettingsPanel() fyne.CanvasObject {
 		a.scheduleOptimize()
 	})
 	tabsCheck.Checked = s.StockTabs.Enabled
+	stackCheck := widget.NewCheck("Stack Identical Sheets (Saw)", func(b bool) {
+		s.StackCutting = b
+	})
+	stackCheck.Checked = s.StackCutting
+	maxStackEntry := widget.NewEntry()
+	maxStackEntry.SetText(fmt.Sprintf("%.1f", s.MaxStackHeight))
+	maxStackEntry.OnChanged = func(text string) {
+		if v, err := strconv.ParseFloat(text, 64); err == nil {
+			s.MaxStackHeight = v
+		}
+	}
 
 	cuttingContent := container.NewVBox(
 		container.NewGridWithColumns(2,
@@ -462,6 +496,10 @@ func (a *App) buildQuickSettingsPanel() fyne.CanvasObject {
 			widget.NewLabel("Pass Depth (mm)"), passDepthEntry,
 		),
 		tabsCheck,
+		stackCheck,
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Max Stack Height (mm)"), maxStackEntry,
+		),
 	)
 
 	// --- Optimizer Section ---
@@ -565,6 +603,7 @@ func (a *App) buildCenterCanvas() fyne.CanvasObject {
 	bottomBar := container.NewVBox(
 		container.NewHBox(a.sheetSelectorBox),
 		zoomBar,
+		a.buildLayoutEditBar(),
 	)
 
 	return container.NewBorder(nil, bottomBar, nil, nil, canvasArea)
@@ -585,6 +624,9 @@ func (a *App) refreshSheetSelector() {
 	for i := range a.project.Result.Sheets {
 		idx := i
 		label := fmt.Sprintf("Sheet %d", idx+1)
+		if a.project.Result.Sheets[idx].Locked {
+			label += " (locked)"
+		}
 		btn := widget.NewButton(label, func() {
 			a.selectedSheetIdx = idx
 			a.updateCanvasForSheet(idx)
@@ -605,6 +647,7 @@ func (a *App) updateCanvasForSheet(idx int) {
 	sheet := a.project.Result.Sheets[idx]
 	a.sheetCanvas.SetSheet(sheet, a.project.Settings)
 	a.refreshSheetSelector()
+	a.updateLayoutIssues()
 }
 
 // ─── Right Panel: Parts + Stock ─────────────────────────────
@@ -682,6 +725,9 @@ func (a *App) buildPartsStockPanel() fyne.CanvasObject {
 		fyne.NewMenuItem("Add from Library...", func() {
 			a.showAddFromLibraryDialog()
 		}),
+		fyne.NewMenuItem("Products / Assemblies...", func() {
+			a.showProductManager()
+		}),
 		fyne.NewMenuItem("Import from DXF...", func() {
 			a.importDXF()
 		}),
@@ -819,7 +865,7 @@ func (a *App) refreshPartsList() {
 	}
 	a.partsContainer.RemoveAll()
 
-	if len(a.project.Parts) == 0 {
+	if len(a.project.Parts) == 0 && len(a.project.Products) == 0 {
 		a.partsContainer.Add(widget.NewLabel("No parts added yet."))
 		return
 	}
@@ -835,8 +881,11 @@ func (a *App) refreshPartsList() {
 		if p.Grain != model.GrainNone {
 			detailText += fmt.Sprintf("  Grain: %s", p.Grain.String())
 		}
-		if p.Material != "" {
-			detailText += fmt.Sprintf("  [%s]", p.Material)
+		if p.Material != "" || p.Thickness > 0 {
+			detailText += "  [" + partMaterialText(p) + "]"
+		}
+		if p.Linear {
+			detailText = fmt.Sprintf("%.0f mm long  x%d  [linear]", p.Width, p.Quantity)
 		}
 		detailLabel := widget.NewLabel(detailText)
 
@@ -859,6 +908,8 @@ func (a *App) refreshPartsList() {
 		card := container.NewVBox(topRow, detailLabel, widget.NewSeparator())
 		a.partsContainer.Add(card)
 	}
+
+	a.addProductCards()
 }
 
 // refreshStockList rebuilds the stock card list in the right panel.
@@ -884,17 +935,28 @@ func (a *App) refreshStockList() {
 			thicknessVal = 18
 		}
 		detailText := fmt.Sprintf("%.0f x %.0f mm  %.0fmm thick  x%d", s.Width, s.Height, thicknessVal, s.Quantity)
+		if s.UnlimitedLength() {
+			detailText = fmt.Sprintf("Roll %.0f mm wide, unlimited  %.0fmm thick  x%d", s.Width, thicknessVal, s.Quantity)
+		} else if s.Roll {
+			detailText = fmt.Sprintf("Roll %.0f mm wide, %.0f mm long  %.0fmm thick  x%d", s.Width, s.Height, thicknessVal, s.Quantity)
+		}
 		if s.Grain != model.GrainNone {
 			detailText += fmt.Sprintf("  Grain: %s", s.Grain.String())
 		}
 		if s.Material != "" {
 			detailText += fmt.Sprintf("  [%s]", s.Material)
 		}
+		if n := len(s.Defects); n > 0 {
+			detailText += fmt.Sprintf("  %d defect(s)", n)
+		}
 		detailLabel := widget.NewLabel(detailText)
 
 		editBtn := widget.NewButtonWithIcon("", theme.DocumentCreateIcon(), func() {
 			a.showEditStockDialog(idx)
 		})
+		defectsBtn := widget.NewButtonWithIcon("", theme.WarningIcon(), func() {
+			a.showStockDefectsDialog(idx)
+		})
 		deleteBtn := widget.NewButtonWithIcon("", theme.DeleteIcon(), func() {
 			a.saveState("Delete Stock Sheet")
 			a.project.Stocks = append(a.project.Stocks[:idx], a.project.Stocks[idx+1:]...)
@@ -902,7 +964,7 @@ func (a *App) refreshStockList() {
 			a.scheduleOptimize()
 		})
 
-		buttons := container.NewHBox(editBtn, deleteBtn)
+		buttons := container.NewHBox(editBtn, defectsBtn, deleteBtn)
 		topRow := container.NewBorder(nil, nil, nil, buttons, nameLabel)
 
 		card := container.NewVBox(topRow, detailLabel, widget.NewSeparator())
@@ -952,19 +1014,23 @@ func (a *App) refreshGCodePreview() {
 	})
 	profileSelect.SetSelected(a.project.Settings.GCodeProfile)
 
+	// Tapping a move in the simulation offers a restart from its pass
+	simulation := func(i int) fyne.CanvasObject {
+		return widgets.RenderGCodeSimulation(a.project.Result.Sheets[i], a.project.Settings, codes[i],
+			func(line int) { a.exportRestart(i, line) })
+	}
+
 	// Default to first sheet
 	previewIdx := 0
 	if previewIdx < len(codes) {
-		sheet := a.project.Result.Sheets[previewIdx]
-		sim := widgets.RenderGCodeSimulation(sheet, a.project.Settings, codes[previewIdx])
+		sim := simulation(previewIdx)
 
 		var sheetSelect *widget.Select
 		sheetSelect = widget.NewSelect(sheetNames, func(selected string) {
 			for i, name := range sheetNames {
 				if name == selected && i < len(codes) {
 					a.gcodePreviewBox.RemoveAll()
-					newSheet := a.project.Result.Sheets[i]
-					newSim := widgets.RenderGCodeSimulation(newSheet, a.project.Settings, codes[i])
+					newSim := simulation(i)
 					newTopBar := container.NewHBox(
 						widget.NewLabel("Sheet:"),
 						sheetSelect,
@@ -1009,9 +1075,12 @@ func (a *App) scheduleOptimize() {
 
 // runAutoOptimize runs the optimizer in a goroutine and updates the UI on the main thread.
 func (a *App) runAutoOptimize() {
-	if len(a.project.Parts) == 0 || len(a.project.Stocks) == 0 {
+	a.optimizeLinear()
+	if len(a.project.AllParts()) == 0 || len(a.project.Stocks) == 0 {
 		// Clear results if nothing to optimize
 		a.project.Result = nil
+		a.manualLayout = false
+		a.lastLayoutDiff = nil
 		a.lastCollisions = nil
 		a.updateStatusBar()
 		a.refreshSheetSelector()
@@ -1030,14 +1099,14 @@ func (a *App) runAutoOptimize() {
 	}
 
 	go func() {
-		opt := engine.New(a.project.Settings)
-		result := opt.Optimize(a.project.Parts, a.project.Stocks)
+		result := a.optimizeProject()
 
 		// Run dust shoe collision detection
 		collisions := gcode.CheckDustShoeCollisions(result, a.project.Settings)
 
 		// Update on UI thread
 		a.project.Result = &result
+		a.manualLayout = result.HasLocks()
 		a.lastCollisions = collisions
 		a.updateStatusBar()
 		a.refreshSheetSelector()
@@ -1058,16 +1127,28 @@ func (a *App) updateStatusBar() {
 	if a.statusLabel == nil {
 		return
 	}
-	if a.project.Result == nil || len(a.project.Result.Sheets) == 0 {
+	if a.project.Result == nil || (len(a.project.Result.Sheets) == 0 && len(a.project.Result.Warnings) == 0) {
 		a.statusLabel.SetText("No optimization yet")
 		return
 	}
 
 	r := a.project.Result
 	text := fmt.Sprintf("%d sheet(s), %.1f%% efficiency", len(r.Sheets), r.TotalEfficiency())
+	if patterns := len(r.Patterns()); patterns < len(r.Sheets) {
+		text += fmt.Sprintf(" | %d pattern(s)", patterns)
+	}
 	if len(r.UnplacedParts) > 0 {
 		text += fmt.Sprintf(" | %d unplaced!", len(r.UnplacedParts))
 	}
+	if len(r.Warnings) > 0 {
+		text += " | " + strings.Join(r.Warnings, "; ")
+	}
+	if a.lastLayoutDiff != nil {
+		text += " | Changes: " + a.lastLayoutDiff.Summary()
+	}
+	if roll := r.TotalRollLength(); roll > 0 {
+		text += fmt.Sprintf(" | Roll: %.2f m", roll/1000)
+	}
 	if r.HasPricing() {
 		text += fmt.Sprintf(" | Cost: %.2f", r.TotalCost())
 	}
@@ -1091,34 +1172,51 @@ func (a *App) refreshResults() {
 
 // saveState captures the current project state before a modification.
 func (a *App) saveState(label string) {
-	a.history.Push(MakeSnapshot(a.project.Parts, a.project.Stocks, label))
+	a.history.Push(a.snapshot(label))
+}
+
+// snapshot captures parts, stocks and products for the undo history, plus
+// the layout while it holds hand edits.
+func (a *App) snapshot(label string) Snapshot {
+	snap := MakeSnapshot(a.project.Parts, a.project.Stocks, label)
+	snap.Products = copyProducts(a.project.Products)
+	if a.manualLayout {
+		snap.Layout = copyResult(a.project.Result)
+	}
+	return snap
 }
 
 // undo restores the previous state from the undo stack.
 func (a *App) undo() {
-	current := MakeSnapshot(a.project.Parts, a.project.Stocks, "current")
-	snap, ok := a.history.Undo(current)
+	snap, ok := a.history.Undo(a.snapshot("current"))
 	if !ok {
 		return
 	}
-	a.project.Parts = snap.Parts
-	a.project.Stocks = snap.Stocks
-	a.refreshPartsList()
-	a.refreshStockList()
-	a.scheduleOptimize()
+	a.restoreSnapshot(snap)
 }
 
 // redo restores the next state from the redo stack.
 func (a *App) redo() {
-	current := MakeSnapshot(a.project.Parts, a.project.Stocks, "current")
-	snap, ok := a.history.Redo(current)
+	snap, ok := a.history.Redo(a.snapshot("current"))
 	if !ok {
 		return
 	}
+	a.restoreSnapshot(snap)
+}
+
+// restoreSnapshot applies an undo/redo snapshot. A hand-edited layout is
+// restored as it was; otherwise the layout is re-optimized.
+func (a *App) restoreSnapshot(snap Snapshot) {
 	a.project.Parts = snap.Parts
 	a.project.Stocks = snap.Stocks
+	a.project.Products = snap.Products
 	a.refreshPartsList()
 	a.refreshStockList()
+	if snap.Layout != nil {
+		a.setEditedLayout(snap.Layout)
+		return
+	}
+	a.manualLayout = false
 	a.scheduleOptimize()
 }
 
@@ -1149,6 +1247,14 @@ func parseInt(s string) int {
 	return v
 }
 
+// partMaterialText describes a part's material and thickness, e.g. "MDF 18 mm".
+func partMaterialText(p model.Part) string {
+	if p.Thickness <= 0 {
+		return p.Material
+	}
+	return strings.TrimSpace(fmt.Sprintf("%s %g mm", p.Material, p.Thickness))
+}
+
 func parseGrain(s string) model.Grain {
 	switch s {
 	case "Horizontal":
@@ -1205,12 +1311,17 @@ func (a *App) showAddPartDialog() {
 	materialEntry := widget.NewEntry()
 	materialEntry.SetPlaceHolder("e.g., Plywood, MDF (optional)")
 
+	thicknessEntry := widget.NewEntry()
+	thicknessEntry.SetPlaceHolder("mm (optional, blank = any)")
+
 	bandTop := widget.NewCheck("Top", nil)
 	bandBottom := widget.NewCheck("Bottom", nil)
 	bandLeft := widget.NewCheck("Left", nil)
 	bandRight := widget.NewCheck("Right", nil)
 	bandingRow := container.NewHBox(bandTop, bandBottom, bandLeft, bandRight)
 
+	linearCheck := widget.NewCheck("Cut to length from linear stock (width = length)", nil)
+
 	form := dialog.NewForm("Add Part", "Add", "Cancel",
 		[]*widget.FormItem{
 			widget.NewFormItem("Label", labelEntry),
@@ -1219,7 +1330,9 @@ func (a *App) showAddPartDialog() {
 			widget.NewFormItem("Quantity", qtyEntry),
 			widget.NewFormItem("Grain", grainSelect),
 			widget.NewFormItem("Material", materialEntry),
+			widget.NewFormItem("Thickness (mm)", thicknessEntry),
 			widget.NewFormItem("Edge Banding", bandingRow),
+			widget.NewFormItem("Linear", linearCheck),
 		},
 		func(ok bool) {
 			if !ok {
@@ -1241,12 +1354,14 @@ func (a *App) showAddPartDialog() {
 				part.Grain = model.GrainVertical
 			}
 			part.Material = strings.TrimSpace(materialEntry.Text)
+			part.Thickness = parseFloat(thicknessEntry.Text)
 			part.EdgeBanding = model.EdgeBanding{
 				Top:    bandTop.Checked,
 				Bottom: bandBottom.Checked,
 				Left:   bandLeft.Checked,
 				Right:  bandRight.Checked,
 			}
+			part.Linear = linearCheck.Checked
 
 			a.saveState("Add Part")
 			a.project.Parts = append(a.project.Parts, part)
@@ -1282,6 +1397,12 @@ func (a *App) showEditPartDialog(idx int) {
 	editMaterialEntry.SetPlaceHolder("e.g., Plywood, MDF (optional)")
 	editMaterialEntry.SetText(p.Material)
 
+	editThicknessEntry := widget.NewEntry()
+	editThicknessEntry.SetPlaceHolder("mm (optional, blank = any)")
+	if p.Thickness > 0 {
+		editThicknessEntry.SetText(fmt.Sprintf("%g", p.Thickness))
+	}
+
 	bandTop := widget.NewCheck("Top", nil)
 	bandTop.Checked = p.EdgeBanding.Top
 	bandBottom := widget.NewCheck("Bottom", nil)
@@ -1292,6 +1413,19 @@ func (a *App) showEditPartDialog(idx int) {
 	bandRight.Checked = p.EdgeBanding.Right
 	bandingRow := container.NewHBox(bandTop, bandBottom, bandLeft, bandRight)
 
+	linearCheck := widget.NewCheck("Cut to length from linear stock (width = length)", nil)
+	linearCheck.Checked = p.Linear
+
+	tabShapeSelect := widget.NewSelect(append([]string{"Default"}, model.TabShapeOptions()...), nil)
+	tabShapeSelect.SetSelected("Default")
+	if p.TabShape != "" {
+		tabShapeSelect.SetSelected(p.TabShape.String())
+	}
+
+	tabsEntry := widget.NewEntry()
+	tabsEntry.SetPlaceHolder("x,y; x,y in part mm (blank = automatic)")
+	tabsEntry.SetText(model.FormatPartTabs(p.Tabs))
+
 	form := dialog.NewForm("Edit Part", "Save", "Cancel",
 		[]*widget.FormItem{
 			widget.NewFormItem("Label", labelEntry),
@@ -1300,7 +1434,11 @@ func (a *App) showEditPartDialog(idx int) {
 			widget.NewFormItem("Quantity", qtyEntry),
 			widget.NewFormItem("Grain", grainSelect),
 			widget.NewFormItem("Material", editMaterialEntry),
+			widget.NewFormItem("Thickness (mm)", editThicknessEntry),
 			widget.NewFormItem("Edge Banding", bandingRow),
+			widget.NewFormItem("Linear", linearCheck),
+			widget.NewFormItem("Tab Shape", tabShapeSelect),
+			widget.NewFormItem("Tab Positions", tabsEntry),
 		},
 		func(ok bool) {
 			if !ok {
@@ -1313,6 +1451,13 @@ func (a *App) showEditPartDialog(idx int) {
 				dialog.ShowError(fmt.Errorf("width, height, and quantity must be > 0"), a.window)
 				return
 			}
+			sized := p
+			sized.Width, sized.Height = w, h
+			tabs, err := model.ParsePartTabs(tabsEntry.Text, sized)
+			if err != nil {
+				dialog.ShowError(err, a.window)
+				return
+			}
 
 			a.saveState("Edit Part")
 			a.project.Parts[idx].Label = labelEntry.Text
@@ -1328,18 +1473,25 @@ func (a *App) showEditPartDialog(idx int) {
 				a.project.Parts[idx].Grain = model.GrainNone
 			}
 			a.project.Parts[idx].Material = strings.TrimSpace(editMaterialEntry.Text)
+			a.project.Parts[idx].Thickness = parseFloat(editThicknessEntry.Text)
 			a.project.Parts[idx].EdgeBanding = model.EdgeBanding{
 				Top:    bandTop.Checked,
 				Bottom: bandBottom.Checked,
 				Left:   bandLeft.Checked,
 				Right:  bandRight.Checked,
 			}
+			a.project.Parts[idx].Linear = linearCheck.Checked
+			a.project.Parts[idx].Tabs = tabs
+			a.project.Parts[idx].TabShape = ""
+			if tabShapeSelect.Selected != "Default" {
+				a.project.Parts[idx].TabShape = model.TabShapeFromString(tabShapeSelect.Selected)
+			}
 			a.refreshPartsList()
 			a.scheduleOptimize()
 		},
 		a.window,
 	)
-	form.Resize(fyne.NewSize(400, 420))
+	form.Resize(fyne.NewSize(400, 500))
 	form.Show()
 }
 
@@ -1407,6 +1559,8 @@ func (a *App) showAddStockDialog() {
 	priceEntry.SetPlaceHolder("0.00 (optional)")
 	priceEntry.SetText("0")
 
+	rollCheck := widget.NewCheck("Roll stock (height is max length, 0 = unlimited)", nil)
+
 	form := dialog.NewForm("Add Stock Sheet", "Add", "Cancel",
 		[]*widget.FormItem{
 			widget.NewFormItem("Preset Size", presetSelect),
@@ -1417,7 +1571,8 @@ func (a *App) showAddStockDialog() {
 			widget.NewFormItem("Quantity", qtyEntry),
 			widget.NewFormItem("Grain Direction", grainSelect),
 			widget.NewFormItem("Material", stockMaterialEntry),
-			widget.NewFormItem("Price per Sheet", priceEntry),
+			widget.NewFormItem("Roll", rollCheck),
+			widget.NewFormItem("Price per Sheet (per metre for rolls)", priceEntry),
 		},
 		func(ok bool) {
 			if !ok {
@@ -1426,7 +1581,7 @@ func (a *App) showAddStockDialog() {
 			w, _ := strconv.ParseFloat(widthEntry.Text, 64)
 			h, _ := strconv.ParseFloat(heightEntry.Text, 64)
 			q, _ := strconv.Atoi(qtyEntry.Text)
-			if w <= 0 || h <= 0 || q <= 0 {
+			if w <= 0 || h < 0 || (h == 0 && !rollCheck.Checked) || q <= 0 {
 				dialog.ShowError(fmt.Errorf("width, height, and quantity must be > 0"), a.window)
 				return
 			}
@@ -1444,7 +1599,13 @@ func (a *App) showAddStockDialog() {
 				sheet.Grain = model.GrainVertical
 			}
 			sheet.Material = strings.TrimSpace(stockMaterialEntry.Text)
-			sheet.PricePerSheet, _ = strconv.ParseFloat(priceEntry.Text, 64)
+			price, _ := strconv.ParseFloat(priceEntry.Text, 64)
+			sheet.Roll = rollCheck.Checked
+			if sheet.Roll {
+				sheet.PricePerMeter = price
+			} else {
+				sheet.PricePerSheet = price
+			}
 			a.project.Stocks = append(a.project.Stocks, sheet)
 			a.refreshStockList()
 			a.scheduleOptimize()
@@ -1485,7 +1646,14 @@ func (a *App) showEditStockDialog(idx int) {
 	editStockMaterialEntry.SetText(s.Material)
 
 	priceEntry := widget.NewEntry()
-	priceEntry.SetText(fmt.Sprintf("%.2f", s.PricePerSheet))
+	if s.Roll {
+		priceEntry.SetText(fmt.Sprintf("%.2f", s.PricePerMeter))
+	} else {
+		priceEntry.SetText(fmt.Sprintf("%.2f", s.PricePerSheet))
+	}
+
+	rollCheck := widget.NewCheck("Roll stock (height is max length, 0 = unlimited)", nil)
+	rollCheck.SetChecked(s.Roll)
 
 	form := dialog.NewForm("Edit Stock Sheet", "Save", "Cancel",
 		[]*widget.FormItem{
@@ -1496,7 +1664,8 @@ func (a *App) showEditStockDialog(idx int) {
 			widget.NewFormItem("Quantity", qtyEntry),
 			widget.NewFormItem("Grain Direction", grainSelect),
 			widget.NewFormItem("Material", editStockMaterialEntry),
-			widget.NewFormItem("Price per Sheet", priceEntry),
+			widget.NewFormItem("Roll", rollCheck),
+			widget.NewFormItem("Price per Sheet (per metre for rolls)", priceEntry),
 		},
 		func(ok bool) {
 			if !ok {
@@ -1505,7 +1674,7 @@ func (a *App) showEditStockDialog(idx int) {
 			w, _ := strconv.ParseFloat(widthEntry.Text, 64)
 			h, _ := strconv.ParseFloat(heightEntry.Text, 64)
 			q, _ := strconv.Atoi(qtyEntry.Text)
-			if w <= 0 || h <= 0 || q <= 0 {
+			if w <= 0 || h < 0 || (h == 0 && !rollCheck.Checked) || q <= 0 {
 				dialog.ShowError(fmt.Errorf("width, height, and quantity must be > 0"), a.window)
 				return
 			}
@@ -1528,7 +1697,15 @@ func (a *App) showEditStockDialog(idx int) {
 				a.project.Stocks[idx].Grain = model.GrainNone
 			}
 			a.project.Stocks[idx].Material = strings.TrimSpace(editStockMaterialEntry.Text)
-			a.project.Stocks[idx].PricePerSheet, _ = strconv.ParseFloat(priceEntry.Text, 64)
+			price, _ := strconv.ParseFloat(priceEntry.Text, 64)
+			a.project.Stocks[idx].Roll = rollCheck.Checked
+			if rollCheck.Checked {
+				a.project.Stocks[idx].PricePerMeter = price
+				a.project.Stocks[idx].PricePerSheet = 0
+			} else {
+				a.project.Stocks[idx].PricePerSheet = price
+				a.project.Stocks[idx].PricePerMeter = 0
+			}
 			a.refreshStockList()
 			a.scheduleOptimize()
 		},
@@ -1749,7 +1926,8 @@ func (a *App) showAddClampZoneDialog() {
 // ─── Actions ───────────────────────────────────────────────
 
 func (a *App) runOptimize() {
-	if len(a.project.Parts) == 0 {
+	a.optimizeLinear()
+	if len(a.project.AllParts()) == 0 {
 		dialog.ShowInformation("Nothing to optimize", "Add at least one part first.", a.window)
 		return
 	}
@@ -1758,9 +1936,9 @@ func (a *App) runOptimize() {
 		return
 	}
 
-	opt := engine.New(a.project.Settings)
-	result := opt.Optimize(a.project.Parts, a.project.Stocks)
+	result := a.optimizeProject()
 	a.project.Result = &result
+	a.manualLayout = result.HasLocks()
 
 	// Run dust shoe collision detection after optimization
 	collisions := gcode.CheckDustShoeCollisions(result, a.project.Settings)
@@ -1781,7 +1959,11 @@ func (a *App) runOptimize() {
 			}
 			msg.WriteString(w + "\n")
 		}
-		msg.WriteString("\nConsider moving clamps or adjusting part positions.")
+		if a.project.Settings.AvoidClampCollisions {
+			msg.WriteString("\nConsider moving clamps or adjusting part positions.")
+		} else {
+			msg.WriteString("\nConsider moving clamps, or enable \"Move Parts Away from Clamps\" in the dust shoe settings.")
+		}
 		dialog.ShowInformation("Dust Shoe Collision Warning", msg.String(), a.window)
 	}
 }
@@ -1829,18 +2011,63 @@ func (a *App) exportGCode() {
 		return
 	}
 
-	gen := gcode.New(a.project.Settings)
-	codes := gen.GenerateAll(*a.project.Result)
+	generate := func() {
+		// Identical sheets share one program
+		gen := gcode.New(a.project.Settings)
+		for _, prog := range gen.GeneratePatterns(*a.project.Result) {
+			a.saveGCodeFile(prog.Code, prog.FileName())
+		}
+	}
 
-	if len(codes) == 1 {
-		a.saveGCodeFile(codes[0], "sheet1.gcode")
+	// Check the layout before committing it to the machine
+	violations := engine.Validate(*a.project.Result, a.project.Settings)
+	if len(violations) == 0 {
+		generate()
 		return
 	}
+	const maxListed = 10
+	var msg strings.Builder
+	msg.WriteString(fmt.Sprintf("The layout has %d problem(s):\n\n", len(violations)))
+	for i, v := range violations {
+		if i == maxListed {
+			msg.WriteString(fmt.Sprintf("... and %d more\n", len(violations)-maxListed))
+			break
+		}
+		msg.WriteString(v.String() + "\n")
+	}
+	msg.WriteString("\nExport GCode anyway?")
+	dialog.ShowConfirm("Layout Problems", msg.String(), func(ok bool) {
+		if ok {
+			generate()
+		}
+	}, a.window)
+}
 
-	for i, code := range codes {
-		filename := fmt.Sprintf("sheet%d.gcode", i+1)
-		a.saveGCodeFile(code, filename)
+// exportRestart saves a sheet's program resumed from the pass that a line of
+// its full program belongs to, for carrying on after a broken bit.
+func (a *App) exportRestart(sheetIdx, line int) {
+	sheet := a.project.Result.Sheets[sheetIdx]
+	gen := gcode.New(a.project.Settings)
+	point, ok := gcode.RestartPointAt(gen.RestartPoints(sheet, sheetIdx+1), line)
+	if !ok {
+		dialog.ShowInformation("Restart", "Select a move on a part to restart from.", a.window)
+		return
 	}
+
+	msg := fmt.Sprintf("Export sheet %d restarting from %s?\n\n"+
+		"The program starts the spindle, then skips every cut before this pass.", sheetIdx+1, point)
+	dialog.ShowConfirm("Restart From Here", msg, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		code, err := gen.GenerateRestart(sheet, sheetIdx+1, point)
+		if err != nil {
+			dialog.ShowError(err, a.window)
+			return
+		}
+		ext := model.GetProfile(a.project.Settings.GCodeProfile).Extension()
+		a.saveGCodeFile(code, fmt.Sprintf("sheet%d_restart_part%d_pass%d%s", sheetIdx+1, point.Part, point.Pass, ext))
+	}, a.window)
 }
 
 func (a *App) saveGCodeFile(code, defaultName string) {
@@ -1922,29 +2149,55 @@ func (a *App) shareProject() {
 		notesEntry.SetText(a.project.Metadata.Notes)
 	}
 
+	const (
+		formatShared = "Project only (.slabshare)"
+		formatBundle = "Bundle with G-code, PDF & labels (.slabcut)"
+	)
+	formatRadio := widget.NewRadioGroup([]string{formatShared, formatBundle}, nil)
+	formatRadio.SetSelected(formatShared)
+	if a.project.Result != nil || len(a.project.ImportSources) > 0 {
+		formatRadio.SetSelected(formatBundle)
+	}
+
 	form := dialog.NewForm("Share Project", "Export", "Cancel",
 		[]*widget.FormItem{
 			widget.NewFormItem("Author", authorEntry),
 			widget.NewFormItem("Notes", notesEntry),
+			widget.NewFormItem("Format", formatRadio),
 		},
 		func(ok bool) {
 			if !ok {
 				return
 			}
+			bundle := formatRadio.Selected == formatBundle
 			d := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
 				if err != nil || writer == nil {
 					return
 				}
 				writer.Close()
 				path := writer.URI().Path()
-				if exportErr := project.ExportShared(path, a.project, authorEntry.Text, notesEntry.Text); exportErr != nil {
+				var exportErr error
+				var warnings []string
+				if bundle {
+					warnings, exportErr = project.ExportBundle(path, a.project, authorEntry.Text, notesEntry.Text)
+				} else {
+					exportErr = project.ExportShared(path, a.project, authorEntry.Text, notesEntry.Text)
+				}
+				if exportErr != nil {
 					dialog.ShowError(exportErr, a.window)
 				} else {
-					dialog.ShowInformation("Shared",
-						fmt.Sprintf("Project shared to:\n%s", path), a.window)
+					msg := fmt.Sprintf("Project shared to:\n%s", path)
+					if len(warnings) > 0 {
+						msg += "\n\n" + strings.Join(warnings, "\n")
+					}
+					dialog.ShowInformation("Shared", msg, a.window)
 				}
 			}, a.window)
-			d.SetFileName(a.project.Name + ".slabshare")
+			if bundle {
+				d.SetFileName(a.project.Name + project.BundleExtension)
+			} else {
+				d.SetFileName(a.project.Name + ".slabshare")
+			}
 			d.Show()
 		},
 		a.window,
@@ -1960,7 +2213,8 @@ func (a *App) importSharedProject() {
 		}
 		defer reader.Close()
 
-		proj, importErr := project.ImportShared(reader.URI().Path())
+		path := reader.URI().Path()
+		proj, importErr := project.ImportShared(path)
 		if importErr != nil {
 			dialog.ShowError(importErr, a.window)
 			return
@@ -1968,6 +2222,12 @@ func (a *App) importSharedProject() {
 
 		info := fmt.Sprintf("Project: %s\nParts: %d\nStock Sheets: %d",
 			proj.Name, len(proj.Parts), len(proj.Stocks))
+		if project.IsBundle(path) {
+			if b, err := project.OpenBundle(path); err == nil {
+				info += fmt.Sprintf("\nBundle: %d file(s), %d G-code program(s)",
+					len(b.Manifest.Files), len(b.GCodeFiles()))
+			}
+		}
 		if proj.Metadata.Author != "" {
 			info += fmt.Sprintf("\nShared by: %s", proj.Metadata.Author)
 		}
@@ -2005,8 +2265,9 @@ func (a *App) importCSV() {
 		}
 		defer reader.Close()
 
-		result := partimporter.ImportCSV(reader.URI().Path())
-		a.handleImportResult(result)
+		path := reader.URI().Path()
+		result := partimporter.ImportCSV(path)
+		a.handleImportResult(path, result)
 	}, a.window)
 }
 
@@ -2017,8 +2278,9 @@ func (a *App) importExcel() {
 		}
 		defer reader.Close()
 
-		result := partimporter.ImportExcel(reader.URI().Path())
-		a.handleImportResult(result)
+		path := reader.URI().Path()
+		result := partimporter.ImportExcel(path)
+		a.handleImportResult(path, result)
 	}, a.window)
 }
 
@@ -2029,12 +2291,13 @@ func (a *App) importDXF() {
 		}
 		defer reader.Close()
 
-		result := partimporter.ImportDXF(reader.URI().Path())
-		a.handleImportResult(result)
+		path := reader.URI().Path()
+		result := partimporter.ImportDXF(path)
+		a.handleImportResult(path, result)
 	}, a.window)
 }
 
-func (a *App) handleImportResult(result partimporter.ImportResult) {
+func (a *App) handleImportResult(path string, result partimporter.ImportResult) {
 	var summary strings.Builder
 
 	summary.WriteString(fmt.Sprintf("Parts imported: %d", len(result.Parts)))
@@ -2065,6 +2328,7 @@ func (a *App) handleImportResult(result partimporter.ImportResult) {
 	if len(result.Parts) > 0 {
 		a.saveState("Import Parts")
 		a.project.Parts = append(a.project.Parts, result.Parts...)
+		a.project.AddImportSource(path)
 		a.refreshPartsList()
 		a.scheduleOptimize()
 	}
@@ -2079,7 +2343,7 @@ func (a *App) handleImportResult(result partimporter.ImportResult) {
 // ─── Purchasing Calculator ──────────────────────────────────
 
 func (a *App) showPurchasingCalculator() {
-	if len(a.project.Parts) == 0 {
+	if len(a.project.AllParts()) == 0 {
 		dialog.ShowInformation("No Parts", "Add parts to the project first.", a.window)
 		return
 	}
@@ -2119,7 +2383,7 @@ func (a *App) showPurchasingCalculator() {
 			return
 		}
 
-		est := model.CalculatePurchaseEstimate(a.project.Parts, sw, sh,
+		est := model.CalculatePurchaseEstimate(a.project.AllParts(), sw, sh,
 			a.project.Settings.KerfWidth, waste, price)
 
 		var text strings.Builder
@@ -2154,7 +2418,7 @@ func (a *App) showPurchasingCalculator() {
 	content := container.NewVBox(
 		widget.NewLabelWithStyle("Purchasing Calculator", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
 		widget.NewLabel(fmt.Sprintf("Parts in project: %d types, %d total pieces",
-			len(a.project.Parts), countTotalParts(a.project.Parts))),
+			len(a.project.AllParts()), countTotalParts(a.project.AllParts()))),
 		widget.NewSeparator(),
 		widget.NewFormItem("Stock Preset", presetSelect).Widget,
 		container.NewGridWithColumns(2,
@@ -2182,8 +2446,9 @@ func countTotalParts(parts []model.Part) int {
 	return total
 }
 
-// saveOffcutsToInventory detects usable offcuts from the current result and saves them
-// as stock presets in the inventory for future projects.
+// saveOffcutsToInventory detects usable offcuts from the current result and saves them,
+// together with the rest of every limited roll, as stock presets in the inventory for
+// future projects.
 func (a *App) saveOffcutsToInventory() {
 	if a.project.Result == nil || len(a.project.Result.Sheets) == 0 {
 		dialog.ShowInformation("No Results", "Run the optimizer first to detect offcuts.", a.window)
@@ -2191,13 +2456,14 @@ func (a *App) saveOffcutsToInventory() {
 	}
 
 	offcuts := model.DetectAllOffcuts(*a.project.Result, a.project.Settings.KerfWidth)
-	if len(offcuts) == 0 {
+	rolls := a.project.Result.RemainingRolls()
+	if len(offcuts) == 0 && len(rolls) == 0 {
 		dialog.ShowInformation("No Offcuts", "No usable remnant areas were detected.", a.window)
 		return
 	}
 
 	var summary strings.Builder
-	summary.WriteString(fmt.Sprintf("Found %d usable offcut(s):\n\n", len(offcuts)))
+	summary.WriteString(fmt.Sprintf("Found %d usable offcut(s):\n\n", len(offcuts)+len(rolls)))
 	for i, o := range offcuts {
 		summary.WriteString(fmt.Sprintf("%d. Sheet %d (%s): %.0f x %.0f mm",
 			i+1, o.SheetIndex+1, o.SheetLabel, o.Width, o.Height))
@@ -2206,6 +2472,10 @@ func (a *App) saveOffcutsToInventory() {
 		}
 		summary.WriteString("\n")
 	}
+	for i, r := range rolls {
+		summary.WriteString(fmt.Sprintf("%d. Rest of roll %s: %.0f mm wide, %.0f mm long\n",
+			len(offcuts)+i+1, r.Label, r.Width, r.Height))
+	}
 	summary.WriteString("\nSave these as stock presets in your inventory?")
 
 	dialog.ShowConfirm("Save Offcuts to Inventory", summary.String(), func(ok bool) {
@@ -2216,9 +2486,14 @@ func (a *App) saveOffcutsToInventory() {
 		for _, o := range offcuts {
 			sheet := o.ToStockSheet()
 			preset := model.NewStockPresetWithPrice(sheet.Label, sheet.Width, sheet.Height, "Offcut", sheet.PricePerSheet)
+			preset.Defects = sheet.Defects
 			a.inventory.Stocks = append(a.inventory.Stocks, preset)
 			count++
 		}
+		for _, r := range rolls {
+			a.inventory.Stocks = append(a.inventory.Stocks, model.NewRollPreset(r))
+			count++
+		}
 		a.saveInventory()
 		dialog.ShowInformation("Offcuts Saved",
 			fmt.Sprintf("%d offcut(s) added to stock inventory.", count), a.window)
@@ -0,0 +1,185 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/piwi3910/SlabCut/internal/engine"
+	"github.com/piwi3910/SlabCut/internal/model"
+)
+
+// showBuyListOptimizer finds the cheapest combination of supplier sheet sizes
+// that packs the cut list. The catalog starts from the stock inventory and can
+// be edited before running; the chosen sheets can replace the project stock.
+func (a *App) showBuyListOptimizer() {
+	if len(model.SheetParts(a.project.AllParts())) == 0 {
+		dialog.ShowInformation("No Parts", "Add parts to the project first.", a.window)
+		return
+	}
+
+	type catalogRow struct {
+		name, width, height, material, price, moq *widget.Entry
+	}
+	var rows []*catalogRow
+	rowsBox := container.NewVBox()
+
+	newEntry := func(placeholder, text string) *widget.Entry {
+		e := widget.NewEntry()
+		e.SetPlaceHolder(placeholder)
+		e.SetText(text)
+		return e
+	}
+
+	var rebuild func()
+	rebuild = func() {
+		rowsBox.RemoveAll()
+		for i, r := range rows {
+			idx := i
+			removeBtn := widget.NewButtonWithIcon("", theme.DeleteIcon(), func() {
+				rows = append(rows[:idx], rows[idx+1:]...)
+				rebuild()
+			})
+			rowsBox.Add(container.NewBorder(nil, nil, nil, removeBtn,
+				container.NewGridWithColumns(6, r.name, r.width, r.height, r.material, r.price, r.moq)))
+		}
+	}
+	addRow := func(c model.CatalogSheet) {
+		rows = append(rows, &catalogRow{
+			name:     newEntry("Name", c.Name),
+			width:    newEntry("Width", fmt.Sprintf("%.0f", c.Width)),
+			height:   newEntry("Height", fmt.Sprintf("%.0f", c.Height)),
+			material: newEntry("Material", c.Material),
+			price:    newEntry("Price", fmt.Sprintf("%.2f", c.Price)),
+			moq:      newEntry("Min. qty", strconv.Itoa(c.MinOrderQty)),
+		})
+		rebuild()
+	}
+	for _, sp := range a.inventory.Stocks {
+		addRow(model.CatalogSheetFromPreset(sp))
+	}
+	if len(rows) == 0 {
+		addRow(model.CatalogSheet{Name: "Full Sheet", Width: 2440, Height: 1220})
+	}
+
+	readCatalog := func() ([]model.CatalogSheet, error) {
+		var catalog []model.CatalogSheet
+		for _, r := range rows {
+			c := model.CatalogSheet{
+				Name:        strings.TrimSpace(r.name.Text),
+				Width:       parseFloat(r.width.Text),
+				Height:      parseFloat(r.height.Text),
+				Material:    strings.TrimSpace(r.material.Text),
+				Price:       parseFloat(r.price.Text),
+				MinOrderQty: parseInt(r.moq.Text),
+			}
+			if c.Width <= 0 || c.Height <= 0 {
+				continue
+			}
+			if c.Price <= 0 {
+				return nil, fmt.Errorf("set a price for %q", c.Name)
+			}
+			catalog = append(catalog, c)
+		}
+		if len(catalog) == 0 {
+			return nil, fmt.Errorf("add at least one catalog sheet")
+		}
+		return catalog, nil
+	}
+
+	creditEntry := widget.NewEntry()
+	creditEntry.SetText("0")
+
+	resultLabel := widget.NewLabel("")
+	resultLabel.Wrapping = fyne.TextWrapWord
+	var plan *model.BuyList
+
+	useBtn := widget.NewButtonWithIcon("Use as Project Stock", theme.ConfirmIcon(), func() {
+		if plan == nil {
+			return
+		}
+		a.saveState("Apply Buy List")
+		a.project.Stocks = plan.Stocks()
+		a.refreshStockList()
+		a.scheduleOptimize()
+	})
+	useBtn.Disable()
+
+	var optimizeBtn *widget.Button
+	optimizeBtn = widget.NewButtonWithIcon("Find Cheapest Sheets", theme.SearchIcon(), func() {
+		catalog, err := readCatalog()
+		if err != nil {
+			dialog.ShowError(err, a.window)
+			return
+		}
+		credit := parseFloat(creditEntry.Text)
+		optimizeBtn.Disable()
+		useBtn.Disable()
+		resultLabel.SetText("Optimizing...")
+
+		parts := a.project.AllParts()
+		settings := a.project.Settings
+		go func() {
+			found, err := engine.New(settings).OptimizeBuyList(parts, catalog, credit)
+			optimizeBtn.Enable()
+			if err != nil {
+				plan = nil
+				resultLabel.SetText("Error: " + err.Error())
+				return
+			}
+			plan = &found
+
+			var text strings.Builder
+			text.WriteString("Sheets to order:\n")
+			for _, l := range found.Lines {
+				text.WriteString(fmt.Sprintf("  %d x %s (%.0f x %.0f mm) @ %.2f = %.2f",
+					l.Buy, l.Sheet.Name, l.Sheet.Width, l.Sheet.Height, l.Sheet.Price, l.Cost))
+				if l.Buy > l.Used {
+					text.WriteString(fmt.Sprintf("  (%d used, minimum order %d)", l.Used, l.Sheet.MinOrderQty))
+				}
+				text.WriteString("\n")
+			}
+			text.WriteString(fmt.Sprintf("\nSheet cost: %.2f\n", found.SheetCost))
+			if found.OffcutCredit > 0 {
+				text.WriteString(fmt.Sprintf("Offcut credit: -%.2f\nNet cost: %.2f\n", found.OffcutCredit, found.NetCost()))
+			}
+			text.WriteString(fmt.Sprintf("Layout efficiency: %.1f%% on %d sheet(s)",
+				found.Result.TotalEfficiency(), len(found.Result.Sheets)))
+			if found.Incomplete {
+				text.WriteString("\nThe search ran out of time before trying every combination of sizes.")
+			}
+			resultLabel.SetText(text.String())
+			useBtn.Enable()
+		}()
+	})
+	optimizeBtn.Importance = widget.HighImportance
+
+	header := container.NewGridWithColumns(6)
+	for _, l := range []string{"Name", "Width", "Height", "Material", "Price", "Min. Qty"} {
+		header.Add(widget.NewLabelWithStyle(l, fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+	}
+
+	content := container.NewVScroll(container.NewVBox(
+		widget.NewLabel("Supplier catalog. Every combination of sizes is packed and the cheapest layout that fits all parts is chosen."),
+		header,
+		rowsBox,
+		widget.NewButtonWithIcon("Add Size", theme.ContentAddIcon(), func() {
+			addRow(model.CatalogSheet{})
+		}),
+		widget.NewSeparator(),
+		widget.NewForm(widget.NewFormItem("Offcut credit (% of value)", creditEntry)),
+		container.NewHBox(optimizeBtn, useBtn),
+		widget.NewSeparator(),
+		resultLabel,
+	))
+
+	d := dialog.NewCustom("Buy List Optimizer", "Close", content, a.window)
+	d.Resize(fyne.NewSize(800, 600))
+	d.Show()
+}
@@ -0,0 +1,210 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/piwi3910/SlabCut/internal/model"
+	"github.com/piwi3910/SlabCut/internal/project"
+)
+
+// showCabinetGenerator opens the parametric cabinet generator. The generated
+// parts can be added to the project as a product or saved as a parametric
+// template. If initial is nil the default base cabinet is shown.
+func (a *App) showCabinetGenerator(initial *model.CabinetParams) {
+	params := model.DefaultCabinetParams()
+	if initial != nil {
+		params = *initial
+	}
+
+	nameEntry := widget.NewEntry()
+	nameEntry.SetText(params.Name)
+	typeSelect := widget.NewSelect(model.CabinetTypeOptions(), nil)
+	typeSelect.SetSelected(params.Type.String())
+	widthEntry := widget.NewEntry()
+	widthEntry.SetText(fmt.Sprintf("%.0f", params.Width))
+	heightEntry := widget.NewEntry()
+	heightEntry.SetText(fmt.Sprintf("%.0f", params.Height))
+	depthEntry := widget.NewEntry()
+	depthEntry.SetText(fmt.Sprintf("%.0f", params.Depth))
+	thicknessEntry := widget.NewEntry()
+	thicknessEntry.SetText(fmt.Sprintf("%.1f", params.Thickness))
+	backSelect := widget.NewSelect(model.BackPanelStyleOptions(), nil)
+	backSelect.SetSelected(params.BackStyle.String())
+	backThicknessEntry := widget.NewEntry()
+	backThicknessEntry.SetText(fmt.Sprintf("%.1f", params.BackThickness))
+	shelvesEntry := widget.NewEntry()
+	shelvesEntry.SetText(strconv.Itoa(params.ShelfCount))
+	toeKickEntry := widget.NewEntry()
+	toeKickEntry.SetText(fmt.Sprintf("%.0f", params.ToeKick))
+	doorsSelect := widget.NewSelect([]string{"0", "1", "2"}, nil)
+	doorsSelect.SetSelected(strconv.Itoa(params.DoorCount))
+	doorGapEntry := widget.NewEntry()
+	doorGapEntry.SetText(fmt.Sprintf("%.1f", params.DoorGap))
+	materialEntry := widget.NewEntry()
+	materialEntry.SetText(params.Material)
+	materialEntry.SetPlaceHolder("e.g., Birch Plywood (optional)")
+	qtyEntry := widget.NewEntry()
+	qtyEntry.SetText("1")
+
+	readParams := func() model.CabinetParams {
+		return model.CabinetParams{
+			Name:          strings.TrimSpace(nameEntry.Text),
+			Type:          model.CabinetTypeFromString(typeSelect.Selected),
+			Width:         parseFloat(widthEntry.Text),
+			Height:        parseFloat(heightEntry.Text),
+			Depth:         parseFloat(depthEntry.Text),
+			Thickness:     parseFloat(thicknessEntry.Text),
+			BackStyle:     model.BackPanelStyleFromString(backSelect.Selected),
+			BackThickness: parseFloat(backThicknessEntry.Text),
+			ShelfCount:    parseInt(shelvesEntry.Text),
+			ToeKick:       parseFloat(toeKickEntry.Text),
+			DoorCount:     parseInt(doorsSelect.Selected),
+			DoorGap:       parseFloat(doorGapEntry.Text),
+			Material:      strings.TrimSpace(materialEntry.Text),
+		}
+	}
+
+	preview := container.NewVBox()
+	refresh := func() {
+		preview.RemoveAll()
+		parts, err := model.GenerateCabinet(readParams())
+		if err != nil {
+			preview.Add(widget.NewLabel("Error: " + err.Error()))
+			return
+		}
+		for _, p := range parts {
+			text := fmt.Sprintf("%s  %.0f x %.0f mm  x%d", p.Label, p.Width, p.Height, p.Quantity)
+			if n := p.EdgeBanding.EdgeCount(); n > 0 {
+				text += fmt.Sprintf("  banded: %d", n)
+			}
+			if len(p.Drills) > 0 {
+				text += fmt.Sprintf("  holes: %d", len(p.Drills))
+			}
+			preview.Add(widget.NewLabel(text))
+		}
+	}
+	for _, e := range []*widget.Entry{nameEntry, widthEntry, heightEntry, depthEntry, thicknessEntry,
+		backThicknessEntry, shelvesEntry, toeKickEntry, doorGapEntry, materialEntry} {
+		e.OnChanged = func(string) { refresh() }
+	}
+	for _, s := range []*widget.Select{typeSelect, backSelect, doorsSelect} {
+		s.OnChanged = func(string) { refresh() }
+	}
+	refresh()
+
+	var d dialog.Dialog
+
+	addBtn := widget.NewButtonWithIcon("Add to Project", theme.ContentAddIcon(), func() {
+		qty := parseInt(qtyEntry.Text)
+		if qty <= 0 {
+			dialog.ShowError(fmt.Errorf("quantity must be > 0"), a.window)
+			return
+		}
+		prod, err := model.NewCabinetProduct(readParams(), qty)
+		if err != nil {
+			dialog.ShowError(err, a.window)
+			return
+		}
+		a.saveState("Add Cabinet")
+		a.project.Products = append(a.project.Products, prod)
+		a.refreshPartsList()
+		a.scheduleOptimize()
+		d.Hide()
+	})
+	addBtn.Importance = widget.HighImportance
+
+	saveTemplateBtn := widget.NewButtonWithIcon("Save as Template...", theme.DocumentSaveIcon(), func() {
+		a.showSaveCabinetTemplateDialog(readParams())
+	})
+
+	form := widget.NewForm(
+		widget.NewFormItem("Name", nameEntry),
+		widget.NewFormItem("Type", typeSelect),
+		widget.NewFormItem("Width (mm)", widthEntry),
+		widget.NewFormItem("Height (mm)", heightEntry),
+		widget.NewFormItem("Depth (mm)", depthEntry),
+		widget.NewFormItem("Material Thickness (mm)", thicknessEntry),
+		widget.NewFormItem("Back Panel", backSelect),
+		widget.NewFormItem("Back Thickness (mm)", backThicknessEntry),
+		widget.NewFormItem("Shelves", shelvesEntry),
+		widget.NewFormItem("Toe-Kick Height (mm)", toeKickEntry),
+		widget.NewFormItem("Doors", doorsSelect),
+		widget.NewFormItem("Door Gap (mm)", doorGapEntry),
+		widget.NewFormItem("Material", materialEntry),
+		widget.NewFormItem("Cabinets", qtyEntry),
+	)
+
+	content := container.NewBorder(
+		nil,
+		container.NewHBox(saveTemplateBtn, addBtn),
+		nil, nil,
+		container.NewHSplit(
+			container.NewVScroll(form),
+			container.NewBorder(
+				widget.NewLabelWithStyle("Generated Parts", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+				nil, nil, nil,
+				container.NewVScroll(preview),
+			),
+		),
+	)
+
+	d = dialog.NewCustom("Cabinet Generator", "Close", content, a.window)
+	d.Resize(fyne.NewSize(900, 600))
+	d.Show()
+}
+
+// showSaveCabinetTemplateDialog saves cabinet parameters as a parametric
+// project template together with the current stock sheets and settings.
+func (a *App) showSaveCabinetTemplateDialog(params model.CabinetParams) {
+	nameEntry := widget.NewEntry()
+	nameEntry.SetText(params.Name)
+	nameEntry.SetPlaceHolder("Template name")
+
+	descEntry := widget.NewMultiLineEntry()
+	descEntry.SetText(fmt.Sprintf("%s cabinet %.0f x %.0f x %.0f mm",
+		params.Type, params.Width, params.Height, params.Depth))
+	descEntry.SetMinRowsVisible(3)
+
+	form := dialog.NewForm("Save Cabinet Template", "Save", "Cancel",
+		[]*widget.FormItem{
+			widget.NewFormItem("Name", nameEntry),
+			widget.NewFormItem("Description", descEntry),
+		},
+		func(ok bool) {
+			if !ok {
+				return
+			}
+			name := strings.TrimSpace(nameEntry.Text)
+			if name == "" {
+				dialog.ShowError(fmt.Errorf("template name cannot be empty"), a.window)
+				return
+			}
+
+			tmpl, err := model.NewCabinetTemplate(name, descEntry.Text, params, a.project.Stocks, a.project.Settings)
+			if err != nil {
+				dialog.ShowError(err, a.window)
+				return
+			}
+			a.templates.Add(tmpl)
+
+			if err := project.SaveDefaultTemplates(a.templates); err != nil {
+				dialog.ShowError(fmt.Errorf("failed to save templates: %w", err), a.window)
+				return
+			}
+			dialog.ShowInformation("Template Saved",
+				fmt.Sprintf("Cabinet template %q saved with %d parts.", name, len(tmpl.Parts)),
+				a.window)
+		},
+		a.window,
+	)
+	form.Resize(fyne.NewSize(450, 300))
+	form.Show()
+}
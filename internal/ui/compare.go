@@ -14,7 +14,7 @@ import (
 // showCompareDialog runs optimization with multiple parameter scenarios and
 // displays the results in a comparison table so the user can pick the best one.
 func (a *App) showCompareDialog() {
-	if len(a.project.Parts) == 0 {
+	if len(a.project.AllParts()) == 0 {
 		dialog.ShowInformation("Nothing to compare", "Add at least one part first.", a.window)
 		return
 	}
@@ -85,7 +85,7 @@ func (a *App) showCompareDialog() {
 
 // runComparison executes the comparison and shows results.
 func (a *App) runComparison(scenarios []engine.ComparisonScenario) {
-	results := engine.CompareScenarios(scenarios, a.project.Parts, a.project.Stocks)
+	results := engine.CompareScenarios(scenarios, a.project.AllParts(), a.project.Stocks)
 
 	if len(results) == 0 {
 		dialog.ShowInformation("No Results", "No comparison results were generated.", a.window)
@@ -135,6 +135,8 @@ func (a *App) showComparisonResults(results []engine.ComparisonResult) {
 			a.saveState("Apply Comparison Result")
 			a.project.Settings = result.Scenario.Settings
 			a.project.Result = &result.Result
+			a.manualLayout = false
+			a.lastLayoutDiff = nil
 			a.refreshResults()
 			dialog.ShowInformation("Applied",
 				fmt.Sprintf("Applied settings from scenario %q.\nEfficiency: %.1f%%",
@@ -0,0 +1,143 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/piwi3910/SlabCut/internal/model"
+)
+
+// showStockDefectsDialog edits the defect zones of one stock sheet. A defect
+// is entered either as a rectangle or as a polygon of "x,y" points.
+func (a *App) showStockDefectsDialog(idx int) {
+	if idx < 0 || idx >= len(a.project.Stocks) {
+		return
+	}
+	stock := &a.project.Stocks[idx]
+
+	list := container.NewVBox()
+	var refresh func()
+	refresh = func() {
+		list.RemoveAll()
+		if len(stock.Defects) == 0 {
+			list.Add(widget.NewLabel("No defects. Parts may use the whole sheet."))
+		}
+		for i := range stock.Defects {
+			di := i
+			d := stock.Defects[di]
+			min, max := d.Outline.BoundingBox()
+			text := fmt.Sprintf("%s — %d points, %.0f,%.0f to %.0f,%.0f mm",
+				defectLabel(d), len(d.Outline), min.X, min.Y, max.X, max.Y)
+			list.Add(container.NewBorder(nil, nil, nil,
+				widget.NewButtonWithIcon("", theme.DeleteIcon(), func() {
+					a.saveState("Delete Defect")
+					stock.Defects = append(stock.Defects[:di], stock.Defects[di+1:]...)
+					refresh()
+					a.refreshStockList()
+					a.scheduleOptimize()
+				}),
+				widget.NewLabel(text),
+			))
+		}
+	}
+	refresh()
+
+	labelEntry := widget.NewEntry()
+	labelEntry.SetPlaceHolder("e.g. Knot, Dent, Broken corner")
+	xEntry := widget.NewEntry()
+	xEntry.SetPlaceHolder("X")
+	yEntry := widget.NewEntry()
+	yEntry.SetPlaceHolder("Y")
+	wEntry := widget.NewEntry()
+	wEntry.SetPlaceHolder("Width")
+	hEntry := widget.NewEntry()
+	hEntry.SetPlaceHolder("Height")
+	pointsEntry := widget.NewEntry()
+	pointsEntry.SetPlaceHolder("Polygon instead: 0,0; 150,0; 0,150")
+
+	addDefect := func(d model.Defect) {
+		a.saveState("Add Defect")
+		stock.Defects = append(stock.Defects, d)
+		labelEntry.SetText("")
+		pointsEntry.SetText("")
+		refresh()
+		a.refreshStockList()
+		a.scheduleOptimize()
+	}
+
+	addBtn := widget.NewButtonWithIcon("Add Defect", theme.ContentAddIcon(), func() {
+		label := strings.TrimSpace(labelEntry.Text)
+		if strings.TrimSpace(pointsEntry.Text) != "" {
+			outline, err := parsePolygon(pointsEntry.Text)
+			if err != nil {
+				dialog.ShowError(err, a.window)
+				return
+			}
+			addDefect(model.Defect{Label: label, Outline: outline})
+			return
+		}
+		w, h := parseFloat(wEntry.Text), parseFloat(hEntry.Text)
+		if w <= 0 || h <= 0 {
+			dialog.ShowError(fmt.Errorf("enter a width and height > 0, or polygon points"), a.window)
+			return
+		}
+		addDefect(model.NewRectDefect(label, parseFloat(xEntry.Text), parseFloat(yEntry.Text), w, h))
+	})
+
+	content := container.NewVScroll(container.NewVBox(
+		widget.NewLabel(fmt.Sprintf("Damaged areas of %q (%.0f x %.0f mm). Coordinates are mm from the top-left corner.",
+			stock.Label, stock.Width, stock.Height)),
+		list,
+		widget.NewSeparator(),
+		widget.NewForm(
+			widget.NewFormItem("Label", labelEntry),
+			widget.NewFormItem("Rectangle", container.NewGridWithColumns(4, xEntry, yEntry, wEntry, hEntry)),
+			widget.NewFormItem("Polygon", pointsEntry),
+		),
+		addBtn,
+	))
+
+	d := dialog.NewCustom("Sheet Defects", "Close", content, a.window)
+	d.Resize(fyne.NewSize(600, 450))
+	d.Show()
+}
+
+// defectLabel returns the defect's label or a generic name.
+func defectLabel(d model.Defect) string {
+	if d.Label != "" {
+		return d.Label
+	}
+	return "Defect"
+}
+
+// parsePolygon parses points written as "x,y; x,y; x,y".
+func parsePolygon(text string) (model.Outline, error) {
+	var outline model.Outline
+	for _, pair := range strings.Split(text, ";") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		xy := strings.Split(pair, ",")
+		if len(xy) != 2 {
+			return nil, fmt.Errorf("point %q must be written as x,y", pair)
+		}
+		x, errX := strconv.ParseFloat(strings.TrimSpace(xy[0]), 64)
+		y, errY := strconv.ParseFloat(strings.TrimSpace(xy[1]), 64)
+		if errX != nil || errY != nil {
+			return nil, fmt.Errorf("point %q is not a number pair", pair)
+		}
+		outline = append(outline, model.Point2D{X: x, Y: y})
+	}
+	if len(outline) < 3 {
+		return nil, fmt.Errorf("a polygon needs at least 3 points")
+	}
+	return outline, nil
+}
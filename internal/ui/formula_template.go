@@ -0,0 +1,254 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/piwi3910/SlabCut/internal/model"
+	"github.com/piwi3910/SlabCut/internal/project"
+)
+
+// showTemplateValuesDialog prompts for the variables of a formula template,
+// previews the evaluated parts, and calls onLoad with the entered values.
+func (a *App) showTemplateValuesDialog(tmpl model.ProjectTemplate, onLoad func(values map[string]float64)) {
+	entries := make([]*widget.Entry, len(tmpl.Variables))
+	items := make([]*widget.FormItem, len(tmpl.Variables))
+	for i, v := range tmpl.Variables {
+		entries[i] = widget.NewEntry()
+		entries[i].SetText(fmt.Sprintf("%g", v.Default))
+		label := v.Label
+		if label == "" {
+			label = v.Name
+		}
+		item := widget.NewFormItem(label, entries[i])
+		if v.HasBounds() {
+			item.HintText = fmt.Sprintf("%s, %g to %g", v.Name, v.Min, v.Max)
+		} else {
+			item.HintText = v.Name
+		}
+		items[i] = item
+	}
+
+	readValues := func() map[string]float64 {
+		values := make(map[string]float64, len(entries))
+		for i, v := range tmpl.Variables {
+			values[v.Name] = parseFloat(entries[i].Text)
+		}
+		return values
+	}
+
+	preview := container.NewVBox()
+	refresh := func() {
+		preview.RemoveAll()
+		parts, err := tmpl.EvaluateParts(readValues())
+		if err != nil {
+			preview.Add(widget.NewLabel("Error: " + err.Error()))
+			return
+		}
+		for _, p := range parts {
+			preview.Add(widget.NewLabel(fmt.Sprintf("%s  %.1f x %.1f mm  x%d", p.Label, p.Width, p.Height, p.Quantity)))
+		}
+	}
+	for _, e := range entries {
+		e.OnChanged = func(string) { refresh() }
+	}
+	refresh()
+
+	content := container.NewBorder(
+		container.NewVBox(
+			widget.NewLabel(fmt.Sprintf("Enter values for %q. Loading replaces your current project.", tmpl.Name)),
+			widget.NewForm(items...),
+			widget.NewSeparator(),
+			widget.NewLabelWithStyle("Parts", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		),
+		nil, nil, nil,
+		container.NewVScroll(preview),
+	)
+
+	d := dialog.NewCustomConfirm("Load Formula Template", "Load", "Cancel", content, func(ok bool) {
+		if ok {
+			onLoad(readValues())
+		}
+	}, a.window)
+	d.Resize(fyne.NewSize(550, 500))
+	d.Show()
+}
+
+// showFormulaTemplateEditor lets the user define a formula template: named
+// variables with defaults and bounds, and parts whose width, height and
+// quantity are expressions over those variables.
+func (a *App) showFormulaTemplateEditor(onSave func()) {
+	nameEntry := widget.NewEntry()
+	nameEntry.SetPlaceHolder("Template name, e.g. Bookcase")
+	descEntry := widget.NewEntry()
+	descEntry.SetPlaceHolder("Optional description")
+
+	type varRow struct {
+		name, label, def, min, max *widget.Entry
+	}
+	type partRow struct {
+		label, width, height, qty *widget.Entry
+		grain                     *widget.Select
+	}
+	var varRows []*varRow
+	var partRows []*partRow
+
+	varsBox := container.NewVBox()
+	partsBox := container.NewVBox()
+
+	newEntry := func(placeholder, text string) *widget.Entry {
+		e := widget.NewEntry()
+		e.SetPlaceHolder(placeholder)
+		e.SetText(text)
+		return e
+	}
+
+	var rebuildVars, rebuildParts func()
+	rebuildVars = func() {
+		varsBox.RemoveAll()
+		for i, r := range varRows {
+			idx := i
+			removeBtn := widget.NewButtonWithIcon("", theme.DeleteIcon(), func() {
+				varRows = append(varRows[:idx], varRows[idx+1:]...)
+				rebuildVars()
+			})
+			varsBox.Add(container.NewBorder(nil, nil, nil, removeBtn,
+				container.NewGridWithColumns(5, r.name, r.label, r.def, r.min, r.max)))
+		}
+	}
+	rebuildParts = func() {
+		partsBox.RemoveAll()
+		for i, r := range partRows {
+			idx := i
+			removeBtn := widget.NewButtonWithIcon("", theme.DeleteIcon(), func() {
+				partRows = append(partRows[:idx], partRows[idx+1:]...)
+				rebuildParts()
+			})
+			partsBox.Add(container.NewBorder(nil, nil, nil, removeBtn,
+				container.NewGridWithColumns(5, r.label, r.width, r.height, r.qty, r.grain)))
+		}
+	}
+
+	addVar := func(name, label, def string) {
+		varRows = append(varRows, &varRow{
+			name:  newEntry("Name", name),
+			label: newEntry("Prompt", label),
+			def:   newEntry("Default", def),
+			min:   newEntry("Min", ""),
+			max:   newEntry("Max", ""),
+		})
+		rebuildVars()
+	}
+	addPart := func(label, w, h, qty string, grain model.Grain) {
+		grainSelect := widget.NewSelect([]string{"None", "Horizontal", "Vertical"}, nil)
+		grainSelect.SetSelected(grain.String())
+		partRows = append(partRows, &partRow{
+			label:  newEntry("Label", label),
+			width:  newEntry("Width", w),
+			height: newEntry("Height", h),
+			qty:    newEntry("Qty", qty),
+			grain:  grainSelect,
+		})
+		rebuildParts()
+	}
+
+	// Start from a simple bookcase so the syntax is self-explanatory.
+	addVar("W", "Width (mm)", "800")
+	addVar("H", "Height (mm)", "1800")
+	addVar("D", "Depth (mm)", "300")
+	addVar("T", "Thickness (mm)", "18")
+	addPart("Side", "D", "H", "2", model.GrainVertical)
+	addPart("Top/Bottom", "W - 2*T", "D", "2", model.GrainHorizontal)
+	addPart("Shelf", "W - 2*T - 2", "D - 20", "max(floor(H/400) - 1, 0)", model.GrainHorizontal)
+
+	buildTemplate := func() (model.ProjectTemplate, error) {
+		var vars []model.TemplateVariable
+		for _, r := range varRows {
+			name := strings.TrimSpace(r.name.Text)
+			if name == "" {
+				continue
+			}
+			vars = append(vars, model.TemplateVariable{
+				Name:    name,
+				Label:   strings.TrimSpace(r.label.Text),
+				Default: parseFloat(r.def.Text),
+				Min:     parseFloat(r.min.Text),
+				Max:     parseFloat(r.max.Text),
+			})
+		}
+		var formulas []model.PartFormula
+		for _, r := range partRows {
+			if strings.TrimSpace(r.label.Text) == "" {
+				continue
+			}
+			formulas = append(formulas, model.PartFormula{
+				Label:    strings.TrimSpace(r.label.Text),
+				Width:    r.width.Text,
+				Height:   r.height.Text,
+				Quantity: r.qty.Text,
+				Grain:    parseGrain(r.grain.Selected),
+			})
+		}
+		name := strings.TrimSpace(nameEntry.Text)
+		if name == "" {
+			return model.ProjectTemplate{}, fmt.Errorf("template name cannot be empty")
+		}
+		if len(formulas) == 0 {
+			return model.ProjectTemplate{}, fmt.Errorf("add at least one part")
+		}
+		return model.NewFormulaTemplate(name, descEntry.Text, vars, formulas, a.project.Stocks, a.project.Settings)
+	}
+
+	columnHeader := func(labels ...string) fyne.CanvasObject {
+		grid := container.NewGridWithColumns(len(labels))
+		for _, l := range labels {
+			grid.Add(widget.NewLabelWithStyle(l, fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+		}
+		return grid
+	}
+
+	content := container.NewVScroll(container.NewVBox(
+		widget.NewForm(
+			widget.NewFormItem("Name", nameEntry),
+			widget.NewFormItem("Description", descEntry),
+		),
+		widget.NewSeparator(),
+		widget.NewLabelWithStyle("Variables", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		columnHeader("Name", "Prompt", "Default", "Min", "Max"),
+		varsBox,
+		widget.NewButtonWithIcon("Add Variable", theme.ContentAddIcon(), func() { addVar("", "", "0") }),
+		widget.NewSeparator(),
+		widget.NewLabelWithStyle("Parts", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		widget.NewLabel("Width, height and quantity are expressions, e.g. H - 2*T or ceil(W/600).\nFunctions: ceil, floor, round, abs, sqrt, min, max. Leave Min/Max empty for no bounds."),
+		columnHeader("Label", "Width", "Height", "Quantity", "Grain"),
+		partsBox,
+		widget.NewButtonWithIcon("Add Part", theme.ContentAddIcon(), func() { addPart("", "", "", "1", model.GrainNone) }),
+	))
+
+	d := dialog.NewCustomConfirm("New Formula Template", "Save", "Cancel", content, func(ok bool) {
+		if !ok {
+			return
+		}
+		tmpl, err := buildTemplate()
+		if err != nil {
+			dialog.ShowError(err, a.window)
+			return
+		}
+		a.templates.Add(tmpl)
+		if err := project.SaveDefaultTemplates(a.templates); err != nil {
+			dialog.ShowError(fmt.Errorf("failed to save templates: %w", err), a.window)
+			return
+		}
+		if onSave != nil {
+			onSave()
+		}
+	}, a.window)
+	d.Resize(fyne.NewSize(850, 650))
+	d.Show()
+}
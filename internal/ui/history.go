@@ -4,11 +4,13 @@ import "github.com/piwi3910/SlabCut/internal/model"
 
 const defaultMaxDepth = 50
 
-// Snapshot captures the parts and stocks state at a point in time.
+// Snapshot captures the parts, stocks and products state at a point in time.
 type Snapshot struct {
-	Parts  []model.Part
-	Stocks []model.StockSheet
-	Label  string // Human-readable description (e.g. "Add Part")
+	Parts    []model.Part
+	Stocks   []model.StockSheet
+	Products []model.Product
+	Layout   *model.OptimizeResult // Hand-edited layout; nil when the layout is derived by optimizing
+	Label    string                // Human-readable description (e.g. "Add Part")
 }
 
 // History manages undo/redo stacks of project snapshots.
@@ -104,10 +106,46 @@ func copyStocks(stocks []model.StockSheet) []model.StockSheet {
 			cp[i].Tabs.CustomZones = make([]model.TabZone, len(s.Tabs.CustomZones))
 			copy(cp[i].Tabs.CustomZones, s.Tabs.CustomZones)
 		}
+		if s.Defects != nil {
+			cp[i].Defects = make([]model.Defect, len(s.Defects))
+			for j, d := range s.Defects {
+				cp[i].Defects[j] = model.Defect{Label: d.Label, Outline: append(model.Outline(nil), d.Outline...)}
+			}
+		}
+	}
+	return cp
+}
+
+// copyProducts returns a deep copy of a products slice.
+func copyProducts(products []model.Product) []model.Product {
+	if products == nil {
+		return nil
+	}
+	cp := make([]model.Product, len(products))
+	for i, p := range products {
+		cp[i] = p
+		cp[i].Parts = copyParts(p.Parts)
 	}
 	return cp
 }
 
+// copyResult returns a deep copy of an optimization result's sheets and
+// placements, or nil for a nil result.
+func copyResult(r *model.OptimizeResult) *model.OptimizeResult {
+	if r == nil {
+		return nil
+	}
+	cp := *r
+	cp.Sheets = make([]model.SheetResult, len(r.Sheets))
+	for i, s := range r.Sheets {
+		cp.Sheets[i] = s
+		cp.Sheets[i].Placements = append([]model.Placement(nil), s.Placements...)
+	}
+	cp.UnplacedParts = copyParts(r.UnplacedParts)
+	cp.Warnings = append([]string(nil), r.Warnings...)
+	return &cp
+}
+
 // MakeSnapshot creates a snapshot from the current project state with a label.
 func MakeSnapshot(parts []model.Part, stocks []model.StockSheet, label string) Snapshot {
 	return Snapshot{
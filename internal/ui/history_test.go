@@ -204,6 +204,27 @@ func TestCopyNilSlices(t *testing.T) {
 	}
 }
 
+func TestDeepCopyResult(t *testing.T) {
+	if copyResult(nil) != nil {
+		t.Error("expected nil copy of nil result")
+	}
+
+	orig := &model.OptimizeResult{Sheets: []model.SheetResult{{
+		Stock:      model.StockSheet{Label: "Sheet", Width: 1000, Height: 600},
+		Placements: []model.Placement{{Part: model.Part{Label: "A"}, X: 10, Y: 10}},
+	}}}
+	cp := copyResult(orig)
+	cp.Sheets[0].Placements[0].X = 500
+	cp.Sheets[0].Placements = append(cp.Sheets[0].Placements, model.Placement{})
+
+	if orig.Sheets[0].Placements[0].X != 10 {
+		t.Error("modifying copied placement affected original")
+	}
+	if len(orig.Sheets[0].Placements) != 1 {
+		t.Error("appending to copied sheet affected original")
+	}
+}
+
 func TestMultipleUndoRedo(t *testing.T) {
 	h := NewHistory()
 
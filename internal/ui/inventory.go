@@ -279,6 +279,8 @@ func (a *App) showStockInventoryDialog() {
 			priceLabel := "-"
 			if s.PricePerSheet > 0 {
 				priceLabel = fmt.Sprintf("%.2f", s.PricePerSheet)
+			} else if s.Roll && s.PricePerMeter > 0 {
+				priceLabel = fmt.Sprintf("%.2f/m", s.PricePerMeter)
 			}
 			row := container.NewGridWithColumns(7,
 				widget.NewLabel(s.Name),
@@ -345,6 +347,9 @@ func (a *App) showAddStockPresetDialog(onDone func()) {
 	priceEntry.SetPlaceHolder("0.00 (optional)")
 	priceEntry.SetText("0")
 
+	moqEntry := widget.NewEntry()
+	moqEntry.SetPlaceHolder("0 (no minimum)")
+
 	form := dialog.NewForm("Add Stock Preset", "Add", "Cancel",
 		[]*widget.FormItem{
 			widget.NewFormItem("Name", nameEntry),
@@ -352,6 +357,7 @@ func (a *App) showAddStockPresetDialog(onDone func()) {
 			widget.NewFormItem("Height (mm)", heightEntry),
 			widget.NewFormItem("Material", materialEntry),
 			widget.NewFormItem("Price per Sheet", priceEntry),
+			widget.NewFormItem("Min. Order Qty", moqEntry),
 		},
 		func(ok bool) {
 			if !ok {
@@ -366,6 +372,7 @@ func (a *App) showAddStockPresetDialog(onDone func()) {
 
 			price, _ := strconv.ParseFloat(priceEntry.Text, 64)
 			preset := model.NewStockPresetWithPrice(nameEntry.Text, w, h, materialEntry.Text, price)
+			preset.MinOrderQty, _ = strconv.Atoi(moqEntry.Text)
 			a.inventory.Stocks = append(a.inventory.Stocks, preset)
 			a.saveInventory()
 			onDone()
@@ -394,6 +401,9 @@ func (a *App) showEditStockPresetDialog(idx int, onDone func()) {
 	priceEntry := widget.NewEntry()
 	priceEntry.SetText(fmt.Sprintf("%.2f", s.PricePerSheet))
 
+	moqEntry := widget.NewEntry()
+	moqEntry.SetText(strconv.Itoa(s.MinOrderQty))
+
 	form := dialog.NewForm("Edit Stock Preset", "Save", "Cancel",
 		[]*widget.FormItem{
 			widget.NewFormItem("Name", nameEntry),
@@ -401,6 +411,7 @@ func (a *App) showEditStockPresetDialog(idx int, onDone func()) {
 			widget.NewFormItem("Height (mm)", heightEntry),
 			widget.NewFormItem("Material", materialEntry),
 			widget.NewFormItem("Price per Sheet", priceEntry),
+			widget.NewFormItem("Min. Order Qty", moqEntry),
 		},
 		func(ok bool) {
 			if !ok {
@@ -411,6 +422,7 @@ func (a *App) showEditStockPresetDialog(idx int, onDone func()) {
 			a.inventory.Stocks[idx].Height, _ = strconv.ParseFloat(heightEntry.Text, 64)
 			a.inventory.Stocks[idx].Material = materialEntry.Text
 			a.inventory.Stocks[idx].PricePerSheet, _ = strconv.ParseFloat(priceEntry.Text, 64)
+			a.inventory.Stocks[idx].MinOrderQty, _ = strconv.Atoi(moqEntry.Text)
 			a.saveInventory()
 			onDone()
 		},
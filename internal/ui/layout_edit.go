@@ -0,0 +1,307 @@
+package ui
+
+import (
+	"fmt"
+	"reflect"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/piwi3910/SlabCut/internal/engine"
+	"github.com/piwi3910/SlabCut/internal/gcode"
+	"github.com/piwi3910/SlabCut/internal/model"
+)
+
+// buildLayoutEditBar wires the sheet canvas for manual layout editing and
+// returns the toolbar with the editing actions.
+func (a *App) buildLayoutEditBar() fyne.CanvasObject {
+	a.layoutIssuesLabel = widget.NewLabel("")
+
+	rotateBtn := widget.NewButtonWithIcon("Rotate", theme.ViewRefreshIcon(), func() {
+		a.sheetCanvas.RotateSelected()
+	})
+	pinBtn := widget.NewButton("Pin / Unpin", func() {
+		a.sheetCanvas.TogglePinSelected()
+	})
+	moveBtn := widget.NewButtonWithIcon("Move to Sheet...", theme.NavigateNextIcon(), func() {
+		a.showMovePlacementDialog()
+	})
+	reoptimizeBtn := widget.NewButtonWithIcon("Re-optimize Around Pinned", theme.ViewRestoreIcon(), func() {
+		a.reoptimizeAroundPinned()
+	})
+	lockBtn := widget.NewButton("Lock / Unlock Sheet", func() {
+		a.toggleSheetLock()
+	})
+	changesBtn := widget.NewButton("Changes...", func() {
+		a.showLayoutChanges()
+	})
+
+	selectionButtons := []*widget.Button{rotateBtn, pinBtn, moveBtn}
+	for _, b := range selectionButtons {
+		b.Disable()
+	}
+	reoptimizeBtn.Disable()
+
+	a.sheetCanvas.OnSelectionChanged = func(idx int) {
+		for _, b := range selectionButtons {
+			if idx >= 0 {
+				b.Enable()
+			} else {
+				b.Disable()
+			}
+		}
+	}
+	a.sheetCanvas.OnLayoutChanged = func(sheet model.SheetResult, action string) {
+		a.applySheetEdit(sheet, action)
+	}
+	a.sheetCanvas.OnPartTabsChanged = func(part model.Part, action string) {
+		a.applyPartTabs(part, action)
+	}
+
+	editCheck := widget.NewCheck("Edit Layout", func(on bool) {
+		a.sheetCanvas.SetEditable(on)
+		if on {
+			reoptimizeBtn.Enable()
+		} else {
+			reoptimizeBtn.Disable()
+		}
+		a.updateLayoutIssues()
+	})
+
+	tabsCheck := widget.NewCheck("Edit Tabs", func(on bool) {
+		a.sheetCanvas.SetTabEditing(on)
+	})
+
+	return container.NewHBox(editCheck, tabsCheck, rotateBtn, pinBtn, moveBtn, reoptimizeBtn, lockBtn, changesBtn, a.layoutIssuesLabel)
+}
+
+// applySheetEdit replaces the selected sheet of the current result with a
+// hand-edited version, recording the previous layout for undo.
+func (a *App) applySheetEdit(sheet model.SheetResult, action string) {
+	if a.project.Result == nil || a.selectedSheetIdx >= len(a.project.Result.Sheets) {
+		return
+	}
+	a.saveLayoutState(action)
+	edited := copyResult(a.project.Result)
+	edited.Sheets[a.selectedSheetIdx] = sheet
+	a.lastLayoutDiff = nil
+	a.setEditedLayout(edited)
+}
+
+// applyPartTabs stores hand-placed holding tabs on the part and on every
+// placement of it in the current layout. Tab positions are in the frame of
+// the edited outline, so tabs edited on an outline part the optimizer turned
+// to another angle are turned back to the part's own outline before they are
+// kept, and turned again for placements at other angles.
+func (a *App) applyPartTabs(part model.Part, action string) {
+	a.saveState(action)
+	rotations := a.project.Settings.NestingRotations
+	var source *model.Part
+	for i := range a.project.Parts {
+		p := &a.project.Parts[i]
+		if p.ID != part.ID {
+			continue
+		}
+		if reflect.DeepEqual(p.Outline, part.Outline) {
+			p.Tabs = part.Tabs
+		} else if tabs, ok := engine.PartTabs(*p, part, rotations); ok {
+			p.Tabs = tabs
+		} else {
+			continue
+		}
+		source = p
+	}
+	if a.project.Result != nil {
+		edited := copyResult(a.project.Result)
+		for s := range edited.Sheets {
+			for i := range edited.Sheets[s].Placements {
+				placed := &edited.Sheets[s].Placements[i].Part
+				if placed.ID != part.ID {
+					continue
+				}
+				if reflect.DeepEqual(placed.Outline, part.Outline) {
+					placed.Tabs = part.Tabs
+				} else if source != nil {
+					if tabs, ok := engine.PlacedTabs(*source, *placed, rotations); ok {
+						placed.Tabs = tabs
+					}
+				}
+			}
+		}
+		a.project.Result = edited
+		a.updateCanvasForSheet(a.selectedSheetIdx)
+	}
+	a.refreshGCodePreview()
+}
+
+// setEditedLayout makes a hand-edited layout the current result and refreshes
+// everything that depends on it.
+func (a *App) setEditedLayout(result *model.OptimizeResult) {
+	a.project.Result = result
+	a.manualLayout = true
+	a.updateStatusBar()
+	a.refreshSheetSelector()
+	if a.selectedSheetIdx >= len(result.Sheets) {
+		a.selectedSheetIdx = 0
+	}
+	if len(result.Sheets) > 0 {
+		a.updateCanvasForSheet(a.selectedSheetIdx)
+	}
+	a.refreshGCodePreview()
+	a.updateLayoutIssues()
+}
+
+// saveLayoutState records the current parts, stocks and layout before a
+// manual layout edit.
+func (a *App) saveLayoutState(label string) {
+	snap := a.snapshot(label)
+	snap.Layout = copyResult(a.project.Result)
+	a.history.Push(snap)
+}
+
+// updateLayoutIssues shows how many layout rule violations the displayed
+// sheet has.
+func (a *App) updateLayoutIssues() {
+	if a.layoutIssuesLabel == nil {
+		return
+	}
+	violations := a.sheetCanvas.Violations()
+	switch {
+	case a.project.Result == nil:
+		a.layoutIssuesLabel.SetText("")
+	case len(violations) == 0:
+		a.layoutIssuesLabel.SetText("Layout OK")
+	default:
+		a.layoutIssuesLabel.SetText(fmt.Sprintf("%d layout problem(s): %s", len(violations), violations[0].Message))
+	}
+}
+
+// showMovePlacementDialog moves the selected placement to another sheet.
+func (a *App) showMovePlacementDialog() {
+	idx := a.sheetCanvas.Selected()
+	if a.project.Result == nil || idx < 0 || len(a.project.Result.Sheets) < 2 {
+		dialog.ShowInformation("Move to Sheet", "Select a part and make sure there is more than one sheet.", a.window)
+		return
+	}
+
+	var names []string
+	for i := range a.project.Result.Sheets {
+		if i != a.selectedSheetIdx {
+			names = append(names, fmt.Sprintf("Sheet %d", i+1))
+		}
+	}
+	target := widget.NewSelect(names, nil)
+	target.SetSelectedIndex(0)
+
+	dialog.ShowForm("Move to Sheet", "Move", "Cancel",
+		[]*widget.FormItem{widget.NewFormItem("Target", target)},
+		func(ok bool) {
+			if !ok {
+				return
+			}
+			var to int
+			if _, err := fmt.Sscanf(target.Selected, "Sheet %d", &to); err != nil {
+				return
+			}
+			edited := copyResult(a.project.Result)
+			if err := engine.New(a.project.Settings).MovePlacement(edited, a.selectedSheetIdx, idx, to-1); err != nil {
+				dialog.ShowError(err, a.window)
+				return
+			}
+			a.saveLayoutState("Move Part to Sheet")
+			a.selectedSheetIdx = to - 1
+			a.setEditedLayout(edited)
+		}, a.window)
+}
+
+// reoptimizeAroundPinned re-packs every unpinned part around the pinned
+// placements of the current layout.
+func (a *App) reoptimizeAroundPinned() {
+	if a.project.Result == nil {
+		return
+	}
+	prev := *copyResult(a.project.Result)
+	a.saveLayoutState("Re-optimize Around Pinned")
+	if a.statusLabel != nil {
+		a.statusLabel.SetText("Optimizing around pinned parts...")
+	}
+	go func() {
+		result := engine.New(a.project.Settings).OptimizeAround(prev, a.project.AllParts(), a.project.Stocks)
+		diff := engine.DiffLayouts(prev, result)
+		a.lastLayoutDiff = &diff
+		a.setEditedLayout(&result)
+	}()
+}
+
+// optimizeProject optimizes the current project. When the current layout
+// has locked sheets or pinned placements, only the other parts are re-packed
+// around them and the changes are kept for the status bar.
+func (a *App) optimizeProject() model.OptimizeResult {
+	opt := engine.New(a.project.Settings)
+	prev := a.project.Result
+	if prev == nil || !prev.HasLocks() {
+		a.lastLayoutDiff = nil
+		parts := a.project.AllParts()
+		result := opt.Optimize(parts, a.project.Stocks)
+		if s := a.project.Settings; s.AvoidClampCollisions && s.DustShoeEnabled && len(s.ClampZones) > 0 {
+			result = opt.AvoidClampCollisions(parts, a.project.Stocks, result, clampHits(s))
+		}
+		return result
+	}
+	result := opt.OptimizeAround(*prev, a.project.AllParts(), a.project.Stocks)
+	diff := engine.DiffLayouts(*prev, result)
+	a.lastLayoutDiff = &diff
+	return result
+}
+
+// clampHits reports the clamps the dust shoe hits during a cut, for the
+// optimizer to move parts away from.
+func clampHits(settings model.CutSettings) engine.ClampHits {
+	return func(result model.OptimizeResult) []string {
+		var labels []string
+		for _, c := range gcode.CheckDustShoeCollisions(result, settings) {
+			if c.IsDuringCut {
+				labels = append(labels, c.ClampLabel)
+			}
+		}
+		return labels
+	}
+}
+
+// toggleSheetLock locks or unlocks the displayed sheet. Locked sheets are
+// kept as they are by every later optimization.
+func (a *App) toggleSheetLock() {
+	if a.project.Result == nil || a.selectedSheetIdx >= len(a.project.Result.Sheets) {
+		return
+	}
+	action := "Lock Sheet"
+	if a.project.Result.Sheets[a.selectedSheetIdx].Locked {
+		action = "Unlock Sheet"
+	}
+	a.saveLayoutState(action)
+	edited := copyResult(a.project.Result)
+	edited.Sheets[a.selectedSheetIdx].Locked = !edited.Sheets[a.selectedSheetIdx].Locked
+	a.setEditedLayout(edited)
+}
+
+// showLayoutChanges lists what moved in the last re-optimization around
+// locked sheets and pinned parts.
+func (a *App) showLayoutChanges() {
+	d := a.lastLayoutDiff
+	if d == nil {
+		dialog.ShowInformation("Layout Changes", "The last optimization did not keep any locked sheets or pinned parts.", a.window)
+		return
+	}
+	msg := d.Summary()
+	if d.Changed() {
+		msg += "\n\n" + d.Details()
+	}
+	content := widget.NewLabel(msg)
+	content.Wrapping = fyne.TextWrapWord
+	scroll := container.NewVScroll(content)
+	scroll.SetMinSize(fyne.NewSize(400, 300))
+	dialog.ShowCustom("Layout Changes", "Close", scroll, a.window)
+}
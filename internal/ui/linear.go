@@ -0,0 +1,208 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/piwi3910/SlabCut/internal/engine"
+	"github.com/piwi3910/SlabCut/internal/export"
+	"github.com/piwi3910/SlabCut/internal/model"
+)
+
+// optimizeLinear recomputes the 1D cutting plan for the project's linear
+// parts. The result is cleared when there is nothing to cut.
+func (a *App) optimizeLinear() {
+	linear := model.LinearParts(a.project.AllParts())
+	if len(linear) == 0 || len(a.project.LinearStocks) == 0 {
+		a.project.LinearResult = nil
+		return
+	}
+	result := engine.New(a.project.Settings).OptimizeLinear(linear, a.project.LinearStocks)
+	a.project.LinearResult = &result
+}
+
+// showLinearCutDialog manages linear stock and saw settings, shows the 1D
+// cutting plan for linear parts, and exports its cut diagrams.
+func (a *App) showLinearCutDialog() {
+	ls := &a.project.Settings.Linear
+
+	kerfEntry := widget.NewEntry()
+	kerfEntry.SetText(fmt.Sprintf("%.1f", ls.Kerf))
+	trimEntry := widget.NewEntry()
+	trimEntry.SetText(fmt.Sprintf("%.1f", ls.Trim))
+	endWasteEntry := widget.NewEntry()
+	endWasteEntry.SetText(fmt.Sprintf("%.1f", ls.EndWaste))
+
+	stockList := container.NewVBox()
+	plan := container.NewVBox()
+
+	var refresh func()
+	refresh = func() {
+		stockList.RemoveAll()
+		if len(a.project.LinearStocks) == 0 {
+			stockList.Add(widget.NewLabel("No linear stock defined."))
+		}
+		for i := range a.project.LinearStocks {
+			idx := i
+			s := a.project.LinearStocks[idx]
+			text := fmt.Sprintf("%s — %.0f mm x%d", s.Label, s.Length, s.Quantity)
+			if s.Material != "" {
+				text += fmt.Sprintf("  [%s]", s.Material)
+			}
+			if s.PricePerPiece > 0 {
+				text += fmt.Sprintf("  %.2f each", s.PricePerPiece)
+			}
+			stockList.Add(container.NewBorder(nil, nil, nil,
+				widget.NewButtonWithIcon("", theme.DeleteIcon(), func() {
+					a.project.LinearStocks = append(a.project.LinearStocks[:idx], a.project.LinearStocks[idx+1:]...)
+					refresh()
+				}),
+				widget.NewLabel(text),
+			))
+		}
+
+		a.optimizeLinear()
+		plan.RemoveAll()
+		linear := model.LinearParts(a.project.AllParts())
+		if len(linear) == 0 {
+			plan.Add(widget.NewLabel("No linear parts. Mark parts as linear in the part dialog."))
+			return
+		}
+		r := a.project.LinearResult
+		if r == nil {
+			plan.Add(widget.NewLabel("Add linear stock to build a cutting plan."))
+			return
+		}
+		plan.Add(widget.NewLabelWithStyle(
+			fmt.Sprintf("%d bars, %.1f%% efficiency, cost %.2f", len(r.Bars), r.TotalEfficiency(), r.TotalCost()),
+			fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+		for i, bar := range r.Bars {
+			cuts := make([]string, len(bar.Cuts))
+			for j, c := range bar.Cuts {
+				cuts[j] = fmt.Sprintf("%s %.0f", c.Part.Label, c.Part.Width)
+			}
+			plan.Add(widget.NewLabel(fmt.Sprintf("#%d %s (%.0f mm): %s | offcut %.0f mm",
+				i+1, bar.Stock.Label, bar.Stock.Length, strings.Join(cuts, ", "), bar.Remnant(r.Settings))))
+		}
+		for _, p := range r.UnplacedParts {
+			plan.Add(widget.NewLabel(fmt.Sprintf("Unplaced: %s (%.0f mm)", p.Label, p.Width)))
+		}
+	}
+
+	applySettings := func() {
+		ls.Kerf = parseFloat(kerfEntry.Text)
+		ls.Trim = parseFloat(trimEntry.Text)
+		ls.EndWaste = parseFloat(endWasteEntry.Text)
+		refresh()
+	}
+	for _, e := range []*widget.Entry{kerfEntry, trimEntry, endWasteEntry} {
+		e.OnChanged = func(string) { applySettings() }
+	}
+
+	labelEntry := widget.NewEntry()
+	labelEntry.SetPlaceHolder("Label")
+	lengthEntry := widget.NewEntry()
+	lengthEntry.SetPlaceHolder("Length (mm)")
+	qtyEntry := widget.NewEntry()
+	qtyEntry.SetText("10")
+	materialEntry := widget.NewEntry()
+	materialEntry.SetPlaceHolder("Material (optional)")
+	priceEntry := widget.NewEntry()
+	priceEntry.SetPlaceHolder("Price each")
+	addStockBtn := widget.NewButtonWithIcon("Add", theme.ContentAddIcon(), func() {
+		length := parseFloat(lengthEntry.Text)
+		qty := parseInt(qtyEntry.Text)
+		if length <= 0 || qty <= 0 {
+			dialog.ShowError(fmt.Errorf("length and quantity must be > 0"), a.window)
+			return
+		}
+		label := strings.TrimSpace(labelEntry.Text)
+		if label == "" {
+			label = fmt.Sprintf("Bar %.0f", length)
+		}
+		s := model.NewLinearStock(label, length, qty)
+		s.Material = strings.TrimSpace(materialEntry.Text)
+		s.PricePerPiece = parseFloat(priceEntry.Text)
+		a.project.LinearStocks = append(a.project.LinearStocks, s)
+		labelEntry.SetText("")
+		lengthEntry.SetText("")
+		refresh()
+	})
+
+	// Quick estimate for a single bar length
+	estLengthEntry := widget.NewEntry()
+	estLengthEntry.SetText("2400")
+	estWasteEntry := widget.NewEntry()
+	estWasteEntry.SetText("10")
+	estPriceEntry := widget.NewEntry()
+	estPriceEntry.SetText("0")
+	estLabel := widget.NewLabel("")
+	estimateBtn := widget.NewButton("Estimate", func() {
+		est := model.CalculateLinearPurchaseEstimate(a.project.AllParts(), parseFloat(estLengthEntry.Text),
+			*ls, parseFloat(estWasteEntry.Text), parseFloat(estPriceEntry.Text))
+		if est.UsableLength <= 0 {
+			estLabel.SetText("Bar length must exceed trim and end waste.")
+			return
+		}
+		estLabel.SetText(fmt.Sprintf("Total length %.0f mm: buy %d bars (minimum %d), est. cost %.2f",
+			est.TotalPartLength, est.BarsWithWaste, est.BarsNeededMin, est.EstimatedCost))
+	})
+
+	exportBtn := widget.NewButtonWithIcon("Export Cut Diagrams PDF...", theme.DocumentSaveIcon(), func() {
+		if a.project.LinearResult == nil || len(a.project.LinearResult.Bars) == 0 {
+			dialog.ShowInformation("No plan", "There is no linear cutting plan to export.", a.window)
+			return
+		}
+		result := *a.project.LinearResult
+		d := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+			if err != nil || writer == nil {
+				return
+			}
+			writer.Close()
+			path := writer.URI().Path()
+			if exportErr := export.ExportLinearPDF(path, result); exportErr != nil {
+				dialog.ShowError(exportErr, a.window)
+			} else {
+				dialog.ShowInformation("Export Complete",
+					fmt.Sprintf("Linear cut diagrams saved to %s", path), a.window)
+			}
+		}, a.window)
+		d.SetFileName("linear_cuts.pdf")
+		d.Show()
+	})
+
+	refresh()
+
+	content := container.NewVScroll(container.NewVBox(
+		widget.NewLabelWithStyle("Saw Settings", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		widget.NewForm(
+			widget.NewFormItem("Kerf (mm)", kerfEntry),
+			widget.NewFormItem("Start Trim (mm)", trimEntry),
+			widget.NewFormItem("End Waste (mm)", endWasteEntry),
+		),
+		widget.NewSeparator(),
+		widget.NewLabelWithStyle("Linear Stock", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		stockList,
+		container.NewBorder(nil, nil, nil, addStockBtn,
+			container.NewGridWithColumns(5, labelEntry, lengthEntry, qtyEntry, materialEntry, priceEntry)),
+		widget.NewSeparator(),
+		widget.NewLabelWithStyle("Cutting Plan", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		plan,
+		exportBtn,
+		widget.NewSeparator(),
+		widget.NewLabelWithStyle("Purchasing Estimate", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		container.NewBorder(nil, nil, nil, estimateBtn,
+			container.NewGridWithColumns(3, estLengthEntry, estWasteEntry, estPriceEntry)),
+		estLabel,
+	))
+
+	d := dialog.NewCustom("Linear Cut Plan", "Close", content, a.window)
+	d.Resize(fyne.NewSize(850, 650))
+	d.Show()
+}
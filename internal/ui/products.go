@@ -0,0 +1,229 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/piwi3910/SlabCut/internal/model"
+)
+
+// addProductCards appends one card per product to the parts list, below the
+// loose parts.
+func (a *App) addProductCards() {
+	for i := range a.project.Products {
+		idx := i
+		prod := a.project.Products[idx]
+
+		nameLabel := widget.NewLabelWithStyle("Product: "+prod.Name, fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+		detailLabel := widget.NewLabel(fmt.Sprintf("%d part types, %d pieces  x%d units",
+			len(prod.Parts), prod.PieceCount(), prod.Quantity))
+
+		editBtn := widget.NewButtonWithIcon("", theme.DocumentCreateIcon(), func() {
+			a.showEditProductDialog(idx)
+		})
+		deleteBtn := widget.NewButtonWithIcon("", theme.DeleteIcon(), func() {
+			a.saveState("Delete Product")
+			a.project.Products = append(a.project.Products[:idx], a.project.Products[idx+1:]...)
+			a.refreshPartsList()
+			a.scheduleOptimize()
+		})
+
+		buttons := container.NewHBox(editBtn, deleteBtn)
+		topRow := container.NewBorder(nil, nil, nil, buttons, nameLabel)
+		a.partsContainer.Add(container.NewVBox(topRow, detailLabel, widget.NewSeparator()))
+	}
+}
+
+// showProductManager lists the project's products and lets the user create,
+// edit and delete them.
+func (a *App) showProductManager() {
+	list := container.NewVBox()
+	var d dialog.Dialog
+
+	var refresh func()
+	refresh = func() {
+		list.RemoveAll()
+		if len(a.project.Products) == 0 {
+			list.Add(widget.NewLabel("No products defined. A product groups parts that are built together, e.g. a cabinet."))
+		}
+		for i := range a.project.Products {
+			idx := i
+			prod := a.project.Products[idx]
+			label := widget.NewLabel(fmt.Sprintf("%s — %d units, %d pieces", prod.Name, prod.Quantity, prod.PieceCount()))
+			editBtn := widget.NewButtonWithIcon("", theme.DocumentCreateIcon(), func() {
+				d.Hide()
+				a.showEditProductDialog(idx)
+			})
+			deleteBtn := widget.NewButtonWithIcon("", theme.DeleteIcon(), func() {
+				a.saveState("Delete Product")
+				a.project.Products = append(a.project.Products[:idx], a.project.Products[idx+1:]...)
+				a.refreshPartsList()
+				a.scheduleOptimize()
+				refresh()
+			})
+			list.Add(container.NewBorder(nil, nil, nil, container.NewHBox(editBtn, deleteBtn), label))
+		}
+	}
+	refresh()
+
+	nameEntry := widget.NewEntry()
+	nameEntry.SetPlaceHolder("Product name")
+	qtyEntry := widget.NewEntry()
+	qtyEntry.SetText("1")
+	addBtn := widget.NewButtonWithIcon("Add Product", theme.ContentAddIcon(), func() {
+		name := strings.TrimSpace(nameEntry.Text)
+		qty, _ := strconv.Atoi(qtyEntry.Text)
+		if name == "" || qty <= 0 {
+			dialog.ShowError(fmt.Errorf("product name is required and quantity must be > 0"), a.window)
+			return
+		}
+		a.saveState("Add Product")
+		a.project.Products = append(a.project.Products, model.NewProduct(name, qty))
+		nameEntry.SetText("")
+		qtyEntry.SetText("1")
+		a.refreshPartsList()
+		refresh()
+	})
+
+	content := container.NewBorder(
+		nil,
+		container.NewBorder(nil, nil, nil, addBtn, container.NewGridWithColumns(2, nameEntry, qtyEntry)),
+		nil, nil,
+		container.NewVScroll(list),
+	)
+	d = dialog.NewCustom("Products / Assemblies", "Close", content, a.window)
+	d.Resize(fyne.NewSize(550, 400))
+	d.Show()
+}
+
+// showEditProductDialog edits a product's name, unit quantity and parts.
+// Loose project parts can be moved into the product, and product parts moved
+// back out to the loose parts list.
+func (a *App) showEditProductDialog(idx int) {
+	if idx < 0 || idx >= len(a.project.Products) {
+		return
+	}
+	prodID := a.project.Products[idx].ID
+	product := func() *model.Product { return a.project.FindProduct(prodID) }
+
+	nameEntry := widget.NewEntry()
+	nameEntry.SetText(product().Name)
+	qtyEntry := widget.NewEntry()
+	qtyEntry.SetText(strconv.Itoa(product().Quantity))
+	notesEntry := widget.NewMultiLineEntry()
+	notesEntry.SetText(product().Notes)
+	notesEntry.SetMinRowsVisible(2)
+
+	partsList := container.NewVBox()
+	looseSelect := widget.NewSelect(nil, nil)
+	looseSelect.PlaceHolder = "Move loose part into product..."
+
+	changed := func() {
+		a.refreshPartsList()
+		a.scheduleOptimize()
+	}
+
+	var refresh func()
+	refresh = func() {
+		prod := product()
+		if prod == nil {
+			return
+		}
+		partsList.RemoveAll()
+		if len(prod.Parts) == 0 {
+			partsList.Add(widget.NewLabel("No parts in this product."))
+		}
+		for i := range prod.Parts {
+			pi := i
+			p := prod.Parts[pi]
+			label := widget.NewLabel(fmt.Sprintf("%s  %.0f x %.0f mm  x%d per unit", p.Label, p.Width, p.Height, p.Quantity))
+			moveOutBtn := widget.NewButtonWithIcon("", theme.MoveUpIcon(), func() {
+				prod := product()
+				if prod == nil || pi >= len(prod.Parts) {
+					return
+				}
+				a.saveState("Remove Part from Product")
+				a.project.Parts = append(a.project.Parts, prod.Parts[pi])
+				prod.Parts = append(prod.Parts[:pi], prod.Parts[pi+1:]...)
+				changed()
+				refresh()
+			})
+			deleteBtn := widget.NewButtonWithIcon("", theme.DeleteIcon(), func() {
+				prod := product()
+				if prod == nil || pi >= len(prod.Parts) {
+					return
+				}
+				a.saveState("Delete Product Part")
+				prod.Parts = append(prod.Parts[:pi], prod.Parts[pi+1:]...)
+				changed()
+				refresh()
+			})
+			partsList.Add(container.NewBorder(nil, nil, nil, container.NewHBox(moveOutBtn, deleteBtn), label))
+		}
+
+		options := make([]string, len(a.project.Parts))
+		for i, p := range a.project.Parts {
+			options[i] = fmt.Sprintf("%d: %s (%.0f x %.0f)", i+1, p.Label, p.Width, p.Height)
+		}
+		looseSelect.Options = options
+		looseSelect.ClearSelected()
+	}
+
+	moveInBtn := widget.NewButtonWithIcon("Add", theme.ContentAddIcon(), func() {
+		pi := looseSelect.SelectedIndex()
+		prod := product()
+		if prod == nil || pi < 0 || pi >= len(a.project.Parts) {
+			return
+		}
+		a.saveState("Add Part to Product")
+		prod.Parts = append(prod.Parts, a.project.Parts[pi])
+		a.project.Parts = append(a.project.Parts[:pi], a.project.Parts[pi+1:]...)
+		changed()
+		refresh()
+	})
+	refresh()
+
+	form := widget.NewForm(
+		widget.NewFormItem("Name", nameEntry),
+		widget.NewFormItem("Units", qtyEntry),
+		widget.NewFormItem("Notes", notesEntry),
+	)
+
+	content := container.NewBorder(
+		container.NewVBox(form, widget.NewSeparator(),
+			widget.NewLabelWithStyle("Parts (quantities per unit)", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})),
+		container.NewBorder(nil, nil, nil, moveInBtn, looseSelect),
+		nil, nil,
+		container.NewVScroll(partsList),
+	)
+
+	d := dialog.NewCustomConfirm("Edit Product", "Save", "Close", content, func(ok bool) {
+		if !ok {
+			return
+		}
+		name := strings.TrimSpace(nameEntry.Text)
+		qty, _ := strconv.Atoi(qtyEntry.Text)
+		if name == "" || qty <= 0 {
+			dialog.ShowError(fmt.Errorf("product name is required and quantity must be > 0"), a.window)
+			return
+		}
+		prod := product()
+		if prod == nil {
+			return
+		}
+		a.saveState("Edit Product")
+		prod.Name = name
+		prod.Quantity = qty
+		prod.Notes = notesEntry.Text
+		changed()
+	}, a.window)
+	d.Resize(fyne.NewSize(600, 500))
+	d.Show()
+}
@@ -12,6 +12,7 @@ import (
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 
+	"github.com/piwi3910/SlabCut/internal/gcode"
 	"github.com/piwi3910/SlabCut/internal/model"
 	"github.com/piwi3910/SlabCut/internal/project"
 )
@@ -170,8 +171,11 @@ func (a *App) showProfileManager() {
 func (a *App) showProfileDetail(c *fyne.Container, p model.GCodeProfile, w fyne.Window, onChanged func()) {
 	c.RemoveAll()
 
-	startCode := strings.Join(p.StartCode, "\n")
-	endCode := strings.Join(p.EndCode, "\n")
+	tmpl := p.Template()
+	lineNumbers := "Off"
+	if tmpl.LineNumberStep > 0 {
+		lineNumbers = fmt.Sprintf("Every line, step %d", tmpl.LineNumberStep)
+	}
 
 	info := container.NewVBox(
 		widget.NewLabelWithStyle(p.Name, fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
@@ -185,29 +189,10 @@ func (a *App) showProfileDetail(c *fyne.Container, p model.GCodeProfile, w fyne.
 			widget.NewLabel(fmt.Sprintf("%d", p.DecimalPlaces)),
 			widget.NewLabelWithStyle("Leading Zeros:", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
 			widget.NewLabel(fmt.Sprintf("%v", p.LeadingZeros)),
-		),
-
-		widget.NewSeparator(),
-		widget.NewLabelWithStyle("Motion Commands", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
-		container.NewGridWithColumns(2,
-			widget.NewLabel("Rapid Move:"), widget.NewLabel(p.RapidMove),
-			widget.NewLabel("Feed Move:"), widget.NewLabel(p.FeedMove),
-			widget.NewLabel("Absolute Mode:"), widget.NewLabel(p.AbsoluteMode),
-			widget.NewLabel("Feed Mode:"), widget.NewLabel(p.FeedMode),
-		),
-
-		widget.NewSeparator(),
-		widget.NewLabelWithStyle("Spindle Commands", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
-		container.NewGridWithColumns(2,
-			widget.NewLabel("Spindle Start:"), widget.NewLabel(p.SpindleStart),
-			widget.NewLabel("Spindle Stop:"), widget.NewLabel(p.SpindleStop),
-		),
-
-		widget.NewSeparator(),
-		widget.NewLabelWithStyle("Homing Commands", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
-		container.NewGridWithColumns(2,
-			widget.NewLabel("Home All:"), widget.NewLabel(p.HomeAll),
-			widget.NewLabel("Home XY:"), widget.NewLabel(p.HomeXY),
+			widget.NewLabelWithStyle("Line Numbers:", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+			widget.NewLabel(lineNumbers),
+			widget.NewLabelWithStyle("File Extension:", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+			widget.NewLabel(p.Extension()),
 		),
 
 		widget.NewSeparator(),
@@ -216,14 +201,26 @@ func (a *App) showProfileDetail(c *fyne.Container, p model.GCodeProfile, w fyne.
 			widget.NewLabel("Prefix:"), widget.NewLabel(fmt.Sprintf("%q", p.CommentPrefix)),
 			widget.NewLabel("Suffix:"), widget.NewLabel(fmt.Sprintf("%q", p.CommentSuffix)),
 		),
-
-		widget.NewSeparator(),
-		widget.NewLabelWithStyle("Start Code", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
-		widget.NewLabel(startCode),
-		widget.NewLabelWithStyle("End Code", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
-		widget.NewLabel(endCode),
 	)
 
+	for _, b := range tmpl.Blocks() {
+		text := b.Text
+		if text == "" {
+			text = "(not used)"
+		}
+		info.Add(widget.NewSeparator())
+		info.Add(widget.NewLabelWithStyle(postBlockTitles[b.Name], fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+		info.Add(widget.NewLabelWithStyle(text, fyne.TextAlignLeading, fyne.TextStyle{Monospace: true}))
+	}
+
+	info.Add(widget.NewSeparator())
+	info.Add(widget.NewLabelWithStyle("Sample Output", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+	sample, err := gcode.SampleProgram(p)
+	if err != nil {
+		sample = "Template error: " + err.Error()
+	}
+	info.Add(widget.NewLabelWithStyle(sample, fyne.TextAlignLeading, fyne.TextStyle{Monospace: true}))
+
 	if !p.IsBuiltIn {
 		editBtn := widget.NewButtonWithIcon("Edit Profile", theme.DocumentCreateIcon(), func() {
 			a.showEditProfileDialog(p, w, onChanged)
@@ -237,6 +234,25 @@ func (a *App) showProfileDetail(c *fyne.Container, p model.GCodeProfile, w fyne.
 	c.Refresh()
 }
 
+// postBlockTitles are the display names of the post-processor blocks.
+var postBlockTitles = map[string]string{
+	model.BlockHeader:     "Header",
+	model.BlockToolChange: "Tool Change",
+	model.BlockRapid:      "Rapid Move",
+	model.BlockLinear:     "Linear Move",
+	model.BlockArc:        "Arc Move",
+	model.BlockDrill:      "Drill Cycle",
+	model.BlockFooter:     "Footer",
+}
+
+// postVariablesHelp lists what post-processor blocks can use.
+const postVariablesHelp = `Blocks are Go templates. Blank lines are dropped.
+Job: {{.Program}} {{.Sheet}} {{.SheetLabel}} {{.StockWidth}} {{.StockHeight}} {{.StockThickness}}
+     {{.Parts}} {{.Banner}} {{.Profile}} {{.Units}} {{.Tool}} {{.ToolDiameter}} {{.SpindleSpeed}}
+     {{.FeedRate}} {{.PlungeRate}} {{.SafeZ}} {{.CutDepth}} {{.PassDepth}} {{.Passes}} {{.ReturnZ}}
+Move: {{.X}} {{.Y}} {{.Z}} {{.I}} {{.J}} {{.F}} {{.R}} {{.Q}} {{.Clockwise}} (empty when not moved)
+Functions: {{comment "text"}} {{num .SafeZ}} {{perSecond .F}} (feed in units per second)`
+
 // showNewProfileDialog shows a dialog to create a new custom profile.
 func (a *App) showNewProfileDialog(w fyne.Window, onCreated func()) {
 	nameEntry := widget.NewEntry()
@@ -310,8 +326,11 @@ func (a *App) duplicateProfile(source model.GCodeProfile, w fyne.Window, onCreat
 	form.Show()
 }
 
-// showEditProfileDialog shows a comprehensive editing dialog for a custom profile.
+// showEditProfileDialog shows a comprehensive editing dialog for a custom
+// profile, with a sample program that follows the edits.
 func (a *App) showEditProfileDialog(p model.GCodeProfile, w fyne.Window, onSaved func()) {
+	tmpl := p.Template()
+
 	nameEntry := widget.NewEntry()
 	nameEntry.SetText(p.Name)
 
@@ -327,29 +346,11 @@ func (a *App) showEditProfileDialog(p model.GCodeProfile, w fyne.Window, onSaved
 	leadingZerosCheck := widget.NewCheck("", nil)
 	leadingZerosCheck.Checked = p.LeadingZeros
 
-	rapidEntry := widget.NewEntry()
-	rapidEntry.SetText(p.RapidMove)
-
-	feedEntry := widget.NewEntry()
-	feedEntry.SetText(p.FeedMove)
-
-	absoluteEntry := widget.NewEntry()
-	absoluteEntry.SetText(p.AbsoluteMode)
-
-	feedModeEntry := widget.NewEntry()
-	feedModeEntry.SetText(p.FeedMode)
+	lineStepEntry := widget.NewEntry()
+	lineStepEntry.SetText(fmt.Sprintf("%d", tmpl.LineNumberStep))
 
-	spindleStartEntry := widget.NewEntry()
-	spindleStartEntry.SetText(p.SpindleStart)
-
-	spindleStopEntry := widget.NewEntry()
-	spindleStopEntry.SetText(p.SpindleStop)
-
-	homeAllEntry := widget.NewEntry()
-	homeAllEntry.SetText(p.HomeAll)
-
-	homeXYEntry := widget.NewEntry()
-	homeXYEntry.SetText(p.HomeXY)
+	extEntry := widget.NewEntry()
+	extEntry.SetText(p.Extension())
 
 	commentPrefixEntry := widget.NewEntry()
 	commentPrefixEntry.SetText(p.CommentPrefix)
@@ -357,46 +358,81 @@ func (a *App) showEditProfileDialog(p model.GCodeProfile, w fyne.Window, onSaved
 	commentSuffixEntry := widget.NewEntry()
 	commentSuffixEntry.SetText(p.CommentSuffix)
 
-	startCodeEntry := widget.NewMultiLineEntry()
-	startCodeEntry.SetText(strings.Join(p.StartCode, "\n"))
-	startCodeEntry.SetMinRowsVisible(4)
+	blockEntries := make(map[string]*widget.Entry)
+	blocksBox := container.NewVBox()
+	for _, b := range tmpl.Blocks() {
+		entry := widget.NewMultiLineEntry()
+		entry.SetText(b.Text)
+		entry.TextStyle = fyne.TextStyle{Monospace: true}
+		entry.SetMinRowsVisible(3)
+		blockEntries[b.Name] = entry
+		blocksBox.Add(widget.NewLabelWithStyle(postBlockTitles[b.Name], fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+		blocksBox.Add(entry)
+	}
 
-	endCodeEntry := widget.NewMultiLineEntry()
-	endCodeEntry.SetText(strings.Join(p.EndCode, "\n"))
-	endCodeEntry.SetMinRowsVisible(4)
+	// build assembles the profile from the form, or reports the first
+	// field that does not hold a valid number.
+	build := func() (model.GCodeProfile, error) {
+		decimals, err := strconv.Atoi(decimalEntry.Text)
+		if err != nil || decimals < 0 || decimals > 10 {
+			return model.GCodeProfile{}, fmt.Errorf("decimal places must be a number between 0 and 10")
+		}
+		lineStep, err := strconv.Atoi(strings.TrimSpace(lineStepEntry.Text))
+		if err != nil || lineStep < 0 {
+			return model.GCodeProfile{}, fmt.Errorf("line number step must be 0 or a positive number")
+		}
+		ext := strings.TrimSpace(extEntry.Text)
+		if ext != "" && !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		return model.GCodeProfile{
+			Name:          strings.TrimSpace(nameEntry.Text),
+			Description:   descEntry.Text,
+			IsBuiltIn:     false,
+			Units:         unitsSelect.Selected,
+			FileExtension: ext,
+			Post: model.PostTemplate{
+				Header:         blockEntries[model.BlockHeader].Text,
+				ToolChange:     blockEntries[model.BlockToolChange].Text,
+				Rapid:          blockEntries[model.BlockRapid].Text,
+				Linear:         blockEntries[model.BlockLinear].Text,
+				Arc:            blockEntries[model.BlockArc].Text,
+				Drill:          blockEntries[model.BlockDrill].Text,
+				Footer:         blockEntries[model.BlockFooter].Text,
+				LineNumberStep: lineStep,
+			},
+			CommentPrefix: commentPrefixEntry.Text,
+			CommentSuffix: commentSuffixEntry.Text,
+			DecimalPlaces: decimals,
+			LeadingZeros:  leadingZerosCheck.Checked,
+		}, nil
+	}
 
-	// Preview section
+	// Live sample output
 	previewLabel := widget.NewMultiLineEntry()
+	previewLabel.TextStyle = fyne.TextStyle{Monospace: true}
 	previewLabel.Disable()
-	previewLabel.SetMinRowsVisible(8)
+	previewLabel.SetMinRowsVisible(16)
 
 	updatePreview := func() {
-		var preview strings.Builder
-		preview.WriteString(commentPrefixEntry.Text + " Sample GCode Preview" + commentSuffixEntry.Text + "\n")
-		preview.WriteString(commentPrefixEntry.Text + " Profile: " + nameEntry.Text + commentSuffixEntry.Text + "\n\n")
-
-		for _, line := range splitLines(startCodeEntry.Text) {
-			if line != "" {
-				preview.WriteString(line + "\n")
-			}
-		}
-		preview.WriteString(spindleStartEntry.Text + "\n")
-		preview.WriteString(rapidEntry.Text + " X0.000 Y0.000\n")
-		preview.WriteString(feedEntry.Text + " X100.000 Y50.000 F1500.000\n")
-		preview.WriteString("\n")
-		for _, line := range splitLines(endCodeEntry.Text) {
-			if line != "" {
-				preview.WriteString(line + "\n")
+		profile, err := build()
+		if err == nil {
+			var sample string
+			sample, err = gcode.SampleProgram(profile)
+			if err == nil {
+				previewLabel.SetText(sample)
+				return
 			}
 		}
-		preview.WriteString(spindleStopEntry.Text + "\n")
-		previewLabel.SetText(preview.String())
+		previewLabel.SetText("Template error: " + err.Error())
 	}
 	updatePreview()
-
-	previewBtn := widget.NewButtonWithIcon("Refresh Preview", theme.ViewRefreshIcon(), func() {
-		updatePreview()
-	})
+	for _, e := range []*widget.Entry{nameEntry, decimalEntry, lineStepEntry, commentPrefixEntry, commentSuffixEntry} {
+		e.OnChanged = func(string) { updatePreview() }
+	}
+	for _, e := range blockEntries {
+		e.OnChanged = func(string) { updatePreview() }
+	}
 
 	// Build tabbed form
 	generalTab := container.NewTabItem("General", container.NewVBox(
@@ -406,24 +442,8 @@ func (a *App) showEditProfileDialog(p model.GCodeProfile, w fyne.Window, onSaved
 			widget.NewLabel("Units"), unitsSelect,
 			widget.NewLabel("Decimal Places"), decimalEntry,
 			widget.NewLabel("Leading Zeros"), leadingZerosCheck,
-		),
-	))
-
-	motionTab := container.NewTabItem("Motion", container.NewVBox(
-		container.NewGridWithColumns(2,
-			widget.NewLabel("Rapid Move Command"), rapidEntry,
-			widget.NewLabel("Feed Move Command"), feedEntry,
-			widget.NewLabel("Absolute Mode"), absoluteEntry,
-			widget.NewLabel("Feed Rate Mode"), feedModeEntry,
-		),
-	))
-
-	spindleTab := container.NewTabItem("Spindle / Homing", container.NewVBox(
-		container.NewGridWithColumns(2,
-			widget.NewLabel("Spindle Start (use %d for RPM)"), spindleStartEntry,
-			widget.NewLabel("Spindle Stop"), spindleStopEntry,
-			widget.NewLabel("Home All Axes"), homeAllEntry,
-			widget.NewLabel("Home XY"), homeXYEntry,
+			widget.NewLabel("Line Number Step (0 = off)"), lineStepEntry,
+			widget.NewLabel("File Extension"), extEntry,
 		),
 	))
 
@@ -434,61 +454,34 @@ func (a *App) showEditProfileDialog(p model.GCodeProfile, w fyne.Window, onSaved
 		),
 	))
 
-	codeTab := container.NewTabItem("Start/End Code", container.NewVBox(
-		widget.NewLabelWithStyle("Start Code (one command per line)", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
-		startCodeEntry,
-		widget.NewSeparator(),
-		widget.NewLabelWithStyle("End Code (one command per line)", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
-		endCodeEntry,
-	))
+	help := widget.NewLabelWithStyle(postVariablesHelp, fyne.TextAlignLeading, fyne.TextStyle{Monospace: true})
+	blocksTab := container.NewTabItem("Blocks", container.NewVScroll(container.NewVBox(help, widget.NewSeparator(), blocksBox)))
 
-	previewTab := container.NewTabItem("Preview", container.NewVBox(
-		previewBtn,
-		previewLabel,
-	))
+	previewTab := container.NewTabItem("Sample Output", container.NewVScroll(previewLabel))
 
-	tabs := container.NewAppTabs(generalTab, motionTab, spindleTab, commentsTab, codeTab, previewTab)
+	tabs := container.NewAppTabs(generalTab, commentsTab, blocksTab, previewTab)
 
 	// Save and Cancel buttons
 	saveBtn := widget.NewButtonWithIcon("Save", theme.DocumentSaveIcon(), func() {
-		name := strings.TrimSpace(nameEntry.Text)
-		if name == "" {
+		updated, err := build()
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		if updated.Name == "" {
 			dialog.ShowError(fmt.Errorf("profile name cannot be empty"), w)
 			return
 		}
-
-		decimals, err := strconv.Atoi(decimalEntry.Text)
-		if err != nil || decimals < 0 || decimals > 10 {
-			dialog.ShowError(fmt.Errorf("decimal places must be a number between 0 and 10"), w)
+		if err := gcode.ValidateProfile(updated); err != nil {
+			dialog.ShowError(fmt.Errorf("invalid post-processor template: %w", err), w)
 			return
 		}
 
 		// If name changed, remove old profile first
-		if name != p.Name {
+		if updated.Name != p.Name {
 			_ = model.RemoveCustomProfile(p.Name)
 		}
 
-		updated := model.GCodeProfile{
-			Name:          name,
-			Description:   descEntry.Text,
-			IsBuiltIn:     false,
-			Units:         unitsSelect.Selected,
-			StartCode:     splitLines(startCodeEntry.Text),
-			SpindleStart:  spindleStartEntry.Text,
-			SpindleStop:   spindleStopEntry.Text,
-			HomeAll:       homeAllEntry.Text,
-			HomeXY:        homeXYEntry.Text,
-			AbsoluteMode:  absoluteEntry.Text,
-			FeedMode:      feedModeEntry.Text,
-			RapidMove:     rapidEntry.Text,
-			FeedMove:      feedEntry.Text,
-			EndCode:       splitLines(endCodeEntry.Text),
-			CommentPrefix: commentPrefixEntry.Text,
-			CommentSuffix: commentSuffixEntry.Text,
-			DecimalPlaces: decimals,
-			LeadingZeros:  leadingZerosCheck.Checked,
-		}
-
 		if err := model.AddCustomProfile(updated); err != nil {
 			dialog.ShowError(err, w)
 			return
@@ -507,7 +500,7 @@ func (a *App) showEditProfileDialog(p model.GCodeProfile, w fyne.Window, onSaved
 
 	editWindow := fyne.CurrentApp().NewWindow("Edit Profile: " + p.Name)
 	editWindow.SetContent(content)
-	editWindow.Resize(fyne.NewSize(600, 500))
+	editWindow.Resize(fyne.NewSize(700, 600))
 	editWindow.Show()
 }
 
@@ -561,16 +554,3 @@ func (a *App) persistCustomProfiles(w fyne.Window) {
 		dialog.ShowError(fmt.Errorf("failed to save profiles: %w", err), w)
 	}
 }
-
-// splitLines splits a multiline string into non-empty lines.
-func splitLines(text string) []string {
-	raw := strings.Split(text, "\n")
-	var lines []string
-	for _, line := range raw {
-		trimmed := strings.TrimSpace(line)
-		if trimmed != "" {
-			lines = append(lines, trimmed)
-		}
-	}
-	return lines
-}
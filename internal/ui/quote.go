@@ -0,0 +1,196 @@
+package ui
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/piwi3910/SlabCut/internal/export"
+	"github.com/piwi3910/SlabCut/internal/model"
+)
+
+// showQuoteDialog lets the user enter pricing inputs, shows the per-line cost
+// breakdown of the current optimization result, and exports a customer quote PDF.
+func (a *App) showQuoteDialog() {
+	if a.project.Result == nil || len(a.project.Result.Sheets) == 0 {
+		dialog.ShowInformation("No results", "Run the optimizer first before creating a quote.", a.window)
+		return
+	}
+
+	// Projects saved before quoting existed have no quote settings.
+	qs := a.project.Quote
+	if qs.Currency == "" && qs.MachineHourlyRate == 0 && qs.MarginPercent == 0 {
+		qs = model.DefaultQuoteSettings()
+	}
+	qs.Hardware = append([]model.HardwareItem(nil), qs.Hardware...)
+
+	customerEntry := widget.NewEntry()
+	customerEntry.SetText(qs.CustomerName)
+	referenceEntry := widget.NewEntry()
+	referenceEntry.SetText(qs.Reference)
+	currencyEntry := widget.NewEntry()
+	currencyEntry.SetText(qs.Currency)
+	notesEntry := widget.NewMultiLineEntry()
+	notesEntry.SetText(qs.Notes)
+	notesEntry.SetMinRowsVisible(2)
+
+	breakdown := container.NewVBox()
+
+	var refresh func()
+	floatEntry := func(val *float64) *widget.Entry {
+		e := widget.NewEntry()
+		e.SetText(fmt.Sprintf("%.2f", *val))
+		e.OnChanged = func(text string) {
+			*val = parseFloat(text)
+			refresh()
+		}
+		return e
+	}
+
+	hardwareList := container.NewVBox()
+	var refreshHardware func()
+	refreshHardware = func() {
+		hardwareList.RemoveAll()
+		if len(qs.Hardware) == 0 {
+			hardwareList.Add(widget.NewLabel("No hardware items."))
+		}
+		for i := range qs.Hardware {
+			idx := i
+			h := qs.Hardware[idx]
+			hardwareList.Add(container.NewBorder(nil, nil, nil,
+				widget.NewButtonWithIcon("", theme.DeleteIcon(), func() {
+					qs.Hardware = append(qs.Hardware[:idx], qs.Hardware[idx+1:]...)
+					refreshHardware()
+					refresh()
+				}),
+				widget.NewLabel(fmt.Sprintf("%s — %d x %.2f = %.2f", h.Name, h.Quantity, h.UnitPrice, h.Total())),
+			))
+		}
+	}
+
+	hwName := widget.NewEntry()
+	hwName.SetPlaceHolder("Item")
+	hwPrice := widget.NewEntry()
+	hwPrice.SetPlaceHolder("Unit price")
+	hwQty := widget.NewEntry()
+	hwQty.SetPlaceHolder("Qty")
+	addHardwareBtn := widget.NewButtonWithIcon("Add", theme.ContentAddIcon(), func() {
+		qty := parseInt(hwQty.Text)
+		if hwName.Text == "" || qty <= 0 {
+			return
+		}
+		qs.Hardware = append(qs.Hardware, model.NewHardwareItem(hwName.Text, parseFloat(hwPrice.Text), qty))
+		hwName.SetText("")
+		hwPrice.SetText("")
+		hwQty.SetText("")
+		refreshHardware()
+		refresh()
+	})
+
+	refresh = func() {
+		qs.Currency = currencyEntry.Text
+		quote := model.BuildQuote(*a.project.Result, a.project.Settings, qs, a.project.Products...)
+		money := func(v float64) string {
+			return fmt.Sprintf("%s %.2f", qs.Currency, v)
+		}
+
+		breakdown.RemoveAll()
+		grid := container.NewGridWithColumns(5,
+			widget.NewLabelWithStyle("Item", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+			widget.NewLabelWithStyle("Material", fyne.TextAlignTrailing, fyne.TextStyle{Bold: true}),
+			widget.NewLabelWithStyle("Machine", fyne.TextAlignTrailing, fyne.TextStyle{Bold: true}),
+			widget.NewLabelWithStyle("Banding", fyne.TextAlignTrailing, fyne.TextStyle{Bold: true}),
+			widget.NewLabelWithStyle("Cost", fyne.TextAlignTrailing, fyne.TextStyle{Bold: true}),
+		)
+		for _, line := range quote.Lines {
+			grid.Add(widget.NewLabel(fmt.Sprintf("%s x%d", line.Label, line.Quantity)))
+			grid.Add(widget.NewLabelWithStyle(money(line.MaterialCost), fyne.TextAlignTrailing, fyne.TextStyle{}))
+			grid.Add(widget.NewLabelWithStyle(money(line.MachineCost), fyne.TextAlignTrailing, fyne.TextStyle{}))
+			grid.Add(widget.NewLabelWithStyle(money(line.EdgeBandingCost), fyne.TextAlignTrailing, fyne.TextStyle{}))
+			grid.Add(widget.NewLabelWithStyle(money(line.Cost()), fyne.TextAlignTrailing, fyne.TextStyle{}))
+		}
+		breakdown.Add(grid)
+		breakdown.Add(widget.NewSeparator())
+		breakdown.Add(widget.NewLabel(fmt.Sprintf(
+			"Material: %s | Machine: %.0f min, %s | Banding: %.1f m, %s | Hardware: %s",
+			money(quote.MaterialCost), quote.MachineMinutes, money(quote.MachineCost),
+			quote.EdgeBandingM, money(quote.EdgeBandingCost), money(quote.HardwareCost),
+		)))
+		total := widget.NewLabel(fmt.Sprintf(
+			"Cost: %s | Margin: %s | Net: %s | Tax: %s | Total: %s",
+			money(quote.Cost), money(quote.Margin), money(quote.Net), money(quote.Tax), money(quote.Total),
+		))
+		total.TextStyle = fyne.TextStyle{Bold: true}
+		breakdown.Add(total)
+	}
+	currencyEntry.OnChanged = func(string) { refresh() }
+
+	form := widget.NewForm(
+		widget.NewFormItem("Customer", customerEntry),
+		widget.NewFormItem("Reference", referenceEntry),
+		widget.NewFormItem("Currency", currencyEntry),
+		widget.NewFormItem("Machine Rate (/hour)", floatEntry(&qs.MachineHourlyRate)),
+		widget.NewFormItem("Setup per Sheet (min)", floatEntry(&qs.SetupMinutesPerSheet)),
+		widget.NewFormItem("Edge Banding (/m)", floatEntry(&qs.EdgeBandingPerMeter)),
+		widget.NewFormItem("Banding Waste (%)", floatEntry(&qs.EdgeBandingWaste)),
+		widget.NewFormItem("Margin (%)", floatEntry(&qs.MarginPercent)),
+		widget.NewFormItem("Tax (%)", floatEntry(&qs.TaxPercent)),
+		widget.NewFormItem("Notes", notesEntry),
+	)
+
+	refreshHardware()
+	refresh()
+
+	saveInputs := func() {
+		qs.CustomerName = customerEntry.Text
+		qs.Reference = referenceEntry.Text
+		qs.Currency = currencyEntry.Text
+		qs.Notes = notesEntry.Text
+		a.project.Quote = qs
+	}
+
+	exportBtn := widget.NewButtonWithIcon("Export Quote PDF...", theme.DocumentSaveIcon(), func() {
+		saveInputs()
+		quote := model.BuildQuote(*a.project.Result, a.project.Settings, qs, a.project.Products...)
+		d := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+			if err != nil || writer == nil {
+				return
+			}
+			writer.Close()
+			path := writer.URI().Path()
+			if exportErr := export.ExportQuotePDF(path, a.project.Name, quote); exportErr != nil {
+				dialog.ShowError(exportErr, a.window)
+			} else {
+				dialog.ShowInformation("Export Complete",
+					fmt.Sprintf("Quote saved to %s", path), a.window)
+			}
+		}, a.window)
+		d.SetFileName("quote.pdf")
+		d.Show()
+	})
+
+	hardwareSection := container.NewVBox(
+		widget.NewLabelWithStyle("Hardware", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		hardwareList,
+		container.NewGridWithColumns(4, hwName, hwPrice, hwQty, addHardwareBtn),
+	)
+
+	content := container.NewVScroll(container.NewVBox(
+		form,
+		widget.NewSeparator(),
+		hardwareSection,
+		widget.NewSeparator(),
+		widget.NewLabelWithStyle("Cost Breakdown", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		breakdown,
+		exportBtn,
+	))
+
+	d := dialog.NewCustom("Quote", "Close", content, a.window)
+	d.SetOnClosed(saveInputs)
+	d.Resize(fyne.NewSize(800, 700))
+	d.Show()
+}
@@ -51,6 +51,10 @@ type GCodePreview struct {
 	// -1 means show all moves (no simulation mode / show everything).
 	mu           sync.Mutex
 	visibleMoves int
+
+	// OnMoveTapped, if set, is called with the index of the move nearest
+	// a tap on the preview.
+	OnMoveTapped func(idx int)
 }
 
 // NewGCodePreview creates a new GCode preview widget.
@@ -127,6 +131,55 @@ func (gp *GCodePreview) GetMoveInfo(idx int) *MoveInfo {
 	}
 }
 
+// layoutScale returns the scale that fits the sheet within the maximum
+// size, and the margin around it left for the tool offset.
+func (gp *GCodePreview) layoutScale() (scale, margin float32) {
+	margin = float32(gp.settings.ToolDiameter) + 10
+	scaleX := (gp.maxWidth - margin*2) / float32(gp.sheetW)
+	scaleY := (gp.maxHeight - margin*2) / float32(gp.sheetH)
+	scale = scaleX
+	if scaleY < scale {
+		scale = scaleY
+	}
+	if scale <= 0 {
+		scale = 1
+	}
+	return scale, margin
+}
+
+// Tapped implements fyne.Tappable: it reports the move nearest the tap to
+// OnMoveTapped, if it is within a few pixels.
+func (gp *GCodePreview) Tapped(ev *fyne.PointEvent) {
+	if gp.OnMoveTapped == nil || gp.sheetW <= 0 || gp.sheetH <= 0 {
+		return
+	}
+	scale, margin := gp.layoutScale()
+	x := float64((ev.Position.X - margin) / scale)
+	y := float64((ev.Position.Y - margin) / scale)
+
+	const tapTolerance = 8 // pixels
+	best, bestDist := -1, float64(tapTolerance)/float64(scale)
+	for i, m := range gp.moves {
+		if d := segmentDistance(x, y, m.FromX, m.FromY, m.ToX, m.ToY); d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	if best >= 0 {
+		gp.OnMoveTapped(best)
+	}
+}
+
+// segmentDistance returns the distance from (px, py) to the segment from
+// (x0, y0) to (x1, y1).
+func segmentDistance(px, py, x0, y0, x1, y1 float64) float64 {
+	dx, dy := x1-x0, y1-y0
+	t := 0.0
+	if l2 := dx*dx + dy*dy; l2 > 0 {
+		t = math.Max(0, math.Min(1, ((px-x0)*dx+(py-y0)*dy)/l2))
+	}
+	return math.Hypot(px-(x0+t*dx), py-(y0+t*dy))
+}
+
 // CreateRenderer implements fyne.Widget.
 func (gp *GCodePreview) CreateRenderer() fyne.WidgetRenderer {
 	return newGCodePreviewRenderer(gp)
@@ -160,18 +213,7 @@ func (r *gcodePreviewRenderer) rebuild() {
 		return
 	}
 
-	// Calculate scale to fit within max bounds, with margin for tool offset
-	margin := float32(gp.settings.ToolDiameter) + 10
-	scaleX := (gp.maxWidth - margin*2) / stockW
-	scaleY := (gp.maxHeight - margin*2) / stockH
-	scale := scaleX
-	if scaleY < scale {
-		scale = scaleY
-	}
-	if scale <= 0 {
-		scale = 1
-	}
-
+	scale, margin := gp.layoutScale()
 	offsetX := margin
 	offsetY := margin
 
@@ -220,10 +262,8 @@ func (r *gcodePreviewRenderer) rebuild() {
 		}
 	}
 
-	// Draw tab markers if tabs are configured
-	if gp.settings.PartTabsPerSide > 0 {
-		r.drawTabMarkers(scale, offsetX, offsetY)
-	}
+	// Draw tab markers where the final pass leaves holding tabs
+	r.drawTabMarkers(scale, offsetX, offsetY)
 
 	gp.mu.Lock()
 	visibleMoves := gp.visibleMoves
@@ -390,60 +430,26 @@ func (r *gcodePreviewRenderer) drawDashedOverlay(x1, y1, x2, y2 float32, col col
 	_ = col
 }
 
-// drawTabMarkers draws small orange rectangles where holding tabs are positioned.
+// drawTabMarkers draws small orange rectangles where holding tabs are
+// positioned, using the same tab positions as the generator.
 func (r *gcodePreviewRenderer) drawTabMarkers(scale, offsetX, offsetY float32) {
-	settings := r.gp.settings
-	tabW := float32(settings.PartTabWidth) * scale
+	gen := gcode.New(r.gp.settings)
 	tabMarkerH := float32(3)
 
 	for _, p := range r.gp.placements {
-		toolR := settings.ToolDiameter / 2.0
-		pw := p.PlacedWidth() + settings.ToolDiameter
-		ph := p.PlacedHeight() + settings.ToolDiameter
-		x0 := float32(p.X-toolR)*scale + offsetX
-		y0 := float32(p.Y-toolR)*scale + offsetY
-
-		for side := 0; side < 4; side++ {
-			var sideLen float64
-			if side == 0 || side == 2 {
-				sideLen = pw
-			} else {
-				sideLen = ph
+		for _, m := range gen.PartTabs(p) {
+			tabW := float32(m.Width) * scale
+			tw, th := tabW, tabMarkerH
+			if math.Abs(m.DY) > math.Abs(m.DX) {
+				tw, th = tabMarkerH, tabW
 			}
-			spacing := sideLen / float64(settings.PartTabsPerSide+1)
-
-			for t := 1; t <= settings.PartTabsPerSide; t++ {
-				pos := float32(spacing*float64(t)) * scale
-				var tx, ty, tw, th float32
-
-				switch side {
-				case 0:
-					tx = x0 + pos - tabW/2
-					ty = y0 - tabMarkerH/2
-					tw = tabW
-					th = tabMarkerH
-				case 1:
-					tx = x0 + float32(pw)*scale - tabMarkerH/2
-					ty = y0 + pos - tabW/2
-					tw = tabMarkerH
-					th = tabW
-				case 2:
-					tx = x0 + float32(pw)*scale - pos - tabW/2
-					ty = y0 + float32(ph)*scale - tabMarkerH/2
-					tw = tabW
-					th = tabMarkerH
-				case 3:
-					tx = x0 - tabMarkerH/2
-					ty = y0 + float32(ph)*scale - pos - tabW/2
-					tw = tabMarkerH
-					th = tabW
-				}
+			cx := float32(m.X)*scale + offsetX
+			cy := float32(m.Y)*scale + offsetY
 
-				tabRect := canvas.NewRectangle(colorTab)
-				tabRect.Resize(fyne.NewSize(tw, th))
-				tabRect.Move(fyne.NewPos(tx, ty))
-				r.objects = append(r.objects, tabRect)
-			}
+			tabRect := canvas.NewRectangle(colorTab)
+			tabRect.Resize(fyne.NewSize(tw, th))
+			tabRect.Move(fyne.NewPos(cx-tw/2, cy-th/2))
+			r.objects = append(r.objects, tabRect)
 		}
 	}
 }
@@ -469,17 +475,7 @@ func (r *gcodePreviewRenderer) MinSize() fyne.Size {
 		return fyne.NewSize(100, 100)
 	}
 
-	margin := float32(gp.settings.ToolDiameter) + 10
-	scaleX := (gp.maxWidth - margin*2) / stockW
-	scaleY := (gp.maxHeight - margin*2) / stockH
-	scale := scaleX
-	if scaleY < scale {
-		scale = scaleY
-	}
-	if scale <= 0 {
-		scale = 1
-	}
-
+	scale, margin := gp.layoutScale()
 	return fyne.NewSize(stockW*scale+margin*2, stockH*scale+margin*2)
 }
 
@@ -517,7 +513,9 @@ var simulationSpeeds = []struct {
 // a progress slider, play/pause button, step forward/backward, speed control,
 // loop toggle, coordinate display, and move counter. Completed toolpath is shown
 // in green, remaining in dim colors, with a red crosshair showing current tool position.
-func RenderGCodeSimulation(sheet model.SheetResult, settings model.CutSettings, gcodeStr string) fyne.CanvasObject {
+// When onRestart is set, tapping a move selects it and a restart button passes
+// the program line of the selected move to onRestart.
+func RenderGCodeSimulation(sheet model.SheetResult, settings model.CutSettings, gcodeStr string, onRestart func(line int)) fyne.CanvasObject {
 	moves := gcode.ParseGCode(gcodeStr)
 	if len(moves) == 0 {
 		return widget.NewLabel("No toolpath moves found in GCode.")
@@ -732,6 +730,25 @@ func RenderGCodeSimulation(sheet model.SheetResult, settings model.CutSettings,
 		updateDisplay(totalMoves)
 	})
 
+	// Restart point: tapping a move selects it for a restart
+	var restartBtn *widget.Button
+	if onRestart != nil {
+		selected := -1
+		restartBtn = widget.NewButtonWithIcon("Restart From Here", theme.MediaReplayIcon(), func() {
+			if selected >= 0 {
+				onRestart(moves[selected].Line)
+			}
+		})
+		restartBtn.Disable()
+		preview.OnMoveTapped = func(idx int) {
+			stopPlayback()
+			selected = idx
+			slider.SetValue(float64(idx + 1))
+			updateDisplay(idx + 1)
+			restartBtn.Enable()
+		}
+	}
+
 	// Loop toggle
 	loopCheck := widget.NewCheck("Loop", func(checked bool) {
 		playMu.Lock()
@@ -740,23 +757,23 @@ func RenderGCodeSimulation(sheet model.SheetResult, settings model.CutSettings,
 	})
 
 	// Layout: controls below the preview
-	controls := container.NewVBox(
-		slider,
-		container.NewHBox(
-			stepBackBtn,
-			playBtn,
-			stopBtn,
-			stepFwdBtn,
-			widget.NewSeparator(),
-			widget.NewLabel("Speed:"),
-			speedSelect,
-			loopCheck,
-			layout.NewSpacer(),
-			moveLabel,
-			resetBtn,
-		),
-		coordLabel,
+	buttons := container.NewHBox(
+		stepBackBtn,
+		playBtn,
+		stopBtn,
+		stepFwdBtn,
+		widget.NewSeparator(),
+		widget.NewLabel("Speed:"),
+		speedSelect,
+		loopCheck,
+		layout.NewSpacer(),
+		moveLabel,
+		resetBtn,
 	)
+	if restartBtn != nil {
+		buttons.Add(restartBtn)
+	}
+	controls := container.NewVBox(slider, buttons, coordLabel)
 
 	return container.NewBorder(nil, controls, nil, nil, preview)
 }
@@ -13,6 +13,7 @@ import (
 	"fyne.io/fyne/v2/driver/desktop"
 	"fyne.io/fyne/v2/widget"
 
+	"github.com/piwi3910/SlabCut/internal/engine"
 	"github.com/piwi3910/SlabCut/internal/model"
 )
 
@@ -33,10 +34,17 @@ const (
 	maxZoom     = 10.0
 	zoomStep    = 1.15 // multiplicative zoom factor per scroll notch
 	defaultZoom = 1.0
+	snapPixels  = 8.0 // snap distance for dragged placements, in screen pixels
 )
 
 // SheetCanvas renders a visual representation of a single sheet result
-// with mouse wheel zoom and click-and-drag panning.
+// with mouse wheel zoom and click-and-drag panning. When editable, parts can
+// be dragged with the primary button (snapping to the edge trim and to
+// neighbours at kerf spacing) and rotated with the secondary button, and
+// placements that break the layout rules are highlighted as they move.
+// Locked sheets are drawn with a heavy border and cannot be edited. In tab
+// editing mode, clicking a part edge adds a holding tab, dragging a tab moves
+// it along the edge and a secondary click removes it.
 type SheetCanvas struct {
 	widget.BaseWidget
 	sheet     model.SheetResult
@@ -53,6 +61,30 @@ type SheetCanvas struct {
 	dragX    float32 // last drag position
 	dragY    float32
 	dirty    bool // set when sheet data changes, forces renderer rebuild
+
+	// Layout editing state
+	editable bool
+	selected int     // index of the selected placement, -1 for none
+	moving   bool    // dragging the selected placement
+	moved    bool    // the dragged placement has changed position
+	grabX    float64 // grab point within the dragged placement (mm)
+	grabY    float64
+	invalid  map[int]bool // placements involved in a layout violation
+
+	// Tab editing state
+	tabEditing bool
+	tabDrag    int // placement whose tab is being dragged, -1 for none
+	tabIdx     int // index of the dragged tab in the part's tabs
+	tabMoved   bool
+
+	// OnLayoutChanged is called after the user edits a placement, with the
+	// edited sheet and a short description of the edit (e.g. "Move Part").
+	OnLayoutChanged func(sheet model.SheetResult, action string)
+	// OnSelectionChanged is called when the selected placement changes.
+	OnSelectionChanged func(idx int)
+	// OnPartTabsChanged is called after the user adds, moves or removes a
+	// holding tab, with the edited part and a short description of the edit.
+	OnPartTabsChanged func(part model.Part, action string)
 }
 
 // NewSheetCanvas creates a new zoomable, pannable sheet canvas widget.
@@ -63,7 +95,10 @@ func NewSheetCanvas(sheet model.SheetResult, settings model.CutSettings, maxW, m
 		maxWidth:  maxW,
 		maxHeight: maxH,
 		zoom:      defaultZoom,
+		selected:  -1,
+		tabDrag:   -1,
 	}
+	sc.revalidate()
 	sc.ExtendBaseWidget(sc)
 	return sc
 }
@@ -92,25 +127,97 @@ func (sc *SheetCanvas) Scrolled(ev *fyne.ScrollEvent) {
 	sc.Refresh()
 }
 
-// MouseDown starts a pan drag operation.
+// MouseDown selects and starts dragging the placement under the cursor when
+// editing, rotates it on a secondary click, and otherwise starts panning.
 func (sc *SheetCanvas) MouseDown(ev *desktop.MouseEvent) {
+	if sc.tabMouseDown(ev) {
+		return
+	}
 	sc.mu.Lock()
-	defer sc.mu.Unlock()
+	if sc.editable && !sc.sheet.Locked {
+		x, y := sc.toSheet(ev.Position)
+		idx := sc.placementAt(x, y)
+		changed := idx != sc.selected
+		sc.selected = idx
+		sc.dirty = true
+		if idx >= 0 && ev.Button == desktop.MouseButtonSecondary {
+			sc.mu.Unlock()
+			sc.notifySelection(changed, idx)
+			sc.RotateSelected()
+			return
+		}
+		if idx >= 0 {
+			p := sc.sheet.Placements[idx]
+			sc.moving = true
+			sc.moved = false
+			sc.grabX, sc.grabY = x-p.X, y-p.Y
+			sc.mu.Unlock()
+			sc.notifySelection(changed, idx)
+			sc.Refresh()
+			return
+		}
+		sc.mu.Unlock()
+		sc.notifySelection(changed, idx)
+		sc.mu.Lock()
+	}
 	sc.dragging = true
 	sc.dragX = ev.Position.X
 	sc.dragY = ev.Position.Y
+	sc.mu.Unlock()
+	sc.Refresh()
 }
 
-// MouseUp ends a pan drag operation.
+// MouseUp ends a pan drag, or drops a dragged placement and reports the move.
 func (sc *SheetCanvas) MouseUp(_ *desktop.MouseEvent) {
+	if sc.tabMouseUp() {
+		return
+	}
 	sc.mu.Lock()
-	defer sc.mu.Unlock()
 	sc.dragging = false
+	if !sc.moving {
+		sc.mu.Unlock()
+		return
+	}
+	sc.moving = false
+	if !sc.moved {
+		sc.mu.Unlock()
+		return
+	}
+	sc.sheet.Placements[sc.selected].Pinned = true
+	sheet := sc.copySheet()
+	sc.dirty = true
+	sc.mu.Unlock()
+
+	sc.Refresh()
+	if sc.OnLayoutChanged != nil {
+		sc.OnLayoutChanged(sheet, "Move Part")
+	}
 }
 
-// MouseMoved pans the view while dragging.
+// MouseMoved pans the view or drags the selected placement.
 func (sc *SheetCanvas) MouseMoved(ev *desktop.MouseEvent) {
+	if sc.tabMouseMoved(ev) {
+		return
+	}
 	sc.mu.Lock()
+	if sc.moving {
+		x, y := sc.toSheet(ev.Position)
+		x, y = engine.SnapPosition(sc.sheet, sc.selected, x-sc.grabX, y-sc.grabY,
+			sc.settings, snapPixels/sc.scale())
+		p := &sc.sheet.Placements[sc.selected]
+		changed := p.X != x || p.Y != y
+		if changed {
+			p.X, p.Y = x, y
+			sc.moved = true
+			sc.revalidate()
+			sc.dirty = true
+		}
+		sc.mu.Unlock()
+		if changed {
+			sc.Refresh()
+		}
+		return
+	}
 	defer sc.mu.Unlock()
 
 	if !sc.dragging {
@@ -127,11 +234,140 @@ func (sc *SheetCanvas) MouseMoved(ev *desktop.MouseEvent) {
 	sc.Refresh()
 }
 
+// SetEditable turns layout editing on or off. Turning it off clears the
+// selection.
+func (sc *SheetCanvas) SetEditable(editable bool) {
+	sc.mu.Lock()
+	sc.editable = editable
+	changed := !editable && sc.selected >= 0
+	if changed {
+		sc.selected = -1
+	}
+	sc.moving = false
+	sc.dirty = true
+	sc.mu.Unlock()
+	sc.notifySelection(changed, -1)
+	sc.Refresh()
+}
+
+// Selected returns the index of the selected placement, or -1.
+func (sc *SheetCanvas) Selected() int {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	return sc.selected
+}
+
+// RotateSelected turns the selected placement 90° about its top-left corner
+// and pins it.
+func (sc *SheetCanvas) RotateSelected() {
+	sc.editSelected("Rotate Part", func(p *model.Placement) {
+		p.Rotated = !p.Rotated
+		p.Pinned = true
+	})
+}
+
+// TogglePinSelected pins or unpins the selected placement.
+func (sc *SheetCanvas) TogglePinSelected() {
+	sc.mu.Lock()
+	action := "Pin Part"
+	if sc.selected >= 0 && sc.sheet.Placements[sc.selected].Pinned {
+		action = "Unpin Part"
+	}
+	sc.mu.Unlock()
+	sc.editSelected(action, func(p *model.Placement) {
+		p.Pinned = !p.Pinned
+	})
+}
+
+// Violations returns the layout rule violations on the displayed sheet.
+func (sc *SheetCanvas) Violations() []engine.Violation {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	return engine.Validate(model.OptimizeResult{Sheets: []model.SheetResult{sc.sheet}}, sc.settings)
+}
+
+// editSelected applies edit to the selected placement and reports the change.
+func (sc *SheetCanvas) editSelected(action string, edit func(p *model.Placement)) {
+	sc.mu.Lock()
+	if !sc.editable || sc.sheet.Locked || sc.selected < 0 || sc.selected >= len(sc.sheet.Placements) {
+		sc.mu.Unlock()
+		return
+	}
+	edit(&sc.sheet.Placements[sc.selected])
+	sc.revalidate()
+	sheet := sc.copySheet()
+	sc.dirty = true
+	sc.mu.Unlock()
+
+	sc.Refresh()
+	if sc.OnLayoutChanged != nil {
+		sc.OnLayoutChanged(sheet, action)
+	}
+}
+
+func (sc *SheetCanvas) notifySelection(changed bool, idx int) {
+	if changed && sc.OnSelectionChanged != nil {
+		sc.OnSelectionChanged(idx)
+	}
+}
+
+// scale returns screen pixels per mm at the current zoom. Callers hold sc.mu.
+func (sc *SheetCanvas) scale() float64 {
+	base := math.Min(float64(sc.maxWidth)/sc.sheet.Stock.Width, float64(sc.maxHeight)/sc.sheet.Stock.Height)
+	return base * sc.zoom
+}
+
+// toSheet converts a widget position to sheet coordinates in mm. Callers
+// hold sc.mu.
+func (sc *SheetCanvas) toSheet(pos fyne.Position) (float64, float64) {
+	s := sc.scale()
+	return (float64(pos.X) - sc.panX) / s, (float64(pos.Y) - sc.panY) / s
+}
+
+// placementAt returns the index of the topmost placement containing the
+// sheet point (x, y), or -1. Callers hold sc.mu.
+func (sc *SheetCanvas) placementAt(x, y float64) int {
+	for i := len(sc.sheet.Placements) - 1; i >= 0; i-- {
+		p := sc.sheet.Placements[i]
+		if x >= p.X && x <= p.X+p.PlacedWidth() && y >= p.Y && y <= p.Y+p.PlacedHeight() {
+			return i
+		}
+	}
+	return -1
+}
+
+// revalidate records which placements break the layout rules. Callers hold
+// sc.mu or own sc exclusively.
+func (sc *SheetCanvas) revalidate() {
+	sc.invalid = make(map[int]bool)
+	for _, v := range engine.Validate(model.OptimizeResult{Sheets: []model.SheetResult{sc.sheet}}, sc.settings) {
+		sc.invalid[v.Placement] = true
+		if v.Other >= 0 {
+			sc.invalid[v.Other] = true
+		}
+	}
+}
+
+// copySheet returns the displayed sheet with its own placements slice.
+// Callers hold sc.mu.
+func (sc *SheetCanvas) copySheet() model.SheetResult {
+	sheet := sc.sheet
+	sheet.Placements = append([]model.Placement(nil), sc.sheet.Placements...)
+	return sheet
+}
+
 // SetSheet updates the displayed sheet and settings, then refreshes the canvas.
 func (sc *SheetCanvas) SetSheet(sheet model.SheetResult, settings model.CutSettings) {
 	sc.mu.Lock()
 	sc.sheet = sheet
+	// Edits must not write through to the caller's placements
+	sc.sheet.Placements = append([]model.Placement(nil), sheet.Placements...)
 	sc.settings = settings
+	if sc.selected >= len(sheet.Placements) {
+		sc.selected = -1
+	}
+	sc.moving = false
+	sc.revalidate()
 	sc.dirty = true
 	sc.mu.Unlock()
 	sc.Refresh()
@@ -214,6 +450,9 @@ func (r *sheetCanvasRenderer) rebuild() {
 	r.lastPanX = r.sc.panX
 	r.lastPanY = r.sc.panY
 	r.built = true
+	selected := r.sc.selected
+	invalid := r.sc.invalid
+	tabEditing := r.sc.tabEditing
 	r.sc.mu.Unlock()
 
 	scale := baseScale * zoom
@@ -230,6 +469,10 @@ func (r *sheetCanvasRenderer) rebuild() {
 	border := canvas.NewRectangle(color.Transparent)
 	border.StrokeColor = color.NRGBA{R: 100, G: 100, B: 100, A: 255}
 	border.StrokeWidth = 2
+	if sheet.Locked {
+		border.StrokeColor = color.NRGBA{R: 25, G: 118, B: 210, A: 255}
+		border.StrokeWidth = 4
+	}
 	border.Resize(fyne.NewSize(canvasW, canvasH))
 	border.Move(fyne.NewPos(panX, panY))
 	r.objects = append(r.objects, border)
@@ -240,9 +483,15 @@ func (r *sheetCanvasRenderer) rebuild() {
 	// Draw clamp/fixture zones
 	r.drawClampZones(scale, panX, panY)
 
+	// Draw sheet defects
+	r.drawDefects(sheet.Stock, scale, panX, panY)
+
 	// Placed parts
 	for i, p := range sheet.Placements {
 		col := partColors[i%len(partColors)]
+		if invalid[i] {
+			col = color.NRGBA{R: 229, G: 57, B: 53, A: 220} // collision feedback
+		}
 		pw := float32(p.PlacedWidth()) * scale
 		ph := float32(p.PlacedHeight()) * scale
 		px := float32(p.X)*scale + panX
@@ -258,21 +507,33 @@ func (r *sheetCanvasRenderer) rebuild() {
 		partBorder := canvas.NewRectangle(color.Transparent)
 		partBorder.StrokeColor = color.NRGBA{R: 30, G: 30, B: 30, A: 255}
 		partBorder.StrokeWidth = 1
+		if p.Pinned {
+			partBorder.StrokeWidth = 2
+		}
+		if i == selected {
+			partBorder.StrokeColor = color.NRGBA{R: 25, G: 118, B: 210, A: 255}
+			partBorder.StrokeWidth = 3
+		}
 		partBorder.Resize(fyne.NewSize(pw, ph))
 		partBorder.Move(fyne.NewPos(px, py))
 		r.objects = append(r.objects, partBorder)
 
 		// Label (only if big enough)
 		if pw > 30 && ph > 16 {
-			label := canvas.NewText(
-				fmt.Sprintf("%s\n%.0fx%.0f", p.Part.Label, p.Part.Width, p.Part.Height),
-				color.Black,
-			)
+			text := fmt.Sprintf("%s\n%.0fx%.0f", p.Part.Label, p.Part.Width, p.Part.Height)
+			if p.Pinned {
+				text += " (pinned)"
+			}
+			label := canvas.NewText(text, color.Black)
 			label.TextSize = 10
 			label.Move(fyne.NewPos(px+3, py+2))
 			r.objects = append(r.objects, label)
 		}
 	}
+
+	if tabEditing {
+		r.drawPartTabs(sheet, r.sc.settings, scale, panX, panY)
+	}
 }
 
 // drawStockTabs visualizes stock sheet holding tab zones
@@ -396,6 +657,42 @@ func (r *sheetCanvasRenderer) drawClampZones(scale, panX, panY float32) {
 	}
 }
 
+// drawDefects visualizes damaged areas of the sheet: the rectangles the
+// optimizer keeps clear are shaded and the defect outline is drawn on top.
+func (r *sheetCanvasRenderer) drawDefects(stock model.StockSheet, scale, panX, panY float32) {
+	for _, d := range stock.Defects {
+		for _, zone := range d.ExclusionZones() {
+			zoneRect := canvas.NewRectangle(color.NRGBA{R: 150, G: 110, B: 170, A: 150})
+			zoneRect.Resize(fyne.NewSize(float32(zone.Width)*scale, float32(zone.Height)*scale))
+			zoneRect.Move(fyne.NewPos(float32(zone.X)*scale+panX, float32(zone.Y)*scale+panY))
+			r.objects = append(r.objects, zoneRect)
+		}
+
+		n := len(d.Outline)
+		for i := 0; i < n && n >= 3; i++ {
+			a, b := d.Outline[i], d.Outline[(i+1)%n]
+			edge := canvas.NewLine(color.NRGBA{R: 90, G: 40, B: 120, A: 255})
+			edge.StrokeWidth = 2
+			edge.Position1 = fyne.NewPos(float32(a.X)*scale+panX, float32(a.Y)*scale+panY)
+			edge.Position2 = fyne.NewPos(float32(b.X)*scale+panX, float32(b.Y)*scale+panY)
+			r.objects = append(r.objects, edge)
+		}
+
+		if n >= 3 {
+			min, _ := d.Outline.BoundingBox()
+			labelText := "DEFECT"
+			if d.Label != "" {
+				labelText = d.Label
+			}
+			label := canvas.NewText(labelText, color.White)
+			label.TextSize = 8
+			label.TextStyle = fyne.TextStyle{Bold: true}
+			label.Move(fyne.NewPos(float32(min.X)*scale+panX+3, float32(min.Y)*scale+panY+2))
+			r.objects = append(r.objects, label)
+		}
+	}
+}
+
 func (r *sheetCanvasRenderer) Layout(size fyne.Size) {}
 func (r *sheetCanvasRenderer) Refresh() {
 	r.sc.mu.Lock()
@@ -456,6 +753,19 @@ func renderSheetToImage(sheet model.SheetResult, settings model.CutSettings, max
 		img.SetNRGBA(imgW-1, y, borderColor)
 	}
 
+	// Draw sheet defects
+	defectColor := color.NRGBA{R: 150, G: 110, B: 170, A: 255}
+	for _, d := range sheet.Stock.Defects {
+		min, max := d.Outline.BoundingBox()
+		for y := int(min.Y * scale); y <= int(max.Y*scale) && y < imgH; y++ {
+			for x := int(min.X * scale); x <= int(max.X*scale) && x < imgW; x++ {
+				if x >= 0 && y >= 0 && d.Outline.ContainsPoint(float64(x)/scale, float64(y)/scale) {
+					img.SetNRGBA(x, y, defectColor)
+				}
+			}
+		}
+	}
+
 	// Draw placed parts
 	for i, p := range sheet.Placements {
 		col := partColors[i%len(partColors)]
@@ -504,11 +814,15 @@ func RenderSheetResults(result *model.OptimizeResult, settings model.CutSettings
 	var items []fyne.CanvasObject
 
 	for i, sheet := range result.Sheets {
-		header := widget.NewLabel(fmt.Sprintf(
+		text := fmt.Sprintf(
 			"Sheet %d: %s (%.0f x %.0f) — %d parts, %.1f%% efficiency",
 			i+1, sheet.Stock.Label, sheet.Stock.Width, sheet.Stock.Height,
 			len(sheet.Placements), sheet.Efficiency(),
-		))
+		)
+		if sheet.Roll != nil {
+			text += " — " + sheet.Roll.String()
+		}
+		header := widget.NewLabel(text)
 		header.TextStyle = fyne.TextStyle{Bold: true}
 
 		img := renderSheetToImage(sheet, settings, 600, 400)
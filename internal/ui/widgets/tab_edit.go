@@ -0,0 +1,164 @@
+package widgets
+
+import (
+	"image/color"
+	"math"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/driver/desktop"
+
+	"github.com/piwi3910/SlabCut/internal/gcode"
+	"github.com/piwi3910/SlabCut/internal/model"
+)
+
+// colorPartTab marks a hand-placed tab handle on the sheet canvas.
+var colorPartTab = color.NRGBA{R: 120, G: 60, B: 0, A: 255}
+
+// SetTabEditing turns holding tab editing on or off. While it is on the
+// canvas shows where the generator will leave tabs.
+func (sc *SheetCanvas) SetTabEditing(on bool) {
+	sc.mu.Lock()
+	sc.tabEditing = on
+	sc.tabDrag = -1
+	sc.dirty = true
+	sc.mu.Unlock()
+	sc.Refresh()
+}
+
+// tabMouseDown adds, removes or starts dragging a tab when tab editing is
+// on. It reports whether it handled the event.
+func (sc *SheetCanvas) tabMouseDown(ev *desktop.MouseEvent) bool {
+	sc.mu.Lock()
+	if !sc.tabEditing {
+		sc.mu.Unlock()
+		return false
+	}
+	x, y := sc.toSheet(ev.Position)
+	tol := snapPixels/sc.scale() + sc.settings.ToolDiameter/2
+	idx := sc.tabPlacementAt(x, y, tol)
+	if idx < 0 {
+		sc.mu.Unlock()
+		return false
+	}
+
+	p := &sc.sheet.Placements[idx]
+	// Edit a copy so other placements of the part keep their tabs until the
+	// change is reported.
+	p.Part.Tabs = append([]model.PartTab(nil), p.Part.Tabs...)
+	hit := -1
+	for i, pt := range p.TabPositions() {
+		if math.Hypot(pt.X-x, pt.Y-y) <= tol {
+			hit = i
+			break
+		}
+	}
+
+	var action string
+	switch {
+	case hit >= 0 && ev.Button == desktop.MouseButtonSecondary:
+		p.Part.Tabs = append(p.Part.Tabs[:hit], p.Part.Tabs[hit+1:]...)
+		action = "Remove Tab"
+	case hit >= 0:
+		sc.tabDrag, sc.tabIdx, sc.tabMoved = idx, hit, false
+		sc.mu.Unlock()
+		return true
+	case ev.Button == desktop.MouseButtonSecondary:
+		sc.mu.Unlock()
+		return true
+	default:
+		px, py := p.ToPart(x, y)
+		edge := p.Part.EdgePoint(px, py)
+		p.Part.Tabs = append(p.Part.Tabs, model.PartTab{X: edge.X, Y: edge.Y})
+		action = "Add Tab"
+	}
+	part := p.Part
+	sc.dirty = true
+	sc.mu.Unlock()
+
+	sc.Refresh()
+	if sc.OnPartTabsChanged != nil {
+		sc.OnPartTabsChanged(part, action)
+	}
+	return true
+}
+
+// tabMouseMoved slides the dragged tab along its part's edge. It reports
+// whether it handled the event.
+func (sc *SheetCanvas) tabMouseMoved(ev *desktop.MouseEvent) bool {
+	sc.mu.Lock()
+	if sc.tabDrag < 0 {
+		sc.mu.Unlock()
+		return false
+	}
+	p := &sc.sheet.Placements[sc.tabDrag]
+	px, py := p.ToPart(sc.toSheet(ev.Position))
+	edge := p.Part.EdgePoint(px, py)
+	p.Part.Tabs[sc.tabIdx] = model.PartTab{X: edge.X, Y: edge.Y}
+	sc.tabMoved = true
+	sc.dirty = true
+	sc.mu.Unlock()
+	sc.Refresh()
+	return true
+}
+
+// tabMouseUp drops the dragged tab and reports the move. It reports whether
+// it handled the event.
+func (sc *SheetCanvas) tabMouseUp() bool {
+	sc.mu.Lock()
+	if sc.tabDrag < 0 {
+		sc.mu.Unlock()
+		return false
+	}
+	part := sc.sheet.Placements[sc.tabDrag].Part
+	moved := sc.tabMoved
+	sc.tabDrag = -1
+	sc.mu.Unlock()
+
+	if moved && sc.OnPartTabsChanged != nil {
+		sc.OnPartTabsChanged(part, "Move Tab")
+	}
+	return true
+}
+
+// tabPlacementAt returns the index of the topmost placement whose edge lies
+// within tol of the sheet point (x, y), or -1. Callers hold sc.mu.
+func (sc *SheetCanvas) tabPlacementAt(x, y, tol float64) int {
+	for i := len(sc.sheet.Placements) - 1; i >= 0; i-- {
+		p := sc.sheet.Placements[i]
+		px, py := p.ToPart(x, y)
+		edge := p.Part.EdgePoint(px, py)
+		if math.Hypot(edge.X-px, edge.Y-py) <= tol {
+			return i
+		}
+	}
+	return -1
+}
+
+// drawPartTabs draws the tabs the generator will leave on every part, and a
+// handle on each hand-placed tab.
+func (r *sheetCanvasRenderer) drawPartTabs(sheet model.SheetResult, settings model.CutSettings, scale, panX, panY float32) {
+	gen := gcode.New(settings)
+	const markerH = float32(4)
+
+	for _, p := range sheet.Placements {
+		for _, m := range gen.PartTabs(p) {
+			w, h := float32(m.Width)*scale, markerH
+			if math.Abs(m.DY) > math.Abs(m.DX) {
+				w, h = h, w
+			}
+			cx := float32(m.X)*scale + panX
+			cy := float32(m.Y)*scale + panY
+			marker := canvas.NewRectangle(colorTab)
+			marker.Resize(fyne.NewSize(w, h))
+			marker.Move(fyne.NewPos(cx-w/2, cy-h/2))
+			r.objects = append(r.objects, marker)
+		}
+		for _, pt := range p.TabPositions() {
+			handle := canvas.NewCircle(colorPartTab)
+			handle.Resize(fyne.NewSize(8, 8))
+			handle.Move(fyne.NewPos(float32(pt.X)*scale+panX-4, float32(pt.Y)*scale+panY-4))
+			r.objects = append(r.objects, handle)
+		}
+	}
+}